@@ -14,14 +14,19 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime/debug"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v2"
 
@@ -46,6 +51,40 @@ var (
 	flagConfigFile     string
 	flagAliasTypes     bool
 	flagPrintVersion   bool
+
+	flagExcludeDeprecated     bool
+	flagOperationIDCasing     string
+	flagNameCollisionStrategy string
+	flagRefCacheDir           string
+	flagRefLockFile           string
+	flagOffline               bool
+	flagWatch                 bool
+	flagLintFormat            string
+	flagLintStrict            bool
+	flagEnumStringer          bool
+	flagEnumText              bool
+	flagEnumSQL               bool
+	flagYAMLTags              bool
+	flagMapstructureTags      bool
+	flagDeepCopy              bool
+	flagJSONPackage           string
+	flagJSONContentTypes      string
+	flagReadWriteModels       bool
+	flagNullableType          bool
+	flagOptionalValues        bool
+	flagTimeFormats           string
+	flagByteEncoding          string
+	flagApplyDefaults         bool
+	flagValidateTags          bool
+	flagValidateMethods       bool
+	flagParamsBuilders        bool
+	flagValidateClient        bool
+	flagEmbedSpecFile         string
+	flagDocsUI                string
+	flagTypesPackage          string
+	flagRegionMarkers         bool
+	flagTypeMappings          string
+	flagUpdate                bool
 )
 
 type configuration struct {
@@ -57,13 +96,44 @@ type configuration struct {
 	TemplatesDir    string            `yaml:"templates"`
 	ImportMapping   map[string]string `yaml:"import-mapping"`
 	ExcludeSchemas  []string          `yaml:"exclude-schemas"`
+
+	ExcludeDeprecated     bool              `yaml:"exclude-deprecated"`
+	OperationIDCasing     string            `yaml:"operation-id-casing"`
+	NameCollisionStrategy string            `yaml:"name-collision-strategy"`
+	RefCacheDir           string            `yaml:"ref-cache-dir"`
+	RefLockFile           string            `yaml:"ref-lockfile"`
+	Offline               bool              `yaml:"offline"`
+	EnumStringer          bool              `yaml:"enum-stringer"`
+	EnumText              bool              `yaml:"enum-text"`
+	EnumSQL               bool              `yaml:"enum-sql"`
+	YAMLTags              bool              `yaml:"yaml-tags"`
+	MapstructureTags      bool              `yaml:"mapstructure-tags"`
+	DeepCopy              bool              `yaml:"deep-copy"`
+	JSONPackage           string            `yaml:"json-package"`
+	JSONContentTypes      []string          `yaml:"json-content-types"`
+	ReadWriteModels       bool              `yaml:"read-write-models"`
+	NullableType          bool              `yaml:"nullable-type"`
+	OptionalValues        bool              `yaml:"optional-values"`
+	TimeFormats           map[string]string `yaml:"time-formats"`
+	ByteEncoding          string            `yaml:"byte-encoding"`
+	ApplyDefaults         bool              `yaml:"apply-defaults"`
+	ValidateTags          bool              `yaml:"validate-tags"`
+	ValidateMethods       bool              `yaml:"validate-methods"`
+	ParamsBuilders        bool              `yaml:"params-builders"`
+	ValidateClient        bool              `yaml:"validate-client-requests"`
+	EmbedSpecFile         string            `yaml:"embed-spec-file"`
+	DocsUI                string            `yaml:"docs-ui"`
+	TypesPackage          string            `yaml:"types-package"`
+	RegionMarkers         bool              `yaml:"region-markers"`
+
+	TypeMappings map[string]codegen.TypeMapping `yaml:"type-mappings"`
 }
 
 func main() {
 
 	flag.StringVar(&flagPackageName, "package", "", "The package name for generated code")
 	flag.StringVar(&flagGenerate, "generate", "types,client,server,spec",
-		`Comma-separated list of code to generate; valid options: "types", "client", "chi-server", "server", "gin", "spec", "skip-fmt", "skip-prune"`)
+		`Comma-separated list of code to generate; valid options: "types", "client", "lazy-client", "urls", "chi-server", "chi-context", "server", "server-responses", "server-recovery", "server-deadlines", "param-error-responses", "gin", "iris", "echo5", "spec", "skip-spec", "skip-fmt", "skip-prune", "prune-unreachable"`)
 	flag.StringVar(&flagOutputFile, "o", "", "Where to output generated code, stdout is default")
 	flag.StringVar(&flagIncludeTags, "include-tags", "", "Only include operations with the given tags. Comma-separated list of tags.")
 	flag.StringVar(&flagExcludeTags, "exclude-tags", "", "Exclude operations that are tagged with the given tags. Comma-separated list of tags.")
@@ -72,8 +142,52 @@ func main() {
 	flag.StringVar(&flagExcludeSchemas, "exclude-schemas", "", "A comma separated list of schemas which must be excluded from generation")
 	flag.StringVar(&flagConfigFile, "config", "", "a YAML config file that controls oapi-codegen behavior")
 	flag.BoolVar(&flagAliasTypes, "alias-types", false, "Alias type declarations of possible")
+	flag.BoolVar(&flagExcludeDeprecated, "exclude-deprecated", false, "Exclude operations which are marked as deprecated")
+	flag.StringVar(&flagOperationIDCasing, "operation-id-casing", "", `Casing of the IDs synthesized for operations without an operationId; valid options: "pascal", "camel", "snake", "kebab"`)
+	flag.StringVar(&flagNameCollisionStrategy, "name-collision-strategy", "", `How to resolve operations with colliding names; valid options: "fail", "method", "path"`)
+	flag.StringVar(&flagRefCacheDir, "ref-cache-dir", "", "Where to cache remote documents referenced by the spec, defaults to the user cache directory")
+	flag.StringVar(&flagRefLockFile, "ref-lockfile", "", "A file recording the digests of remote documents referenced by the spec, which pins their content")
+	flag.BoolVar(&flagOffline, "offline", false, "Fail instead of fetching remote documents which aren't cached")
+	flag.BoolVar(&flagWatch, "watch", false, "Regenerate the code whenever the spec, the files it refers to or the templates change")
+	flag.StringVar(&flagLintFormat, "format", "text", `Output format of lint issues and API changes; valid options: "text", "json"`)
+	flag.BoolVar(&flagLintStrict, "strict", false, "Make lint fail on warnings too, and diff fail on breaking changes")
+	flag.BoolVar(&flagEnumStringer, "enum-stringer", false, "Generate String methods for enums")
+	flag.BoolVar(&flagEnumText, "enum-text", false, "Generate MarshalText and UnmarshalText methods for enums, which reject unknown values")
+	flag.BoolVar(&flagEnumSQL, "enum-sql", false, "Generate sql.Scanner and driver.Valuer implementations for enums, which reject unknown values")
+	flag.BoolVar(&flagYAMLTags, "yaml-tags", false, "Add yaml tags, matching the json ones, to the fields of generated types")
+	flag.BoolVar(&flagMapstructureTags, "mapstructure-tags", false, "Add mapstructure tags, matching the json ones, to the fields of generated types")
+	flag.BoolVar(&flagDeepCopy, "deep-copy", false, "Generate DeepCopy and DeepCopyInto methods for generated types")
+	flag.StringVar(&flagJSONPackage, "json-package", "", "Import path of a JSON package with the API of encoding/json, such as github.com/goccy/go-json, for the generated code to use")
+	flag.StringVar(&flagJSONContentTypes, "json-content-types", "", "A comma separated list of content types to handle as JSON, besides application/json and the +json ones")
+	flag.BoolVar(&flagReadWriteModels, "read-write-models", false, "Generate request and response variants of the schemas with readOnly or writeOnly properties, for the operations to use")
+	flag.BoolVar(&flagNullableType, "nullable-type", false, "Make nullable fields runtime.Nullable values, which tell null values from absent ones, rather than pointers")
+	flag.BoolVar(&flagOptionalValues, "optional-values", false, "Make optional fields plain values tagged omitempty, rather than pointers, unless they are structs")
+	flag.StringVar(&flagTimeFormats, "time-formats", "", `A dict from schema formats to the layouts of their times, eg, yyyymmdd:20060102, or "unix" or "unix-millis"`)
+	flag.StringVar(&flagByteEncoding, "byte-encoding", "", `Base64 encoding of strings with the byte format; valid options: "std" (default), "url"`)
+	flag.BoolVar(&flagApplyDefaults, "apply-defaults", false, "Generate ApplyDefaults methods, which set absent fields to the defaults of their schemas, and call them on bound parameters and decoded responses")
+	flag.BoolVar(&flagValidateTags, "validate-tags", false, "Add validate tags, for github.com/go-playground/validator, which check the constraints of schemas, to the fields of generated types")
+	flag.BoolVar(&flagValidateMethods, "validate-methods", false, "Generate Validate methods, which check the constraints of schemas, and call them on bound parameters")
+	flag.BoolVar(&flagParamsBuilders, "params-builders", false, "Generate New...Params constructors and With methods, which set and check parameters, for the Params types")
+	flag.BoolVar(&flagValidateClient, "validate-client-requests", false, "Make clients validate the parameters and bodies of requests with their Validate methods")
+	flag.StringVar(&flagEmbedSpecFile, "embed-spec-file", "", "Name of a file, written next to the output file, which the spec is embedded from with go:embed, rather than inlined as a gzipped string")
+	flag.StringVar(&flagDocsUI, "docs-ui", "", `Serve the embedded spec at openapi.json, and a documentation page of it at docs, from the servers; valid options: "swagger-ui", "redoc"`)
+	flag.StringVar(&flagTypesPackage, "types-package", "", "Import path of the package which the types are generated into, which the code generated without the types target imports, rather than declaring them")
+	flag.BoolVar(&flagRegionMarkers, "region-markers", false, "Delimit the sections of the generated code, such as the types, the client and the handlers of the operations, with region markers")
+	flag.StringVar(&flagTypeMappings, "type-mappings", "", `A dict from schema types and formats to Go types, qualified by the import paths of their packages, eg, string/uuid:github.com/gofrs/uuid.UUID, or integer:int64`)
+	flag.BoolVar(&flagUpdate, "update", false, "Rewrite only the regions of the output file whose code changed, and not the file when none did; implies -region-markers")
 	flag.BoolVar(&flagPrintVersion, "version", false, "when specified, print version and exit")
-	flag.Parse()
+
+	// Besides generating code, which is the default, the command has modes,
+	// which are given as a subcommand before the flags, eg, "lint".
+	args := os.Args[1:]
+	mode := ""
+	if len(args) > 0 && (args[0] == "lint" || args[0] == "diff") {
+		mode = args[0]
+		args = args[1:]
+	}
+	if err := flag.CommandLine.Parse(args); err != nil {
+		errExit("error parsing flags: %s\n", err)
+	}
 
 	if flagPrintVersion {
 		bi, ok := debug.ReadBuildInfo()
@@ -90,6 +204,10 @@ func main() {
 		fmt.Println("Please specify a path to a OpenAPI 3.0 spec file")
 		os.Exit(1)
 	}
+	if mode == "diff" && flag.NArg() < 2 {
+		fmt.Println("Please specify the paths to the old and the new OpenAPI 3.0 spec files")
+		os.Exit(1)
+	}
 
 	cfg := configFromFlags()
 
@@ -104,26 +222,50 @@ func main() {
 	}
 
 	opts := codegen.Options{
-		AliasTypes: flagAliasTypes,
+		PackageName: cfg.PackageName,
+		AliasTypes:  flagAliasTypes,
 	}
+	skipSpec := false
 	for _, g := range cfg.GenerateTargets {
 		switch g {
 		case "client":
 			opts.GenerateClient = true
+		case "lazy-client":
+			opts.GenerateLazyClient = true
+		case "urls":
+			opts.GenerateURLs = true
 		case "chi-server":
 			opts.GenerateChiServer = true
+		case "chi-context":
+			opts.ChiServerContext = true
+		case "server-responses":
+			opts.GenerateServerResponses = true
+		case "server-recovery":
+			opts.ServerRecovery = true
+		case "server-deadlines":
+			opts.ServerDeadlines = true
+		case "param-error-responses":
+			opts.ParamErrorResponses = true
 		case "server":
 			opts.GenerateEchoServer = true
 		case "gin":
 			opts.GenerateGinServer = true
+		case "iris":
+			opts.GenerateIrisServer = true
+		case "echo5":
+			opts.GenerateEcho5Server = true
 		case "types":
 			opts.GenerateTypes = true
 		case "spec":
 			opts.EmbedSpec = true
+		case "skip-spec":
+			skipSpec = true
 		case "skip-fmt":
 			opts.SkipFmt = true
 		case "skip-prune":
 			opts.SkipPrune = true
+		case "prune-unreachable":
+			opts.PruneUnreachable = true
 		default:
 			fmt.Printf("unknown generate option %s\n", g)
 			flag.PrintDefaults()
@@ -131,40 +273,313 @@ func main() {
 		}
 	}
 
+	// The spec is skipped even when it's among the targets, eg, when they
+	// are the default ones.
+	if skipSpec {
+		opts.EmbedSpec = false
+	}
+
 	opts.IncludeTags = cfg.IncludeTags
 	opts.ExcludeTags = cfg.ExcludeTags
 	opts.ExcludeSchemas = cfg.ExcludeSchemas
+	opts.ExcludeDeprecated = cfg.ExcludeDeprecated
+	opts.OperationIDCasing = cfg.OperationIDCasing
+	opts.NameCollisionStrategy = cfg.NameCollisionStrategy
+	opts.EnumStringer = cfg.EnumStringer
+	opts.EnumText = cfg.EnumText
+	opts.EnumSQL = cfg.EnumSQL
+	opts.YAMLTags = cfg.YAMLTags
+	opts.MapstructureTags = cfg.MapstructureTags
+	opts.DeepCopy = cfg.DeepCopy
+	opts.JSONPackage = cfg.JSONPackage
+	opts.JSONContentTypes = cfg.JSONContentTypes
+	opts.ReadWriteModels = cfg.ReadWriteModels
+	opts.NullableType = cfg.NullableType
+	opts.OptionalValues = cfg.OptionalValues
+	opts.TimeFormats = cfg.TimeFormats
+	opts.ByteEncoding = cfg.ByteEncoding
+	opts.TypeMappings = cfg.TypeMappings
+	opts.ApplyDefaults = cfg.ApplyDefaults
+	opts.ValidateTags = cfg.ValidateTags
+	opts.ValidateMethods = cfg.ValidateMethods
+	opts.ParamsBuilders = cfg.ParamsBuilders
+	opts.ValidateClientRequests = cfg.ValidateClient
+	opts.EmbedSpecFile = cfg.EmbedSpecFile
+	opts.DocsUI = cfg.DocsUI
+	opts.TypesPackage = cfg.TypesPackage
+	opts.RegionMarkers = cfg.RegionMarkers || flagUpdate
 
 	if opts.GenerateEchoServer && opts.GenerateChiServer {
 		errExit("can not specify both server and chi-server targets simultaneously")
 	}
 
-	swagger, err := util.LoadSwagger(flag.Arg(0))
+	loadOpts := util.LoadOptions{
+		CacheDir: cfg.RefCacheDir,
+		LockFile: cfg.RefLockFile,
+		Offline:  cfg.Offline,
+	}
+	if loadOpts.CacheDir == "" {
+		loadOpts.CacheDir = util.DefaultCacheDir()
+	}
+	loadOpts.OnUnpinnedFetch = func(url string) {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: fetched %s without -ref-lockfile, so its content isn't pinned\n", url)
+	}
+
+	opts.ImportMapping = cfg.ImportMapping
+
+	switch mode {
+	case "lint":
+		os.Exit(lint(cfg, opts, loadOpts))
+	case "diff":
+		os.Exit(diff(cfg, opts, loadOpts))
+	}
+
+	if opts.EmbedSpec && opts.EmbedSpecFile != "" && cfg.OutputFile == "" {
+		errExit("-embed-spec-file requires an output file\n")
+	}
+
+	if flagUpdate && cfg.OutputFile == "" {
+		errExit("-update requires an output file\n")
+	}
+
+	if flagWatch {
+		if cfg.OutputFile == "" {
+			errExit("-watch requires an output file\n")
+		}
+		watchAndRegenerate(func() ([]string, error) {
+			return generate(cfg, opts, loadOpts)
+		})
+		return
+	}
+
+	if _, err := generate(cfg, opts, loadOpts); err != nil {
+		errExit("%s\n", err)
+	}
+}
+
+// generate loads the spec and generates the code for it, and returns the
+// local files it was generated from.
+func generate(cfg *configuration, opts codegen.Options, loadOpts util.LoadOptions) ([]string, error) {
+	files := []string{}
+	if flagConfigFile != "" {
+		files = append(files, flagConfigFile)
+	}
+	// The loader may read the documents concurrently
+	var mu sync.Mutex
+	loadOpts.OnLocalFile = func(path string) {
+		mu.Lock()
+		files = append(files, path)
+		mu.Unlock()
+	}
+
+	swagger, err := util.LoadSwaggerWithOptions(context.Background(), flag.Arg(0), loadOpts)
+	if err != nil {
+		return files, fmt.Errorf("error loading swagger spec in %s\n: %s", flag.Arg(0), err)
+	}
+
+	templates, err := loadTemplateOverrides(cfg.TemplatesDir)
 	if err != nil {
-		errExit("error loading swagger spec in %s\n: %s", flag.Arg(0), err)
+		return files, fmt.Errorf("error loading template overrides: %s", err)
+	}
+	opts.UserTemplates = templates
+	if cfg.TemplatesDir != "" {
+		files = append(files, cfg.TemplatesDir)
+		for name := range templates {
+			files = append(files, path.Join(cfg.TemplatesDir, name))
+		}
+	}
+
+	// With skip-fmt, the code is streamed to its destination, rather than
+	// held in memory, which matters for large specs. Otherwise, goimports
+	// needs all of it.
+	if cfg.OutputFile != "" && flagUpdate {
+		err = updateOutputFile(cfg.OutputFile, func(w io.Writer) error {
+			return codegen.GenerateTo(w, swagger, cfg.PackageName, opts)
+		})
+		if err != nil {
+			return files, fmt.Errorf("error generating code: %s", err)
+		}
+	} else if cfg.OutputFile != "" {
+		err = writeOutputFile(cfg.OutputFile, func(w io.Writer) error {
+			return codegen.GenerateTo(w, swagger, cfg.PackageName, opts)
+		})
+		if err != nil {
+			return files, fmt.Errorf("error generating code: %s", err)
+		}
+		// The spec is written once the code is generated, which may have
+		// pruned it.
+		if opts.EmbedSpec && opts.EmbedSpecFile != "" {
+			specFile := filepath.Join(filepath.Dir(cfg.OutputFile), opts.EmbedSpecFile)
+			err = writeOutputFile(specFile, func(w io.Writer) error {
+				spec, err := codegen.EmbeddedSpec(swagger)
+				if err != nil {
+					return err
+				}
+				_, err = w.Write(spec)
+				return err
+			})
+			if err != nil {
+				return files, fmt.Errorf("error writing embedded spec: %s", err)
+			}
+		}
+	} else {
+		err = codegen.GenerateTo(os.Stdout, swagger, cfg.PackageName, opts)
+		if err != nil {
+			return files, fmt.Errorf("error generating code: %s", err)
+		}
+		fmt.Println()
 	}
+	return files, nil
+}
 
+// lint reports the constructs in the spec which don't generate cleanly, and
+// returns the exit status: 1 when there are errors, or warnings in strict
+// mode.
+func lint(cfg *configuration, opts codegen.Options, loadOpts util.LoadOptions) int {
+	swagger, err := util.LoadSwaggerWithOptions(context.Background(), flag.Arg(0), loadOpts)
+	if err != nil {
+		errExit("error loading swagger spec in %s\n: %s\n", flag.Arg(0), err)
+	}
 	templates, err := loadTemplateOverrides(cfg.TemplatesDir)
 	if err != nil {
 		errExit("error loading template overrides: %s\n", err)
 	}
 	opts.UserTemplates = templates
 
-	opts.ImportMapping = cfg.ImportMapping
+	issues := codegen.Lint(swagger, opts)
 
-	code, err := codegen.Generate(swagger, cfg.PackageName, opts)
+	switch flagLintFormat {
+	case "json":
+		if issues == nil {
+			issues = []codegen.Diagnostic{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(issues); err != nil {
+			errExit("error writing lint issues: %s\n", err)
+		}
+	case "text":
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	default:
+		errExit("unknown lint format %s, valid options: text, json\n", flagLintFormat)
+	}
+
+	for _, issue := range issues {
+		if issue.Severity == codegen.SeverityError || flagLintStrict {
+			return 1
+		}
+	}
+	return 0
+}
+
+// diff reports the changes between the Go APIs generated from two specs, and
+// returns the exit status: 1 when there are breaking changes in strict mode.
+func diff(cfg *configuration, opts codegen.Options, loadOpts util.LoadOptions) int {
+	templates, err := loadTemplateOverrides(cfg.TemplatesDir)
 	if err != nil {
-		errExit("error generating code: %s\n", err)
+		errExit("error loading template overrides: %s\n", err)
 	}
+	opts.UserTemplates = templates
 
-	if cfg.OutputFile != "" {
-		err = ioutil.WriteFile(cfg.OutputFile, []byte(code), 0644)
+	var code [2]string
+	for i, specPath := range []string{flag.Arg(0), flag.Arg(1)} {
+		swagger, err := util.LoadSwaggerWithOptions(context.Background(), specPath, loadOpts)
 		if err != nil {
-			errExit("error writing generated code to file: %s", err)
+			errExit("error loading swagger spec in %s\n: %s\n", specPath, err)
 		}
-	} else {
-		fmt.Println(code)
+		artifacts, _, err := codegen.Generate(context.Background(), swagger, opts)
+		if err != nil {
+			errExit("error generating code for %s: %s\n", specPath, err)
+		}
+		code[i] = artifacts.Code
 	}
+
+	changes, err := codegen.DiffAPI(code[0], code[1])
+	if err != nil {
+		errExit("error comparing the generated code: %s\n", err)
+	}
+	bump := codegen.SemverBump(changes)
+
+	switch flagLintFormat {
+	case "json":
+		if changes == nil {
+			changes = []codegen.APIChange{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(struct {
+			Bump    string              `json:"bump"`
+			Changes []codegen.APIChange `json:"changes"`
+		}{bump, changes})
+		if err != nil {
+			errExit("error writing API changes: %s\n", err)
+		}
+	case "text":
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		fmt.Printf("version bump: %s\n", bump)
+	default:
+		errExit("unknown diff format %s, valid options: text, json\n", flagLintFormat)
+	}
+
+	if bump == "major" && flagLintStrict {
+		return 1
+	}
+	return 0
+}
+
+// writeOutputFile writes the generated code to a temporary file next to the
+// output file, which replaces the output file once it's complete, so that a
+// failure doesn't leave a truncated file behind.
+func writeOutputFile(outputFile string, generate func(w io.Writer) error) error {
+	f, err := ioutil.TempFile(filepath.Dir(outputFile), filepath.Base(outputFile)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := generate(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), outputFile)
+}
+
+// updateOutputFile updates the regions of the output file which differ from
+// the generated code, and leaves the file alone when none do, so that its
+// modification time only changes with its code. A missing file is written.
+func updateOutputFile(outputFile string, generate func(w io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := generate(&buf); err != nil {
+		return err
+	}
+	oldCode, err := ioutil.ReadFile(outputFile)
+	if os.IsNotExist(err) {
+		oldCode = nil
+	} else if err != nil {
+		return err
+	}
+
+	code, _, err := codegen.UpdateRegions(oldCode, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("error updating %s: %w", outputFile, err)
+	}
+	if oldCode != nil && bytes.Equal(code, oldCode) {
+		return nil
+	}
+	return writeOutputFile(outputFile, func(w io.Writer) error {
+		_, err := w.Write(code)
+		return err
+	})
 }
 
 func loadTemplateOverrides(templatesDir string) (map[string]string, error) {
@@ -237,5 +652,125 @@ func configFromFlags() *configuration {
 	if cfg.OutputFile == "" {
 		cfg.OutputFile = flagOutputFile
 	}
+	if !cfg.ExcludeDeprecated {
+		cfg.ExcludeDeprecated = flagExcludeDeprecated
+	}
+	if cfg.OperationIDCasing == "" {
+		cfg.OperationIDCasing = flagOperationIDCasing
+	}
+	if cfg.NameCollisionStrategy == "" {
+		cfg.NameCollisionStrategy = flagNameCollisionStrategy
+	}
+	if cfg.RefCacheDir == "" {
+		cfg.RefCacheDir = flagRefCacheDir
+	}
+	if cfg.RefLockFile == "" {
+		cfg.RefLockFile = flagRefLockFile
+	}
+	if !cfg.Offline {
+		cfg.Offline = flagOffline
+	}
+	if !cfg.EnumStringer {
+		cfg.EnumStringer = flagEnumStringer
+	}
+	if !cfg.EnumText {
+		cfg.EnumText = flagEnumText
+	}
+	if !cfg.EnumSQL {
+		cfg.EnumSQL = flagEnumSQL
+	}
+	if !cfg.YAMLTags {
+		cfg.YAMLTags = flagYAMLTags
+	}
+	if !cfg.MapstructureTags {
+		cfg.MapstructureTags = flagMapstructureTags
+	}
+	if !cfg.DeepCopy {
+		cfg.DeepCopy = flagDeepCopy
+	}
+	if cfg.JSONPackage == "" {
+		cfg.JSONPackage = flagJSONPackage
+	}
+	if cfg.JSONContentTypes == nil {
+		cfg.JSONContentTypes = util.ParseCommandLineList(flagJSONContentTypes)
+	}
+	if !cfg.ReadWriteModels {
+		cfg.ReadWriteModels = flagReadWriteModels
+	}
+	if !cfg.NullableType {
+		cfg.NullableType = flagNullableType
+	}
+	if !cfg.OptionalValues {
+		cfg.OptionalValues = flagOptionalValues
+	}
+	if cfg.ByteEncoding == "" {
+		cfg.ByteEncoding = flagByteEncoding
+	}
+	if !cfg.ApplyDefaults {
+		cfg.ApplyDefaults = flagApplyDefaults
+	}
+	if !cfg.ValidateTags {
+		cfg.ValidateTags = flagValidateTags
+	}
+	if !cfg.ValidateMethods {
+		cfg.ValidateMethods = flagValidateMethods
+	}
+	if !cfg.ParamsBuilders {
+		cfg.ParamsBuilders = flagParamsBuilders
+	}
+	if !cfg.ValidateClient {
+		cfg.ValidateClient = flagValidateClient
+	}
+	if cfg.EmbedSpecFile == "" {
+		cfg.EmbedSpecFile = flagEmbedSpecFile
+	}
+	if cfg.DocsUI == "" {
+		cfg.DocsUI = flagDocsUI
+	}
+	if cfg.TypesPackage == "" {
+		cfg.TypesPackage = flagTypesPackage
+	}
+	if !cfg.RegionMarkers {
+		cfg.RegionMarkers = flagRegionMarkers
+	}
+	if cfg.TimeFormats == nil && flagTimeFormats != "" {
+		var err error
+		cfg.TimeFormats, err = util.ParseCommandlineMap(flagTimeFormats)
+		if err != nil {
+			errExit("error parsing time-formats: %s\n", err)
+		}
+	}
+	if cfg.TypeMappings == nil && flagTypeMappings != "" {
+		var err error
+		cfg.TypeMappings, err = parseTypeMappings(flagTypeMappings)
+		if err != nil {
+			errExit("error parsing type-mappings: %s\n", err)
+		}
+	}
 	return &cfg
 }
+
+// parseTypeMappings parses the type mappings of the command line, whose Go
+// types are qualified by the import paths of their packages, which are
+// imported under the last element of their path, eg,
+// string/uuid:github.com/gofrs/uuid.UUID.
+func parseTypeMappings(src string) (map[string]codegen.TypeMapping, error) {
+	types, err := util.ParseCommandlineMap(src)
+	if err != nil {
+		return nil, err
+	}
+	mappings := map[string]codegen.TypeMapping{}
+	for key, goType := range types {
+		i := strings.LastIndex(goType, ".")
+		if i < 0 {
+			mappings[key] = codegen.TypeMapping{GoType: goType}
+			continue
+		}
+		importPath := goType[:i]
+		if importPath == "" || i == len(goType)-1 {
+			return nil, fmt.Errorf("type mapping %q: expected an import path and a type, got %s", key, goType)
+		}
+		mappings[key] = codegen.TypeMapping{GoType: path.Base(importPath) + goType[i:], Import: importPath}
+	}
+	return mappings, nil
+}
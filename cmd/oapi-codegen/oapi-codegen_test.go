@@ -3,6 +3,10 @@ package main
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/codegen"
 	"github.com/deepmap/oapi-codegen/pkg/util"
 )
 
@@ -24,3 +28,16 @@ func TestLoader(t *testing.T) {
 		}
 	}
 }
+
+func TestParseTypeMappings(t *testing.T) {
+	mappings, err := parseTypeMappings("string/uuid:github.com/gofrs/uuid.UUID,integer:int64,string/duration:time.Duration")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]codegen.TypeMapping{
+		"string/uuid":     {GoType: "uuid.UUID", Import: "github.com/gofrs/uuid"},
+		"integer":         {GoType: "int64"},
+		"string/duration": {GoType: "time.Duration", Import: "time"},
+	}, mappings)
+
+	_, err = parseTypeMappings("string/uuid:github.com/gofrs/uuid.")
+	assert.Error(t, err)
+}
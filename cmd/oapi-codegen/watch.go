@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"time"
+)
+
+const (
+	// watchInterval is how often the files are checked for changes
+	watchInterval = 250 * time.Millisecond
+	// watchDebounce is how long the files have to stay unchanged before
+	// regenerating, so that a burst of saves only regenerates once.
+	watchDebounce = 500 * time.Millisecond
+)
+
+// fileStamp identifies a version of a file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFiles(files []string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(files))
+	for _, f := range files {
+		// Missing files get a zero stamp, so that creating them again counts
+		// as a change.
+		var stamp fileStamp
+		if info, err := os.Stat(f); err == nil {
+			stamp = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+		stamps[f] = stamp
+	}
+	return stamps
+}
+
+// watchAndRegenerate generates the code, and then generates it again whenever
+// one of the files it was generated from changes, until interrupted. When
+// interrupted, it prints a summary, and exits with an error if the last
+// generation failed.
+func watchAndRegenerate(generate func() ([]string, error)) {
+	var runs, failures int
+	run := func() ([]string, error) {
+		files, err := generate()
+		runs++
+		if err != nil {
+			failures++
+			fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().Format("15:04:05"), err)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s: generated code\n", time.Now().Format("15:04:05"))
+		}
+		return files, err
+	}
+
+	files, lastErr := run()
+	stamps := statFiles(files)
+	fmt.Fprintf(os.Stderr, "watching %d files for changes, press Ctrl+C to stop\n", len(files))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var changedAt time.Time
+	for {
+		select {
+		case <-interrupt:
+			fmt.Fprintf(os.Stderr, "generated %d times, %d failed\n", runs, failures)
+			if lastErr != nil {
+				os.Exit(1)
+			}
+			return
+		case <-ticker.C:
+			current := statFiles(files)
+			if !reflect.DeepEqual(current, stamps) {
+				stamps = current
+				changedAt = time.Now()
+				continue
+			}
+			if changedAt.IsZero() || time.Since(changedAt) < watchDebounce {
+				continue
+			}
+			changedAt = time.Time{}
+			files, lastErr = run()
+			stamps = statFiles(files)
+		}
+	}
+}
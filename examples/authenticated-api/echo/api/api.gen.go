@@ -16,7 +16,11 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 )
@@ -53,6 +57,58 @@ type AddThingJSONBody Thing
 // AddThingJSONRequestBody defines body for AddThing for application/json ContentType.
 type AddThingJSONRequestBody AddThingJSONBody
 
+// ListThingsRoute is the route of ListThings, as in the spec.
+const ListThingsRoute = "/things"
+
+// AddThingRoute is the route of AddThing, as in the spec.
+const AddThingRoute = "/things"
+
+// BuildListThingsURL builds the URL of ListThings on server, serializing its
+// path and query parameters like the client does.
+func BuildListThingsURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/things")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildAddThingURL builds the URL of AddThing on server, serializing its
+// path and query parameters like the client does.
+func BuildAddThingURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/things")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -71,6 +127,17 @@ type Client struct {
 	// paths in the swagger spec will be appended to the server.
 	Server string
 
+	// Servers are the endpoints which the requests are sent to, as picked by
+	// the ServerPolicy, when there are several, which WithServers sets up.
+	// Server is the first one.
+	Servers []string
+
+	// ServerPolicy is how the servers of the requests are picked.
+	ServerPolicy runtime.ServerPolicy
+
+	// requests counts the requests, for picking their servers in turn.
+	requests uint32
+
 	// Doer for performing requests, typically a *http.Client with any
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
@@ -78,6 +145,26 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// RateLimiter, when set, is waited on before sending each request, with
+	// the ID of its operation.
+	RateLimiter runtime.RateLimiter
+
+	// MaxRetryAfter is how long, in total, a request which is rate limited,
+	// with a 429 status and a Retry-After header, is retried for, after
+	// waiting as long as the server asks, and at least
+	// runtime.MinRetryAfter. It isn't retried when it's zero.
+	MaxRetryAfter time.Duration
+
+	// MaxResponseSize is the maximum size of the response bodies, in bytes,
+	// beyond which reading them fails with a *runtime.ResponseTooLargeError,
+	// unless the operation has its own, from x-max-response-size. The
+	// bodies aren't limited when it's zero.
+	MaxResponseSize int64
+
+	// recorder, which WithRecorder sets, sends the requests through the
+	// Client once all the options are applied.
+	recorder *runtime.Recorder
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -99,6 +186,16 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if !strings.HasSuffix(client.Server, "/") {
 		client.Server += "/"
 	}
+	for i, server := range client.Servers {
+		if !strings.HasSuffix(server, "/") {
+			client.Servers[i] = server + "/"
+		}
+	}
+	// send the requests of the Client through the recorder, whatever the
+	// order of the options
+	if client.recorder != nil {
+		client.Client = &http.Client{Transport: client.recorder.WithDoer(client.Client)}
+	}
 	// create httpClient, if not already present
 	if client.Client == nil {
 		client.Client = &http.Client{}
@@ -115,6 +212,26 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 	}
 }
 
+// WithServers makes the client send its requests to several servers, such as
+// the ones declared in the spec, instead of the one given to NewClient. They
+// are picked by policy: with runtime.ServerFailover, the requests fail over
+// to the next servers when one can't be reached, or, for idempotent requests,
+// responds with a 5xx status, runtime.ServerFailoverAnyMethod fails over the
+// requests of any method on 5xx statuses too, and runtime.ServerRoundRobin
+// sends them to each server in turn.
+// The server of a request is picked before the request editors run.
+func WithServers(policy runtime.ServerPolicy, servers ...string) ClientOption {
+	return func(c *Client) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("no servers given")
+		}
+		c.Server = servers[0]
+		c.Servers = append([]string{}, servers...)
+		c.ServerPolicy = policy
+		return nil
+	}
+}
+
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
@@ -124,6 +241,59 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithAccept sets the Accept header of a request, eg, to ask for one of the
+// content types of the responses of its operation, rather than all of them,
+// which the request builders list.
+func WithAccept(accept string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", accept)
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, eg, for a token of the bucket of its operation.
+func WithRateLimiter(limiter runtime.RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRetryAfter makes the client retry the requests which are rate limited,
+// with a 429 status and a Retry-After header, after waiting as long as the
+// server asks, for up to maxWait in total. The response is returned when the
+// server asks to wait longer.
+func WithRetryAfter(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.MaxRetryAfter = maxWait
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies which the client
+// reads to maxSize bytes, so that a misbehaving server can't exhaust its
+// memory. Reading larger bodies, such as when parsing them, fails with a
+// *runtime.ResponseTooLargeError. The operations with x-max-response-size
+// have their own limit.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		c.MaxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithRecorder makes the client send its requests through recorder, which
+// records their responses to golden files, or replays them, in tests. The
+// requests which are recorded are sent with the Doer of WithHTTPClient, if
+// any, unless recorder has a Transport of its own.
+func WithRecorder(recorder *runtime.Recorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// ListThings request
@@ -136,56 +306,50 @@ type ClientInterface interface {
 }
 
 func (c *Client) ListThings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListThingsRequest(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "ListThings", func(server string) (*http.Request, error) {
+		return NewListThingsRequest(server)
+	}, reqEditors)
 }
 
 func (c *Client) AddThingWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewAddThingRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "AddThing", func(server string) (*http.Request, error) {
+		return NewAddThingRequestWithBody(server, contentType, body)
+	}, reqEditors)
 }
 
 func (c *Client) AddThing(ctx context.Context, body AddThingJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewAddThingRequest(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "AddThing", func(server string) (*http.Request, error) {
+		return NewAddThingRequest(server, body)
+	}, reqEditors)
 }
 
-// NewListThingsRequest generates requests for ListThings
-func NewListThingsRequest(server string) (*http.Request, error) {
-	var err error
+// NewListThingsRequest builds the request which ListThings sends, without
+// sending it.
+func (c *Client) NewListThingsRequest(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "ListThings", func(server string) (*http.Request, error) {
+		return NewListThingsRequest(server)
+	}, reqEditors)
+}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+// NewAddThingRequestWithBody builds the request which AddThingWithBody sends, without
+// sending it.
+func (c *Client) NewAddThingRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "AddThing", func(server string) (*http.Request, error) {
+		return NewAddThingRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
 
-	operationPath := fmt.Sprintf("/things")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+// NewAddThingRequest builds the request which AddThing sends, without
+// sending it.
+func (c *Client) NewAddThingRequest(ctx context.Context, body AddThingJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "AddThing", func(server string) (*http.Request, error) {
+		return NewAddThingRequest(server, body)
+	}, reqEditors)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+// NewListThingsRequest generates requests for ListThings
+func NewListThingsRequest(server string) (*http.Request, error) {
+	queryURL, err := BuildListThingsURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -195,6 +359,8 @@ func NewListThingsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "application/json")
+
 	return req, nil
 }
 
@@ -211,30 +377,120 @@ func NewAddThingRequest(server string, body AddThingJSONRequestBody) (*http.Requ
 
 // NewAddThingRequestWithBody generates requests for AddThing with any type of body
 func NewAddThingRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+	queryURL, err := BuildAddThingURL(server)
+	if err != nil {
+		return nil, err
+	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/things")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req.Header.Add("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	return req, nil
+}
+
+// do sends the request built by newRequest for a server, which is picked by
+// the ServerPolicy, after editing it, once the RateLimiter lets the operation
+// through. The context of the request carries the operation ID, for the
+// editors and the Doer. When it fails over, or is retried after being rate limited, the
+// request is built again, with the body of the first one, unless the body
+// can't be read again.
+func (c *Client) do(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Response, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	var first *http.Request
+	build := func(server string) (*http.Request, error) {
+		req, err := newRequest(server)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = req
+		} else if first.GetBody != nil {
+			if req.Body, err = first.GetBody(); err != nil {
+				return nil, err
+			}
+			req.GetBody = first.GetBody
+			req.ContentLength = first.ContentLength
+		}
+		return req.WithContext(ctx), nil
+	}
+	replayable := func() bool {
+		return first.Body == nil || first.Body == http.NoBody || first.GetBody != nil
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+	var waited time.Duration
+	for {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, operationID); err != nil {
+				return nil, err
+			}
+		}
+		rsp, err := c.send(ctx, build, replayable, reqEditors)
+		if err != nil || c.MaxRetryAfter <= 0 || !replayable() {
+			return rsp, err
+		}
+		wait, retry := runtime.RetryAfter(rsp, time.Now())
+		if !retry || waited+wait > c.MaxRetryAfter {
+			return rsp, nil
+		}
+		_, _ = io.Copy(ioutil.Discard, rsp.Body)
+		rsp.Body.Close()
+		if err := runtime.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		waited += wait
 	}
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
+// send sends the request built by build to the servers, in the order of the
+// ServerPolicy, until one of them doesn't fail over.
+func (c *Client) send(ctx context.Context, build func(server string) (*http.Request, error), replayable func() bool, reqEditors []RequestEditorFn) (*http.Response, error) {
+	servers := []string{c.Server}
+	if len(c.Servers) > 0 {
+		servers = runtime.ServerOrder(c.ServerPolicy, c.Servers, atomic.AddUint32(&c.requests, 1)-1)
 	}
+	for i, server := range servers {
+		req, err := build(server)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+			return nil, err
+		}
+		rsp, err := c.Client.Do(req)
+		if i == len(servers)-1 || !replayable() || !runtime.ShouldFailOver(ctx, c.ServerPolicy, req, rsp, err) {
+			return runtime.LimitResponseBody(rsp, c.maxResponseSize(runtime.OperationIDFromContext(ctx))), err
+		}
+		if rsp != nil {
+			_, _ = io.Copy(ioutil.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
+	}
+	return nil, fmt.Errorf("no servers")
+}
 
-	req.Header.Add("Content-Type", contentType)
+// maxResponseSize returns the maximum size of the response bodies of the
+// operation with the given ID, or zero when they aren't limited.
+func (c *Client) maxResponseSize(operationID string) int64 {
+	return c.MaxResponseSize
+}
 
+// newRequest builds the request of the operation, like do does, for the
+// first server, with ctx, after editing it, without sending it.
+func (c *Client) newRequest(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Request, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	req, err := newRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -267,7 +523,7 @@ func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithRes
 	return &ClientWithResponses{client}, nil
 }
 
-// WithBaseURL overrides the baseURL.
+// WithBaseURL overrides the baseURL, and the servers set by WithServers.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
@@ -275,6 +531,7 @@ func WithBaseURL(baseURL string) ClientOption {
 			return err
 		}
 		c.Server = newBaseURL.String()
+		c.Servers = nil
 		return nil
 	}
 }
@@ -360,6 +617,52 @@ func (c *ClientWithResponses) AddThingWithResponse(ctx context.Context, body Add
 	return ParseAddThingResponse(rsp)
 }
 
+// BatchCall is a call of an operation in a batch, which returns its
+// *...Response, eg,
+//
+//	func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+//		return c.FindPetByIdWithResponse(ctx, id)
+//	}
+type BatchCall func(ctx context.Context, client ClientWithResponsesInterface) (interface{}, error)
+
+// BatchResult is the result of a call in a batch.
+type BatchResult struct {
+	Response interface{} // The *...Response of the operation, if it was called
+	Err      error
+}
+
+// Batch makes the calls with the client, with at most concurrency of them at
+// a time, or all at once when concurrency isn't positive, and returns their
+// results in the order of the calls. A failed call doesn't stop the others,
+// but those which haven't started once ctx is done fail with its error.
+func (c *ClientWithResponses) Batch(ctx context.Context, concurrency int, calls ...BatchCall) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	errs := runtime.RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+		var err error
+		results[i].Response, err = calls[i](ctx, c)
+		return err
+	})
+	for i, err := range errs {
+		results[i].Err = err
+	}
+	return results
+}
+
+// ParseOperationResponse parses an HTTP response to a request of the
+// operation with the given ID, such as one built by a New...Request method of
+// the Client, which runtime.OperationIDFromContext tells from the context of
+// the request, into the *...Response of the operation.
+func ParseOperationResponse(operationID string, rsp *http.Response) (interface{}, error) {
+	switch operationID {
+	case "ListThings":
+		return ParseListThingsResponse(rsp)
+	case "AddThing":
+		return ParseAddThingResponse(rsp)
+	}
+	_ = rsp.Body.Close()
+	return nil, fmt.Errorf("unknown operation %s", operationID)
+}
+
 // ParseListThingsResponse parses an HTTP response from a ListThingsWithResponse call
 func ParseListThingsResponse(rsp *http.Response) (*ListThingsResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -424,7 +727,8 @@ type ServerInterface interface {
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	Handler             ServerInterface
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // ListThings converts echo context to params.
@@ -464,21 +768,62 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+	BaseURL string
+	// RouteMiddlewares returns the middlewares of the route of an operation.
+	RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+	// RouteName names the route of an operation, which it's registered
+	// under, for echo.Echo.Reverse.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute func(route runtime.Route)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{})
 }
 
 // Registers handlers, and prepends BaseURL to the paths, so that the paths
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
 
 	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+		Handler:             si,
+		BindErrorTranslator: options.BindErrorTranslator,
 	}
 
-	router.GET(baseURL+"/things", wrapper.ListThings)
-	router.POST(baseURL+"/things", wrapper.AddThing)
+	register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route, handler echo.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []echo.MiddlewareFunc
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		if added := add(route.RouterPath, handler, middlewares...); route.Name != "" {
+			added.Name = route.Name
+		}
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
+	}
+
+	register(runtime.Route{OperationID: "ListThings", Method: "GET", Path: "/things", RouterPath: "/things"}, router.GET, wrapper.ListThings)
+	register(runtime.Route{OperationID: "AddThing", Method: "POST", Path: "/things", RouterPath: "/things"}, router.POST, wrapper.AddThing)
 
 }
 
@@ -518,14 +863,19 @@ func decodeSpec() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var rawSpec = decodeSpecCached()
+var (
+	rawSpecOnce sync.Once
+	rawSpecData []byte
+	rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+	rawSpecOnce.Do(func() {
+		rawSpecData, rawSpecErr = decodeSpec()
+	})
+	return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
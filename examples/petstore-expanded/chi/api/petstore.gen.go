@@ -4,14 +4,12 @@
 package api
 
 import (
-	"bytes"
-	"compress/gzip"
-	"encoding/base64"
+	_ "embed"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
-	"strings"
+	"sync"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -78,9 +76,10 @@ type ServerInterface interface {
 
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	Handler             ServerInterface
+	HandlerMiddlewares  []MiddlewareFunc
+	ErrorHandlerFunc    func(w http.ResponseWriter, r *http.Request, err error)
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
@@ -90,6 +89,7 @@ func (siw *ServerInterfaceWrapper) FindPets(w http.ResponseWriter, r *http.Reque
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// Parameter object where we will unmarshal all parameters from the context
 	var params FindPetsParams
@@ -101,7 +101,8 @@ func (siw *ServerInterfaceWrapper) FindPets(w http.ResponseWriter, r *http.Reque
 
 	err = runtime.BindQueryParameter("form", true, false, "tags", r.URL.Query(), &params.Tags)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tags", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "tags", runtime.ParamLocationQuery, err),
+			&InvalidParamFormatError{ParamName: "tags", Err: err}))
 		return
 	}
 
@@ -112,7 +113,8 @@ func (siw *ServerInterfaceWrapper) FindPets(w http.ResponseWriter, r *http.Reque
 
 	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "limit", runtime.ParamLocationQuery, err),
+			&InvalidParamFormatError{ParamName: "limit", Err: err}))
 		return
 	}
 
@@ -147,13 +149,15 @@ func (siw *ServerInterfaceWrapper) DeletePet(w http.ResponseWriter, r *http.Requ
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// ------------- Path parameter "id" -------------
 	var id int64
 
 	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			&InvalidParamFormatError{ParamName: "id", Err: err}))
 		return
 	}
 
@@ -173,13 +177,15 @@ func (siw *ServerInterfaceWrapper) FindPetByID(w http.ResponseWriter, r *http.Re
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// ------------- Path parameter "id" -------------
 	var id int64
 
 	err = runtime.BindStyledParameter("simple", false, "id", chi.URLParam(r, "id"), &id)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			&InvalidParamFormatError{ParamName: "id", Err: err}))
 		return
 	}
 
@@ -269,10 +275,22 @@ func Handler(si ServerInterface) http.Handler {
 }
 
 type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
+	BaseURL     string
+	BaseRouter  chi.Router
+	Middlewares []MiddlewareFunc
+	// RouteMiddlewares returns the chi middlewares of the route of an
+	// operation, which run before its parameters are bound, and before the
+	// Middlewares.
+	RouteMiddlewares func(route runtime.Route) []func(http.Handler) http.Handler
+	// RouteName names the route of an operation.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute          func(route runtime.Route)
 	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
@@ -302,88 +320,59 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		}
 	}
 	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+		Handler:             si,
+		HandlerMiddlewares:  options.Middlewares,
+		ErrorHandlerFunc:    options.ErrorHandlerFunc,
+		BindErrorTranslator: options.BindErrorTranslator,
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/pets", wrapper.FindPets)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/pets", wrapper.AddPet)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/pets/{id}", wrapper.DeletePet)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/pets/{id}", wrapper.FindPetByID)
-	})
+	register := func(route runtime.Route, handler http.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []func(http.Handler) http.Handler
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		r.With(middlewares...).MethodFunc(route.Method, route.RouterPath, handler)
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
+	}
+
+	register(runtime.Route{OperationID: "FindPets", Method: "GET", Path: "/pets", RouterPath: "/pets"}, wrapper.FindPets)
+	register(runtime.Route{OperationID: "AddPet", Method: "POST", Path: "/pets", RouterPath: "/pets"}, wrapper.AddPet)
+	register(runtime.Route{OperationID: "DeletePet", Method: "DELETE", Path: "/pets/{id}", RouterPath: "/pets/{id}"}, wrapper.DeletePet)
+	register(runtime.Route{OperationID: "FindPetByID", Method: "GET", Path: "/pets/{id}", RouterPath: "/pets/{id}"}, wrapper.FindPetByID)
 
 	return r
 }
 
-// Base64 encoded, gzipped, json marshaled Swagger object
-var swaggerSpec = []string{
-
-	"H4sIAAAAAAAC/+RXW48budH9KwV+32OnNbEXedBTvB4vICBrT+LdvKznoYZdkmrBSw9Z1FgY6L8HRbZu",
-	"I3k2QYIgQV506WY1T51zqlj9bGz0YwwUJJv5s8l2TR7rzw8pxaQ/xhRHSsJUL9s4kH4PlG3iUTgGM2+L",
-	"od7rzDImj2LmhoO8fWM6I9uR2l9aUTK7znjKGVfffND+9iE0S+KwMrtdZxI9Fk40mPkvZtpwv/x+15mP",
-	"9HRHcok7oL+y3Uf0BHEJsiYYSS437Izg6jLup+34etwLoHV3hTdhQ+c+Lc38l2fz/4mWZm7+b3YUYjap",
-	"MJty2XUvk+HhEtLPgR8LAQ/nuE7F+MN3V8R4gZQHc7+73+llDsvYJA+CtuImj+zM3ODIQuj/mJ9wtaLU",
-	"czTdRLH53K7Bu7sF/EToTWdK0qC1yDifzU5idt2LJN5BRj86qsGyRoGSKQNqMlliIsAMGIC+tmUSYSAf",
-	"Q5aEQrAklJIoA4dKwaeRgj7pbX8DeSTLS7ZYt+qMY0sh09Eb5t2Idk3wpr85g5zns9nT01OP9XYf02o2",
-	"xebZnxbvP3z8/OF3b/qbfi3eVcNQ8vnT8jOlDVu6lvesLpmpGCzulLO7KU3TmQ2l3Ej5fX/T3+iT40gB",
-	"RzZz87Ze6syIsq6OmClB+mPVDHZO619ISgoZ0LnKJCxT9JWhvM1CvlGt/0umBGsl2VrKGSR+CR/RQ6YB",
-	"bAwDewpSPFCWHn5EshQwg5AfY4KMKxbhDBlHptBBIAtpHYMtGTL5kwUsgJ6kh3cUCAOgwCrhhgcELKtC",
-	"HaAFRlsc19Ae3peEDywlQRw4gouJfAcxBUwEtCIBcjShC2Q7sCXlkrUgHFkpuYfbwhk8g5Q0cu5gLG7D",
-	"AZPuRSlq0h0IB8tDCQIbTFwy/FqyxB4WAdZoYa0gMGeC0aEQwsBWilc6Fq2kNBcceORsOawAg2g2x9wd",
-	"r4rDQ+bjGhNJwj2Juh58dJSFCdiPlAZWpv7KG/QtIXT8WNDDwKjMJMzwqLltyLFAiAEkJolJKeElheGw",
-	"ew93CSlTEIVJgf0RQEkBYRNdkREFNhQooAJu5OqHx5L0GYtwfPKS0sT6Ei07zmeb1B30ozvqayHHAR2p",
-	"sEOnPFpKKJqYfvfwueSRwsDKskM1zxBdTJ06MJMVdXPNslpFs+5gQ2u2xSFoY0tD8eD4gVLs4ceYHhio",
-	"cPZxOJVBb1djO7QcGPsv4Uv4TENVomRYkprPxYeYagDFo2NSkVR8D1obHusDJ/I5uw6onFVLkxxcUR+q",
-	"O3u4W2Mm51phjJSm8EpzlZcEllgsP5RGOO730XWn8Rtyk3S8oZSwO99a6wR46A6FGPhh3cPPAiM5R0Eo",
-	"67kxxlxIK2lfRD0oFbivAi26PZf7J+3Tqkx2FcjBFqEEC5I4Sz2WNixIPfxQsiUgqd1gKHyoAu0U2ZKj",
-	"xBVO8+8+wKtbClbz2OIzBvC40pTJTWr18OfSQn10qltTj0rzzhFKd2g+gMVqkbSVkz1b2pM5piZzqEY1",
-	"iwoMHLojlKlwA2feA86KwbKUgRVqzghF9j6bhGw7nZFW9+vh7lSYytyEcUwkXPxJ52qmKd2Jv7X19l/0",
-	"iNORoR53i8HMzQ8cBj1f6rGRlABKuc4g54eF4Er7PizZCSV42BodBczcPBZK2+M5r+tMN42MdSoR8vUM",
-	"upyh2gVMCbf6P8u2Hns6nNTx5hyBx6/stY0X/0BJ55lEuTipsFI9y76BybFnOQP1m8Po7l4HoDxqa6no",
-	"39zc7KceCm1aG0c3DQ6zX7NCfL6W9mujXJvjXhCxu5h/RhLYg2nT0RKLk38Iz2sw2lB/ZeMS6OuorVV7",
-	"cFvTmVy8x7S9MkAotjHmK6PG+0QodWQL9KRr97NYnWv0DG7YdYmOc87FJxouzPpuUK+aNptSlu/jsP2X",
-	"sbCfqy9puCNRj+Ew6NcBtjmdkSUV2v2TnvlNq/z3WONC8Hq/zqOzZx52zSKO5MrrV7uusZnDytV3FnhA",
-	"bbOxuWZxC7loTlc8clujm01e7WiLW+0hY9N2wjL1Dx2gj+2Dhwulv9VLrr9LXfaS7y6zViANxfCfJOTt",
-	"QYyqwhYWtwrv9ReKc8UOOi5uv3X8fL+t9/5+vZYkdv1vk+t/toxfKNrUr0sobfYynb3H71/J+5MXW307",
-	"3d3v/hYAAP//wO3O5VcSAAA=",
-}
-
-// GetSwagger returns the content of the embedded swagger specification file
-// or error if failed to decode
-func decodeSpec() ([]byte, error) {
-	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
-	if err != nil {
-		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
-	}
-	zr, err := gzip.NewReader(bytes.NewReader(zipped))
-	if err != nil {
-		return nil, fmt.Errorf("error decompressing spec: %s", err)
-	}
-	var buf bytes.Buffer
-	_, err = buf.ReadFrom(zr)
-	if err != nil {
-		return nil, fmt.Errorf("error decompressing spec: %s", err)
-	}
+// swaggerSpec is the json marshaled Swagger object, embedded from petstore.gen.json
+//
+//go:embed petstore.gen.json
+var swaggerSpec []byte
 
-	return buf.Bytes(), nil
+// decodeSpec returns the content of the embedded swagger specification file
+func decodeSpec() ([]byte, error) {
+	return swaggerSpec, nil
 }
 
-var rawSpec = decodeSpecCached()
+var (
+	rawSpecOnce sync.Once
+	rawSpecData []byte
+	rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+	rawSpecOnce.Do(func() {
+		rawSpecData, rawSpecErr = decodeSpec()
+	})
+	return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -36,7 +37,8 @@ type ServerInterface interface {
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	Handler             ServerInterface
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // FindPets converts echo context to params.
@@ -49,14 +51,16 @@ func (w *ServerInterfaceWrapper) FindPets(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, false, "tags", ctx.QueryParams(), &params.Tags)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tags: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "tags", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tags: %s", err)))
 	}
 
 	// ------------- Optional query parameter "limit" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "limit", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -81,7 +85,8 @@ func (w *ServerInterfaceWrapper) DeletePet(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -97,7 +102,8 @@ func (w *ServerInterfaceWrapper) FindPetByID(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -120,23 +126,64 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+	BaseURL string
+	// RouteMiddlewares returns the middlewares of the route of an operation.
+	RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+	// RouteName names the route of an operation, which it's registered
+	// under, for echo.Echo.Reverse.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute func(route runtime.Route)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{})
 }
 
 // Registers handlers, and prepends BaseURL to the paths, so that the paths
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
 
 	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+		Handler:             si,
+		BindErrorTranslator: options.BindErrorTranslator,
+	}
+
+	register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route, handler echo.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []echo.MiddlewareFunc
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		if added := add(route.RouterPath, handler, middlewares...); route.Name != "" {
+			added.Name = route.Name
+		}
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
 	}
 
-	router.GET(baseURL+"/pets", wrapper.FindPets)
-	router.POST(baseURL+"/pets", wrapper.AddPet)
-	router.DELETE(baseURL+"/pets/:id", wrapper.DeletePet)
-	router.GET(baseURL+"/pets/:id", wrapper.FindPetByID)
+	register(runtime.Route{OperationID: "FindPets", Method: "GET", Path: "/pets", RouterPath: "/pets"}, router.GET, wrapper.FindPets)
+	register(runtime.Route{OperationID: "AddPet", Method: "POST", Path: "/pets", RouterPath: "/pets"}, router.POST, wrapper.AddPet)
+	register(runtime.Route{OperationID: "DeletePet", Method: "DELETE", Path: "/pets/{id}", RouterPath: "/pets/:id"}, router.DELETE, wrapper.DeletePet)
+	register(runtime.Route{OperationID: "FindPetByID", Method: "GET", Path: "/pets/{id}", RouterPath: "/pets/:id"}, router.GET, wrapper.FindPetByID)
 
 }
 
@@ -193,14 +240,19 @@ func decodeSpec() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var rawSpec = decodeSpecCached()
+var (
+	rawSpecOnce sync.Once
+	rawSpecData []byte
+	rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+	rawSpecOnce.Do(func() {
+		rawSpecData, rawSpecErr = decodeSpec()
+	})
+	return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
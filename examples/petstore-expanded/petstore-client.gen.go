@@ -13,6 +13,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 )
@@ -59,6 +61,160 @@ type AddPetJSONBody NewPet
 // AddPetJSONRequestBody defines body for AddPet for application/json ContentType.
 type AddPetJSONRequestBody AddPetJSONBody
 
+// FindPetsRoute is the route of FindPets, as in the spec.
+const FindPetsRoute = "/pets"
+
+// AddPetRoute is the route of AddPet, as in the spec.
+const AddPetRoute = "/pets"
+
+// DeletePetRoute is the route of DeletePet, as in the spec.
+const DeletePetRoute = "/pets/{id}"
+
+// FindPetByIDRoute is the route of FindPetByID, as in the spec.
+const FindPetByIDRoute = "/pets/{id}"
+
+// BuildFindPetsURL builds the URL of FindPets on server, serializing its
+// path and query parameters like the client does.
+func BuildFindPetsURL(server string, params *FindPetsParams) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Tags != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tags", runtime.ParamLocationQuery, *params.Tags); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Limit != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	return queryURL, nil
+}
+
+// BuildAddPetURL builds the URL of AddPet on server, serializing its
+// path and query parameters like the client does.
+func BuildAddPetURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildDeletePetURL builds the URL of DeletePet on server, serializing its
+// path and query parameters like the client does.
+func BuildDeletePetURL(server string, id int64) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildFindPetByIDURL builds the URL of FindPetByID on server, serializing its
+// path and query parameters like the client does.
+func BuildFindPetByIDURL(server string, id int64) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -77,6 +233,17 @@ type Client struct {
 	// paths in the swagger spec will be appended to the server.
 	Server string
 
+	// Servers are the endpoints which the requests are sent to, as picked by
+	// the ServerPolicy, when there are several, which WithServers sets up.
+	// Server is the first one.
+	Servers []string
+
+	// ServerPolicy is how the servers of the requests are picked.
+	ServerPolicy runtime.ServerPolicy
+
+	// requests counts the requests, for picking their servers in turn.
+	requests uint32
+
 	// Doer for performing requests, typically a *http.Client with any
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
@@ -84,6 +251,26 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// RateLimiter, when set, is waited on before sending each request, with
+	// the ID of its operation.
+	RateLimiter runtime.RateLimiter
+
+	// MaxRetryAfter is how long, in total, a request which is rate limited,
+	// with a 429 status and a Retry-After header, is retried for, after
+	// waiting as long as the server asks, and at least
+	// runtime.MinRetryAfter. It isn't retried when it's zero.
+	MaxRetryAfter time.Duration
+
+	// MaxResponseSize is the maximum size of the response bodies, in bytes,
+	// beyond which reading them fails with a *runtime.ResponseTooLargeError,
+	// unless the operation has its own, from x-max-response-size. The
+	// bodies aren't limited when it's zero.
+	MaxResponseSize int64
+
+	// recorder, which WithRecorder sets, sends the requests through the
+	// Client once all the options are applied.
+	recorder *runtime.Recorder
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -105,6 +292,16 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if !strings.HasSuffix(client.Server, "/") {
 		client.Server += "/"
 	}
+	for i, server := range client.Servers {
+		if !strings.HasSuffix(server, "/") {
+			client.Servers[i] = server + "/"
+		}
+	}
+	// send the requests of the Client through the recorder, whatever the
+	// order of the options
+	if client.recorder != nil {
+		client.Client = &http.Client{Transport: client.recorder.WithDoer(client.Client)}
+	}
 	// create httpClient, if not already present
 	if client.Client == nil {
 		client.Client = &http.Client{}
@@ -121,6 +318,26 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 	}
 }
 
+// WithServers makes the client send its requests to several servers, such as
+// the ones declared in the spec, instead of the one given to NewClient. They
+// are picked by policy: with runtime.ServerFailover, the requests fail over
+// to the next servers when one can't be reached, or, for idempotent requests,
+// responds with a 5xx status, runtime.ServerFailoverAnyMethod fails over the
+// requests of any method on 5xx statuses too, and runtime.ServerRoundRobin
+// sends them to each server in turn.
+// The server of a request is picked before the request editors run.
+func WithServers(policy runtime.ServerPolicy, servers ...string) ClientOption {
+	return func(c *Client) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("no servers given")
+		}
+		c.Server = servers[0]
+		c.Servers = append([]string{}, servers...)
+		c.ServerPolicy = policy
+		return nil
+	}
+}
+
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
@@ -130,6 +347,59 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithAccept sets the Accept header of a request, eg, to ask for one of the
+// content types of the responses of its operation, rather than all of them,
+// which the request builders list.
+func WithAccept(accept string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", accept)
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, eg, for a token of the bucket of its operation.
+func WithRateLimiter(limiter runtime.RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRetryAfter makes the client retry the requests which are rate limited,
+// with a 429 status and a Retry-After header, after waiting as long as the
+// server asks, for up to maxWait in total. The response is returned when the
+// server asks to wait longer.
+func WithRetryAfter(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.MaxRetryAfter = maxWait
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies which the client
+// reads to maxSize bytes, so that a misbehaving server can't exhaust its
+// memory. Reading larger bodies, such as when parsing them, fails with a
+// *runtime.ResponseTooLargeError. The operations with x-max-response-size
+// have their own limit.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		c.MaxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithRecorder makes the client send its requests through recorder, which
+// records their responses to golden files, or replays them, in tests. The
+// requests which are recorded are sent with the Doer of WithHTTPClient, if
+// any, unless recorder has a Transport of its own.
+func WithRecorder(recorder *runtime.Recorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// FindPets request
@@ -148,125 +418,89 @@ type ClientInterface interface {
 }
 
 func (c *Client) FindPets(ctx context.Context, params *FindPetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewFindPetsRequest(c.Server, params)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "FindPets", func(server string) (*http.Request, error) {
+		return NewFindPetsRequest(server, params)
+	}, reqEditors)
 }
 
 func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewAddPetRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "AddPet", func(server string) (*http.Request, error) {
+		return NewAddPetRequestWithBody(server, contentType, body)
+	}, reqEditors)
 }
 
 func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewAddPetRequest(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "AddPet", func(server string) (*http.Request, error) {
+		return NewAddPetRequest(server, body)
+	}, reqEditors)
 }
 
 func (c *Client) DeletePet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeletePetRequest(c.Server, id)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "DeletePet", func(server string) (*http.Request, error) {
+		return NewDeletePetRequest(server, id)
+	}, reqEditors)
 }
 
 func (c *Client) FindPetByID(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewFindPetByIDRequest(c.Server, id)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "FindPetByID", func(server string) (*http.Request, error) {
+		return NewFindPetByIDRequest(server, id)
+	}, reqEditors)
 }
 
-// NewFindPetsRequest generates requests for FindPets
-func NewFindPetsRequest(server string, params *FindPetsParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/pets")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
-
-	queryValues := queryURL.Query()
-
-	if params.Tags != nil {
+// NewFindPetsRequest builds the request which FindPets sends, without
+// sending it.
+func (c *Client) NewFindPetsRequest(ctx context.Context, params *FindPetsParams, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "FindPets", func(server string) (*http.Request, error) {
+		return NewFindPetsRequest(server, params)
+	}, reqEditors)
+}
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tags", runtime.ParamLocationQuery, *params.Tags); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
+// NewAddPetRequestWithBody builds the request which AddPetWithBody sends, without
+// sending it.
+func (c *Client) NewAddPetRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "AddPet", func(server string) (*http.Request, error) {
+		return NewAddPetRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
 
-	}
+// NewAddPetRequest builds the request which AddPet sends, without
+// sending it.
+func (c *Client) NewAddPetRequest(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "AddPet", func(server string) (*http.Request, error) {
+		return NewAddPetRequest(server, body)
+	}, reqEditors)
+}
 
-	if params.Limit != nil {
+// NewDeletePetRequest builds the request which DeletePet sends, without
+// sending it.
+func (c *Client) NewDeletePetRequest(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "DeletePet", func(server string) (*http.Request, error) {
+		return NewDeletePetRequest(server, id)
+	}, reqEditors)
+}
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
+// NewFindPetByIDRequest builds the request which FindPetByID sends, without
+// sending it.
+func (c *Client) NewFindPetByIDRequest(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "FindPetByID", func(server string) (*http.Request, error) {
+		return NewFindPetByIDRequest(server, id)
+	}, reqEditors)
+}
 
+// NewFindPetsRequest generates requests for FindPets
+func NewFindPetsRequest(server string, params *FindPetsParams) (*http.Request, error) {
+	queryURL, err := BuildFindPetsURL(server, params)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL.RawQuery = queryValues.Encode()
-
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "application/json")
+
 	return req, nil
 }
 
@@ -283,19 +517,7 @@ func NewAddPetRequest(server string, body AddPetJSONRequestBody) (*http.Request,
 
 // NewAddPetRequestWithBody generates requests for AddPet with any type of body
 func NewAddPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/pets")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildAddPetURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -306,75 +528,143 @@ func NewAddPetRequestWithBody(server string, contentType string, body io.Reader)
 	}
 
 	req.Header.Add("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
 
 	return req, nil
 }
 
 // NewDeletePetRequest generates requests for DeletePet
 func NewDeletePetRequest(server string, id int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	queryURL, err := BuildDeletePetURL(server, id)
 	if err != nil {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	req.Header.Set("Accept", "application/json")
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// NewFindPetByIDRequest generates requests for FindPetByID
+func NewFindPetByIDRequest(server string, id int64) (*http.Request, error) {
+	queryURL, err := BuildFindPetByIDURL(server, id)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "application/json")
+
 	return req, nil
 }
 
-// NewFindPetByIDRequest generates requests for FindPetByID
-func NewFindPetByIDRequest(server string, id int64) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
+// do sends the request built by newRequest for a server, which is picked by
+// the ServerPolicy, after editing it, once the RateLimiter lets the operation
+// through. The context of the request carries the operation ID, for the
+// editors and the Doer. When it fails over, or is retried after being rate limited, the
+// request is built again, with the body of the first one, unless the body
+// can't be read again.
+func (c *Client) do(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Response, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	var first *http.Request
+	build := func(server string) (*http.Request, error) {
+		req, err := newRequest(server)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = req
+		} else if first.GetBody != nil {
+			if req.Body, err = first.GetBody(); err != nil {
+				return nil, err
+			}
+			req.GetBody = first.GetBody
+			req.ContentLength = first.ContentLength
+		}
+		return req.WithContext(ctx), nil
+	}
+	replayable := func() bool {
+		return first.Body == nil || first.Body == http.NoBody || first.GetBody != nil
 	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
+	var waited time.Duration
+	for {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, operationID); err != nil {
+				return nil, err
+			}
+		}
+		rsp, err := c.send(ctx, build, replayable, reqEditors)
+		if err != nil || c.MaxRetryAfter <= 0 || !replayable() {
+			return rsp, err
+		}
+		wait, retry := runtime.RetryAfter(rsp, time.Now())
+		if !retry || waited+wait > c.MaxRetryAfter {
+			return rsp, nil
+		}
+		_, _ = io.Copy(ioutil.Discard, rsp.Body)
+		rsp.Body.Close()
+		if err := runtime.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		waited += wait
 	}
+}
 
-	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+// send sends the request built by build to the servers, in the order of the
+// ServerPolicy, until one of them doesn't fail over.
+func (c *Client) send(ctx context.Context, build func(server string) (*http.Request, error), replayable func() bool, reqEditors []RequestEditorFn) (*http.Response, error) {
+	servers := []string{c.Server}
+	if len(c.Servers) > 0 {
+		servers = runtime.ServerOrder(c.ServerPolicy, c.Servers, atomic.AddUint32(&c.requests, 1)-1)
 	}
+	for i, server := range servers {
+		req, err := build(server)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+			return nil, err
+		}
+		rsp, err := c.Client.Do(req)
+		if i == len(servers)-1 || !replayable() || !runtime.ShouldFailOver(ctx, c.ServerPolicy, req, rsp, err) {
+			return runtime.LimitResponseBody(rsp, c.maxResponseSize(runtime.OperationIDFromContext(ctx))), err
+		}
+		if rsp != nil {
+			_, _ = io.Copy(ioutil.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
+	}
+	return nil, fmt.Errorf("no servers")
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+// maxResponseSize returns the maximum size of the response bodies of the
+// operation with the given ID, or zero when they aren't limited.
+func (c *Client) maxResponseSize(operationID string) int64 {
+	return c.MaxResponseSize
+}
+
+// newRequest builds the request of the operation, like do does, for the
+// first server, with ctx, after editing it, without sending it.
+func (c *Client) newRequest(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Request, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	req, err := newRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
 	return req, nil
 }
 
@@ -407,7 +697,7 @@ func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithRes
 	return &ClientWithResponses{client}, nil
 }
 
-// WithBaseURL overrides the baseURL.
+// WithBaseURL overrides the baseURL, and the servers set by WithServers.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
@@ -415,6 +705,7 @@ func WithBaseURL(baseURL string) ClientOption {
 			return err
 		}
 		c.Server = newBaseURL.String()
+		c.Servers = nil
 		return nil
 	}
 }
@@ -571,6 +862,56 @@ func (c *ClientWithResponses) FindPetByIDWithResponse(ctx context.Context, id in
 	return ParseFindPetByIDResponse(rsp)
 }
 
+// BatchCall is a call of an operation in a batch, which returns its
+// *...Response, eg,
+//
+//	func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+//		return c.FindPetByIdWithResponse(ctx, id)
+//	}
+type BatchCall func(ctx context.Context, client ClientWithResponsesInterface) (interface{}, error)
+
+// BatchResult is the result of a call in a batch.
+type BatchResult struct {
+	Response interface{} // The *...Response of the operation, if it was called
+	Err      error
+}
+
+// Batch makes the calls with the client, with at most concurrency of them at
+// a time, or all at once when concurrency isn't positive, and returns their
+// results in the order of the calls. A failed call doesn't stop the others,
+// but those which haven't started once ctx is done fail with its error.
+func (c *ClientWithResponses) Batch(ctx context.Context, concurrency int, calls ...BatchCall) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	errs := runtime.RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+		var err error
+		results[i].Response, err = calls[i](ctx, c)
+		return err
+	})
+	for i, err := range errs {
+		results[i].Err = err
+	}
+	return results
+}
+
+// ParseOperationResponse parses an HTTP response to a request of the
+// operation with the given ID, such as one built by a New...Request method of
+// the Client, which runtime.OperationIDFromContext tells from the context of
+// the request, into the *...Response of the operation.
+func ParseOperationResponse(operationID string, rsp *http.Response) (interface{}, error) {
+	switch operationID {
+	case "FindPets":
+		return ParseFindPetsResponse(rsp)
+	case "AddPet":
+		return ParseAddPetResponse(rsp)
+	case "DeletePet":
+		return ParseDeletePetResponse(rsp)
+	case "FindPetByID":
+		return ParseFindPetByIDResponse(rsp)
+	}
+	_ = rsp.Body.Close()
+	return nil, fmt.Errorf("unknown operation %s", operationID)
+}
+
 // ParseFindPetsResponse parses an HTTP response from a FindPetsWithResponse call
 func ParseFindPetsResponse(rsp *http.Response) (*FindPetsResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -695,3 +1036,33 @@ func ParseFindPetByIDResponse(rsp *http.Response) (*FindPetByIDResponse, error)
 
 	return response, nil
 }
+
+// Server URLs declared in the OpenAPI specification. They may contain
+// {variable} placeholders, which are filled in by WithServerVariables.
+const (
+	ServerURLPetstoreSwaggerIoapi = "http://petstore.swagger.io/api"
+)
+
+// serverVariables holds the variables declared for each server URL.
+var serverVariables = map[string]map[string]runtime.ServerVariable{
+	ServerURLPetstoreSwaggerIoapi: {},
+}
+
+// WithServerVariables substitutes the {variable} placeholders in the server
+// URLs. Variables which aren't given take the default value from the spec, and
+// values are validated against the enums declared in the spec.
+func WithServerVariables(variables map[string]string) ClientOption {
+	return func(c *Client) error {
+		server, err := runtime.SubstituteServerVariables(c.Server, runtime.LookupServerVariables(serverVariables, c.Server), variables)
+		if err != nil {
+			return err
+		}
+		c.Server = server
+		for i, server := range c.Servers {
+			if c.Servers[i], err = runtime.SubstituteServerVariables(server, runtime.LookupServerVariables(serverVariables, server), variables); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -16,7 +16,11 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 )
@@ -43,6 +47,188 @@ type PostBothJSONRequestBody PostBothJSONBody
 // PostJsonJSONRequestBody defines body for PostJson for application/json ContentType.
 type PostJsonJSONRequestBody PostJsonJSONBody
 
+// PostBothRoute is the route of PostBoth, as in the spec.
+const PostBothRoute = "/with_both_bodies"
+
+// GetBothRoute is the route of GetBoth, as in the spec.
+const GetBothRoute = "/with_both_responses"
+
+// PostJsonRoute is the route of PostJson, as in the spec.
+const PostJsonRoute = "/with_json_body"
+
+// GetJsonRoute is the route of GetJson, as in the spec.
+const GetJsonRoute = "/with_json_response"
+
+// PostOtherRoute is the route of PostOther, as in the spec.
+const PostOtherRoute = "/with_other_body"
+
+// GetOtherRoute is the route of GetOther, as in the spec.
+const GetOtherRoute = "/with_other_response"
+
+// GetJsonWithTrailingSlashRoute is the route of GetJsonWithTrailingSlash, as in the spec.
+const GetJsonWithTrailingSlashRoute = "/with_trailing_slash/"
+
+// BuildPostBothURL builds the URL of PostBoth on server, serializing its
+// path and query parameters like the client does.
+func BuildPostBothURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/with_both_bodies")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildGetBothURL builds the URL of GetBoth on server, serializing its
+// path and query parameters like the client does.
+func BuildGetBothURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/with_both_responses")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildPostJsonURL builds the URL of PostJson on server, serializing its
+// path and query parameters like the client does.
+func BuildPostJsonURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/with_json_body")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildGetJsonURL builds the URL of GetJson on server, serializing its
+// path and query parameters like the client does.
+func BuildGetJsonURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/with_json_response")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildPostOtherURL builds the URL of PostOther on server, serializing its
+// path and query parameters like the client does.
+func BuildPostOtherURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/with_other_body")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildGetOtherURL builds the URL of GetOther on server, serializing its
+// path and query parameters like the client does.
+func BuildGetOtherURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/with_other_response")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildGetJsonWithTrailingSlashURL builds the URL of GetJsonWithTrailingSlash on server, serializing its
+// path and query parameters like the client does.
+func BuildGetJsonWithTrailingSlashURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/with_trailing_slash/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -61,6 +247,17 @@ type Client struct {
 	// paths in the swagger spec will be appended to the server.
 	Server string
 
+	// Servers are the endpoints which the requests are sent to, as picked by
+	// the ServerPolicy, when there are several, which WithServers sets up.
+	// Server is the first one.
+	Servers []string
+
+	// ServerPolicy is how the servers of the requests are picked.
+	ServerPolicy runtime.ServerPolicy
+
+	// requests counts the requests, for picking their servers in turn.
+	requests uint32
+
 	// Doer for performing requests, typically a *http.Client with any
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
@@ -68,6 +265,26 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// RateLimiter, when set, is waited on before sending each request, with
+	// the ID of its operation.
+	RateLimiter runtime.RateLimiter
+
+	// MaxRetryAfter is how long, in total, a request which is rate limited,
+	// with a 429 status and a Retry-After header, is retried for, after
+	// waiting as long as the server asks, and at least
+	// runtime.MinRetryAfter. It isn't retried when it's zero.
+	MaxRetryAfter time.Duration
+
+	// MaxResponseSize is the maximum size of the response bodies, in bytes,
+	// beyond which reading them fails with a *runtime.ResponseTooLargeError,
+	// unless the operation has its own, from x-max-response-size. The
+	// bodies aren't limited when it's zero.
+	MaxResponseSize int64
+
+	// recorder, which WithRecorder sets, sends the requests through the
+	// Client once all the options are applied.
+	recorder *runtime.Recorder
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -89,6 +306,16 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if !strings.HasSuffix(client.Server, "/") {
 		client.Server += "/"
 	}
+	for i, server := range client.Servers {
+		if !strings.HasSuffix(server, "/") {
+			client.Servers[i] = server + "/"
+		}
+	}
+	// send the requests of the Client through the recorder, whatever the
+	// order of the options
+	if client.recorder != nil {
+		client.Client = &http.Client{Transport: client.recorder.WithDoer(client.Client)}
+	}
 	// create httpClient, if not already present
 	if client.Client == nil {
 		client.Client = &http.Client{}
@@ -105,6 +332,26 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 	}
 }
 
+// WithServers makes the client send its requests to several servers, such as
+// the ones declared in the spec, instead of the one given to NewClient. They
+// are picked by policy: with runtime.ServerFailover, the requests fail over
+// to the next servers when one can't be reached, or, for idempotent requests,
+// responds with a 5xx status, runtime.ServerFailoverAnyMethod fails over the
+// requests of any method on 5xx statuses too, and runtime.ServerRoundRobin
+// sends them to each server in turn.
+// The server of a request is picked before the request editors run.
+func WithServers(policy runtime.ServerPolicy, servers ...string) ClientOption {
+	return func(c *Client) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("no servers given")
+		}
+		c.Server = servers[0]
+		c.Servers = append([]string{}, servers...)
+		c.ServerPolicy = policy
+		return nil
+	}
+}
+
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
@@ -114,6 +361,59 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithAccept sets the Accept header of a request, eg, to ask for one of the
+// content types of the responses of its operation, rather than all of them,
+// which the request builders list.
+func WithAccept(accept string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", accept)
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, eg, for a token of the bucket of its operation.
+func WithRateLimiter(limiter runtime.RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRetryAfter makes the client retry the requests which are rate limited,
+// with a 429 status and a Retry-After header, after waiting as long as the
+// server asks, for up to maxWait in total. The response is returned when the
+// server asks to wait longer.
+func WithRetryAfter(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.MaxRetryAfter = maxWait
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies which the client
+// reads to maxSize bytes, so that a misbehaving server can't exhaust its
+// memory. Reading larger bodies, such as when parsing them, fails with a
+// *runtime.ResponseTooLargeError. The operations with x-max-response-size
+// have their own limit.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		c.MaxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithRecorder makes the client send its requests through recorder, which
+// records their responses to golden files, or replays them, in tests. The
+// requests which are recorded are sent with the Doer of WithHTTPClient, if
+// any, unless recorder has a Transport of its own.
+func WithRecorder(recorder *runtime.Recorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// PostBoth request with any body
@@ -143,111 +443,129 @@ type ClientInterface interface {
 }
 
 func (c *Client) PostBothWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostBothRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "PostBoth", func(server string) (*http.Request, error) {
+		return NewPostBothRequestWithBody(server, contentType, body)
+	}, reqEditors)
 }
 
 func (c *Client) PostBoth(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostBothRequest(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "PostBoth", func(server string) (*http.Request, error) {
+		return NewPostBothRequest(server, body)
+	}, reqEditors)
 }
 
 func (c *Client) GetBoth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetBothRequest(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "GetBoth", func(server string) (*http.Request, error) {
+		return NewGetBothRequest(server)
+	}, reqEditors)
 }
 
 func (c *Client) PostJsonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostJsonRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "PostJson", func(server string) (*http.Request, error) {
+		return NewPostJsonRequestWithBody(server, contentType, body)
+	}, reqEditors)
 }
 
 func (c *Client) PostJson(ctx context.Context, body PostJsonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostJsonRequest(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "PostJson", func(server string) (*http.Request, error) {
+		return NewPostJsonRequest(server, body)
+	}, reqEditors)
 }
 
 func (c *Client) GetJson(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetJsonRequest(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "GetJson", func(server string) (*http.Request, error) {
+		return NewGetJsonRequest(server)
+	}, reqEditors)
 }
 
 func (c *Client) PostOtherWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostOtherRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "PostOther", func(server string) (*http.Request, error) {
+		return NewPostOtherRequestWithBody(server, contentType, body)
+	}, reqEditors)
 }
 
 func (c *Client) GetOther(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetOtherRequest(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "GetOther", func(server string) (*http.Request, error) {
+		return NewGetOtherRequest(server)
+	}, reqEditors)
 }
 
 func (c *Client) GetJsonWithTrailingSlash(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetJsonWithTrailingSlashRequest(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "GetJsonWithTrailingSlash", func(server string) (*http.Request, error) {
+		return NewGetJsonWithTrailingSlashRequest(server)
+	}, reqEditors)
+}
+
+// NewPostBothRequestWithBody builds the request which PostBothWithBody sends, without
+// sending it.
+func (c *Client) NewPostBothRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "PostBoth", func(server string) (*http.Request, error) {
+		return NewPostBothRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
+
+// NewPostBothRequest builds the request which PostBoth sends, without
+// sending it.
+func (c *Client) NewPostBothRequest(ctx context.Context, body PostBothJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "PostBoth", func(server string) (*http.Request, error) {
+		return NewPostBothRequest(server, body)
+	}, reqEditors)
+}
+
+// NewGetBothRequest builds the request which GetBoth sends, without
+// sending it.
+func (c *Client) NewGetBothRequest(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetBoth", func(server string) (*http.Request, error) {
+		return NewGetBothRequest(server)
+	}, reqEditors)
+}
+
+// NewPostJsonRequestWithBody builds the request which PostJsonWithBody sends, without
+// sending it.
+func (c *Client) NewPostJsonRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "PostJson", func(server string) (*http.Request, error) {
+		return NewPostJsonRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
+
+// NewPostJsonRequest builds the request which PostJson sends, without
+// sending it.
+func (c *Client) NewPostJsonRequest(ctx context.Context, body PostJsonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "PostJson", func(server string) (*http.Request, error) {
+		return NewPostJsonRequest(server, body)
+	}, reqEditors)
+}
+
+// NewGetJsonRequest builds the request which GetJson sends, without
+// sending it.
+func (c *Client) NewGetJsonRequest(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetJson", func(server string) (*http.Request, error) {
+		return NewGetJsonRequest(server)
+	}, reqEditors)
+}
+
+// NewPostOtherRequestWithBody builds the request which PostOtherWithBody sends, without
+// sending it.
+func (c *Client) NewPostOtherRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "PostOther", func(server string) (*http.Request, error) {
+		return NewPostOtherRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
+
+// NewGetOtherRequest builds the request which GetOther sends, without
+// sending it.
+func (c *Client) NewGetOtherRequest(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetOther", func(server string) (*http.Request, error) {
+		return NewGetOtherRequest(server)
+	}, reqEditors)
+}
+
+// NewGetJsonWithTrailingSlashRequest builds the request which GetJsonWithTrailingSlash sends, without
+// sending it.
+func (c *Client) NewGetJsonWithTrailingSlashRequest(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetJsonWithTrailingSlash", func(server string) (*http.Request, error) {
+		return NewGetJsonWithTrailingSlashRequest(server)
+	}, reqEditors)
 }
 
 // NewPostBothRequest calls the generic PostBoth builder with application/json body
@@ -263,19 +581,7 @@ func NewPostBothRequest(server string, body PostBothJSONRequestBody) (*http.Requ
 
 // NewPostBothRequestWithBody generates requests for PostBoth with any type of body
 func NewPostBothRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_both_bodies")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildPostBothURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -292,19 +598,7 @@ func NewPostBothRequestWithBody(server string, contentType string, body io.Reade
 
 // NewGetBothRequest generates requests for GetBoth
 func NewGetBothRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_both_responses")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildGetBothURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -330,19 +624,7 @@ func NewPostJsonRequest(server string, body PostJsonJSONRequestBody) (*http.Requ
 
 // NewPostJsonRequestWithBody generates requests for PostJson with any type of body
 func NewPostJsonRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_json_body")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildPostJsonURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -359,19 +641,7 @@ func NewPostJsonRequestWithBody(server string, contentType string, body io.Reade
 
 // NewGetJsonRequest generates requests for GetJson
 func NewGetJsonRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_json_response")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildGetJsonURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -386,19 +656,7 @@ func NewGetJsonRequest(server string) (*http.Request, error) {
 
 // NewPostOtherRequestWithBody generates requests for PostOther with any type of body
 func NewPostOtherRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/with_other_body")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildPostOtherURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -415,19 +673,22 @@ func NewPostOtherRequestWithBody(server string, contentType string, body io.Read
 
 // NewGetOtherRequest generates requests for GetOther
 func NewGetOtherRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+	queryURL, err := BuildGetOtherURL(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/with_other_response")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// NewGetJsonWithTrailingSlashRequest generates requests for GetJsonWithTrailingSlash
+func NewGetJsonWithTrailingSlashRequest(server string) (*http.Request, error) {
+	queryURL, err := BuildGetJsonWithTrailingSlashURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -440,30 +701,107 @@ func NewGetOtherRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetJsonWithTrailingSlashRequest generates requests for GetJsonWithTrailingSlash
-func NewGetJsonWithTrailingSlashRequest(server string) (*http.Request, error) {
-	var err error
+// do sends the request built by newRequest for a server, which is picked by
+// the ServerPolicy, after editing it, once the RateLimiter lets the operation
+// through. The context of the request carries the operation ID, for the
+// editors and the Doer. When it fails over, or is retried after being rate limited, the
+// request is built again, with the body of the first one, unless the body
+// can't be read again.
+func (c *Client) do(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Response, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	var first *http.Request
+	build := func(server string) (*http.Request, error) {
+		req, err := newRequest(server)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = req
+		} else if first.GetBody != nil {
+			if req.Body, err = first.GetBody(); err != nil {
+				return nil, err
+			}
+			req.GetBody = first.GetBody
+			req.ContentLength = first.ContentLength
+		}
+		return req.WithContext(ctx), nil
+	}
+	replayable := func() bool {
+		return first.Body == nil || first.Body == http.NoBody || first.GetBody != nil
+	}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
+	var waited time.Duration
+	for {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, operationID); err != nil {
+				return nil, err
+			}
+		}
+		rsp, err := c.send(ctx, build, replayable, reqEditors)
+		if err != nil || c.MaxRetryAfter <= 0 || !replayable() {
+			return rsp, err
+		}
+		wait, retry := runtime.RetryAfter(rsp, time.Now())
+		if !retry || waited+wait > c.MaxRetryAfter {
+			return rsp, nil
+		}
+		_, _ = io.Copy(ioutil.Discard, rsp.Body)
+		rsp.Body.Close()
+		if err := runtime.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		waited += wait
 	}
+}
 
-	operationPath := fmt.Sprintf("/with_trailing_slash/")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+// send sends the request built by build to the servers, in the order of the
+// ServerPolicy, until one of them doesn't fail over.
+func (c *Client) send(ctx context.Context, build func(server string) (*http.Request, error), replayable func() bool, reqEditors []RequestEditorFn) (*http.Response, error) {
+	servers := []string{c.Server}
+	if len(c.Servers) > 0 {
+		servers = runtime.ServerOrder(c.ServerPolicy, c.Servers, atomic.AddUint32(&c.requests, 1)-1)
+	}
+	for i, server := range servers {
+		req, err := build(server)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+			return nil, err
+		}
+		rsp, err := c.Client.Do(req)
+		if i == len(servers)-1 || !replayable() || !runtime.ShouldFailOver(ctx, c.ServerPolicy, req, rsp, err) {
+			return runtime.LimitResponseBody(rsp, c.maxResponseSize(runtime.OperationIDFromContext(ctx))), err
+		}
+		if rsp != nil {
+			_, _ = io.Copy(ioutil.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
 	}
+	return nil, fmt.Errorf("no servers")
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+// maxResponseSize returns the maximum size of the response bodies of the
+// operation with the given ID, or zero when they aren't limited.
+func (c *Client) maxResponseSize(operationID string) int64 {
+	if operationID == "GetOther" {
+		return 64
 	}
+	return c.MaxResponseSize
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+// newRequest builds the request of the operation, like do does, for the
+// first server, with ctx, after editing it, without sending it.
+func (c *Client) newRequest(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Request, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	req, err := newRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -496,7 +834,7 @@ func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithRes
 	return &ClientWithResponses{client}, nil
 }
 
-// WithBaseURL overrides the baseURL.
+// WithBaseURL overrides the baseURL, and the servers set by WithServers.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
@@ -504,6 +842,7 @@ func WithBaseURL(baseURL string) ClientOption {
 			return err
 		}
 		c.Server = newBaseURL.String()
+		c.Servers = nil
 		return nil
 	}
 }
@@ -762,6 +1101,62 @@ func (c *ClientWithResponses) GetJsonWithTrailingSlashWithResponse(ctx context.C
 	return ParseGetJsonWithTrailingSlashResponse(rsp)
 }
 
+// BatchCall is a call of an operation in a batch, which returns its
+// *...Response, eg,
+//
+//	func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+//		return c.FindPetByIdWithResponse(ctx, id)
+//	}
+type BatchCall func(ctx context.Context, client ClientWithResponsesInterface) (interface{}, error)
+
+// BatchResult is the result of a call in a batch.
+type BatchResult struct {
+	Response interface{} // The *...Response of the operation, if it was called
+	Err      error
+}
+
+// Batch makes the calls with the client, with at most concurrency of them at
+// a time, or all at once when concurrency isn't positive, and returns their
+// results in the order of the calls. A failed call doesn't stop the others,
+// but those which haven't started once ctx is done fail with its error.
+func (c *ClientWithResponses) Batch(ctx context.Context, concurrency int, calls ...BatchCall) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	errs := runtime.RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+		var err error
+		results[i].Response, err = calls[i](ctx, c)
+		return err
+	})
+	for i, err := range errs {
+		results[i].Err = err
+	}
+	return results
+}
+
+// ParseOperationResponse parses an HTTP response to a request of the
+// operation with the given ID, such as one built by a New...Request method of
+// the Client, which runtime.OperationIDFromContext tells from the context of
+// the request, into the *...Response of the operation.
+func ParseOperationResponse(operationID string, rsp *http.Response) (interface{}, error) {
+	switch operationID {
+	case "PostBoth":
+		return ParsePostBothResponse(rsp)
+	case "GetBoth":
+		return ParseGetBothResponse(rsp)
+	case "PostJson":
+		return ParsePostJsonResponse(rsp)
+	case "GetJson":
+		return ParseGetJsonResponse(rsp)
+	case "PostOther":
+		return ParsePostOtherResponse(rsp)
+	case "GetOther":
+		return ParseGetOtherResponse(rsp)
+	case "GetJsonWithTrailingSlash":
+		return ParseGetJsonWithTrailingSlashResponse(rsp)
+	}
+	_ = rsp.Body.Close()
+	return nil, fmt.Errorf("unknown operation %s", operationID)
+}
+
 // ParsePostBothResponse parses an HTTP response from a PostBothWithResponse call
 func ParsePostBothResponse(rsp *http.Response) (*PostBothResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -901,7 +1296,8 @@ type ServerInterface interface {
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	Handler             ServerInterface
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // PostBoth converts echo context to params.
@@ -986,41 +1382,82 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+	BaseURL string
+	// RouteMiddlewares returns the middlewares of the route of an operation.
+	RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+	// RouteName names the route of an operation, which it's registered
+	// under, for echo.Echo.Reverse.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute func(route runtime.Route)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{})
 }
 
 // Registers handlers, and prepends BaseURL to the paths, so that the paths
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
 
 	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+		Handler:             si,
+		BindErrorTranslator: options.BindErrorTranslator,
+	}
+
+	register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route, handler echo.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []echo.MiddlewareFunc
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		if added := add(route.RouterPath, handler, middlewares...); route.Name != "" {
+			added.Name = route.Name
+		}
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
 	}
 
-	router.POST(baseURL+"/with_both_bodies", wrapper.PostBoth)
-	router.GET(baseURL+"/with_both_responses", wrapper.GetBoth)
-	router.POST(baseURL+"/with_json_body", wrapper.PostJson)
-	router.GET(baseURL+"/with_json_response", wrapper.GetJson)
-	router.POST(baseURL+"/with_other_body", wrapper.PostOther)
-	router.GET(baseURL+"/with_other_response", wrapper.GetOther)
-	router.GET(baseURL+"/with_trailing_slash/", wrapper.GetJsonWithTrailingSlash)
+	register(runtime.Route{OperationID: "PostBoth", Method: "POST", Path: "/with_both_bodies", RouterPath: "/with_both_bodies"}, router.POST, wrapper.PostBoth)
+	register(runtime.Route{OperationID: "GetBoth", Method: "GET", Path: "/with_both_responses", RouterPath: "/with_both_responses"}, router.GET, wrapper.GetBoth)
+	register(runtime.Route{OperationID: "PostJson", Method: "POST", Path: "/with_json_body", RouterPath: "/with_json_body"}, router.POST, wrapper.PostJson)
+	register(runtime.Route{OperationID: "GetJson", Method: "GET", Path: "/with_json_response", RouterPath: "/with_json_response"}, router.GET, wrapper.GetJson)
+	register(runtime.Route{OperationID: "PostOther", Method: "POST", Path: "/with_other_body", RouterPath: "/with_other_body"}, router.POST, wrapper.PostOther)
+	register(runtime.Route{OperationID: "GetOther", Method: "GET", Path: "/with_other_response", RouterPath: "/with_other_response"}, router.GET, wrapper.GetOther)
+	register(runtime.Route{OperationID: "GetJsonWithTrailingSlash", Method: "GET", Path: "/with_trailing_slash/", RouterPath: "/with_trailing_slash/"}, router.GET, wrapper.GetJsonWithTrailingSlash)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8yUz24TMRDGX2U1cFyyKdz2CAdUJAgikTiEqHK8k9jVrm1mJq1W0b47GicliahCkGjV",
-	"SzTO/NE338/rLdjYpRgwCEO9BbYOO5PDaQ4ny1u0oudEMSGJx5xdeWL5YjrUg/QJoQYW8mENQwkU28cS",
-	"msGfG0/YQD3fVZVHoxaDlviwitrcIFvySXwMUMPMeS4EWbi4dygOqRCHxYfWY5DChGYffvfiviGnGBi5",
-	"MITFGgOSEWwKG4nQStv/CFBC6y0GzjpDXgQ+X89UvXhR+TBDlmKKdIcEJdwh8U7K1Wg8GmthTBhM8lDD",
-	"u9F4dAUlJCMu+1Pde3E3y5h/mr1pKXK2Uo00utd1AzV8jSzvozjYuYN6anqtszEIhtxiUmq9zU3VLauM",
-	"B1gavSZcQQ2vqgPNao+yOuGo/h6PilZQ3rAQmu505CpSZwRqWPpgqIfyD5gnNIU2mP/YOw912LSt1hw5",
-	"cZTdwhof8eIjHqw4qn07Hr9UE4bDjipJaffnWX9S5c/C+p8IZfUP2XOAfut/QkAqi9FuyEsP9XwLk4RZ",
-	"wBx07ojQNFDuYtN0PsBiWBx2ifo+XIBionUXs3i2j2Un/xIWhwXOw/hfV1zI+NaH9Q23hl31t2uij/Fs",
-	"3zLVjhd6b4bhVwAAAP//2pHiCAkHAAA=",
+	"H4sIAAAAAAAC/8yUz27TQBDGX8UaODpxCoiDj3BARYIiGolDiKLNehJvZe8uM5O2IfK7o1mnOBGlBCGq",
+	"XqLZzJ988/02uwMb2hg8emEod8C2xtak8DKFF8srtKLnSCEiicOUXTli+Wha1INsI0IJLOT8GrocKDT3",
+	"JTSD3zaOsIJy1lflB6PmnZY4vwraXCFbclFc8FDCtHacCbJwdlOj1EiZ1Ji9bRx6yYyv9uEXJ/Vn5Bg8",
+	"I2eGMFujRzKCVWYDEVpptl895NA4i56TTp8WgQ/nU1UvTlQ+TJElu0S6RoIcrpG4l3I2nownWhgiehMd",
+	"lPByPBmfQQ7RSJ38KW6c1ItlSB/V3rQYOFmpRhrd67yCEj4FljdBaujdQT1VW62zwQv61GJibJxNTcUV",
+	"Bz/A0ug54QpKeFYMNIs+y8URR/X3cFSwgjJiITTt8chVoNYIlLB03tAW8l9gHtEU2mD6Yu88lH7TNFpz",
+	"4MRBdgdrvMeLdzhYcVD7YjJ5qiZ0w44qabHcs/s96/eq/FFY/xWhpP4u+xCgn/r/IyCVxWg35GQL5WwH",
+	"FxGTgBno3DGhqSDvY1O1zsO8mw+7BH0fTkBxoXUns3i0P0sv/xQWwwIPw/i3K57D7ag1t6O7Xxmx+45Q",
+	"vn41SBYyrnF+veDGcF386f7oKz3dt1xqxxO9UF33YwCm95MuIgcAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1043,14 +1480,19 @@ func decodeSpec() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var rawSpec = decodeSpecCached()
+var (
+	rawSpecOnce sync.Once
+	rawSpecData []byte
+	rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+	rawSpecOnce.Do(func() {
+		rawSpecData, rawSpecErr = decodeSpec()
+	})
+	return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
@@ -1,9 +1,19 @@
 package client
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTemp(t *testing.T) {
@@ -42,3 +52,325 @@ func TestTemp(t *testing.T) {
 	assert.Equal(t, expectedURL, client3.Server)
 	assert.Equal(t, expectedURL, client4.Server)
 }
+
+// testServer records the bodies of the requests it gets, and responds with
+// status.
+func testServer(t *testing.T, status int, bodies *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*bodies = append(*bodies, r.Host+" "+string(body))
+		w.WriteHeader(status)
+	}))
+}
+
+func TestServerFailover(t *testing.T) {
+	var bodies []string
+	failing := testServer(t, http.StatusServiceUnavailable, &bodies)
+	defer failing.Close()
+	working := testServer(t, http.StatusOK, &bodies)
+	defer working.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	var hosts []string
+	client, err := NewClient("",
+		WithServers(runtime.ServerFailoverAnyMethod, down.URL, failing.URL, working.URL),
+		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			// The editors see the server of the request
+			hosts = append(hosts, req.URL.Host)
+			return nil
+		}))
+	assert.NoError(t, err)
+
+	rsp, err := client.PostJson(context.Background(), PostJsonJSONRequestBody{FirstName: "Alex"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	body := `{"firstName":"Alex","role":""}`
+	assert.Equal(t, []string{failing.Listener.Addr().String() + " " + body, working.Listener.Addr().String() + " " + body}, bodies)
+	assert.Equal(t, []string{down.Listener.Addr().String(), failing.Listener.Addr().String(), working.Listener.Addr().String()}, hosts)
+
+	// Bodies which can't be read again aren't failed over
+	bodies = nil
+	rsp, err = client.PostJsonWithBody(context.Background(), "application/json", ioutil.NopCloser(strings.NewReader(body)))
+	assert.Error(t, err)
+	assert.Nil(t, rsp)
+	assert.Empty(t, bodies)
+
+	// By default, only idempotent requests fail over on 5xx statuses, the
+	// others only when the server can't be reached
+	bodies = nil
+	client.ServerPolicy = runtime.ServerFailover
+	rsp, err = client.PostJson(context.Background(), PostJsonJSONRequestBody{FirstName: "Alex"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rsp.StatusCode)
+	assert.Equal(t, []string{failing.Listener.Addr().String() + " " + body}, bodies)
+
+	bodies = nil
+	rsp, err = client.GetJson(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	assert.Equal(t, []string{failing.Listener.Addr().String() + " ", working.Listener.Addr().String() + " "}, bodies)
+}
+
+func TestServerRoundRobin(t *testing.T) {
+	var bodies []string
+	first := testServer(t, http.StatusOK, &bodies)
+	defer first.Close()
+	second := testServer(t, http.StatusServiceUnavailable, &bodies)
+	defer second.Close()
+
+	client, err := NewClient("", WithServers(runtime.ServerRoundRobin, first.URL, second.URL))
+	assert.NoError(t, err)
+
+	var statuses []int
+	for i := 0; i < 3; i++ {
+		rsp, err := client.GetJson(context.Background())
+		assert.NoError(t, err)
+		statuses = append(statuses, rsp.StatusCode)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusServiceUnavailable, http.StatusOK}, statuses)
+}
+
+func TestRetryAfter(t *testing.T) {
+	var bodies []string
+	limited := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, string(body))
+		if limited > 0 {
+			limited--
+			// A date in the past, to retry after the minimum wait
+			w.Header().Set("Retry-After", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var operations []string
+	client, err := NewClient(server.URL,
+		WithRetryAfter(time.Minute),
+		WithRateLimiter(runtime.RateLimiterFunc(func(ctx context.Context, operationID string) error {
+			operations = append(operations, operationID)
+			return nil
+		})))
+	assert.NoError(t, err)
+
+	rsp, err := client.PostJson(context.Background(), PostJsonJSONRequestBody{FirstName: "Alex"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	body := `{"firstName":"Alex","role":""}`
+	assert.Equal(t, []string{body, body}, bodies)
+	// The limiter is waited on for every attempt
+	assert.Equal(t, []string{"PostJson", "PostJson"}, operations)
+
+	// Longer waits than the maximum aren't retried
+	bodies = nil
+	limited = 1
+	client.MaxRetryAfter = time.Second
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodies = append(bodies, "")
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	rsp, err = client.GetJson(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, rsp.StatusCode)
+	assert.Len(t, bodies, 1)
+
+	// Servers asking to retry right away are retried after the minimum
+	// wait, until the maximum is reached
+	bodies = nil
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodies = append(bodies, "")
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	start := time.Now()
+	rsp, err = client.GetJson(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, rsp.StatusCode)
+	assert.Len(t, bodies, 2)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(runtime.MinRetryAfter))
+
+	// Requests which the limiter refuses aren't sent
+	bodies = nil
+	client.RateLimiter = runtime.RateLimiterFunc(func(ctx context.Context, operationID string) error {
+		return errors.New("over budget")
+	})
+	_, err = client.GetJson(context.Background())
+	assert.EqualError(t, err, "over budget")
+	assert.Empty(t, bodies)
+}
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"firstName":"Alex","role":"admin"}`))
+	}))
+
+	client, err := NewClientWithResponses(server.URL, WithRecorder(&runtime.Recorder{Dir: dir, Mode: runtime.RecorderRecord}))
+	assert.NoError(t, err)
+	rsp, err := client.GetJsonWithResponse(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode())
+	server.Close()
+
+	// The responses are replayed without the server, whose URL doesn't matter
+	client, err = NewClientWithResponses("https://example.com", WithRecorder(&runtime.Recorder{Dir: dir}))
+	assert.NoError(t, err)
+	rsp, err = client.GetJsonWithResponse(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode())
+	assert.Equal(t, `{"firstName":"Alex","role":"admin"}`, string(rsp.Body))
+	files, err := filepath.Glob(filepath.Join(dir, "GetJson-*.json"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+// countingDoer counts the requests which it sends with http.DefaultClient.
+type countingDoer struct {
+	requests int
+}
+
+func (d *countingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests++
+	return http.DefaultClient.Do(req)
+}
+
+func TestRecorderWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"firstName":"Alex","role":"admin"}`))
+	}))
+	defer server.Close()
+
+	// The recorded requests are sent with the Doer, whatever the order of
+	// the options
+	for _, recorderFirst := range []bool{false, true} {
+		doer := &countingDoer{}
+		recorder := &runtime.Recorder{Dir: t.TempDir(), Mode: runtime.RecorderRecord}
+		opts := []ClientOption{WithHTTPClient(doer), WithRecorder(recorder)}
+		if recorderFirst {
+			opts = []ClientOption{WithRecorder(recorder), WithHTTPClient(doer)}
+		}
+		client, err := NewClientWithResponses(server.URL, opts...)
+		require.NoError(t, err)
+		rsp, err := client.GetJsonWithResponse(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rsp.StatusCode())
+		assert.Equal(t, 1, doer.requests)
+		assert.Nil(t, recorder.Transport)
+
+		files, err := filepath.Glob(filepath.Join(recorder.Dir, "GetJson-*.json"))
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"firstName":"Alex","role":"admin"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer token")
+		return nil
+	}))
+	assert.NoError(t, err)
+
+	req, err := client.NewGetJsonRequest(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/with_json_response", req.URL.String())
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+	operationID := runtime.OperationIDFromContext(req.Context())
+	assert.Equal(t, "GetJson", operationID)
+	assert.Nil(t, received)
+
+	rsp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	parsed, err := ParseOperationResponse(operationID, rsp)
+	assert.NoError(t, err)
+	if assert.IsType(t, &GetJsonResponse{}, parsed) {
+		assert.Equal(t, `{"firstName":"Alex","role":"admin"}`, string(parsed.(*GetJsonResponse).Body))
+	}
+	assert.NotNil(t, received)
+
+	_, err = ParseOperationResponse("Unknown", &http.Response{Body: ioutil.NopCloser(strings.NewReader(""))})
+	assert.Error(t, err)
+
+	// The Accept header can be set per call
+	req, err = client.NewGetJsonRequest(context.Background(), WithAccept("application/json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", req.Header.Get("Accept"))
+}
+
+func TestBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/with_other_response" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"firstName":"Alex","role":"admin"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithResponses(server.URL)
+	assert.NoError(t, err)
+	results := client.Batch(context.Background(), 2,
+		func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+			return c.GetJsonWithResponse(ctx)
+		},
+		func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+			return c.GetOtherWithResponse(ctx)
+		},
+		func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+			return nil, errors.New("failed")
+		},
+	)
+	assert.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	if assert.IsType(t, &GetJsonResponse{}, results[0].Response) {
+		assert.Equal(t, http.StatusOK, results[0].Response.(*GetJsonResponse).StatusCode())
+	}
+	assert.NoError(t, results[1].Err)
+	if assert.IsType(t, &GetOtherResponse{}, results[1].Response) {
+		assert.Equal(t, http.StatusNotFound, results[1].Response.(*GetOtherResponse).StatusCode())
+	}
+	assert.EqualError(t, results[2].Err, "failed")
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	body := strings.Repeat("x", 32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/with_json_response" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"firstName":"` + body + `","role":"admin"}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithResponses(server.URL, WithMaxResponseSize(16))
+	assert.NoError(t, err)
+
+	_, err = client.GetJsonWithResponse(context.Background())
+	var tooLarge *runtime.ResponseTooLargeError
+	assert.True(t, errors.As(err, &tooLarge))
+	assert.Equal(t, int64(16), tooLarge.Limit)
+
+	// The operation's x-max-response-size overrides the client's limit
+	rsp, err := client.GetOtherWithResponse(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(rsp.Body))
+}
@@ -16,6 +16,9 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -60,6 +63,34 @@ type AdditionalPropertiesObject5 struct {
 	AdditionalProperties map[string]SchemaObject `json:"-"`
 }
 
+// Has additional properties of an anonymous object, which has its own
+type AdditionalPropertiesObject6 struct {
+	Name                 string                                                      `json:"name"`
+	Tags                 *[]AdditionalPropertiesObject6_Tags_Item                    `json:"tags,omitempty"`
+	AdditionalProperties map[string]AdditionalPropertiesObject6_AdditionalProperties `json:"-"`
+}
+
+// AdditionalPropertiesObject6_Tags_Item defines model for AdditionalPropertiesObject6.Tags.Item.
+type AdditionalPropertiesObject6_Tags_Item struct {
+	Label                string             `json:"label"`
+	AdditionalProperties map[string]float32 `json:"-"`
+}
+
+// AdditionalPropertiesObject6_AdditionalProperties defines model for AdditionalPropertiesObject6.AdditionalProperties.
+type AdditionalPropertiesObject6_AdditionalProperties struct {
+	Count                *int              `json:"count,omitempty"`
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// AdditionalPropertiesObject7 defines model for AdditionalPropertiesObject7.
+type AdditionalPropertiesObject7 struct {
+	// Embedded struct due to allOf(#/components/schemas/SchemaObject)
+	SchemaObject `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	Age                  int            `json:"age"`
+	AdditionalProperties map[string]int `json:"-"`
+}
+
 // ObjectWithJsonField defines model for ObjectWithJsonField.
 type ObjectWithJsonField struct {
 	Name   string          `json:"name"`
@@ -88,6 +119,11 @@ type ParamsWithAddPropsParams_P1 struct {
 	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
+// ParamsWithAddPropsParams_P2 defines parameters for ParamsWithAddProps.
+type ParamsWithAddPropsParams_P2 struct {
+	Inner ParamsWithAddPropsParams_P2_Inner `json:"inner"`
+}
+
 // ParamsWithAddPropsParams defines parameters for ParamsWithAddProps.
 type ParamsWithAddPropsParams struct {
 	// This parameter has additional properties
@@ -95,9 +131,7 @@ type ParamsWithAddPropsParams struct {
 
 	// This parameter has an anonymous inner property which needs to be
 	// turned into a proper type for additionalProperties to work
-	P2 struct {
-		Inner ParamsWithAddPropsParams_P2_Inner `json:"inner"`
-	} `json:"p2"`
+	P2 ParamsWithAddPropsParams_P2 `json:"p2"`
 }
 
 // ParamsWithAddPropsParams_P2_Inner defines parameters for ParamsWithAddProps.
@@ -719,216 +753,369 @@ func (a AdditionalPropertiesObject5) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(ctx context.Context, req *http.Request) error
-
-// Doer performs HTTP requests.
-//
-// The standard http.Client implements this interface.
-type HttpRequestDoer interface {
-	Do(req *http.Request) (*http.Response, error)
+// Getter for additional properties for AdditionalPropertiesObject6. Returns the specified
+// element and whether it was found
+func (a AdditionalPropertiesObject6) Get(fieldName string) (value AdditionalPropertiesObject6_AdditionalProperties, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
 }
 
-// Client which conforms to the OpenAPI3 specification for this service.
-type Client struct {
-	// The endpoint of the server conforming to this interface, with scheme,
-	// https://api.deepmap.com for example. This can contain a path relative
-	// to the server, such as https://api.deepmap.com/dev-test, and all the
-	// paths in the swagger spec will be appended to the server.
-	Server string
-
-	// Doer for performing requests, typically a *http.Client with any
-	// customized settings, such as certificate chains.
-	Client HttpRequestDoer
-
-	// A list of callbacks for modifying requests which are generated before sending over
-	// the network.
-	RequestEditors []RequestEditorFn
+// Setter for additional properties for AdditionalPropertiesObject6
+func (a *AdditionalPropertiesObject6) Set(fieldName string, value AdditionalPropertiesObject6_AdditionalProperties) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]AdditionalPropertiesObject6_AdditionalProperties)
+	}
+	a.AdditionalProperties[fieldName] = value
 }
 
-// ClientOption allows setting custom parameters during construction
-type ClientOption func(*Client) error
-
-// Creates a new Client, with reasonable defaults
-func NewClient(server string, opts ...ClientOption) (*Client, error) {
-	// create a client with sane default values
-	client := Client{
-		Server: server,
+// Override default JSON handling for AdditionalPropertiesObject6 to handle AdditionalProperties
+func (a *AdditionalPropertiesObject6) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
 	}
-	// mutate client and add all optional params
-	for _, o := range opts {
-		if err := o(&client); err != nil {
-			return nil, err
+
+	if raw, found := object["name"]; found {
+		err = json.Unmarshal(raw, &a.Name)
+		if err != nil {
+			return fmt.Errorf("error reading 'name': %w", err)
 		}
+		delete(object, "name")
 	}
-	// ensure the server URL always has a trailing slash
-	if !strings.HasSuffix(client.Server, "/") {
-		client.Server += "/"
-	}
-	// create httpClient, if not already present
-	if client.Client == nil {
-		client.Client = &http.Client{}
-	}
-	return &client, nil
-}
 
-// WithHTTPClient allows overriding the default Doer, which is
-// automatically created using http.Client. This is useful for tests.
-func WithHTTPClient(doer HttpRequestDoer) ClientOption {
-	return func(c *Client) error {
-		c.Client = doer
-		return nil
+	if raw, found := object["tags"]; found {
+		err = json.Unmarshal(raw, &a.Tags)
+		if err != nil {
+			return fmt.Errorf("error reading 'tags': %w", err)
+		}
+		delete(object, "tags")
 	}
-}
 
-// WithRequestEditorFn allows setting up a callback function, which will be
-// called right before sending the request. This can be used to mutate the request.
-func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
-	return func(c *Client) error {
-		c.RequestEditors = append(c.RequestEditors, fn)
-		return nil
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]AdditionalPropertiesObject6_AdditionalProperties)
+		for fieldName, fieldBuf := range object {
+			var fieldVal AdditionalPropertiesObject6_AdditionalProperties
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
 	}
+	return nil
 }
 
-// The interface specification for the client above.
-type ClientInterface interface {
-	// EnsureEverythingIsReferenced request with any body
-	EnsureEverythingIsReferencedWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
-
-	EnsureEverythingIsReferenced(ctx context.Context, body EnsureEverythingIsReferencedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
-
-	// ParamsWithAddProps request
-	ParamsWithAddProps(ctx context.Context, params *ParamsWithAddPropsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
-
-	// BodyWithAddProps request with any body
-	BodyWithAddPropsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
-
-	BodyWithAddProps(ctx context.Context, body BodyWithAddPropsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
-}
+// Override default JSON handling for AdditionalPropertiesObject6 to handle AdditionalProperties
+func (a AdditionalPropertiesObject6) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
 
-func (c *Client) EnsureEverythingIsReferencedWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewEnsureEverythingIsReferencedRequestWithBody(c.Server, contentType, body)
+	object["name"], err = json.Marshal(a.Name)
 	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error marshaling 'name': %w", err)
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) EnsureEverythingIsReferenced(ctx context.Context, body EnsureEverythingIsReferencedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewEnsureEverythingIsReferencedRequest(c.Server, body)
-	if err != nil {
-		return nil, err
+	if a.Tags != nil {
+		object["tags"], err = json.Marshal(a.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'tags': %w", err)
+		}
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
 	}
-	return c.Client.Do(req)
+	return json.Marshal(object)
 }
 
-func (c *Client) ParamsWithAddProps(ctx context.Context, params *ParamsWithAddPropsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewParamsWithAddPropsRequest(c.Server, params)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+// Getter for additional properties for AdditionalPropertiesObject6_Tags_Item. Returns the specified
+// element and whether it was found
+func (a AdditionalPropertiesObject6_Tags_Item) Get(fieldName string) (value float32, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
 	}
-	return c.Client.Do(req)
+	return
 }
 
-func (c *Client) BodyWithAddPropsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewBodyWithAddPropsRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+// Setter for additional properties for AdditionalPropertiesObject6_Tags_Item
+func (a *AdditionalPropertiesObject6_Tags_Item) Set(fieldName string, value float32) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]float32)
 	}
-	return c.Client.Do(req)
+	a.AdditionalProperties[fieldName] = value
 }
 
-func (c *Client) BodyWithAddProps(ctx context.Context, body BodyWithAddPropsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewBodyWithAddPropsRequest(c.Server, body)
+// Override default JSON handling for AdditionalPropertiesObject6_Tags_Item to handle AdditionalProperties
+func (a *AdditionalPropertiesObject6_Tags_Item) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	if raw, found := object["label"]; found {
+		err = json.Unmarshal(raw, &a.Label)
+		if err != nil {
+			return fmt.Errorf("error reading 'label': %w", err)
+		}
+		delete(object, "label")
 	}
-	return c.Client.Do(req)
-}
 
-// NewEnsureEverythingIsReferencedRequest calls the generic EnsureEverythingIsReferenced builder with application/json body
-func NewEnsureEverythingIsReferencedRequest(server string, body EnsureEverythingIsReferencedJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]float32)
+		for fieldName, fieldBuf := range object {
+			var fieldVal float32
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewEnsureEverythingIsReferencedRequestWithBody(server, "application/json", bodyReader)
+	return nil
 }
 
-// NewEnsureEverythingIsReferencedRequestWithBody generates requests for EnsureEverythingIsReferenced with any type of body
-func NewEnsureEverythingIsReferencedRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// Override default JSON handling for AdditionalPropertiesObject6_Tags_Item to handle AdditionalProperties
+func (a AdditionalPropertiesObject6_Tags_Item) MarshalJSON() ([]byte, error) {
 	var err error
+	object := make(map[string]json.RawMessage)
 
-	serverURL, err := url.Parse(server)
+	object["label"], err = json.Marshal(a.Label)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error marshaling 'label': %w", err)
 	}
 
-	operationPath := fmt.Sprintf("/ensure-everything-is-referenced")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
 	}
+	return json.Marshal(object)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+// Getter for additional properties for AdditionalPropertiesObject6_AdditionalProperties. Returns the specified
+// element and whether it was found
+func (a AdditionalPropertiesObject6_AdditionalProperties) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
 	}
+	return
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), body)
-	if err != nil {
-		return nil, err
+// Setter for additional properties for AdditionalPropertiesObject6_AdditionalProperties
+func (a *AdditionalPropertiesObject6_AdditionalProperties) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	a.AdditionalProperties[fieldName] = value
 }
 
-// NewParamsWithAddPropsRequest generates requests for ParamsWithAddProps
-func NewParamsWithAddPropsRequest(server string, params *ParamsWithAddPropsParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+// Override default JSON handling for AdditionalPropertiesObject6_AdditionalProperties to handle AdditionalProperties
+func (a *AdditionalPropertiesObject6_AdditionalProperties) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	operationPath := fmt.Sprintf("/params_with_add_props")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	if raw, found := object["count"]; found {
+		err = json.Unmarshal(raw, &a.Count)
+		if err != nil {
+			return fmt.Errorf("error reading 'count': %w", err)
+		}
+		delete(object, "count")
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
 	}
+	return nil
+}
 
-	queryValues := queryURL.Query()
+// Override default JSON handling for AdditionalPropertiesObject6_AdditionalProperties to handle AdditionalProperties
+func (a AdditionalPropertiesObject6_AdditionalProperties) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
 
-	if queryFrag, err := runtime.StyleParamWithLocation("simple", true, "p1", runtime.ParamLocationQuery, params.P1); err != nil {
-		return nil, err
+	if a.Count != nil {
+		object["count"], err = json.Marshal(a.Count)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'count': %w", err)
+		}
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for AdditionalPropertiesObject7. Returns the specified
+// element and whether it was found
+func (a AdditionalPropertiesObject7) Get(fieldName string) (value int, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for AdditionalPropertiesObject7
+func (a *AdditionalPropertiesObject7) Set(fieldName string, value int) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]int)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for AdditionalPropertiesObject7 to handle AdditionalProperties
+func (a *AdditionalPropertiesObject7) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if raw, found := object["age"]; found {
+		err = json.Unmarshal(raw, &a.Age)
+		if err != nil {
+			return fmt.Errorf("error reading 'age': %w", err)
+		}
+		delete(object, "age")
+	}
+
+	if raw, found := object["firstName"]; found {
+		err = json.Unmarshal(raw, &a.FirstName)
+		if err != nil {
+			return fmt.Errorf("error reading 'firstName': %w", err)
+		}
+		delete(object, "firstName")
+	}
+
+	if raw, found := object["role"]; found {
+		err = json.Unmarshal(raw, &a.Role)
+		if err != nil {
+			return fmt.Errorf("error reading 'role': %w", err)
+		}
+		delete(object, "role")
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]int)
+		for fieldName, fieldBuf := range object {
+			var fieldVal int
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for AdditionalPropertiesObject7 to handle AdditionalProperties
+func (a AdditionalPropertiesObject7) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	object["age"], err = json.Marshal(a.Age)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'age': %w", err)
+	}
+
+	object["firstName"], err = json.Marshal(a.FirstName)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'firstName': %w", err)
+	}
+
+	object["role"], err = json.Marshal(a.Role)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling 'role': %w", err)
+	}
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// EnsureEverythingIsReferencedRoute is the route of EnsureEverythingIsReferenced, as in the spec.
+const EnsureEverythingIsReferencedRoute = "/ensure-everything-is-referenced"
+
+// ParamsWithAddPropsRoute is the route of ParamsWithAddProps, as in the spec.
+const ParamsWithAddPropsRoute = "/params_with_add_props"
+
+// BodyWithAddPropsRoute is the route of BodyWithAddProps, as in the spec.
+const BodyWithAddPropsRoute = "/params_with_add_props"
+
+// BuildEnsureEverythingIsReferencedURL builds the URL of EnsureEverythingIsReferenced on server, serializing its
+// path and query parameters like the client does.
+func BuildEnsureEverythingIsReferencedURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ensure-everything-is-referenced")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildParamsWithAddPropsURL builds the URL of ParamsWithAddProps on server, serializing its
+// path and query parameters like the client does.
+func BuildParamsWithAddPropsURL(server string, params *ParamsWithAddPropsParams) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/params_with_add_props")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("simple", true, "p1", runtime.ParamLocationQuery, params.P1); err != nil {
+		return nil, err
 	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 		return nil, err
 	} else {
@@ -953,6 +1140,339 @@ func NewParamsWithAddPropsRequest(server string, params *ParamsWithAddPropsParam
 
 	queryURL.RawQuery = queryValues.Encode()
 
+	return queryURL, nil
+}
+
+// BuildBodyWithAddPropsURL builds the URL of BodyWithAddProps on server, serializing its
+// path and query parameters like the client does.
+func BuildBodyWithAddPropsURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/params_with_add_props")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Servers are the endpoints which the requests are sent to, as picked by
+	// the ServerPolicy, when there are several, which WithServers sets up.
+	// Server is the first one.
+	Servers []string
+
+	// ServerPolicy is how the servers of the requests are picked.
+	ServerPolicy runtime.ServerPolicy
+
+	// requests counts the requests, for picking their servers in turn.
+	requests uint32
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// RateLimiter, when set, is waited on before sending each request, with
+	// the ID of its operation.
+	RateLimiter runtime.RateLimiter
+
+	// MaxRetryAfter is how long, in total, a request which is rate limited,
+	// with a 429 status and a Retry-After header, is retried for, after
+	// waiting as long as the server asks, and at least
+	// runtime.MinRetryAfter. It isn't retried when it's zero.
+	MaxRetryAfter time.Duration
+
+	// MaxResponseSize is the maximum size of the response bodies, in bytes,
+	// beyond which reading them fails with a *runtime.ResponseTooLargeError,
+	// unless the operation has its own, from x-max-response-size. The
+	// bodies aren't limited when it's zero.
+	MaxResponseSize int64
+
+	// recorder, which WithRecorder sets, sends the requests through the
+	// Client once all the options are applied.
+	recorder *runtime.Recorder
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	for i, server := range client.Servers {
+		if !strings.HasSuffix(server, "/") {
+			client.Servers[i] = server + "/"
+		}
+	}
+	// send the requests of the Client through the recorder, whatever the
+	// order of the options
+	if client.recorder != nil {
+		client.Client = &http.Client{Transport: client.recorder.WithDoer(client.Client)}
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithServers makes the client send its requests to several servers, such as
+// the ones declared in the spec, instead of the one given to NewClient. They
+// are picked by policy: with runtime.ServerFailover, the requests fail over
+// to the next servers when one can't be reached, or, for idempotent requests,
+// responds with a 5xx status, runtime.ServerFailoverAnyMethod fails over the
+// requests of any method on 5xx statuses too, and runtime.ServerRoundRobin
+// sends them to each server in turn.
+// The server of a request is picked before the request editors run.
+func WithServers(policy runtime.ServerPolicy, servers ...string) ClientOption {
+	return func(c *Client) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("no servers given")
+		}
+		c.Server = servers[0]
+		c.Servers = append([]string{}, servers...)
+		c.ServerPolicy = policy
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithAccept sets the Accept header of a request, eg, to ask for one of the
+// content types of the responses of its operation, rather than all of them,
+// which the request builders list.
+func WithAccept(accept string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", accept)
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, eg, for a token of the bucket of its operation.
+func WithRateLimiter(limiter runtime.RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRetryAfter makes the client retry the requests which are rate limited,
+// with a 429 status and a Retry-After header, after waiting as long as the
+// server asks, for up to maxWait in total. The response is returned when the
+// server asks to wait longer.
+func WithRetryAfter(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.MaxRetryAfter = maxWait
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies which the client
+// reads to maxSize bytes, so that a misbehaving server can't exhaust its
+// memory. Reading larger bodies, such as when parsing them, fails with a
+// *runtime.ResponseTooLargeError. The operations with x-max-response-size
+// have their own limit.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		c.MaxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithRecorder makes the client send its requests through recorder, which
+// records their responses to golden files, or replays them, in tests. The
+// requests which are recorded are sent with the Doer of WithHTTPClient, if
+// any, unless recorder has a Transport of its own.
+func WithRecorder(recorder *runtime.Recorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// EnsureEverythingIsReferenced request with any body
+	EnsureEverythingIsReferencedWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	EnsureEverythingIsReferenced(ctx context.Context, body EnsureEverythingIsReferencedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ParamsWithAddProps request
+	ParamsWithAddProps(ctx context.Context, params *ParamsWithAddPropsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BodyWithAddProps request with any body
+	BodyWithAddPropsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BodyWithAddProps(ctx context.Context, body BodyWithAddPropsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) EnsureEverythingIsReferencedWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "EnsureEverythingIsReferenced", func(server string) (*http.Request, error) {
+		return NewEnsureEverythingIsReferencedRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
+
+func (c *Client) EnsureEverythingIsReferenced(ctx context.Context, body EnsureEverythingIsReferencedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "EnsureEverythingIsReferenced", func(server string) (*http.Request, error) {
+		return NewEnsureEverythingIsReferencedRequest(server, body)
+	}, reqEditors)
+}
+
+func (c *Client) ParamsWithAddProps(ctx context.Context, params *ParamsWithAddPropsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "ParamsWithAddProps", func(server string) (*http.Request, error) {
+		return NewParamsWithAddPropsRequest(server, params)
+	}, reqEditors)
+}
+
+func (c *Client) BodyWithAddPropsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "BodyWithAddProps", func(server string) (*http.Request, error) {
+		return NewBodyWithAddPropsRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
+
+func (c *Client) BodyWithAddProps(ctx context.Context, body BodyWithAddPropsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "BodyWithAddProps", func(server string) (*http.Request, error) {
+		return NewBodyWithAddPropsRequest(server, body)
+	}, reqEditors)
+}
+
+// NewEnsureEverythingIsReferencedRequestWithBody builds the request which EnsureEverythingIsReferencedWithBody sends, without
+// sending it.
+func (c *Client) NewEnsureEverythingIsReferencedRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "EnsureEverythingIsReferenced", func(server string) (*http.Request, error) {
+		return NewEnsureEverythingIsReferencedRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
+
+// NewEnsureEverythingIsReferencedRequest builds the request which EnsureEverythingIsReferenced sends, without
+// sending it.
+func (c *Client) NewEnsureEverythingIsReferencedRequest(ctx context.Context, body EnsureEverythingIsReferencedJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "EnsureEverythingIsReferenced", func(server string) (*http.Request, error) {
+		return NewEnsureEverythingIsReferencedRequest(server, body)
+	}, reqEditors)
+}
+
+// NewParamsWithAddPropsRequest builds the request which ParamsWithAddProps sends, without
+// sending it.
+func (c *Client) NewParamsWithAddPropsRequest(ctx context.Context, params *ParamsWithAddPropsParams, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "ParamsWithAddProps", func(server string) (*http.Request, error) {
+		return NewParamsWithAddPropsRequest(server, params)
+	}, reqEditors)
+}
+
+// NewBodyWithAddPropsRequestWithBody builds the request which BodyWithAddPropsWithBody sends, without
+// sending it.
+func (c *Client) NewBodyWithAddPropsRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "BodyWithAddProps", func(server string) (*http.Request, error) {
+		return NewBodyWithAddPropsRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
+
+// NewBodyWithAddPropsRequest builds the request which BodyWithAddProps sends, without
+// sending it.
+func (c *Client) NewBodyWithAddPropsRequest(ctx context.Context, body BodyWithAddPropsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "BodyWithAddProps", func(server string) (*http.Request, error) {
+		return NewBodyWithAddPropsRequest(server, body)
+	}, reqEditors)
+}
+
+// NewEnsureEverythingIsReferencedRequest calls the generic EnsureEverythingIsReferenced builder with application/json body
+func NewEnsureEverythingIsReferencedRequest(server string, body EnsureEverythingIsReferencedJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewEnsureEverythingIsReferencedRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewEnsureEverythingIsReferencedRequestWithBody generates requests for EnsureEverythingIsReferenced with any type of body
+func NewEnsureEverythingIsReferencedRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	queryURL, err := BuildEnsureEverythingIsReferencedURL(server)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json, text/plain;q=0.9")
+
+	return req, nil
+}
+
+// NewParamsWithAddPropsRequest generates requests for ParamsWithAddProps
+func NewParamsWithAddPropsRequest(server string, params *ParamsWithAddPropsParams) (*http.Request, error) {
+	queryURL, err := BuildParamsWithAddPropsURL(server, params)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -974,30 +1494,119 @@ func NewBodyWithAddPropsRequest(server string, body BodyWithAddPropsJSONRequestB
 
 // NewBodyWithAddPropsRequestWithBody generates requests for BodyWithAddProps with any type of body
 func NewBodyWithAddPropsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+	queryURL, err := BuildBodyWithAddPropsURL(server)
+	if err != nil {
+		return nil, err
+	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/params_with_add_props")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// do sends the request built by newRequest for a server, which is picked by
+// the ServerPolicy, after editing it, once the RateLimiter lets the operation
+// through. The context of the request carries the operation ID, for the
+// editors and the Doer. When it fails over, or is retried after being rate limited, the
+// request is built again, with the body of the first one, unless the body
+// can't be read again.
+func (c *Client) do(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Response, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	var first *http.Request
+	build := func(server string) (*http.Request, error) {
+		req, err := newRequest(server)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = req
+		} else if first.GetBody != nil {
+			if req.Body, err = first.GetBody(); err != nil {
+				return nil, err
+			}
+			req.GetBody = first.GetBody
+			req.ContentLength = first.ContentLength
+		}
+		return req.WithContext(ctx), nil
+	}
+	replayable := func() bool {
+		return first.Body == nil || first.Body == http.NoBody || first.GetBody != nil
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+	var waited time.Duration
+	for {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, operationID); err != nil {
+				return nil, err
+			}
+		}
+		rsp, err := c.send(ctx, build, replayable, reqEditors)
+		if err != nil || c.MaxRetryAfter <= 0 || !replayable() {
+			return rsp, err
+		}
+		wait, retry := runtime.RetryAfter(rsp, time.Now())
+		if !retry || waited+wait > c.MaxRetryAfter {
+			return rsp, nil
+		}
+		_, _ = io.Copy(ioutil.Discard, rsp.Body)
+		rsp.Body.Close()
+		if err := runtime.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		waited += wait
 	}
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
+// send sends the request built by build to the servers, in the order of the
+// ServerPolicy, until one of them doesn't fail over.
+func (c *Client) send(ctx context.Context, build func(server string) (*http.Request, error), replayable func() bool, reqEditors []RequestEditorFn) (*http.Response, error) {
+	servers := []string{c.Server}
+	if len(c.Servers) > 0 {
+		servers = runtime.ServerOrder(c.ServerPolicy, c.Servers, atomic.AddUint32(&c.requests, 1)-1)
 	}
+	for i, server := range servers {
+		req, err := build(server)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+			return nil, err
+		}
+		rsp, err := c.Client.Do(req)
+		if i == len(servers)-1 || !replayable() || !runtime.ShouldFailOver(ctx, c.ServerPolicy, req, rsp, err) {
+			return runtime.LimitResponseBody(rsp, c.maxResponseSize(runtime.OperationIDFromContext(ctx))), err
+		}
+		if rsp != nil {
+			_, _ = io.Copy(ioutil.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
+	}
+	return nil, fmt.Errorf("no servers")
+}
 
-	req.Header.Add("Content-Type", contentType)
+// maxResponseSize returns the maximum size of the response bodies of the
+// operation with the given ID, or zero when they aren't limited.
+func (c *Client) maxResponseSize(operationID string) int64 {
+	return c.MaxResponseSize
+}
 
+// newRequest builds the request of the operation, like do does, for the
+// first server, with ctx, after editing it, without sending it.
+func (c *Client) newRequest(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Request, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	req, err := newRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -1030,7 +1639,7 @@ func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithRes
 	return &ClientWithResponses{client}, nil
 }
 
-// WithBaseURL overrides the baseURL.
+// WithBaseURL overrides the baseURL, and the servers set by WithServers.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
@@ -1038,6 +1647,7 @@ func WithBaseURL(baseURL string) ClientOption {
 			return err
 		}
 		c.Server = newBaseURL.String()
+		c.Servers = nil
 		return nil
 	}
 }
@@ -1072,6 +1682,12 @@ type EnsureEverythingIsReferencedResponse struct {
 		// Has additional properties of type int
 		One *AdditionalPropertiesObject1 `json:"one,omitempty"`
 
+		// Embeds a schema, and has typed additional properties
+		Seven *AdditionalPropertiesObject7 `json:"seven,omitempty"`
+
+		// Has additional properties of an anonymous object, which has its own
+		Six *AdditionalPropertiesObject6 `json:"six,omitempty"`
+
 		// Allows any additional property
 		Three *AdditionalPropertiesObject3 `json:"three,omitempty"`
 
@@ -1184,6 +1800,54 @@ func (c *ClientWithResponses) BodyWithAddPropsWithResponse(ctx context.Context,
 	return ParseBodyWithAddPropsResponse(rsp)
 }
 
+// BatchCall is a call of an operation in a batch, which returns its
+// *...Response, eg,
+//
+//	func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+//		return c.FindPetByIdWithResponse(ctx, id)
+//	}
+type BatchCall func(ctx context.Context, client ClientWithResponsesInterface) (interface{}, error)
+
+// BatchResult is the result of a call in a batch.
+type BatchResult struct {
+	Response interface{} // The *...Response of the operation, if it was called
+	Err      error
+}
+
+// Batch makes the calls with the client, with at most concurrency of them at
+// a time, or all at once when concurrency isn't positive, and returns their
+// results in the order of the calls. A failed call doesn't stop the others,
+// but those which haven't started once ctx is done fail with its error.
+func (c *ClientWithResponses) Batch(ctx context.Context, concurrency int, calls ...BatchCall) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	errs := runtime.RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+		var err error
+		results[i].Response, err = calls[i](ctx, c)
+		return err
+	})
+	for i, err := range errs {
+		results[i].Err = err
+	}
+	return results
+}
+
+// ParseOperationResponse parses an HTTP response to a request of the
+// operation with the given ID, such as one built by a New...Request method of
+// the Client, which runtime.OperationIDFromContext tells from the context of
+// the request, into the *...Response of the operation.
+func ParseOperationResponse(operationID string, rsp *http.Response) (interface{}, error) {
+	switch operationID {
+	case "EnsureEverythingIsReferenced":
+		return ParseEnsureEverythingIsReferencedResponse(rsp)
+	case "ParamsWithAddProps":
+		return ParseParamsWithAddPropsResponse(rsp)
+	case "BodyWithAddProps":
+		return ParseBodyWithAddPropsResponse(rsp)
+	}
+	_ = rsp.Body.Close()
+	return nil, fmt.Errorf("unknown operation %s", operationID)
+}
+
 // ParseEnsureEverythingIsReferencedResponse parses an HTTP response from a EnsureEverythingIsReferencedWithResponse call
 func ParseEnsureEverythingIsReferencedResponse(rsp *http.Response) (*EnsureEverythingIsReferencedResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -1210,6 +1874,12 @@ func ParseEnsureEverythingIsReferencedResponse(rsp *http.Response) (*EnsureEvery
 			// Has additional properties of type int
 			One *AdditionalPropertiesObject1 `json:"one,omitempty"`
 
+			// Embeds a schema, and has typed additional properties
+			Seven *AdditionalPropertiesObject7 `json:"seven,omitempty"`
+
+			// Has additional properties of an anonymous object, which has its own
+			Six *AdditionalPropertiesObject6 `json:"six,omitempty"`
+
 			// Allows any additional property
 			Three *AdditionalPropertiesObject3 `json:"three,omitempty"`
 
@@ -1285,7 +1955,8 @@ type ServerInterface interface {
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	Handler             ServerInterface
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // EnsureEverythingIsReferenced converts echo context to params.
@@ -1307,14 +1978,16 @@ func (w *ServerInterfaceWrapper) ParamsWithAddProps(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("simple", true, true, "p1", ctx.QueryParams(), &params.P1)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p1: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "p1", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p1: %s", err)))
 	}
 
 	// ------------- Required query parameter "p2" -------------
 
 	err = runtime.BindQueryParameter("form", true, true, "p2", ctx.QueryParams(), &params.P2)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p2: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "p2", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p2: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -1346,45 +2019,88 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+	BaseURL string
+	// RouteMiddlewares returns the middlewares of the route of an operation.
+	RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+	// RouteName names the route of an operation, which it's registered
+	// under, for echo.Echo.Reverse.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute func(route runtime.Route)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{})
 }
 
 // Registers handlers, and prepends BaseURL to the paths, so that the paths
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
 
 	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+		Handler:             si,
+		BindErrorTranslator: options.BindErrorTranslator,
+	}
+
+	register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route, handler echo.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []echo.MiddlewareFunc
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		if added := add(route.RouterPath, handler, middlewares...); route.Name != "" {
+			added.Name = route.Name
+		}
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
 	}
 
-	router.GET(baseURL+"/ensure-everything-is-referenced", wrapper.EnsureEverythingIsReferenced)
-	router.GET(baseURL+"/params_with_add_props", wrapper.ParamsWithAddProps)
-	router.POST(baseURL+"/params_with_add_props", wrapper.BodyWithAddProps)
+	register(runtime.Route{OperationID: "EnsureEverythingIsReferenced", Method: "GET", Path: "/ensure-everything-is-referenced", RouterPath: "/ensure-everything-is-referenced"}, router.GET, wrapper.EnsureEverythingIsReferenced)
+	register(runtime.Route{OperationID: "ParamsWithAddProps", Method: "GET", Path: "/params_with_add_props", RouterPath: "/params_with_add_props"}, router.GET, wrapper.ParamsWithAddProps)
+	register(runtime.Route{OperationID: "BodyWithAddProps", Method: "POST", Path: "/params_with_add_props", RouterPath: "/params_with_add_props"}, router.POST, wrapper.BodyWithAddProps)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RXTW/jNhD9KwTboxLHzvaim4tu0RRoG+wG6GFjBLQ4ipjKQy05sldY6L8XQ8nflNdx",
-	"9rK5RJY5X49v3oy/yswuKouA5GX6VTr4XIOnX602EF582Lxo+GNmkQCJH1VVlSZTZCyOXrxFfuezAhaK",
-	"nypnK3DUe/ndQKn54WcHuUzlT6Nt2FFn5Ecfw/9/5i+QkWzbJCRjHGiZfuo9zPg1wRcaVaUyByGpqUCm",
-	"0pMz+Cxb/mMfvrLo18V0H/oYP1o9idTgM2cqzlGmciq8WVQliHWRwm6D9Vmwo6nWhk1Ueb+poktrHAqP",
-	"fL0T3yDBMzh5FP4P5cXWVmwREjYXbCwMkkwOoDM67hvVAiJVJ9JWXYAYJPuYBhcJR5gl66NrRJITKEyG",
-	"UchV6eGw8N8seIGWhCpLu4pj8Na6v1Npt8OlkauPKptyQV4obCJVNUc1vSL316X97nVpByaixWZhay9y",
-	"bi2xKkxWiGKIo8f3gwjuW2G/a/nnmXd5JZeg+Mup7j5fuc7v+5WhQnRORG6d0CYLh1wH+FHqXYR/DRV/",
-	"eosbUT0L5UQuVVlDULDcuoUimcqg28nA0ckZR+Nt10eKob8H1VHuuXGe/h4qwNnyDAKEU8mOq1kYBQZz",
-	"y8alyQA9bJGSf909sHcyxO7lA3gSH8EtA42W4Hx3jePrm+ubTmABVWVkKm+vb67H3BmKipD/CNDXDq5g",
-	"Ca6hwuDzlfFXDnJwgBmE23qGUPg+Rx4K4wWgrqxBEvDFePLCW0GFIrFlnMgUijmIzIEi0MKgoML4R/QV",
-	"ZEKhDjI7B1G5GkE/8o0xvmFK32mZyvchwfeb/O78h212yc4+0wyRfm/lGe3uO4frw+Tm5g07Q26W8K3G",
-	"O9XLbSJzW7vLXbxjFy+7jXbKT6w3mSz4hiLGgZeFgzf4uA0+VvZyD5PQYged3K9XuapLGmZKT4bRwSLZ",
-	"WY8q5dTCP7EKPimtn/j+/WCLTAW3WaeZwRIInA+kV2JuddOPsF4L4pIb6Yj7kAVf3FTr+5ACd/Q6gEw/",
-	"RZt1c2J4ZoZgvKXKzzW4Zj2UUlmN5a5mdbNy2wenJuqRoHpqgmx1q61sk3OyxZ3xHwbmZmfpQUQA7QVZ",
-	"MYdHpNphEBuyQvUnu4WVh1YsW7ZcWfffMAKTkwi8atWITIpDssZWhFnbzvYEC+uybBNZWR9hXxjiote+",
-	"XbqxuimD/K02DjKKApIwTx/xJPBM7JhthLMstweMdRf+8Dx/fTv3GnaW9Qt3uPX2vr6n9pAr7fHFtW37",
-	"fwAAAP//Xv36ip0PAAA=",
+	"H4sIAAAAAAAC/9RY32/bNhD+Vwhuj2qcH10L+M3DOiwDtgZtgD00RkCLJ4uZdFTJkx2h0P8+kJIt2aYc",
+	"2cnLnmLT5N19d98dP+YHj3VeaAQky6c/uIHvJVj6VUsFfuHLdqFyX2ONBEjuoyiKTMWClMbJk9Xo1myc",
+	"Qi7cp8LoAgy1Vn5XkEn34WcDCZ/ynyad20lzyE6++r+fF08QE6/ryAejDEg+/dZamLtlgmeaFJlQey6p",
+	"KoBPuSWjcMnrum5s2EKj3YBpvrQ+/m94Ii7BxkYVLkY+5TNmVV5kwDYgme6ctVE4QzMplTsisrstiias",
+	"Kw888HPPv0KCJRh+4P4PYVl3lnUZYjph7jBTSDzaS52SYdsocgigjrguGgehlOzm1JuInId5tNm6yUh0",
+	"JAvXw1lIRGZhH/hvGixDTUxkmV6Hc/Ba3G8E7WYYGpnyANnMAbJMYBVAVR1gOiH208J+f1rYnomoscp1",
+	"aVniWoutUxWnLB3i6GF9EMG85PZN4Y873sQVnZPFX4519/jJNb7v14pS1hhhiTZMqthvMk3CTwj9w7HQ",
+	"XxhY3ejYLVesS6Tevm0T1oHQThh1AnvkayxEPfopskyvcWzzRJzE0m9QBPkYvFjmi2aW7DrIxAJGTM1m",
+	"W4hZ7YIwRlRv0dUfPZgs+5zw6beTGHjKJbWbBLGEgZL3wYhlCMt8nwaf8gVIy0TL8YgJlL7G7qAcGDR1",
+	"xBsc/yhK/7Qat+JhJB9WIivB39SJNrkgPuVen0QDW69HbA1fL62nUFV3CnIQe6KMpb+HABidjRh0flfU",
+	"MzX3kkdhoj2bVQxoocsU/+v23rNUkTPP78ES+wpm5cflCoxtinZ1cXlx2QgJQFEoPuU3F5cXV64hBaU+",
+	"/gmgLQ28gxWYilKFy3fKvjOQgAGMwVdrCR74LiPuU2UZoCy0QmLwrCxZZjWjVBDreM1igWwBLDYgCCRT",
+	"yChV9gFtAbFnkZMTC2CFKRHkg6uYy69Xo7fSUc8H+Gkb36390kUXcbMr00OttSPtJ31dvy+Try8vX6GN",
+	"E7WCly6YY3dWHfFEl+Z8E++diad+ox2zE+pNRxZ8BYgrZ8HCCvB8Gx+9DfV8voUPvjtSA69AcuNtrPX5",
+	"Fq5D92v7mElEmdEwX1tKTvaebc3pSSGMyO2j0xyPQspHx0I72Kgz5pq9USj+JBAY61tPsIWWVXtjtxMp",
+	"PMsDfXnno3D0mUl550OIeOfAX3SBkbHdMaxQvTPlTnwvwVQbCTjlxRXvT85GmXbdeEy/Hox1S5Ufns1D",
+	"0t+0L0fb1ztenm5fCG0SEdw1SZot4AGpNOhHHmkm2p3N89BJxFC07uRam3+HM3B9NAMnCfuQANsja0iQ",
+	"z2snD3pjE8ssc+pD2wD7vH5k7QTu083NWKHQ/SqVgZiCCfFC4wGPJt4RO3Q2wFk39PcYa878N8/4x9LY",
+	"MvSU3Jkvps1beVOnep8r9WHh6rr+bwAzlzLxCxMAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1407,14 +2123,19 @@ func decodeSpec() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var rawSpec = decodeSpecCached()
+var (
+	rawSpecOnce sync.Once
+	rawSpecData []byte
+	rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+	rawSpecOnce.Do(func() {
+		rawSpecData, rawSpecErr = decodeSpec()
+	})
+	return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
@@ -70,3 +70,36 @@ func TestAdditionalProperties(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, bossSchema, obj5.AdditionalProperties["boss"])
 }
+
+func TestNestedAdditionalProperties(t *testing.T) {
+	buf := `{"name": "bob", "tags": [{"label": "a", "weight": 1.5}], "extra": {"count": 2, "color": "red"}}`
+	var obj6 AdditionalPropertiesObject6
+	err := json.Unmarshal([]byte(buf), &obj6)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", obj6.Name)
+	assert.Equal(t, "a", (*obj6.Tags)[0].Label)
+	weight, found := (*obj6.Tags)[0].Get("weight")
+	assert.True(t, found)
+	assert.EqualValues(t, 1.5, weight)
+	extra, found := obj6.Get("extra")
+	assert.True(t, found)
+	assert.Equal(t, 2, *extra.Count)
+	assert.Equal(t, map[string]string{"color": "red"}, extra.AdditionalProperties)
+
+	buf2, err := json.Marshal(obj6)
+	assert.NoError(t, err)
+	assertJsonEqual(t, []byte(buf), buf2)
+
+	// The fields of the embedded struct aren't taken for additional properties
+	buf = `{"firstName": "bob", "role": "manager", "age": 40, "reports": 3}`
+	var obj7 AdditionalPropertiesObject7
+	err = json.Unmarshal([]byte(buf), &obj7)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", obj7.FirstName)
+	assert.Equal(t, 40, obj7.Age)
+	assert.Equal(t, map[string]int{"reports": 3}, obj7.AdditionalProperties)
+
+	buf2, err = json.Marshal(obj7)
+	assert.NoError(t, err)
+	assertJsonEqual(t, []byte(buf), buf2)
+}
@@ -0,0 +1,62 @@
+package echo5
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+func TestParameters(t *testing.T) {
+	m := ServerInterfaceMock{}
+
+	m.CreateResource2Func = func(ctx *echo.Context, inlineArgument int, params CreateResource2Params) error {
+		assert.Equal(t, 99, *params.InlineQueryArgument)
+		assert.Equal(t, 1, inlineArgument)
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	e := echo.New()
+	RegisterHandlers(e, &m)
+
+	req := httptest.NewRequest("POST", "http://openapitest.deepmap.ai/resource2/1?inline_query_argument=99", nil)
+	rr := httptest.NewRecorder()
+	e.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, 1, len(m.CreateResource2Calls()))
+}
+
+func TestRouteOptions(t *testing.T) {
+	m := ServerInterfaceMock{}
+
+	var routes []runtime.Route
+	e := echo.New()
+	RegisterHandlersWithOptions(e.Group("/api"), &m, EchoServerOptions{
+		RouteName: func(route runtime.Route) string { return route.OperationID },
+		OnRoute:   func(route runtime.Route) { routes = append(routes, route) },
+	})
+
+	require.NotEmpty(t, routes)
+	assert.Equal(t, "CreateResource2", routes[7].Name)
+	assert.Equal(t, "/resource2/:inline_argument", routes[7].RouterPath)
+}
+
+func TestBindError(t *testing.T) {
+	m := ServerInterfaceMock{}
+
+	e := echo.New()
+	RegisterHandlers(e, &m)
+
+	req := httptest.NewRequest("POST", "http://openapitest.deepmap.ai/resource2/notanumber", nil)
+	rr := httptest.NewRecorder()
+	e.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Empty(t, m.CreateResource2Calls())
+}
@@ -0,0 +1,4 @@
+package iris
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --generate=types,iris --package=iris -o server.gen.go ../test-schema.yaml
+//go:generate go run github.com/matryer/moq -out server_moq.gen.go . ServerInterface
@@ -0,0 +1,72 @@
+package iris
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kataras/iris/v12"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+func TestParameters(t *testing.T) {
+	m := ServerInterfaceMock{}
+
+	m.CreateResource2Func = func(ctx iris.Context, inlineArgument int, params CreateResource2Params) {
+		assert.Equal(t, 99, *params.InlineQueryArgument)
+		assert.Equal(t, 1, inlineArgument)
+	}
+
+	app := iris.New()
+	RegisterHandlers(app, &m)
+	require.NoError(t, app.Build())
+
+	req := httptest.NewRequest("POST", "http://openapitest.deepmap.ai/resource2/1?inline_query_argument=99", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	assert.Equal(t, 1, len(m.CreateResource2Calls()))
+}
+
+func TestRouteOptions(t *testing.T) {
+	m := ServerInterfaceMock{}
+
+	var routes []runtime.Route
+	app := iris.New()
+	RegisterHandlersWithOptions(app, &m, IrisServerOptions{
+		BaseURL:   "/api",
+		RouteName: func(route runtime.Route) string { return route.OperationID },
+		OnRoute:   func(route runtime.Route) { routes = append(routes, route) },
+	})
+	require.NoError(t, app.Build())
+
+	require.NotEmpty(t, routes)
+	assert.Equal(t, "/api/resource2/{inline_argument}", routes[7].RouterPath)
+	require.NotNil(t, app.GetRoute("CreateResource2"))
+	assert.Equal(t, "/api/resource2/{inline_argument}", app.GetRoute("CreateResource2").Tmpl().Src)
+}
+
+func TestErrorHandler(t *testing.T) {
+	m := ServerInterfaceMock{}
+
+	var handled error
+	app := iris.New()
+	RegisterHandlersWithOptions(app, &m, IrisServerOptions{
+		ErrorHandler: func(ctx iris.Context, err error, statusCode int) {
+			handled = err
+			ctx.StatusCode(statusCode)
+		},
+	})
+	require.NoError(t, app.Build())
+
+	req := httptest.NewRequest("POST", "http://openapitest.deepmap.ai/resource2/notanumber", nil)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Error(t, handled)
+	assert.Empty(t, m.CreateResource2Calls())
+}
@@ -16,6 +16,9 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -49,6 +52,65 @@ type ValidatePetsJSONBody PetNames
 // ValidatePetsJSONRequestBody defines body for ValidatePets for application/json ContentType.
 type ValidatePetsJSONRequestBody ValidatePetsJSONBody
 
+// GetPetRoute is the route of GetPet, as in the spec.
+const GetPetRoute = "/pets/{petId}"
+
+// ValidatePetsRoute is the route of ValidatePets, as in the spec.
+const ValidatePetsRoute = "/pets:validate"
+
+// BuildGetPetURL builds the URL of GetPet on server, serializing its
+// path and query parameters like the client does.
+func BuildGetPetURL(server string, petId string) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "petId", runtime.ParamLocationPath, petId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildValidatePetsURL builds the URL of ValidatePets on server, serializing its
+// path and query parameters like the client does.
+func BuildValidatePetsURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets:validate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -67,6 +129,17 @@ type Client struct {
 	// paths in the swagger spec will be appended to the server.
 	Server string
 
+	// Servers are the endpoints which the requests are sent to, as picked by
+	// the ServerPolicy, when there are several, which WithServers sets up.
+	// Server is the first one.
+	Servers []string
+
+	// ServerPolicy is how the servers of the requests are picked.
+	ServerPolicy runtime.ServerPolicy
+
+	// requests counts the requests, for picking their servers in turn.
+	requests uint32
+
 	// Doer for performing requests, typically a *http.Client with any
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
@@ -74,6 +147,26 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// RateLimiter, when set, is waited on before sending each request, with
+	// the ID of its operation.
+	RateLimiter runtime.RateLimiter
+
+	// MaxRetryAfter is how long, in total, a request which is rate limited,
+	// with a 429 status and a Retry-After header, is retried for, after
+	// waiting as long as the server asks, and at least
+	// runtime.MinRetryAfter. It isn't retried when it's zero.
+	MaxRetryAfter time.Duration
+
+	// MaxResponseSize is the maximum size of the response bodies, in bytes,
+	// beyond which reading them fails with a *runtime.ResponseTooLargeError,
+	// unless the operation has its own, from x-max-response-size. The
+	// bodies aren't limited when it's zero.
+	MaxResponseSize int64
+
+	// recorder, which WithRecorder sets, sends the requests through the
+	// Client once all the options are applied.
+	recorder *runtime.Recorder
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -95,6 +188,16 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if !strings.HasSuffix(client.Server, "/") {
 		client.Server += "/"
 	}
+	for i, server := range client.Servers {
+		if !strings.HasSuffix(server, "/") {
+			client.Servers[i] = server + "/"
+		}
+	}
+	// send the requests of the Client through the recorder, whatever the
+	// order of the options
+	if client.recorder != nil {
+		client.Client = &http.Client{Transport: client.recorder.WithDoer(client.Client)}
+	}
 	// create httpClient, if not already present
 	if client.Client == nil {
 		client.Client = &http.Client{}
@@ -111,6 +214,26 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 	}
 }
 
+// WithServers makes the client send its requests to several servers, such as
+// the ones declared in the spec, instead of the one given to NewClient. They
+// are picked by policy: with runtime.ServerFailover, the requests fail over
+// to the next servers when one can't be reached, or, for idempotent requests,
+// responds with a 5xx status, runtime.ServerFailoverAnyMethod fails over the
+// requests of any method on 5xx statuses too, and runtime.ServerRoundRobin
+// sends them to each server in turn.
+// The server of a request is picked before the request editors run.
+func WithServers(policy runtime.ServerPolicy, servers ...string) ClientOption {
+	return func(c *Client) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("no servers given")
+		}
+		c.Server = servers[0]
+		c.Servers = append([]string{}, servers...)
+		c.ServerPolicy = policy
+		return nil
+	}
+}
+
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
@@ -120,6 +243,59 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithAccept sets the Accept header of a request, eg, to ask for one of the
+// content types of the responses of its operation, rather than all of them,
+// which the request builders list.
+func WithAccept(accept string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", accept)
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, eg, for a token of the bucket of its operation.
+func WithRateLimiter(limiter runtime.RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRetryAfter makes the client retry the requests which are rate limited,
+// with a 429 status and a Retry-After header, after waiting as long as the
+// server asks, for up to maxWait in total. The response is returned when the
+// server asks to wait longer.
+func WithRetryAfter(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.MaxRetryAfter = maxWait
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies which the client
+// reads to maxSize bytes, so that a misbehaving server can't exhaust its
+// memory. Reading larger bodies, such as when parsing them, fails with a
+// *runtime.ResponseTooLargeError. The operations with x-max-response-size
+// have their own limit.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		c.MaxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithRecorder makes the client send its requests through recorder, which
+// records their responses to golden files, or replays them, in tests. The
+// requests which are recorded are sent with the Doer of WithHTTPClient, if
+// any, unless recorder has a Transport of its own.
+func WithRecorder(recorder *runtime.Recorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetPet request
@@ -132,63 +308,50 @@ type ClientInterface interface {
 }
 
 func (c *Client) GetPet(ctx context.Context, petId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetPetRequest(c.Server, petId)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "GetPet", func(server string) (*http.Request, error) {
+		return NewGetPetRequest(server, petId)
+	}, reqEditors)
 }
 
 func (c *Client) ValidatePetsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewValidatePetsRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "ValidatePets", func(server string) (*http.Request, error) {
+		return NewValidatePetsRequestWithBody(server, contentType, body)
+	}, reqEditors)
 }
 
 func (c *Client) ValidatePets(ctx context.Context, body ValidatePetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewValidatePetsRequest(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "ValidatePets", func(server string) (*http.Request, error) {
+		return NewValidatePetsRequest(server, body)
+	}, reqEditors)
 }
 
-// NewGetPetRequest generates requests for GetPet
-func NewGetPetRequest(server string, petId string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "petId", runtime.ParamLocationPath, petId)
-	if err != nil {
-		return nil, err
-	}
+// NewGetPetRequest builds the request which GetPet sends, without
+// sending it.
+func (c *Client) NewGetPetRequest(ctx context.Context, petId string, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetPet", func(server string) (*http.Request, error) {
+		return NewGetPetRequest(server, petId)
+	}, reqEditors)
+}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+// NewValidatePetsRequestWithBody builds the request which ValidatePetsWithBody sends, without
+// sending it.
+func (c *Client) NewValidatePetsRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "ValidatePets", func(server string) (*http.Request, error) {
+		return NewValidatePetsRequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
 
-	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+// NewValidatePetsRequest builds the request which ValidatePets sends, without
+// sending it.
+func (c *Client) NewValidatePetsRequest(ctx context.Context, body ValidatePetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "ValidatePets", func(server string) (*http.Request, error) {
+		return NewValidatePetsRequest(server, body)
+	}, reqEditors)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+// NewGetPetRequest generates requests for GetPet
+func NewGetPetRequest(server string, petId string) (*http.Request, error) {
+	queryURL, err := BuildGetPetURL(server, petId)
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +361,8 @@ func NewGetPetRequest(server string, petId string) (*http.Request, error) {
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "application/json")
+
 	return req, nil
 }
 
@@ -214,30 +379,120 @@ func NewValidatePetsRequest(server string, body ValidatePetsJSONRequestBody) (*h
 
 // NewValidatePetsRequestWithBody generates requests for ValidatePets with any type of body
 func NewValidatePetsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+	queryURL, err := BuildValidatePetsURL(server)
+	if err != nil {
+		return nil, err
+	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/pets:validate")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req.Header.Add("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	return req, nil
+}
+
+// do sends the request built by newRequest for a server, which is picked by
+// the ServerPolicy, after editing it, once the RateLimiter lets the operation
+// through. The context of the request carries the operation ID, for the
+// editors and the Doer. When it fails over, or is retried after being rate limited, the
+// request is built again, with the body of the first one, unless the body
+// can't be read again.
+func (c *Client) do(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Response, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	var first *http.Request
+	build := func(server string) (*http.Request, error) {
+		req, err := newRequest(server)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = req
+		} else if first.GetBody != nil {
+			if req.Body, err = first.GetBody(); err != nil {
+				return nil, err
+			}
+			req.GetBody = first.GetBody
+			req.ContentLength = first.ContentLength
+		}
+		return req.WithContext(ctx), nil
+	}
+	replayable := func() bool {
+		return first.Body == nil || first.Body == http.NoBody || first.GetBody != nil
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+	var waited time.Duration
+	for {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, operationID); err != nil {
+				return nil, err
+			}
+		}
+		rsp, err := c.send(ctx, build, replayable, reqEditors)
+		if err != nil || c.MaxRetryAfter <= 0 || !replayable() {
+			return rsp, err
+		}
+		wait, retry := runtime.RetryAfter(rsp, time.Now())
+		if !retry || waited+wait > c.MaxRetryAfter {
+			return rsp, nil
+		}
+		_, _ = io.Copy(ioutil.Discard, rsp.Body)
+		rsp.Body.Close()
+		if err := runtime.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		waited += wait
 	}
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
+// send sends the request built by build to the servers, in the order of the
+// ServerPolicy, until one of them doesn't fail over.
+func (c *Client) send(ctx context.Context, build func(server string) (*http.Request, error), replayable func() bool, reqEditors []RequestEditorFn) (*http.Response, error) {
+	servers := []string{c.Server}
+	if len(c.Servers) > 0 {
+		servers = runtime.ServerOrder(c.ServerPolicy, c.Servers, atomic.AddUint32(&c.requests, 1)-1)
+	}
+	for i, server := range servers {
+		req, err := build(server)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+			return nil, err
+		}
+		rsp, err := c.Client.Do(req)
+		if i == len(servers)-1 || !replayable() || !runtime.ShouldFailOver(ctx, c.ServerPolicy, req, rsp, err) {
+			return runtime.LimitResponseBody(rsp, c.maxResponseSize(runtime.OperationIDFromContext(ctx))), err
+		}
+		if rsp != nil {
+			_, _ = io.Copy(ioutil.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
 	}
+	return nil, fmt.Errorf("no servers")
+}
 
-	req.Header.Add("Content-Type", contentType)
+// maxResponseSize returns the maximum size of the response bodies of the
+// operation with the given ID, or zero when they aren't limited.
+func (c *Client) maxResponseSize(operationID string) int64 {
+	return c.MaxResponseSize
+}
 
+// newRequest builds the request of the operation, like do does, for the
+// first server, with ctx, after editing it, without sending it.
+func (c *Client) newRequest(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Request, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	req, err := newRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -270,7 +525,7 @@ func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithRes
 	return &ClientWithResponses{client}, nil
 }
 
-// WithBaseURL overrides the baseURL.
+// WithBaseURL overrides the baseURL, and the servers set by WithServers.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
@@ -278,6 +533,7 @@ func WithBaseURL(baseURL string) ClientOption {
 			return err
 		}
 		c.Server = newBaseURL.String()
+		c.Servers = nil
 		return nil
 	}
 }
@@ -364,6 +620,52 @@ func (c *ClientWithResponses) ValidatePetsWithResponse(ctx context.Context, body
 	return ParseValidatePetsResponse(rsp)
 }
 
+// BatchCall is a call of an operation in a batch, which returns its
+// *...Response, eg,
+//
+//	func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+//		return c.FindPetByIdWithResponse(ctx, id)
+//	}
+type BatchCall func(ctx context.Context, client ClientWithResponsesInterface) (interface{}, error)
+
+// BatchResult is the result of a call in a batch.
+type BatchResult struct {
+	Response interface{} // The *...Response of the operation, if it was called
+	Err      error
+}
+
+// Batch makes the calls with the client, with at most concurrency of them at
+// a time, or all at once when concurrency isn't positive, and returns their
+// results in the order of the calls. A failed call doesn't stop the others,
+// but those which haven't started once ctx is done fail with its error.
+func (c *ClientWithResponses) Batch(ctx context.Context, concurrency int, calls ...BatchCall) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	errs := runtime.RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+		var err error
+		results[i].Response, err = calls[i](ctx, c)
+		return err
+	})
+	for i, err := range errs {
+		results[i].Err = err
+	}
+	return results
+}
+
+// ParseOperationResponse parses an HTTP response to a request of the
+// operation with the given ID, such as one built by a New...Request method of
+// the Client, which runtime.OperationIDFromContext tells from the context of
+// the request, into the *...Response of the operation.
+func ParseOperationResponse(operationID string, rsp *http.Response) (interface{}, error) {
+	switch operationID {
+	case "GetPet":
+		return ParseGetPetResponse(rsp)
+	case "ValidatePets":
+		return ParseValidatePetsResponse(rsp)
+	}
+	_ = rsp.Body.Close()
+	return nil, fmt.Errorf("unknown operation %s", operationID)
+}
+
 // ParseGetPetResponse parses an HTTP response from a GetPetWithResponse call
 func ParseGetPetResponse(rsp *http.Response) (*GetPetResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -435,7 +737,8 @@ type ServerInterface interface {
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	Handler             ServerInterface
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // GetPet converts echo context to params.
@@ -446,7 +749,8 @@ func (w *ServerInterfaceWrapper) GetPet(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "petId", runtime.ParamLocationPath, ctx.Param("petId"), &petId)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter petId: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "petId", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter petId: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -478,21 +782,62 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+	BaseURL string
+	// RouteMiddlewares returns the middlewares of the route of an operation.
+	RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+	// RouteName names the route of an operation, which it's registered
+	// under, for echo.Echo.Reverse.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute func(route runtime.Route)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{})
 }
 
 // Registers handlers, and prepends BaseURL to the paths, so that the paths
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
 
 	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+		Handler:             si,
+		BindErrorTranslator: options.BindErrorTranslator,
+	}
+
+	register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route, handler echo.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []echo.MiddlewareFunc
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		if added := add(route.RouterPath, handler, middlewares...); route.Name != "" {
+			added.Name = route.Name
+		}
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
 	}
 
-	router.GET(baseURL+"/pets/:petId", wrapper.GetPet)
-	router.POST(baseURL+"/pets:validate", wrapper.ValidatePets)
+	register(runtime.Route{OperationID: "GetPet", Method: "GET", Path: "/pets/{petId}", RouterPath: "/pets/:petId"}, router.GET, wrapper.GetPet)
+	register(runtime.Route{OperationID: "ValidatePets", Method: "POST", Path: "/pets:validate", RouterPath: "/pets:validate"}, router.POST, wrapper.ValidatePets)
 
 }
 
@@ -530,14 +875,19 @@ func decodeSpec() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var rawSpec = decodeSpecCached()
+var (
+	rawSpecOnce sync.Once
+	rawSpecData []byte
+	rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+	rawSpecOnce.Do(func() {
+		rawSpecData, rawSpecErr = decodeSpec()
+	})
+	return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
@@ -1,6 +1,7 @@
 package issue_52
 
 import (
+	"context"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -60,6 +61,7 @@ func TestIssue(t *testing.T) {
 		EmbedSpec:          true,
 	}
 
-	_, err = codegen.Generate(swagger, "issue_52", opts)
+	opts.PackageName = "issue_52"
+	_, _, err = codegen.Generate(context.Background(), swagger, opts)
 	require.NoError(t, err)
 }
@@ -1,6 +1,7 @@
 package grab_import_names
 
 import (
+	"context"
 	"testing"
 
 	"github.com/deepmap/oapi-codegen/pkg/codegen"
@@ -19,7 +20,9 @@ func TestLineComments(t *testing.T) {
 		EmbedSpec:          true,
 	}
 
-	code, err := codegen.Generate(swagger, "grab_import_names", opts)
+	opts.PackageName = "grab_import_names"
+	artifacts, _, err := codegen.Generate(context.Background(), swagger, opts)
+	code := artifacts.Code
 	require.NoError(t, err)
 	require.NotContains(t, code, `"openapi_types"`)
 }
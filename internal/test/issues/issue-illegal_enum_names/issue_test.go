@@ -1,6 +1,7 @@
 package illegal_enum_names
 
 import (
+	"context"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -22,7 +23,9 @@ func TestIllegalEnumNames(t *testing.T) {
 		EmbedSpec:          true,
 	}
 
-	code, err := codegen.Generate(swagger, "illegal_enum_names", opts)
+	opts.PackageName = "illegal_enum_names"
+	artifacts, _, err := codegen.Generate(context.Background(), swagger, opts)
+	code := artifacts.Code
 	require.NoError(t, err)
 
 	f, err := parser.ParseFile(token.NewFileSet(), "", code, parser.AllErrors)
@@ -10,11 +10,15 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -124,399 +128,312 @@ type GetQueryFormParams struct {
 	N1s *string `json:"1s,omitempty"`
 }
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(ctx context.Context, req *http.Request) error
-
-// Doer performs HTTP requests.
-//
-// The standard http.Client implements this interface.
-type HttpRequestDoer interface {
-	Do(req *http.Request) (*http.Response, error)
-}
-
-// Client which conforms to the OpenAPI3 specification for this service.
-type Client struct {
-	// The endpoint of the server conforming to this interface, with scheme,
-	// https://api.deepmap.com for example. This can contain a path relative
-	// to the server, such as https://api.deepmap.com/dev-test, and all the
-	// paths in the swagger spec will be appended to the server.
-	Server string
-
-	// Doer for performing requests, typically a *http.Client with any
-	// customized settings, such as certificate chains.
-	Client HttpRequestDoer
-
-	// A list of callbacks for modifying requests which are generated before sending over
-	// the network.
-	RequestEditors []RequestEditorFn
-}
-
-// ClientOption allows setting custom parameters during construction
-type ClientOption func(*Client) error
-
-// Creates a new Client, with reasonable defaults
-func NewClient(server string, opts ...ClientOption) (*Client, error) {
-	// create a client with sane default values
-	client := Client{
-		Server: server,
-	}
-	// mutate client and add all optional params
-	for _, o := range opts {
-		if err := o(&client); err != nil {
-			return nil, err
-		}
-	}
-	// ensure the server URL always has a trailing slash
-	if !strings.HasSuffix(client.Server, "/") {
-		client.Server += "/"
-	}
-	// create httpClient, if not already present
-	if client.Client == nil {
-		client.Client = &http.Client{}
-	}
-	return &client, nil
-}
-
-// WithHTTPClient allows overriding the default Doer, which is
-// automatically created using http.Client. This is useful for tests.
-func WithHTTPClient(doer HttpRequestDoer) ClientOption {
-	return func(c *Client) error {
-		c.Client = doer
-		return nil
-	}
-}
+// GetContentObjectRoute is the route of GetContentObject, as in the spec.
+const GetContentObjectRoute = "/contentObject/{param}"
 
-// WithRequestEditorFn allows setting up a callback function, which will be
-// called right before sending the request. This can be used to mutate the request.
-func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
-	return func(c *Client) error {
-		c.RequestEditors = append(c.RequestEditors, fn)
-		return nil
-	}
-}
+// GetCookieRoute is the route of GetCookie, as in the spec.
+const GetCookieRoute = "/cookie"
 
-// The interface specification for the client above.
-type ClientInterface interface {
-	// GetContentObject request
-	GetContentObject(ctx context.Context, param ComplexObject, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetHeaderRoute is the route of GetHeader, as in the spec.
+const GetHeaderRoute = "/header"
 
-	// GetCookie request
-	GetCookie(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetLabelExplodeArrayRoute is the route of GetLabelExplodeArray, as in the spec.
+const GetLabelExplodeArrayRoute = "/labelExplodeArray/{.param*}"
 
-	// GetHeader request
-	GetHeader(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetLabelExplodeObjectRoute is the route of GetLabelExplodeObject, as in the spec.
+const GetLabelExplodeObjectRoute = "/labelExplodeObject/{.param*}"
 
-	// GetLabelExplodeArray request
-	GetLabelExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetLabelNoExplodeArrayRoute is the route of GetLabelNoExplodeArray, as in the spec.
+const GetLabelNoExplodeArrayRoute = "/labelNoExplodeArray/{.param}"
 
-	// GetLabelExplodeObject request
-	GetLabelExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetLabelNoExplodeObjectRoute is the route of GetLabelNoExplodeObject, as in the spec.
+const GetLabelNoExplodeObjectRoute = "/labelNoExplodeObject/{.param}"
 
-	// GetLabelNoExplodeArray request
-	GetLabelNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetMatrixExplodeArrayRoute is the route of GetMatrixExplodeArray, as in the spec.
+const GetMatrixExplodeArrayRoute = "/matrixExplodeArray/{.id*}"
 
-	// GetLabelNoExplodeObject request
-	GetLabelNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetMatrixExplodeObjectRoute is the route of GetMatrixExplodeObject, as in the spec.
+const GetMatrixExplodeObjectRoute = "/matrixExplodeObject/{.id*}"
 
-	// GetMatrixExplodeArray request
-	GetMatrixExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetMatrixNoExplodeArrayRoute is the route of GetMatrixNoExplodeArray, as in the spec.
+const GetMatrixNoExplodeArrayRoute = "/matrixNoExplodeArray/{.id}"
 
-	// GetMatrixExplodeObject request
-	GetMatrixExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetMatrixNoExplodeObjectRoute is the route of GetMatrixNoExplodeObject, as in the spec.
+const GetMatrixNoExplodeObjectRoute = "/matrixNoExplodeObject/{.id}"
 
-	// GetMatrixNoExplodeArray request
-	GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetPassThroughRoute is the route of GetPassThrough, as in the spec.
+const GetPassThroughRoute = "/passThrough/{param}"
 
-	// GetMatrixNoExplodeObject request
-	GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetDeepObjectRoute is the route of GetDeepObject, as in the spec.
+const GetDeepObjectRoute = "/queryDeepObject"
 
-	// GetPassThrough request
-	GetPassThrough(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetQueryFormRoute is the route of GetQueryForm, as in the spec.
+const GetQueryFormRoute = "/queryForm"
 
-	// GetDeepObject request
-	GetDeepObject(ctx context.Context, params *GetDeepObjectParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetSimpleExplodeArrayRoute is the route of GetSimpleExplodeArray, as in the spec.
+const GetSimpleExplodeArrayRoute = "/simpleExplodeArray/{param*}"
 
-	// GetQueryForm request
-	GetQueryForm(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetSimpleExplodeObjectRoute is the route of GetSimpleExplodeObject, as in the spec.
+const GetSimpleExplodeObjectRoute = "/simpleExplodeObject/{param*}"
 
-	// GetSimpleExplodeArray request
-	GetSimpleExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetSimpleNoExplodeArrayRoute is the route of GetSimpleNoExplodeArray, as in the spec.
+const GetSimpleNoExplodeArrayRoute = "/simpleNoExplodeArray/{param}"
 
-	// GetSimpleExplodeObject request
-	GetSimpleExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetSimpleNoExplodeObjectRoute is the route of GetSimpleNoExplodeObject, as in the spec.
+const GetSimpleNoExplodeObjectRoute = "/simpleNoExplodeObject/{param}"
 
-	// GetSimpleNoExplodeArray request
-	GetSimpleNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetSimplePrimitiveRoute is the route of GetSimplePrimitive, as in the spec.
+const GetSimplePrimitiveRoute = "/simplePrimitive/{param}"
 
-	// GetSimpleNoExplodeObject request
-	GetSimpleNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+// GetStartingWithNumberRoute is the route of GetStartingWithNumber, as in the spec.
+const GetStartingWithNumberRoute = "/startingWithNumber/{1param}"
 
-	// GetSimplePrimitive request
-	GetSimplePrimitive(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+// BuildGetContentObjectURL builds the URL of GetContentObject on server, serializing its
+// path and query parameters like the client does.
+func BuildGetContentObjectURL(server string, param ComplexObject) (*url.URL, error) {
+	var err error
 
-	// GetStartingWithNumber request
-	GetStartingWithNumber(ctx context.Context, n1param string, reqEditors ...RequestEditorFn) (*http.Response, error)
-}
+	var pathParam0 string
 
-func (c *Client) GetContentObject(ctx context.Context, param ComplexObject, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetContentObjectRequest(c.Server, param)
+	var pathParamBuf0 []byte
+	pathParamBuf0, err = json.Marshal(param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
+	pathParam0 = url.PathEscape(string(pathParamBuf0))
 
-func (c *Client) GetCookie(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetCookieRequest(c.Server, params)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	operationPath := fmt.Sprintf("/contentObject/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetHeader(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetHeaderRequest(c.Server, params)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+
+	return queryURL, nil
 }
 
-func (c *Client) GetLabelExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetLabelExplodeArrayRequest(c.Server, param)
+// BuildGetCookieURL builds the URL of GetCookie on server, serializing its
+// path and query parameters like the client does.
+func BuildGetCookieURL(server string, params *GetCookieParams) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	operationPath := fmt.Sprintf("/cookie")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetLabelExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetLabelExplodeObjectRequest(c.Server, param)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+
+	return queryURL, nil
 }
 
-func (c *Client) GetLabelNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetLabelNoExplodeArrayRequest(c.Server, param)
+// BuildGetHeaderURL builds the URL of GetHeader on server, serializing its
+// path and query parameters like the client does.
+func BuildGetHeaderURL(server string, params *GetHeaderParams) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	operationPath := fmt.Sprintf("/header")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetLabelNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetLabelNoExplodeObjectRequest(c.Server, param)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+
+	return queryURL, nil
 }
 
-func (c *Client) GetMatrixExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetMatrixExplodeArrayRequest(c.Server, id)
+// BuildGetLabelExplodeArrayURL builds the URL of GetLabelExplodeArray on server, serializing its
+// path and query parameters like the client does.
+func BuildGetLabelExplodeArrayURL(server string, param []int32) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("label", true, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetMatrixExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetMatrixExplodeObjectRequest(c.Server, id)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	operationPath := fmt.Sprintf("/labelExplodeArray/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetMatrixNoExplodeArrayRequest(c.Server, id)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+
+	return queryURL, nil
 }
 
-func (c *Client) GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetMatrixNoExplodeObjectRequest(c.Server, id)
+// BuildGetLabelExplodeObjectURL builds the URL of GetLabelExplodeObject on server, serializing its
+// path and query parameters like the client does.
+func BuildGetLabelExplodeObjectURL(server string, param Object) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("label", true, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetPassThrough(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetPassThroughRequest(c.Server, param)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	operationPath := fmt.Sprintf("/labelExplodeObject/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetDeepObject(ctx context.Context, params *GetDeepObjectParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetDeepObjectRequest(c.Server, params)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+
+	return queryURL, nil
 }
 
-func (c *Client) GetQueryForm(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetQueryFormRequest(c.Server, params)
+// BuildGetLabelNoExplodeArrayURL builds the URL of GetLabelNoExplodeArray on server, serializing its
+// path and query parameters like the client does.
+func BuildGetLabelNoExplodeArrayURL(server string, param []int32) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("label", false, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetSimpleExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSimpleExplodeArrayRequest(c.Server, param)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	operationPath := fmt.Sprintf("/labelNoExplodeArray/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetSimpleExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSimpleExplodeObjectRequest(c.Server, param)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+
+	return queryURL, nil
 }
 
-func (c *Client) GetSimpleNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSimpleNoExplodeArrayRequest(c.Server, param)
+// BuildGetLabelNoExplodeObjectURL builds the URL of GetLabelNoExplodeObject on server, serializing its
+// path and query parameters like the client does.
+func BuildGetLabelNoExplodeObjectURL(server string, param Object) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("label", false, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetSimpleNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSimpleNoExplodeObjectRequest(c.Server, param)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
+
+	operationPath := fmt.Sprintf("/labelNoExplodeObject/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetSimplePrimitive(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetSimplePrimitiveRequest(c.Server, param)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+
+	return queryURL, nil
+}
+
+// BuildGetMatrixExplodeArrayURL builds the URL of GetMatrixExplodeArray on server, serializing its
+// path and query parameters like the client does.
+func BuildGetMatrixExplodeArrayURL(server string, id []int32) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("matrix", true, "id", runtime.ParamLocationPath, id)
+	if err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
-}
 
-func (c *Client) GetStartingWithNumber(ctx context.Context, n1param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetStartingWithNumberRequest(c.Server, n1param)
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+
+	operationPath := fmt.Sprintf("/matrixExplodeArray/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+
+	return queryURL, nil
 }
 
-// NewGetContentObjectRequest generates requests for GetContentObject
-func NewGetContentObjectRequest(server string, param ComplexObject) (*http.Request, error) {
+// BuildGetMatrixExplodeObjectURL builds the URL of GetMatrixExplodeObject on server, serializing its
+// path and query parameters like the client does.
+func BuildGetMatrixExplodeObjectURL(server string, id Object) (*url.URL, error) {
 	var err error
 
 	var pathParam0 string
 
-	var pathParamBuf0 []byte
-	pathParamBuf0, err = json.Marshal(param)
+	pathParam0, err = runtime.StyleParamWithLocation("matrix", true, "id", runtime.ParamLocationPath, id)
 	if err != nil {
 		return nil, err
 	}
-	pathParam0 = string(pathParamBuf0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/contentObject/%s", pathParam0)
+	operationPath := fmt.Sprintf("/matrixExplodeObject/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -526,24 +443,27 @@ func NewGetContentObjectRequest(server string, param ComplexObject) (*http.Reque
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
+	return queryURL, nil
 }
 
-// NewGetCookieRequest generates requests for GetCookie
-func NewGetCookieRequest(server string, params *GetCookieParams) (*http.Request, error) {
+// BuildGetMatrixNoExplodeArrayURL builds the URL of GetMatrixNoExplodeArray on server, serializing its
+// path and query parameters like the client does.
+func BuildGetMatrixNoExplodeArrayURL(server string, id []int32) (*url.URL, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("matrix", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/cookie")
+	operationPath := fmt.Sprintf("/matrixNoExplodeArray/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -553,138 +473,108 @@ func NewGetCookieRequest(server string, params *GetCookieParams) (*http.Request,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	return queryURL, nil
+}
 
-	if params.P != nil {
-		var cookieParam0 string
+// BuildGetMatrixNoExplodeObjectURL builds the URL of GetMatrixNoExplodeObject on server, serializing its
+// path and query parameters like the client does.
+func BuildGetMatrixNoExplodeObjectURL(server string, id Object) (*url.URL, error) {
+	var err error
 
-		cookieParam0, err = runtime.StyleParamWithLocation("simple", false, "p", runtime.ParamLocationCookie, *params.P)
-		if err != nil {
-			return nil, err
-		}
+	var pathParam0 string
 
-		cookie0 := &http.Cookie{
-			Name:  "p",
-			Value: cookieParam0,
-		}
-		req.AddCookie(cookie0)
+	pathParam0, err = runtime.StyleParamWithLocation("matrix", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
 	}
 
-	if params.Ep != nil {
-		var cookieParam1 string
-
-		cookieParam1, err = runtime.StyleParamWithLocation("simple", true, "ep", runtime.ParamLocationCookie, *params.Ep)
-		if err != nil {
-			return nil, err
-		}
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-		cookie1 := &http.Cookie{
-			Name:  "ep",
-			Value: cookieParam1,
-		}
-		req.AddCookie(cookie1)
+	operationPath := fmt.Sprintf("/matrixNoExplodeObject/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
 
-	if params.Ea != nil {
-		var cookieParam2 string
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-		cookieParam2, err = runtime.StyleParamWithLocation("simple", true, "ea", runtime.ParamLocationCookie, *params.Ea)
-		if err != nil {
-			return nil, err
-		}
+	return queryURL, nil
+}
 
-		cookie2 := &http.Cookie{
-			Name:  "ea",
-			Value: cookieParam2,
-		}
-		req.AddCookie(cookie2)
-	}
+// BuildGetPassThroughURL builds the URL of GetPassThrough on server, serializing its
+// path and query parameters like the client does.
+func BuildGetPassThroughURL(server string, param string) (*url.URL, error) {
+	var err error
 
-	if params.A != nil {
-		var cookieParam3 string
+	var pathParam0 string
 
-		cookieParam3, err = runtime.StyleParamWithLocation("simple", false, "a", runtime.ParamLocationCookie, *params.A)
-		if err != nil {
-			return nil, err
-		}
+	pathParam0 = param
 
-		cookie3 := &http.Cookie{
-			Name:  "a",
-			Value: cookieParam3,
-		}
-		req.AddCookie(cookie3)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
 
-	if params.Eo != nil {
-		var cookieParam4 string
-
-		cookieParam4, err = runtime.StyleParamWithLocation("simple", true, "eo", runtime.ParamLocationCookie, *params.Eo)
-		if err != nil {
-			return nil, err
-		}
+	operationPath := fmt.Sprintf("/passThrough/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-		cookie4 := &http.Cookie{
-			Name:  "eo",
-			Value: cookieParam4,
-		}
-		req.AddCookie(cookie4)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
 
-	if params.O != nil {
-		var cookieParam5 string
+	return queryURL, nil
+}
 
-		cookieParam5, err = runtime.StyleParamWithLocation("simple", false, "o", runtime.ParamLocationCookie, *params.O)
-		if err != nil {
-			return nil, err
-		}
+// BuildGetDeepObjectURL builds the URL of GetDeepObject on server, serializing its
+// path and query parameters like the client does.
+func BuildGetDeepObjectURL(server string, params *GetDeepObjectParams) (*url.URL, error) {
+	var err error
 
-		cookie5 := &http.Cookie{
-			Name:  "o",
-			Value: cookieParam5,
-		}
-		req.AddCookie(cookie5)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
 
-	if params.Co != nil {
-		var cookieParam6 string
-
-		var cookieParamBuf6 []byte
-		cookieParamBuf6, err = json.Marshal(*params.Co)
-		if err != nil {
-			return nil, err
-		}
-		cookieParam6 = url.QueryEscape(string(cookieParamBuf6))
-
-		cookie6 := &http.Cookie{
-			Name:  "co",
-			Value: cookieParam6,
-		}
-		req.AddCookie(cookie6)
+	operationPath := fmt.Sprintf("/queryDeepObject")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
 
-	if params.N1s != nil {
-		var cookieParam7 string
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-		cookieParam7, err = runtime.StyleParamWithLocation("simple", true, "1s", runtime.ParamLocationCookie, *params.N1s)
-		if err != nil {
-			return nil, err
-		}
+	queryValues := queryURL.Query()
 
-		cookie7 := &http.Cookie{
-			Name:  "1s",
-			Value: cookieParam7,
+	if queryFrag, err := runtime.StyleParamWithLocation("deepObject", true, "deepObj", runtime.ParamLocationQuery, params.DeepObj); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
 		}
-		req.AddCookie(cookie7)
 	}
 
-	return req, nil
+	queryURL.RawQuery = queryValues.Encode()
+
+	return queryURL, nil
 }
 
-// NewGetHeaderRequest generates requests for GetHeader
-func NewGetHeaderRequest(server string, params *GetHeaderParams) (*http.Request, error) {
+// BuildGetQueryFormURL builds the URL of GetQueryForm on server, serializing its
+// path and query parameters like the client does.
+func BuildGetQueryFormURL(server string, params *GetQueryFormParams) (*url.URL, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -692,7 +582,7 @@ func NewGetHeaderRequest(server string, params *GetHeaderParams) (*http.Request,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/header")
+	operationPath := fmt.Sprintf("/queryForm")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -702,111 +592,159 @@ func NewGetHeaderRequest(server string, params *GetHeaderParams) (*http.Request,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	queryValues := queryURL.Query()
 
-	if params.XPrimitive != nil {
-		var headerParam0 string
+	if params.Ea != nil {
 
-		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Primitive", runtime.ParamLocationHeader, *params.XPrimitive)
-		if err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ea", runtime.ParamLocationQuery, *params.Ea); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
 		}
 
-		req.Header.Set("X-Primitive", headerParam0)
 	}
 
-	if params.XPrimitiveExploded != nil {
-		var headerParam1 string
+	if params.A != nil {
 
-		headerParam1, err = runtime.StyleParamWithLocation("simple", true, "X-Primitive-Exploded", runtime.ParamLocationHeader, *params.XPrimitiveExploded)
-		if err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "a", runtime.ParamLocationQuery, *params.A); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
 		}
 
-		req.Header.Set("X-Primitive-Exploded", headerParam1)
 	}
 
-	if params.XArrayExploded != nil {
-		var headerParam2 string
+	if params.Eo != nil {
 
-		headerParam2, err = runtime.StyleParamWithLocation("simple", true, "X-Array-Exploded", runtime.ParamLocationHeader, *params.XArrayExploded)
-		if err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "eo", runtime.ParamLocationQuery, *params.Eo); err != nil {
 			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
 		}
 
-		req.Header.Set("X-Array-Exploded", headerParam2)
 	}
 
-	if params.XArray != nil {
-		var headerParam3 string
+	if params.O != nil {
 
-		headerParam3, err = runtime.StyleParamWithLocation("simple", false, "X-Array", runtime.ParamLocationHeader, *params.XArray)
-		if err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "o", runtime.ParamLocationQuery, *params.O); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
 		}
 
-		req.Header.Set("X-Array", headerParam3)
 	}
 
-	if params.XObjectExploded != nil {
-		var headerParam4 string
+	if params.Ep != nil {
 
-		headerParam4, err = runtime.StyleParamWithLocation("simple", true, "X-Object-Exploded", runtime.ParamLocationHeader, *params.XObjectExploded)
-		if err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ep", runtime.ParamLocationQuery, *params.Ep); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
 		}
 
-		req.Header.Set("X-Object-Exploded", headerParam4)
 	}
 
-	if params.XObject != nil {
-		var headerParam5 string
+	if params.P != nil {
 
-		headerParam5, err = runtime.StyleParamWithLocation("simple", false, "X-Object", runtime.ParamLocationHeader, *params.XObject)
-		if err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "p", runtime.ParamLocationQuery, *params.P); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
 		}
 
-		req.Header.Set("X-Object", headerParam5)
 	}
 
-	if params.XComplexObject != nil {
-		var headerParam6 string
+	if params.Ps != nil {
 
-		var headerParamBuf6 []byte
-		headerParamBuf6, err = json.Marshal(*params.XComplexObject)
-		if err != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ps", runtime.ParamLocationQuery, *params.Ps); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
 		}
-		headerParam6 = string(headerParamBuf6)
 
-		req.Header.Set("X-Complex-Object", headerParam6)
 	}
 
-	if params.N1StartingWithNumber != nil {
-		var headerParam7 string
+	if params.Co != nil {
 
-		headerParam7, err = runtime.StyleParamWithLocation("simple", false, "1-Starting-With-Number", runtime.ParamLocationHeader, *params.N1StartingWithNumber)
-		if err != nil {
+		if queryParamBuf, err := json.Marshal(*params.Co); err != nil {
 			return nil, err
+		} else {
+			queryValues.Add("co", string(queryParamBuf))
 		}
 
-		req.Header.Set("1-Starting-With-Number", headerParam7)
 	}
 
-	return req, nil
+	if params.N1s != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "1s", runtime.ParamLocationQuery, *params.N1s); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	return queryURL, nil
 }
 
-// NewGetLabelExplodeArrayRequest generates requests for GetLabelExplodeArray
-func NewGetLabelExplodeArrayRequest(server string, param []int32) (*http.Request, error) {
+// BuildGetSimpleExplodeArrayURL builds the URL of GetSimpleExplodeArray on server, serializing its
+// path and query parameters like the client does.
+func BuildGetSimpleExplodeArrayURL(server string, param []int32) (*url.URL, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("label", true, "param", runtime.ParamLocationPath, param)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", true, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
@@ -816,7 +754,7 @@ func NewGetLabelExplodeArrayRequest(server string, param []int32) (*http.Request
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/labelExplodeArray/%s", pathParam0)
+	operationPath := fmt.Sprintf("/simpleExplodeArray/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -826,21 +764,17 @@ func NewGetLabelExplodeArrayRequest(server string, param []int32) (*http.Request
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
+	return queryURL, nil
 }
 
-// NewGetLabelExplodeObjectRequest generates requests for GetLabelExplodeObject
-func NewGetLabelExplodeObjectRequest(server string, param Object) (*http.Request, error) {
+// BuildGetSimpleExplodeObjectURL builds the URL of GetSimpleExplodeObject on server, serializing its
+// path and query parameters like the client does.
+func BuildGetSimpleExplodeObjectURL(server string, param Object) (*url.URL, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("label", true, "param", runtime.ParamLocationPath, param)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", true, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
@@ -850,7 +784,7 @@ func NewGetLabelExplodeObjectRequest(server string, param Object) (*http.Request
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/labelExplodeObject/%s", pathParam0)
+	operationPath := fmt.Sprintf("/simpleExplodeObject/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -860,21 +794,17 @@ func NewGetLabelExplodeObjectRequest(server string, param Object) (*http.Request
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
+	return queryURL, nil
 }
 
-// NewGetLabelNoExplodeArrayRequest generates requests for GetLabelNoExplodeArray
-func NewGetLabelNoExplodeArrayRequest(server string, param []int32) (*http.Request, error) {
+// BuildGetSimpleNoExplodeArrayURL builds the URL of GetSimpleNoExplodeArray on server, serializing its
+// path and query parameters like the client does.
+func BuildGetSimpleNoExplodeArrayURL(server string, param []int32) (*url.URL, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("label", false, "param", runtime.ParamLocationPath, param)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
@@ -884,7 +814,7 @@ func NewGetLabelNoExplodeArrayRequest(server string, param []int32) (*http.Reque
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/labelNoExplodeArray/%s", pathParam0)
+	operationPath := fmt.Sprintf("/simpleNoExplodeArray/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -894,21 +824,17 @@ func NewGetLabelNoExplodeArrayRequest(server string, param []int32) (*http.Reque
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
+	return queryURL, nil
 }
 
-// NewGetLabelNoExplodeObjectRequest generates requests for GetLabelNoExplodeObject
-func NewGetLabelNoExplodeObjectRequest(server string, param Object) (*http.Request, error) {
+// BuildGetSimpleNoExplodeObjectURL builds the URL of GetSimpleNoExplodeObject on server, serializing its
+// path and query parameters like the client does.
+func BuildGetSimpleNoExplodeObjectURL(server string, param Object) (*url.URL, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("label", false, "param", runtime.ParamLocationPath, param)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
@@ -918,7 +844,7 @@ func NewGetLabelNoExplodeObjectRequest(server string, param Object) (*http.Reque
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/labelNoExplodeObject/%s", pathParam0)
+	operationPath := fmt.Sprintf("/simpleNoExplodeObject/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -928,21 +854,17 @@ func NewGetLabelNoExplodeObjectRequest(server string, param Object) (*http.Reque
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
+	return queryURL, nil
 }
 
-// NewGetMatrixExplodeArrayRequest generates requests for GetMatrixExplodeArray
-func NewGetMatrixExplodeArrayRequest(server string, id []int32) (*http.Request, error) {
+// BuildGetSimplePrimitiveURL builds the URL of GetSimplePrimitive on server, serializing its
+// path and query parameters like the client does.
+func BuildGetSimplePrimitiveURL(server string, param int32) (*url.URL, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("matrix", true, "id", runtime.ParamLocationPath, id)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "param", runtime.ParamLocationPath, param)
 	if err != nil {
 		return nil, err
 	}
@@ -952,7 +874,7 @@ func NewGetMatrixExplodeArrayRequest(server string, id []int32) (*http.Request,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/matrixExplodeArray/%s", pathParam0)
+	operationPath := fmt.Sprintf("/simplePrimitive/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -962,31 +884,24 @@ func NewGetMatrixExplodeArrayRequest(server string, id []int32) (*http.Request,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
+	return queryURL, nil
 }
 
-// NewGetMatrixExplodeObjectRequest generates requests for GetMatrixExplodeObject
-func NewGetMatrixExplodeObjectRequest(server string, id Object) (*http.Request, error) {
+// BuildGetStartingWithNumberURL builds the URL of GetStartingWithNumber on server, serializing its
+// path and query parameters like the client does.
+func BuildGetStartingWithNumberURL(server string, n1param string) (*url.URL, error) {
 	var err error
 
 	var pathParam0 string
 
-	pathParam0, err = runtime.StyleParamWithLocation("matrix", true, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
+	pathParam0 = n1param
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/matrixExplodeObject/%s", pathParam0)
+	operationPath := fmt.Sprintf("/startingWithNumber/%s", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -996,70 +911,540 @@ func NewGetMatrixExplodeObjectRequest(server string, id Object) (*http.Request,
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return req, nil
+	return queryURL, nil
 }
 
-// NewGetMatrixNoExplodeArrayRequest generates requests for GetMatrixNoExplodeArray
-func NewGetMatrixNoExplodeArrayRequest(server string, id []int32) (*http.Request, error) {
-	var err error
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
-	var pathParam0 string
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
 
-	pathParam0, err = runtime.StyleParamWithLocation("matrix", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+	// Servers are the endpoints which the requests are sent to, as picked by
+	// the ServerPolicy, when there are several, which WithServers sets up.
+	// Server is the first one.
+	Servers []string
 
-	operationPath := fmt.Sprintf("/matrixNoExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// ServerPolicy is how the servers of the requests are picked.
+	ServerPolicy runtime.ServerPolicy
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// requests counts the requests, for picking their servers in turn.
+	requests uint32
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
 
-	return req, nil
-}
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
 
-// NewGetMatrixNoExplodeObjectRequest generates requests for GetMatrixNoExplodeObject
-func NewGetMatrixNoExplodeObjectRequest(server string, id Object) (*http.Request, error) {
-	var err error
+	// RateLimiter, when set, is waited on before sending each request, with
+	// the ID of its operation.
+	RateLimiter runtime.RateLimiter
 
-	var pathParam0 string
+	// MaxRetryAfter is how long, in total, a request which is rate limited,
+	// with a 429 status and a Retry-After header, is retried for, after
+	// waiting as long as the server asks, and at least
+	// runtime.MinRetryAfter. It isn't retried when it's zero.
+	MaxRetryAfter time.Duration
 
-	pathParam0, err = runtime.StyleParamWithLocation("matrix", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
+	// MaxResponseSize is the maximum size of the response bodies, in bytes,
+	// beyond which reading them fails with a *runtime.ResponseTooLargeError,
+	// unless the operation has its own, from x-max-response-size. The
+	// bodies aren't limited when it's zero.
+	MaxResponseSize int64
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+	// recorder, which WithRecorder sets, sends the requests through the
+	// Client once all the options are applied.
+	recorder *runtime.Recorder
+}
 
-	operationPath := fmt.Sprintf("/matrixNoExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	for i, server := range client.Servers {
+		if !strings.HasSuffix(server, "/") {
+			client.Servers[i] = server + "/"
+		}
 	}
+	// send the requests of the Client through the recorder, whatever the
+	// order of the options
+	if client.recorder != nil {
+		client.Client = &http.Client{Transport: client.recorder.WithDoer(client.Client)}
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithServers makes the client send its requests to several servers, such as
+// the ones declared in the spec, instead of the one given to NewClient. They
+// are picked by policy: with runtime.ServerFailover, the requests fail over
+// to the next servers when one can't be reached, or, for idempotent requests,
+// responds with a 5xx status, runtime.ServerFailoverAnyMethod fails over the
+// requests of any method on 5xx statuses too, and runtime.ServerRoundRobin
+// sends them to each server in turn.
+// The server of a request is picked before the request editors run.
+func WithServers(policy runtime.ServerPolicy, servers ...string) ClientOption {
+	return func(c *Client) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("no servers given")
+		}
+		c.Server = servers[0]
+		c.Servers = append([]string{}, servers...)
+		c.ServerPolicy = policy
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithAccept sets the Accept header of a request, eg, to ask for one of the
+// content types of the responses of its operation, rather than all of them,
+// which the request builders list.
+func WithAccept(accept string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", accept)
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, eg, for a token of the bucket of its operation.
+func WithRateLimiter(limiter runtime.RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRetryAfter makes the client retry the requests which are rate limited,
+// with a 429 status and a Retry-After header, after waiting as long as the
+// server asks, for up to maxWait in total. The response is returned when the
+// server asks to wait longer.
+func WithRetryAfter(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.MaxRetryAfter = maxWait
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies which the client
+// reads to maxSize bytes, so that a misbehaving server can't exhaust its
+// memory. Reading larger bodies, such as when parsing them, fails with a
+// *runtime.ResponseTooLargeError. The operations with x-max-response-size
+// have their own limit.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		c.MaxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithRecorder makes the client send its requests through recorder, which
+// records their responses to golden files, or replays them, in tests. The
+// requests which are recorded are sent with the Doer of WithHTTPClient, if
+// any, unless recorder has a Transport of its own.
+func WithRecorder(recorder *runtime.Recorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetContentObject request
+	GetContentObject(ctx context.Context, param ComplexObject, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCookie request
+	GetCookie(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHeader request
+	GetHeader(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLabelExplodeArray request
+	GetLabelExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLabelExplodeObject request
+	GetLabelExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLabelNoExplodeArray request
+	GetLabelNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLabelNoExplodeObject request
+	GetLabelNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMatrixExplodeArray request
+	GetMatrixExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMatrixExplodeObject request
+	GetMatrixExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMatrixNoExplodeArray request
+	GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMatrixNoExplodeObject request
+	GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPassThrough request
+	GetPassThrough(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDeepObject request
+	GetDeepObject(ctx context.Context, params *GetDeepObjectParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQueryForm request
+	GetQueryForm(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSimpleExplodeArray request
+	GetSimpleExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSimpleExplodeObject request
+	GetSimpleExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSimpleNoExplodeArray request
+	GetSimpleNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSimpleNoExplodeObject request
+	GetSimpleNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSimplePrimitive request
+	GetSimplePrimitive(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStartingWithNumber request
+	GetStartingWithNumber(ctx context.Context, n1param string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetContentObject(ctx context.Context, param ComplexObject, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetContentObject", func(server string) (*http.Request, error) {
+		return NewGetContentObjectRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetCookie(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetCookie", func(server string) (*http.Request, error) {
+		return NewGetCookieRequest(server, params)
+	}, reqEditors)
+}
+
+func (c *Client) GetHeader(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetHeader", func(server string) (*http.Request, error) {
+		return NewGetHeaderRequest(server, params)
+	}, reqEditors)
+}
+
+func (c *Client) GetLabelExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetLabelExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetLabelExplodeArrayRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetLabelExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetLabelExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetLabelExplodeObjectRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetLabelNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetLabelNoExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetLabelNoExplodeArrayRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetLabelNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetLabelNoExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetLabelNoExplodeObjectRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetMatrixExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetMatrixExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetMatrixExplodeArrayRequest(server, id)
+	}, reqEditors)
+}
+
+func (c *Client) GetMatrixExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetMatrixExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetMatrixExplodeObjectRequest(server, id)
+	}, reqEditors)
+}
+
+func (c *Client) GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetMatrixNoExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetMatrixNoExplodeArrayRequest(server, id)
+	}, reqEditors)
+}
+
+func (c *Client) GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetMatrixNoExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetMatrixNoExplodeObjectRequest(server, id)
+	}, reqEditors)
+}
+
+func (c *Client) GetPassThrough(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetPassThrough", func(server string) (*http.Request, error) {
+		return NewGetPassThroughRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetDeepObject(ctx context.Context, params *GetDeepObjectParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetDeepObject", func(server string) (*http.Request, error) {
+		return NewGetDeepObjectRequest(server, params)
+	}, reqEditors)
+}
+
+func (c *Client) GetQueryForm(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetQueryForm", func(server string) (*http.Request, error) {
+		return NewGetQueryFormRequest(server, params)
+	}, reqEditors)
+}
+
+func (c *Client) GetSimpleExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetSimpleExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetSimpleExplodeArrayRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetSimpleExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetSimpleExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetSimpleExplodeObjectRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetSimpleNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetSimpleNoExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetSimpleNoExplodeArrayRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetSimpleNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetSimpleNoExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetSimpleNoExplodeObjectRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetSimplePrimitive(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetSimplePrimitive", func(server string) (*http.Request, error) {
+		return NewGetSimplePrimitiveRequest(server, param)
+	}, reqEditors)
+}
+
+func (c *Client) GetStartingWithNumber(ctx context.Context, n1param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetStartingWithNumber", func(server string) (*http.Request, error) {
+		return NewGetStartingWithNumberRequest(server, n1param)
+	}, reqEditors)
+}
+
+// NewGetContentObjectRequest builds the request which GetContentObject sends, without
+// sending it.
+func (c *Client) NewGetContentObjectRequest(ctx context.Context, param ComplexObject, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetContentObject", func(server string) (*http.Request, error) {
+		return NewGetContentObjectRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetCookieRequest builds the request which GetCookie sends, without
+// sending it.
+func (c *Client) NewGetCookieRequest(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetCookie", func(server string) (*http.Request, error) {
+		return NewGetCookieRequest(server, params)
+	}, reqEditors)
+}
+
+// NewGetHeaderRequest builds the request which GetHeader sends, without
+// sending it.
+func (c *Client) NewGetHeaderRequest(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetHeader", func(server string) (*http.Request, error) {
+		return NewGetHeaderRequest(server, params)
+	}, reqEditors)
+}
+
+// NewGetLabelExplodeArrayRequest builds the request which GetLabelExplodeArray sends, without
+// sending it.
+func (c *Client) NewGetLabelExplodeArrayRequest(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetLabelExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetLabelExplodeArrayRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetLabelExplodeObjectRequest builds the request which GetLabelExplodeObject sends, without
+// sending it.
+func (c *Client) NewGetLabelExplodeObjectRequest(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetLabelExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetLabelExplodeObjectRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetLabelNoExplodeArrayRequest builds the request which GetLabelNoExplodeArray sends, without
+// sending it.
+func (c *Client) NewGetLabelNoExplodeArrayRequest(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetLabelNoExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetLabelNoExplodeArrayRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetLabelNoExplodeObjectRequest builds the request which GetLabelNoExplodeObject sends, without
+// sending it.
+func (c *Client) NewGetLabelNoExplodeObjectRequest(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetLabelNoExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetLabelNoExplodeObjectRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetMatrixExplodeArrayRequest builds the request which GetMatrixExplodeArray sends, without
+// sending it.
+func (c *Client) NewGetMatrixExplodeArrayRequest(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetMatrixExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetMatrixExplodeArrayRequest(server, id)
+	}, reqEditors)
+}
+
+// NewGetMatrixExplodeObjectRequest builds the request which GetMatrixExplodeObject sends, without
+// sending it.
+func (c *Client) NewGetMatrixExplodeObjectRequest(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetMatrixExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetMatrixExplodeObjectRequest(server, id)
+	}, reqEditors)
+}
+
+// NewGetMatrixNoExplodeArrayRequest builds the request which GetMatrixNoExplodeArray sends, without
+// sending it.
+func (c *Client) NewGetMatrixNoExplodeArrayRequest(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetMatrixNoExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetMatrixNoExplodeArrayRequest(server, id)
+	}, reqEditors)
+}
+
+// NewGetMatrixNoExplodeObjectRequest builds the request which GetMatrixNoExplodeObject sends, without
+// sending it.
+func (c *Client) NewGetMatrixNoExplodeObjectRequest(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetMatrixNoExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetMatrixNoExplodeObjectRequest(server, id)
+	}, reqEditors)
+}
+
+// NewGetPassThroughRequest builds the request which GetPassThrough sends, without
+// sending it.
+func (c *Client) NewGetPassThroughRequest(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetPassThrough", func(server string) (*http.Request, error) {
+		return NewGetPassThroughRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetDeepObjectRequest builds the request which GetDeepObject sends, without
+// sending it.
+func (c *Client) NewGetDeepObjectRequest(ctx context.Context, params *GetDeepObjectParams, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetDeepObject", func(server string) (*http.Request, error) {
+		return NewGetDeepObjectRequest(server, params)
+	}, reqEditors)
+}
+
+// NewGetQueryFormRequest builds the request which GetQueryForm sends, without
+// sending it.
+func (c *Client) NewGetQueryFormRequest(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetQueryForm", func(server string) (*http.Request, error) {
+		return NewGetQueryFormRequest(server, params)
+	}, reqEditors)
+}
+
+// NewGetSimpleExplodeArrayRequest builds the request which GetSimpleExplodeArray sends, without
+// sending it.
+func (c *Client) NewGetSimpleExplodeArrayRequest(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetSimpleExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetSimpleExplodeArrayRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetSimpleExplodeObjectRequest builds the request which GetSimpleExplodeObject sends, without
+// sending it.
+func (c *Client) NewGetSimpleExplodeObjectRequest(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetSimpleExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetSimpleExplodeObjectRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetSimpleNoExplodeArrayRequest builds the request which GetSimpleNoExplodeArray sends, without
+// sending it.
+func (c *Client) NewGetSimpleNoExplodeArrayRequest(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetSimpleNoExplodeArray", func(server string) (*http.Request, error) {
+		return NewGetSimpleNoExplodeArrayRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetSimpleNoExplodeObjectRequest builds the request which GetSimpleNoExplodeObject sends, without
+// sending it.
+func (c *Client) NewGetSimpleNoExplodeObjectRequest(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetSimpleNoExplodeObject", func(server string) (*http.Request, error) {
+		return NewGetSimpleNoExplodeObjectRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetSimplePrimitiveRequest builds the request which GetSimplePrimitive sends, without
+// sending it.
+func (c *Client) NewGetSimplePrimitiveRequest(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetSimplePrimitive", func(server string) (*http.Request, error) {
+		return NewGetSimplePrimitiveRequest(server, param)
+	}, reqEditors)
+}
+
+// NewGetStartingWithNumberRequest builds the request which GetStartingWithNumber sends, without
+// sending it.
+func (c *Client) NewGetStartingWithNumberRequest(ctx context.Context, n1param string, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetStartingWithNumber", func(server string) (*http.Request, error) {
+		return NewGetStartingWithNumberRequest(server, n1param)
+	}, reqEditors)
+}
+
+// NewGetContentObjectRequest generates requests for GetContentObject
+func NewGetContentObjectRequest(server string, param ComplexObject) (*http.Request, error) {
+	queryURL, err := BuildGetContentObjectURL(server, param)
 	if err != nil {
 		return nil, err
 	}
@@ -1069,274 +1454,358 @@ func NewGetMatrixNoExplodeObjectRequest(server string, id Object) (*http.Request
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "text/plain")
+
 	return req, nil
 }
 
-// NewGetPassThroughRequest generates requests for GetPassThrough
-func NewGetPassThroughRequest(server string, param string) (*http.Request, error) {
-	var err error
+// NewGetCookieRequest generates requests for GetCookie
+func NewGetCookieRequest(server string, params *GetCookieParams) (*http.Request, error) {
+	queryURL, err := BuildGetCookieURL(server, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.P != nil {
+		var cookieParam0 string
+
+		cookieParam0, err = runtime.StyleParamWithLocation("simple", false, "p", runtime.ParamLocationCookie, *params.P)
+		if err != nil {
+			return nil, err
+		}
+
+		cookie0 := &http.Cookie{
+			Name:  "p",
+			Value: cookieParam0,
+		}
+		req.AddCookie(cookie0)
+	}
+
+	if params.Ep != nil {
+		var cookieParam1 string
+
+		cookieParam1, err = runtime.StyleParamWithLocation("simple", true, "ep", runtime.ParamLocationCookie, *params.Ep)
+		if err != nil {
+			return nil, err
+		}
+
+		cookie1 := &http.Cookie{
+			Name:  "ep",
+			Value: cookieParam1,
+		}
+		req.AddCookie(cookie1)
+	}
+
+	if params.Ea != nil {
+		var cookieParam2 string
+
+		cookieParam2, err = runtime.StyleParamWithLocation("simple", true, "ea", runtime.ParamLocationCookie, *params.Ea)
+		if err != nil {
+			return nil, err
+		}
+
+		cookie2 := &http.Cookie{
+			Name:  "ea",
+			Value: cookieParam2,
+		}
+		req.AddCookie(cookie2)
+	}
+
+	if params.A != nil {
+		var cookieParam3 string
+
+		cookieParam3, err = runtime.StyleParamWithLocation("simple", false, "a", runtime.ParamLocationCookie, *params.A)
+		if err != nil {
+			return nil, err
+		}
+
+		cookie3 := &http.Cookie{
+			Name:  "a",
+			Value: cookieParam3,
+		}
+		req.AddCookie(cookie3)
+	}
+
+	if params.Eo != nil {
+		var cookieParam4 string
+
+		cookieParam4, err = runtime.StyleParamWithLocation("simple", true, "eo", runtime.ParamLocationCookie, *params.Eo)
+		if err != nil {
+			return nil, err
+		}
+
+		cookie4 := &http.Cookie{
+			Name:  "eo",
+			Value: cookieParam4,
+		}
+		req.AddCookie(cookie4)
+	}
 
-	var pathParam0 string
+	if params.O != nil {
+		var cookieParam5 string
 
-	pathParam0 = param
+		cookieParam5, err = runtime.StyleParamWithLocation("simple", false, "o", runtime.ParamLocationCookie, *params.O)
+		if err != nil {
+			return nil, err
+		}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
+		cookie5 := &http.Cookie{
+			Name:  "o",
+			Value: cookieParam5,
+		}
+		req.AddCookie(cookie5)
 	}
 
-	operationPath := fmt.Sprintf("/passThrough/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	if params.Co != nil {
+		var cookieParam6 string
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+		var cookieParamBuf6 []byte
+		cookieParamBuf6, err = json.Marshal(*params.Co)
+		if err != nil {
+			return nil, err
+		}
+		cookieParam6 = url.QueryEscape(string(cookieParamBuf6))
+
+		cookie6 := &http.Cookie{
+			Name:  "co",
+			Value: cookieParam6,
+		}
+		req.AddCookie(cookie6)
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
+	if params.N1s != nil {
+		var cookieParam7 string
+
+		cookieParam7, err = runtime.StyleParamWithLocation("simple", true, "1s", runtime.ParamLocationCookie, *params.N1s)
+		if err != nil {
+			return nil, err
+		}
+
+		cookie7 := &http.Cookie{
+			Name:  "1s",
+			Value: cookieParam7,
+		}
+		req.AddCookie(cookie7)
 	}
 
 	return req, nil
 }
 
-// NewGetDeepObjectRequest generates requests for GetDeepObject
-func NewGetDeepObjectRequest(server string, params *GetDeepObjectParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+// NewGetHeaderRequest generates requests for GetHeader
+func NewGetHeaderRequest(server string, params *GetHeaderParams) (*http.Request, error) {
+	queryURL, err := BuildGetHeaderURL(server, params)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/queryDeepObject")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	queryValues := queryURL.Query()
+	if params.XPrimitive != nil {
+		var headerParam0 string
 
-	if queryFrag, err := runtime.StyleParamWithLocation("deepObject", true, "deepObj", runtime.ParamLocationQuery, params.DeepObj); err != nil {
-		return nil, err
-	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-		return nil, err
-	} else {
-		for k, v := range parsed {
-			for _, v2 := range v {
-				queryValues.Add(k, v2)
-			}
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Primitive", runtime.ParamLocationHeader, *params.XPrimitive)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	queryURL.RawQuery = queryValues.Encode()
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
+		req.Header.Set("X-Primitive", headerParam0)
 	}
 
-	return req, nil
-}
+	if params.XPrimitiveExploded != nil {
+		var headerParam1 string
 
-// NewGetQueryFormRequest generates requests for GetQueryForm
-func NewGetQueryFormRequest(server string, params *GetQueryFormParams) (*http.Request, error) {
-	var err error
+		headerParam1, err = runtime.StyleParamWithLocation("simple", true, "X-Primitive-Exploded", runtime.ParamLocationHeader, *params.XPrimitiveExploded)
+		if err != nil {
+			return nil, err
+		}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
+		req.Header.Set("X-Primitive-Exploded", headerParam1)
 	}
 
-	operationPath := fmt.Sprintf("/queryForm")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	if params.XArrayExploded != nil {
+		var headerParam2 string
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+		headerParam2, err = runtime.StyleParamWithLocation("simple", true, "X-Array-Exploded", runtime.ParamLocationHeader, *params.XArrayExploded)
+		if err != nil {
+			return nil, err
+		}
 
-	queryValues := queryURL.Query()
+		req.Header.Set("X-Array-Exploded", headerParam2)
+	}
 
-	if params.Ea != nil {
+	if params.XArray != nil {
+		var headerParam3 string
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ea", runtime.ParamLocationQuery, *params.Ea); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		headerParam3, err = runtime.StyleParamWithLocation("simple", false, "X-Array", runtime.ParamLocationHeader, *params.XArray)
+		if err != nil {
 			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
 		}
 
+		req.Header.Set("X-Array", headerParam3)
 	}
 
-	if params.A != nil {
+	if params.XObjectExploded != nil {
+		var headerParam4 string
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "a", runtime.ParamLocationQuery, *params.A); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		headerParam4, err = runtime.StyleParamWithLocation("simple", true, "X-Object-Exploded", runtime.ParamLocationHeader, *params.XObjectExploded)
+		if err != nil {
 			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
 		}
 
+		req.Header.Set("X-Object-Exploded", headerParam4)
 	}
 
-	if params.Eo != nil {
+	if params.XObject != nil {
+		var headerParam5 string
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "eo", runtime.ParamLocationQuery, *params.Eo); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		headerParam5, err = runtime.StyleParamWithLocation("simple", false, "X-Object", runtime.ParamLocationHeader, *params.XObject)
+		if err != nil {
 			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
 		}
 
+		req.Header.Set("X-Object", headerParam5)
 	}
 
-	if params.O != nil {
+	if params.XComplexObject != nil {
+		var headerParam6 string
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "o", runtime.ParamLocationQuery, *params.O); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		var headerParamBuf6 []byte
+		headerParamBuf6, err = json.Marshal(*params.XComplexObject)
+		if err != nil {
 			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
 		}
+		headerParam6 = string(headerParamBuf6)
 
+		req.Header.Set("X-Complex-Object", headerParam6)
 	}
 
-	if params.Ep != nil {
+	if params.N1StartingWithNumber != nil {
+		var headerParam7 string
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ep", runtime.ParamLocationQuery, *params.Ep); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		headerParam7, err = runtime.StyleParamWithLocation("simple", false, "1-Starting-With-Number", runtime.ParamLocationHeader, *params.N1StartingWithNumber)
+		if err != nil {
 			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
 		}
 
+		req.Header.Set("1-Starting-With-Number", headerParam7)
 	}
 
-	if params.P != nil {
+	return req, nil
+}
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "p", runtime.ParamLocationQuery, *params.P); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
+// NewGetLabelExplodeArrayRequest generates requests for GetLabelExplodeArray
+func NewGetLabelExplodeArrayRequest(server string, param []int32) (*http.Request, error) {
+	queryURL, err := BuildGetLabelExplodeArrayURL(server, param)
+	if err != nil {
+		return nil, err
+	}
 
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	if params.Ps != nil {
+	req.Header.Set("Accept", "text/plain")
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ps", runtime.ParamLocationQuery, *params.Ps); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
+	return req, nil
+}
+
+// NewGetLabelExplodeObjectRequest generates requests for GetLabelExplodeObject
+func NewGetLabelExplodeObjectRequest(server string, param Object) (*http.Request, error) {
+	queryURL, err := BuildGetLabelExplodeObjectURL(server, param)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/plain")
+
+	return req, nil
+}
+
+// NewGetLabelNoExplodeArrayRequest generates requests for GetLabelNoExplodeArray
+func NewGetLabelNoExplodeArrayRequest(server string, param []int32) (*http.Request, error) {
+	queryURL, err := BuildGetLabelNoExplodeArrayURL(server, param)
+	if err != nil {
+		return nil, err
+	}
 
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	if params.Co != nil {
+	req.Header.Set("Accept", "text/plain")
 
-		if queryParamBuf, err := json.Marshal(*params.Co); err != nil {
-			return nil, err
-		} else {
-			queryValues.Add("co", string(queryParamBuf))
-		}
+	return req, nil
+}
 
+// NewGetLabelNoExplodeObjectRequest generates requests for GetLabelNoExplodeObject
+func NewGetLabelNoExplodeObjectRequest(server string, param Object) (*http.Request, error) {
+	queryURL, err := BuildGetLabelNoExplodeObjectURL(server, param)
+	if err != nil {
+		return nil, err
 	}
 
-	if params.N1s != nil {
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "1s", runtime.ParamLocationQuery, *params.N1s); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
+	req.Header.Set("Accept", "text/plain")
 
-	}
+	return req, nil
+}
 
-	queryURL.RawQuery = queryValues.Encode()
+// NewGetMatrixExplodeArrayRequest generates requests for GetMatrixExplodeArray
+func NewGetMatrixExplodeArrayRequest(server string, id []int32) (*http.Request, error) {
+	queryURL, err := BuildGetMatrixExplodeArrayURL(server, id)
+	if err != nil {
+		return nil, err
+	}
 
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "text/plain")
+
 	return req, nil
 }
 
-// NewGetSimpleExplodeArrayRequest generates requests for GetSimpleExplodeArray
-func NewGetSimpleExplodeArrayRequest(server string, param []int32) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", true, "param", runtime.ParamLocationPath, param)
+// NewGetMatrixExplodeObjectRequest generates requests for GetMatrixExplodeObject
+func NewGetMatrixExplodeObjectRequest(server string, id Object) (*http.Request, error) {
+	queryURL, err := BuildGetMatrixExplodeObjectURL(server, id)
 	if err != nil {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/simpleExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	req.Header.Set("Accept", "text/plain")
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// NewGetMatrixNoExplodeArrayRequest generates requests for GetMatrixNoExplodeArray
+func NewGetMatrixNoExplodeArrayRequest(server string, id []int32) (*http.Request, error) {
+	queryURL, err := BuildGetMatrixNoExplodeArrayURL(server, id)
 	if err != nil {
 		return nil, err
 	}
@@ -1346,31 +1815,31 @@ func NewGetSimpleExplodeArrayRequest(server string, param []int32) (*http.Reques
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "text/plain")
+
 	return req, nil
 }
 
-// NewGetSimpleExplodeObjectRequest generates requests for GetSimpleExplodeObject
-func NewGetSimpleExplodeObjectRequest(server string, param Object) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", true, "param", runtime.ParamLocationPath, param)
+// NewGetMatrixNoExplodeObjectRequest generates requests for GetMatrixNoExplodeObject
+func NewGetMatrixNoExplodeObjectRequest(server string, id Object) (*http.Request, error) {
+	queryURL, err := BuildGetMatrixNoExplodeObjectURL(server, id)
 	if err != nil {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/simpleExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	req.Header.Set("Accept", "text/plain")
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// NewGetPassThroughRequest generates requests for GetPassThrough
+func NewGetPassThroughRequest(server string, param string) (*http.Request, error) {
+	queryURL, err := BuildGetPassThroughURL(server, param)
 	if err != nil {
 		return nil, err
 	}
@@ -1380,31 +1849,29 @@ func NewGetSimpleExplodeObjectRequest(server string, param Object) (*http.Reques
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "text/plain")
+
 	return req, nil
 }
 
-// NewGetSimpleNoExplodeArrayRequest generates requests for GetSimpleNoExplodeArray
-func NewGetSimpleNoExplodeArrayRequest(server string, param []int32) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "param", runtime.ParamLocationPath, param)
+// NewGetDeepObjectRequest generates requests for GetDeepObject
+func NewGetDeepObjectRequest(server string, params *GetDeepObjectParams) (*http.Request, error) {
+	queryURL, err := BuildGetDeepObjectURL(server, params)
 	if err != nil {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/simpleNoExplodeArray/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	return req, nil
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+// NewGetQueryFormRequest generates requests for GetQueryForm
+func NewGetQueryFormRequest(server string, params *GetQueryFormParams) (*http.Request, error) {
+	queryURL, err := BuildGetQueryFormURL(server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -1414,31 +1881,31 @@ func NewGetSimpleNoExplodeArrayRequest(server string, param []int32) (*http.Requ
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "text/plain")
+
 	return req, nil
 }
 
-// NewGetSimpleNoExplodeObjectRequest generates requests for GetSimpleNoExplodeObject
-func NewGetSimpleNoExplodeObjectRequest(server string, param Object) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "param", runtime.ParamLocationPath, param)
+// NewGetSimpleExplodeArrayRequest generates requests for GetSimpleExplodeArray
+func NewGetSimpleExplodeArrayRequest(server string, param []int32) (*http.Request, error) {
+	queryURL, err := BuildGetSimpleExplodeArrayURL(server, param)
 	if err != nil {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/simpleNoExplodeObject/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	req.Header.Set("Accept", "text/plain")
 
-	queryURL, err := serverURL.Parse(operationPath)
+	return req, nil
+}
+
+// NewGetSimpleExplodeObjectRequest generates requests for GetSimpleExplodeObject
+func NewGetSimpleExplodeObjectRequest(server string, param Object) (*http.Request, error) {
+	queryURL, err := BuildGetSimpleExplodeObjectURL(server, param)
 	if err != nil {
 		return nil, err
 	}
@@ -1448,31 +1915,48 @@ func NewGetSimpleNoExplodeObjectRequest(server string, param Object) (*http.Requ
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "text/plain")
+
 	return req, nil
 }
 
-// NewGetSimplePrimitiveRequest generates requests for GetSimplePrimitive
-func NewGetSimplePrimitiveRequest(server string, param int32) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
+// NewGetSimpleNoExplodeArrayRequest generates requests for GetSimpleNoExplodeArray
+func NewGetSimpleNoExplodeArrayRequest(server string, param []int32) (*http.Request, error) {
+	queryURL, err := BuildGetSimpleNoExplodeArrayURL(server, param)
+	if err != nil {
+		return nil, err
+	}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "param", runtime.ParamLocationPath, param)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	serverURL, err := url.Parse(server)
+	req.Header.Set("Accept", "text/plain")
+
+	return req, nil
+}
+
+// NewGetSimpleNoExplodeObjectRequest generates requests for GetSimpleNoExplodeObject
+func NewGetSimpleNoExplodeObjectRequest(server string, param Object) (*http.Request, error) {
+	queryURL, err := BuildGetSimpleNoExplodeObjectURL(server, param)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/simplePrimitive/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	req.Header.Set("Accept", "text/plain")
+
+	return req, nil
+}
+
+// NewGetSimplePrimitiveRequest generates requests for GetSimplePrimitive
+func NewGetSimplePrimitiveRequest(server string, param int32) (*http.Request, error) {
+	queryURL, err := BuildGetSimplePrimitiveURL(server, param)
 	if err != nil {
 		return nil, err
 	}
@@ -1482,37 +1966,126 @@ func NewGetSimplePrimitiveRequest(server string, param int32) (*http.Request, er
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "text/plain")
+
 	return req, nil
 }
 
 // NewGetStartingWithNumberRequest generates requests for GetStartingWithNumber
 func NewGetStartingWithNumberRequest(server string, n1param string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0 = n1param
+	queryURL, err := BuildGetStartingWithNumberURL(server, n1param)
+	if err != nil {
+		return nil, err
+	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/startingWithNumber/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req.Header.Set("Accept", "text/plain")
+
+	return req, nil
+}
+
+// do sends the request built by newRequest for a server, which is picked by
+// the ServerPolicy, after editing it, once the RateLimiter lets the operation
+// through. The context of the request carries the operation ID, for the
+// editors and the Doer. When it fails over, or is retried after being rate limited, the
+// request is built again, with the body of the first one, unless the body
+// can't be read again.
+func (c *Client) do(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Response, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	var first *http.Request
+	build := func(server string) (*http.Request, error) {
+		req, err := newRequest(server)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = req
+		} else if first.GetBody != nil {
+			if req.Body, err = first.GetBody(); err != nil {
+				return nil, err
+			}
+			req.GetBody = first.GetBody
+			req.ContentLength = first.ContentLength
+		}
+		return req.WithContext(ctx), nil
+	}
+	replayable := func() bool {
+		return first.Body == nil || first.Body == http.NoBody || first.GetBody != nil
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
+	var waited time.Duration
+	for {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, operationID); err != nil {
+				return nil, err
+			}
+		}
+		rsp, err := c.send(ctx, build, replayable, reqEditors)
+		if err != nil || c.MaxRetryAfter <= 0 || !replayable() {
+			return rsp, err
+		}
+		wait, retry := runtime.RetryAfter(rsp, time.Now())
+		if !retry || waited+wait > c.MaxRetryAfter {
+			return rsp, nil
+		}
+		_, _ = io.Copy(ioutil.Discard, rsp.Body)
+		rsp.Body.Close()
+		if err := runtime.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		waited += wait
 	}
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+// send sends the request built by build to the servers, in the order of the
+// ServerPolicy, until one of them doesn't fail over.
+func (c *Client) send(ctx context.Context, build func(server string) (*http.Request, error), replayable func() bool, reqEditors []RequestEditorFn) (*http.Response, error) {
+	servers := []string{c.Server}
+	if len(c.Servers) > 0 {
+		servers = runtime.ServerOrder(c.ServerPolicy, c.Servers, atomic.AddUint32(&c.requests, 1)-1)
+	}
+	for i, server := range servers {
+		req, err := build(server)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+			return nil, err
+		}
+		rsp, err := c.Client.Do(req)
+		if i == len(servers)-1 || !replayable() || !runtime.ShouldFailOver(ctx, c.ServerPolicy, req, rsp, err) {
+			return runtime.LimitResponseBody(rsp, c.maxResponseSize(runtime.OperationIDFromContext(ctx))), err
+		}
+		if rsp != nil {
+			_, _ = io.Copy(ioutil.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
+	}
+	return nil, fmt.Errorf("no servers")
+}
+
+// maxResponseSize returns the maximum size of the response bodies of the
+// operation with the given ID, or zero when they aren't limited.
+func (c *Client) maxResponseSize(operationID string) int64 {
+	return c.MaxResponseSize
+}
+
+// newRequest builds the request of the operation, like do does, for the
+// first server, with ctx, after editing it, without sending it.
+func (c *Client) newRequest(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Request, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	req, err := newRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -1545,7 +2118,7 @@ func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithRes
 	return &ClientWithResponses{client}, nil
 }
 
-// WithBaseURL overrides the baseURL.
+// WithBaseURL overrides the baseURL, and the servers set by WithServers.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
@@ -1553,6 +2126,7 @@ func WithBaseURL(baseURL string) ClientOption {
 			return err
 		}
 		c.Server = newBaseURL.String()
+		c.Servers = nil
 		return nil
 	}
 }
@@ -2220,6 +2794,88 @@ func (c *ClientWithResponses) GetStartingWithNumberWithResponse(ctx context.Cont
 	return ParseGetStartingWithNumberResponse(rsp)
 }
 
+// BatchCall is a call of an operation in a batch, which returns its
+// *...Response, eg,
+//
+//	func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+//		return c.FindPetByIdWithResponse(ctx, id)
+//	}
+type BatchCall func(ctx context.Context, client ClientWithResponsesInterface) (interface{}, error)
+
+// BatchResult is the result of a call in a batch.
+type BatchResult struct {
+	Response interface{} // The *...Response of the operation, if it was called
+	Err      error
+}
+
+// Batch makes the calls with the client, with at most concurrency of them at
+// a time, or all at once when concurrency isn't positive, and returns their
+// results in the order of the calls. A failed call doesn't stop the others,
+// but those which haven't started once ctx is done fail with its error.
+func (c *ClientWithResponses) Batch(ctx context.Context, concurrency int, calls ...BatchCall) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	errs := runtime.RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+		var err error
+		results[i].Response, err = calls[i](ctx, c)
+		return err
+	})
+	for i, err := range errs {
+		results[i].Err = err
+	}
+	return results
+}
+
+// ParseOperationResponse parses an HTTP response to a request of the
+// operation with the given ID, such as one built by a New...Request method of
+// the Client, which runtime.OperationIDFromContext tells from the context of
+// the request, into the *...Response of the operation.
+func ParseOperationResponse(operationID string, rsp *http.Response) (interface{}, error) {
+	switch operationID {
+	case "GetContentObject":
+		return ParseGetContentObjectResponse(rsp)
+	case "GetCookie":
+		return ParseGetCookieResponse(rsp)
+	case "GetHeader":
+		return ParseGetHeaderResponse(rsp)
+	case "GetLabelExplodeArray":
+		return ParseGetLabelExplodeArrayResponse(rsp)
+	case "GetLabelExplodeObject":
+		return ParseGetLabelExplodeObjectResponse(rsp)
+	case "GetLabelNoExplodeArray":
+		return ParseGetLabelNoExplodeArrayResponse(rsp)
+	case "GetLabelNoExplodeObject":
+		return ParseGetLabelNoExplodeObjectResponse(rsp)
+	case "GetMatrixExplodeArray":
+		return ParseGetMatrixExplodeArrayResponse(rsp)
+	case "GetMatrixExplodeObject":
+		return ParseGetMatrixExplodeObjectResponse(rsp)
+	case "GetMatrixNoExplodeArray":
+		return ParseGetMatrixNoExplodeArrayResponse(rsp)
+	case "GetMatrixNoExplodeObject":
+		return ParseGetMatrixNoExplodeObjectResponse(rsp)
+	case "GetPassThrough":
+		return ParseGetPassThroughResponse(rsp)
+	case "GetDeepObject":
+		return ParseGetDeepObjectResponse(rsp)
+	case "GetQueryForm":
+		return ParseGetQueryFormResponse(rsp)
+	case "GetSimpleExplodeArray":
+		return ParseGetSimpleExplodeArrayResponse(rsp)
+	case "GetSimpleExplodeObject":
+		return ParseGetSimpleExplodeObjectResponse(rsp)
+	case "GetSimpleNoExplodeArray":
+		return ParseGetSimpleNoExplodeArrayResponse(rsp)
+	case "GetSimpleNoExplodeObject":
+		return ParseGetSimpleNoExplodeObjectResponse(rsp)
+	case "GetSimplePrimitive":
+		return ParseGetSimplePrimitiveResponse(rsp)
+	case "GetStartingWithNumber":
+		return ParseGetStartingWithNumberResponse(rsp)
+	}
+	_ = rsp.Body.Close()
+	return nil, fmt.Errorf("unknown operation %s", operationID)
+}
+
 // ParseGetContentObjectResponse parses an HTTP response from a GetContentObjectWithResponse call
 func ParseGetContentObjectResponse(rsp *http.Response) (*GetContentObjectResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -2540,6 +3196,36 @@ func ParseGetStartingWithNumberResponse(rsp *http.Response) (*GetStartingWithNum
 	return response, nil
 }
 
+// Server URLs declared in the OpenAPI specification. They may contain
+// {variable} placeholders, which are filled in by WithServerVariables.
+const (
+	ServerURLOpenapitestDeepmapAi = "http://openapitest.deepmap.ai"
+)
+
+// serverVariables holds the variables declared for each server URL.
+var serverVariables = map[string]map[string]runtime.ServerVariable{
+	ServerURLOpenapitestDeepmapAi: {},
+}
+
+// WithServerVariables substitutes the {variable} placeholders in the server
+// URLs. Variables which aren't given take the default value from the spec, and
+// values are validated against the enums declared in the spec.
+func WithServerVariables(variables map[string]string) ClientOption {
+	return func(c *Client) error {
+		server, err := runtime.SubstituteServerVariables(c.Server, runtime.LookupServerVariables(serverVariables, c.Server), variables)
+		if err != nil {
+			return err
+		}
+		c.Server = server
+		for i, server := range c.Servers {
+			if c.Servers[i], err = runtime.SubstituteServerVariables(server, runtime.LookupServerVariables(serverVariables, server), variables); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
@@ -2606,7 +3292,8 @@ type ServerInterface interface {
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	Handler             ServerInterface
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // GetContentObject converts echo context to params.
@@ -2615,9 +3302,10 @@ func (w *ServerInterfaceWrapper) GetContentObject(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param ComplexObject
 
-	err = json.Unmarshal([]byte(ctx.Param("param")), &param)
+	err = runtime.BindJSONParameter("param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'param' as JSON")
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'param' as JSON"))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2637,7 +3325,8 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value int32
 		err = runtime.BindStyledParameterWithLocation("simple", false, "p", runtime.ParamLocationCookie, cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "p", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p: %s", err)))
 		}
 		params.P = &value
 
@@ -2648,7 +3337,8 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value int32
 		err = runtime.BindStyledParameterWithLocation("simple", true, "ep", runtime.ParamLocationCookie, cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ep: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "ep", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ep: %s", err)))
 		}
 		params.Ep = &value
 
@@ -2659,7 +3349,8 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value []int32
 		err = runtime.BindStyledParameterWithLocation("simple", true, "ea", runtime.ParamLocationCookie, cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ea: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "ea", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ea: %s", err)))
 		}
 		params.Ea = &value
 
@@ -2670,7 +3361,8 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value []int32
 		err = runtime.BindStyledParameterWithLocation("simple", false, "a", runtime.ParamLocationCookie, cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter a: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "a", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter a: %s", err)))
 		}
 		params.A = &value
 
@@ -2681,7 +3373,8 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value Object
 		err = runtime.BindStyledParameterWithLocation("simple", true, "eo", runtime.ParamLocationCookie, cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter eo: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "eo", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter eo: %s", err)))
 		}
 		params.Eo = &value
 
@@ -2692,7 +3385,8 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value Object
 		err = runtime.BindStyledParameterWithLocation("simple", false, "o", runtime.ParamLocationCookie, cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter o: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "o", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter o: %s", err)))
 		}
 		params.O = &value
 
@@ -2704,11 +3398,13 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var decoded string
 		decoded, err := url.QueryUnescape(cookie.Value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Error unescaping cookie parameter 'co'")
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "co", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, "Error unescaping cookie parameter 'co'"))
 		}
 		err = json.Unmarshal([]byte(decoded), &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'co' as JSON")
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "co", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'co' as JSON"))
 		}
 		params.Co = &value
 
@@ -2719,7 +3415,8 @@ func (w *ServerInterfaceWrapper) GetCookie(ctx echo.Context) error {
 		var value string
 		err = runtime.BindStyledParameterWithLocation("simple", true, "1s", runtime.ParamLocationCookie, cookie.Value, &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1s: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "1s", runtime.ParamLocationCookie, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1s: %s", err)))
 		}
 		params.N1s = &value
 
@@ -2743,12 +3440,14 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XPrimitive int32
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Primitive, got %d", n))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "X-Primitive", runtime.ParamLocationHeader, nil),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Primitive, got %d", n)))
 		}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Primitive", runtime.ParamLocationHeader, valueList[0], &XPrimitive)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Primitive: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "X-Primitive", runtime.ParamLocationHeader, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Primitive: %s", err)))
 		}
 
 		params.XPrimitive = &XPrimitive
@@ -2758,12 +3457,14 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XPrimitiveExploded int32
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Primitive-Exploded, got %d", n))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "X-Primitive-Exploded", runtime.ParamLocationHeader, nil),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Primitive-Exploded, got %d", n)))
 		}
 
 		err = runtime.BindStyledParameterWithLocation("simple", true, "X-Primitive-Exploded", runtime.ParamLocationHeader, valueList[0], &XPrimitiveExploded)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Primitive-Exploded: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "X-Primitive-Exploded", runtime.ParamLocationHeader, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Primitive-Exploded: %s", err)))
 		}
 
 		params.XPrimitiveExploded = &XPrimitiveExploded
@@ -2771,14 +3472,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 	// ------------- Optional header parameter "X-Array-Exploded" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Array-Exploded")]; found {
 		var XArrayExploded []int32
-		n := len(valueList)
-		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Array-Exploded, got %d", n))
-		}
+		valueList = []string{strings.Join(valueList, ",")}
 
 		err = runtime.BindStyledParameterWithLocation("simple", true, "X-Array-Exploded", runtime.ParamLocationHeader, valueList[0], &XArrayExploded)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Array-Exploded: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "X-Array-Exploded", runtime.ParamLocationHeader, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Array-Exploded: %s", err)))
 		}
 
 		params.XArrayExploded = &XArrayExploded
@@ -2786,14 +3485,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 	// ------------- Optional header parameter "X-Array" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Array")]; found {
 		var XArray []int32
-		n := len(valueList)
-		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Array, got %d", n))
-		}
+		valueList = []string{strings.Join(valueList, ",")}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Array", runtime.ParamLocationHeader, valueList[0], &XArray)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Array: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "X-Array", runtime.ParamLocationHeader, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Array: %s", err)))
 		}
 
 		params.XArray = &XArray
@@ -2801,14 +3498,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 	// ------------- Optional header parameter "X-Object-Exploded" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Object-Exploded")]; found {
 		var XObjectExploded Object
-		n := len(valueList)
-		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Object-Exploded, got %d", n))
-		}
+		valueList = []string{strings.Join(valueList, ",")}
 
 		err = runtime.BindStyledParameterWithLocation("simple", true, "X-Object-Exploded", runtime.ParamLocationHeader, valueList[0], &XObjectExploded)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Object-Exploded: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "X-Object-Exploded", runtime.ParamLocationHeader, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Object-Exploded: %s", err)))
 		}
 
 		params.XObjectExploded = &XObjectExploded
@@ -2816,14 +3511,12 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 	// ------------- Optional header parameter "X-Object" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Object")]; found {
 		var XObject Object
-		n := len(valueList)
-		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Object, got %d", n))
-		}
+		valueList = []string{strings.Join(valueList, ",")}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "X-Object", runtime.ParamLocationHeader, valueList[0], &XObject)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Object: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "X-Object", runtime.ParamLocationHeader, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Object: %s", err)))
 		}
 
 		params.XObject = &XObject
@@ -2833,12 +3526,14 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var XComplexObject ComplexObject
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Complex-Object, got %d", n))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "X-Complex-Object", runtime.ParamLocationHeader, nil),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Complex-Object, got %d", n)))
 		}
 
 		err = json.Unmarshal([]byte(valueList[0]), &XComplexObject)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'X-Complex-Object' as JSON")
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "X-Complex-Object", runtime.ParamLocationHeader, err),
+				echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'X-Complex-Object' as JSON"))
 		}
 
 		params.XComplexObject = &XComplexObject
@@ -2848,12 +3543,14 @@ func (w *ServerInterfaceWrapper) GetHeader(ctx echo.Context) error {
 		var N1StartingWithNumber string
 		n := len(valueList)
 		if n != 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for 1-Starting-With-Number, got %d", n))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "1-Starting-With-Number", runtime.ParamLocationHeader, nil),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for 1-Starting-With-Number, got %d", n)))
 		}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "1-Starting-With-Number", runtime.ParamLocationHeader, valueList[0], &N1StartingWithNumber)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1-Starting-With-Number: %s", err))
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "1-Starting-With-Number", runtime.ParamLocationHeader, err),
+				echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1-Starting-With-Number: %s", err)))
 		}
 
 		params.N1StartingWithNumber = &N1StartingWithNumber
@@ -2872,7 +3569,8 @@ func (w *ServerInterfaceWrapper) GetLabelExplodeArray(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("label", true, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2888,7 +3586,8 @@ func (w *ServerInterfaceWrapper) GetLabelExplodeObject(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("label", true, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2904,7 +3603,8 @@ func (w *ServerInterfaceWrapper) GetLabelNoExplodeArray(ctx echo.Context) error
 
 	err = runtime.BindStyledParameterWithLocation("label", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2920,7 +3620,8 @@ func (w *ServerInterfaceWrapper) GetLabelNoExplodeObject(ctx echo.Context) error
 
 	err = runtime.BindStyledParameterWithLocation("label", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2936,7 +3637,8 @@ func (w *ServerInterfaceWrapper) GetMatrixExplodeArray(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("matrix", true, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2952,7 +3654,8 @@ func (w *ServerInterfaceWrapper) GetMatrixExplodeObject(ctx echo.Context) error
 
 	err = runtime.BindStyledParameterWithLocation("matrix", true, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2968,7 +3671,8 @@ func (w *ServerInterfaceWrapper) GetMatrixNoExplodeArray(ctx echo.Context) error
 
 	err = runtime.BindStyledParameterWithLocation("matrix", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -2984,7 +3688,8 @@ func (w *ServerInterfaceWrapper) GetMatrixNoExplodeObject(ctx echo.Context) erro
 
 	err = runtime.BindStyledParameterWithLocation("matrix", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -3015,7 +3720,8 @@ func (w *ServerInterfaceWrapper) GetDeepObject(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("deepObject", true, true, "deepObj", ctx.QueryParams(), &params.DeepObj)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter deepObj: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "deepObj", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter deepObj: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -3033,49 +3739,56 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, false, "ea", ctx.QueryParams(), &params.Ea)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ea: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "ea", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ea: %s", err)))
 	}
 
 	// ------------- Optional query parameter "a" -------------
 
 	err = runtime.BindQueryParameter("form", false, false, "a", ctx.QueryParams(), &params.A)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter a: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "a", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter a: %s", err)))
 	}
 
 	// ------------- Optional query parameter "eo" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "eo", ctx.QueryParams(), &params.Eo)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter eo: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "eo", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter eo: %s", err)))
 	}
 
 	// ------------- Optional query parameter "o" -------------
 
 	err = runtime.BindQueryParameter("form", false, false, "o", ctx.QueryParams(), &params.O)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter o: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "o", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter o: %s", err)))
 	}
 
 	// ------------- Optional query parameter "ep" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "ep", ctx.QueryParams(), &params.Ep)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ep: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "ep", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ep: %s", err)))
 	}
 
 	// ------------- Optional query parameter "p" -------------
 
 	err = runtime.BindQueryParameter("form", false, false, "p", ctx.QueryParams(), &params.P)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "p", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter p: %s", err)))
 	}
 
 	// ------------- Optional query parameter "ps" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "ps", ctx.QueryParams(), &params.Ps)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ps: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "ps", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ps: %s", err)))
 	}
 
 	// ------------- Optional query parameter "co" -------------
@@ -3085,7 +3798,8 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 		var value ComplexObject
 		err = json.Unmarshal([]byte(paramValue), &value)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'co' as JSON")
+			return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "co", runtime.ParamLocationQuery, err),
+				echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'co' as JSON"))
 		}
 		params.Co = &value
 
@@ -3095,7 +3809,8 @@ func (w *ServerInterfaceWrapper) GetQueryForm(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, false, "1s", ctx.QueryParams(), &params.N1s)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1s: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "1s", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1s: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -3111,7 +3826,8 @@ func (w *ServerInterfaceWrapper) GetSimpleExplodeArray(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("simple", true, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -3127,7 +3843,8 @@ func (w *ServerInterfaceWrapper) GetSimpleExplodeObject(ctx echo.Context) error
 
 	err = runtime.BindStyledParameterWithLocation("simple", true, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -3143,7 +3860,8 @@ func (w *ServerInterfaceWrapper) GetSimpleNoExplodeArray(ctx echo.Context) error
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -3159,7 +3877,8 @@ func (w *ServerInterfaceWrapper) GetSimpleNoExplodeObject(ctx echo.Context) erro
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -3175,7 +3894,8 @@ func (w *ServerInterfaceWrapper) GetSimplePrimitive(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "param", runtime.ParamLocationPath, ctx.Param("param"), &param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -3211,39 +3931,80 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+	BaseURL string
+	// RouteMiddlewares returns the middlewares of the route of an operation.
+	RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+	// RouteName names the route of an operation, which it's registered
+	// under, for echo.Echo.Reverse.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute func(route runtime.Route)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{})
 }
 
 // Registers handlers, and prepends BaseURL to the paths, so that the paths
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
 
 	wrapper := ServerInterfaceWrapper{
-		Handler: si,
-	}
-
-	router.GET(baseURL+"/contentObject/:param", wrapper.GetContentObject)
-	router.GET(baseURL+"/cookie", wrapper.GetCookie)
-	router.GET(baseURL+"/header", wrapper.GetHeader)
-	router.GET(baseURL+"/labelExplodeArray/:param", wrapper.GetLabelExplodeArray)
-	router.GET(baseURL+"/labelExplodeObject/:param", wrapper.GetLabelExplodeObject)
-	router.GET(baseURL+"/labelNoExplodeArray/:param", wrapper.GetLabelNoExplodeArray)
-	router.GET(baseURL+"/labelNoExplodeObject/:param", wrapper.GetLabelNoExplodeObject)
-	router.GET(baseURL+"/matrixExplodeArray/:id", wrapper.GetMatrixExplodeArray)
-	router.GET(baseURL+"/matrixExplodeObject/:id", wrapper.GetMatrixExplodeObject)
-	router.GET(baseURL+"/matrixNoExplodeArray/:id", wrapper.GetMatrixNoExplodeArray)
-	router.GET(baseURL+"/matrixNoExplodeObject/:id", wrapper.GetMatrixNoExplodeObject)
-	router.GET(baseURL+"/passThrough/:param", wrapper.GetPassThrough)
-	router.GET(baseURL+"/queryDeepObject", wrapper.GetDeepObject)
-	router.GET(baseURL+"/queryForm", wrapper.GetQueryForm)
-	router.GET(baseURL+"/simpleExplodeArray/:param", wrapper.GetSimpleExplodeArray)
-	router.GET(baseURL+"/simpleExplodeObject/:param", wrapper.GetSimpleExplodeObject)
-	router.GET(baseURL+"/simpleNoExplodeArray/:param", wrapper.GetSimpleNoExplodeArray)
-	router.GET(baseURL+"/simpleNoExplodeObject/:param", wrapper.GetSimpleNoExplodeObject)
-	router.GET(baseURL+"/simplePrimitive/:param", wrapper.GetSimplePrimitive)
-	router.GET(baseURL+"/startingWithNumber/:1param", wrapper.GetStartingWithNumber)
+		Handler:             si,
+		BindErrorTranslator: options.BindErrorTranslator,
+	}
+
+	register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route, handler echo.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []echo.MiddlewareFunc
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		if added := add(route.RouterPath, handler, middlewares...); route.Name != "" {
+			added.Name = route.Name
+		}
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
+	}
+
+	register(runtime.Route{OperationID: "GetContentObject", Method: "GET", Path: "/contentObject/{param}", RouterPath: "/contentObject/:param"}, router.GET, wrapper.GetContentObject)
+	register(runtime.Route{OperationID: "GetCookie", Method: "GET", Path: "/cookie", RouterPath: "/cookie"}, router.GET, wrapper.GetCookie)
+	register(runtime.Route{OperationID: "GetHeader", Method: "GET", Path: "/header", RouterPath: "/header"}, router.GET, wrapper.GetHeader)
+	register(runtime.Route{OperationID: "GetLabelExplodeArray", Method: "GET", Path: "/labelExplodeArray/{.param*}", RouterPath: "/labelExplodeArray/:param"}, router.GET, wrapper.GetLabelExplodeArray)
+	register(runtime.Route{OperationID: "GetLabelExplodeObject", Method: "GET", Path: "/labelExplodeObject/{.param*}", RouterPath: "/labelExplodeObject/:param"}, router.GET, wrapper.GetLabelExplodeObject)
+	register(runtime.Route{OperationID: "GetLabelNoExplodeArray", Method: "GET", Path: "/labelNoExplodeArray/{.param}", RouterPath: "/labelNoExplodeArray/:param"}, router.GET, wrapper.GetLabelNoExplodeArray)
+	register(runtime.Route{OperationID: "GetLabelNoExplodeObject", Method: "GET", Path: "/labelNoExplodeObject/{.param}", RouterPath: "/labelNoExplodeObject/:param"}, router.GET, wrapper.GetLabelNoExplodeObject)
+	register(runtime.Route{OperationID: "GetMatrixExplodeArray", Method: "GET", Path: "/matrixExplodeArray/{.id*}", RouterPath: "/matrixExplodeArray/:id"}, router.GET, wrapper.GetMatrixExplodeArray)
+	register(runtime.Route{OperationID: "GetMatrixExplodeObject", Method: "GET", Path: "/matrixExplodeObject/{.id*}", RouterPath: "/matrixExplodeObject/:id"}, router.GET, wrapper.GetMatrixExplodeObject)
+	register(runtime.Route{OperationID: "GetMatrixNoExplodeArray", Method: "GET", Path: "/matrixNoExplodeArray/{.id}", RouterPath: "/matrixNoExplodeArray/:id"}, router.GET, wrapper.GetMatrixNoExplodeArray)
+	register(runtime.Route{OperationID: "GetMatrixNoExplodeObject", Method: "GET", Path: "/matrixNoExplodeObject/{.id}", RouterPath: "/matrixNoExplodeObject/:id"}, router.GET, wrapper.GetMatrixNoExplodeObject)
+	register(runtime.Route{OperationID: "GetPassThrough", Method: "GET", Path: "/passThrough/{param}", RouterPath: "/passThrough/:param"}, router.GET, wrapper.GetPassThrough)
+	register(runtime.Route{OperationID: "GetDeepObject", Method: "GET", Path: "/queryDeepObject", RouterPath: "/queryDeepObject"}, router.GET, wrapper.GetDeepObject)
+	register(runtime.Route{OperationID: "GetQueryForm", Method: "GET", Path: "/queryForm", RouterPath: "/queryForm"}, router.GET, wrapper.GetQueryForm)
+	register(runtime.Route{OperationID: "GetSimpleExplodeArray", Method: "GET", Path: "/simpleExplodeArray/{param*}", RouterPath: "/simpleExplodeArray/:param"}, router.GET, wrapper.GetSimpleExplodeArray)
+	register(runtime.Route{OperationID: "GetSimpleExplodeObject", Method: "GET", Path: "/simpleExplodeObject/{param*}", RouterPath: "/simpleExplodeObject/:param"}, router.GET, wrapper.GetSimpleExplodeObject)
+	register(runtime.Route{OperationID: "GetSimpleNoExplodeArray", Method: "GET", Path: "/simpleNoExplodeArray/{param}", RouterPath: "/simpleNoExplodeArray/:param"}, router.GET, wrapper.GetSimpleNoExplodeArray)
+	register(runtime.Route{OperationID: "GetSimpleNoExplodeObject", Method: "GET", Path: "/simpleNoExplodeObject/{param}", RouterPath: "/simpleNoExplodeObject/:param"}, router.GET, wrapper.GetSimpleNoExplodeObject)
+	register(runtime.Route{OperationID: "GetSimplePrimitive", Method: "GET", Path: "/simplePrimitive/{param}", RouterPath: "/simplePrimitive/:param"}, router.GET, wrapper.GetSimplePrimitive)
+	register(runtime.Route{OperationID: "GetStartingWithNumber", Method: "GET", Path: "/startingWithNumber/{1param}", RouterPath: "/startingWithNumber/:1param"}, router.GET, wrapper.GetStartingWithNumber)
 
 }
 
@@ -3291,14 +4052,19 @@ func decodeSpec() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var rawSpec = decodeSpecCached()
+var (
+	rawSpecOnce sync.Once
+	rawSpecData []byte
+	rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+	rawSpecOnce.Do(func() {
+		rawSpecData, rawSpecErr = decodeSpec()
+	})
+	return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
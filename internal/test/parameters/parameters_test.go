@@ -471,6 +471,26 @@ func TestParameterBinding(t *testing.T) {
 	assert.EqualValues(t, &expectedObject, ts.object)
 	ts.reset()
 
+	// header lists may have whitespace after their commas
+	result = testutil.NewRequest().WithHeader("X-Array", "3, 4, 5").Get("/header").Go(t, e)
+	assert.Equal(t, http.StatusOK, result.Code())
+	assert.EqualValues(t, expectedArray, ts.array)
+	ts.reset()
+
+	result = testutil.NewRequest().WithHeader("X-Object-Exploded",
+		"role=admin, firstName=Alex").Get("/header").Go(t, e)
+	assert.Equal(t, http.StatusOK, result.Code())
+	assert.EqualValues(t, &expectedObject, ts.object)
+	ts.reset()
+
+	// lists sent as several header lines are joined
+	req := httptest.NewRequest(http.MethodGet, "/header", nil)
+	req.Header.Add("X-Array-Exploded", "3")
+	req.Header.Add("X-Array-Exploded", "4,5")
+	doRequest(t, e, http.StatusOK, req)
+	assert.EqualValues(t, expectedArray, ts.array)
+	ts.reset()
+
 	// complex object
 	result = testutil.NewRequest().WithHeader("X-Complex-Object",
 		string(marshaledComplexObject)).Get("/header").Go(t, e)
@@ -562,6 +582,15 @@ func TestClientPathParams(t *testing.T) {
 	assert.EqualValues(t, &expectedComplexObject, ts.complexObject)
 	ts.reset()
 
+	// JSON which needs escaping in the path
+	escapedComplexObject := expectedComplexObject
+	escapedComplexObject.Object.FirstName = "Alex/Jr. 100%"
+	req, err = NewGetContentObjectRequest(server, escapedComplexObject)
+	assert.NoError(t, err)
+	doRequest(t, e, http.StatusOK, req)
+	assert.EqualValues(t, &escapedComplexObject, ts.complexObject)
+	ts.reset()
+
 	// Label style
 	req, err = NewGetLabelExplodeArrayRequest(server, expectedArray)
 	assert.NoError(t, err)
@@ -0,0 +1,3 @@
+package paramsbuilders
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --generate=types,client --params-builders --package=paramsbuilders -o params.gen.go params.yaml
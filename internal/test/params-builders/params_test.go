@@ -0,0 +1,44 @@
+package paramsbuilders
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+func TestWithMethods(t *testing.T) {
+	species := FindPetsParamsFilterSpecies("dog")
+	params := NewFindPetsParams().
+		WithLimit(20).
+		WithTags([]string{"a", "b"}).
+		WithFilter(FindPetsParams_Filter{Species: &species}).
+		WithXRequestId("abc")
+	require.NoError(t, params.BuildError())
+
+	req, err := NewFindPetsRequest(ServerURLOpenapitestDeepmapAi, params)
+	require.NoError(t, err)
+	assert.Equal(t, "filter%5Bspecies%5D=dog&limit=20&tags=a&tags=b", req.URL.RawQuery)
+	assert.Equal(t, "abc", req.Header.Get("X-Request-Id"))
+
+	// The parameters stay comparable
+	copied := *params
+	assert.True(t, copied == *params)
+}
+
+func TestWithMethodErrors(t *testing.T) {
+	params := NewFindPetsParams().
+		WithLimit(500).
+		WithTags([]string{"long"}).
+		WithXRequestId("abc")
+
+	// The errors of the invalid values surface when the request is built
+	_, err := NewFindPetsRequest(ServerURLOpenapitestDeepmapAi, params)
+	var errs runtime.ValidationErrors
+	require.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "limit: must be at most 100")
+}
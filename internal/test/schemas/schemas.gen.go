@@ -17,11 +17,15 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
@@ -69,6 +73,12 @@ type NullableProperties struct {
 	RequiredAndNullable *string `json:"requiredAndNullable"`
 }
 
+// UuidObject defines model for UuidObject.
+type UuidObject struct {
+	Id    uuid.UUID  `json:"id"`
+	Owner *uuid.UUID `json:"owner,omitempty"`
+}
+
 // StringInPath defines model for StringInPath.
 type StringInPath string
 
@@ -89,6 +99,284 @@ type Issue185JSONRequestBody Issue185JSONBody
 // Issue9JSONRequestBody defines body for Issue9 for application/json ContentType.
 type Issue9JSONRequestBody Issue9JSONBody
 
+// EnsureEverythingIsReferencedRoute is the route of EnsureEverythingIsReferenced, as in the spec.
+const EnsureEverythingIsReferencedRoute = "/ensure-everything-is-referenced"
+
+// Issue127Route is the route of Issue127, as in the spec.
+const Issue127Route = "/issues/127"
+
+// Issue185Route is the route of Issue185, as in the spec.
+const Issue185Route = "/issues/185"
+
+// Issue209Route is the route of Issue209, as in the spec.
+const Issue209Route = "/issues/209/${str}"
+
+// Issue30Route is the route of Issue30, as in the spec.
+const Issue30Route = "/issues/30/{fallthrough}"
+
+// GetIssues375Route is the route of GetIssues375, as in the spec.
+const GetIssues375Route = "/issues/375"
+
+// Issue41Route is the route of Issue41, as in the spec.
+const Issue41Route = "/issues/41/{1param}"
+
+// Issue9Route is the route of Issue9, as in the spec.
+const Issue9Route = "/issues/9"
+
+// GetUuidRoute is the route of GetUuid, as in the spec.
+const GetUuidRoute = "/uuids/{id}"
+
+// BuildEnsureEverythingIsReferencedURL builds the URL of EnsureEverythingIsReferenced on server, serializing its
+// path and query parameters like the client does.
+func BuildEnsureEverythingIsReferencedURL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ensure-everything-is-referenced")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildIssue127URL builds the URL of Issue127 on server, serializing its
+// path and query parameters like the client does.
+func BuildIssue127URL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issues/127")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildIssue185URL builds the URL of Issue185 on server, serializing its
+// path and query parameters like the client does.
+func BuildIssue185URL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issues/185")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildIssue209URL builds the URL of Issue209 on server, serializing its
+// path and query parameters like the client does.
+func BuildIssue209URL(server string, str StringInPath) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "str", runtime.ParamLocationPath, str)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issues/209/$%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildIssue30URL builds the URL of Issue30 on server, serializing its
+// path and query parameters like the client does.
+func BuildIssue30URL(server string, pFallthrough string) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fallthrough", runtime.ParamLocationPath, pFallthrough)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issues/30/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildGetIssues375URL builds the URL of GetIssues375 on server, serializing its
+// path and query parameters like the client does.
+func BuildGetIssues375URL(server string) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issues/375")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildIssue41URL builds the URL of Issue41 on server, serializing its
+// path and query parameters like the client does.
+func BuildIssue41URL(server string, n1param N5StartsWithNumber) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "1param", runtime.ParamLocationPath, n1param)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issues/41/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
+// BuildIssue9URL builds the URL of Issue9 on server, serializing its
+// path and query parameters like the client does.
+func BuildIssue9URL(server string, params *Issue9Params) (*url.URL, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issues/9")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "foo", runtime.ParamLocationQuery, params.Foo); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	return queryURL, nil
+}
+
+// BuildGetUuidURL builds the URL of GetUuid on server, serializing its
+// path and query parameters like the client does.
+func BuildGetUuidURL(server string, id uuid.UUID) (*url.URL, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/uuids/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryURL, nil
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -107,6 +395,17 @@ type Client struct {
 	// paths in the swagger spec will be appended to the server.
 	Server string
 
+	// Servers are the endpoints which the requests are sent to, as picked by
+	// the ServerPolicy, when there are several, which WithServers sets up.
+	// Server is the first one.
+	Servers []string
+
+	// ServerPolicy is how the servers of the requests are picked.
+	ServerPolicy runtime.ServerPolicy
+
+	// requests counts the requests, for picking their servers in turn.
+	requests uint32
+
 	// Doer for performing requests, typically a *http.Client with any
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
@@ -114,6 +413,26 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// RateLimiter, when set, is waited on before sending each request, with
+	// the ID of its operation.
+	RateLimiter runtime.RateLimiter
+
+	// MaxRetryAfter is how long, in total, a request which is rate limited,
+	// with a 429 status and a Retry-After header, is retried for, after
+	// waiting as long as the server asks, and at least
+	// runtime.MinRetryAfter. It isn't retried when it's zero.
+	MaxRetryAfter time.Duration
+
+	// MaxResponseSize is the maximum size of the response bodies, in bytes,
+	// beyond which reading them fails with a *runtime.ResponseTooLargeError,
+	// unless the operation has its own, from x-max-response-size. The
+	// bodies aren't limited when it's zero.
+	MaxResponseSize int64
+
+	// recorder, which WithRecorder sets, sends the requests through the
+	// Client once all the options are applied.
+	recorder *runtime.Recorder
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -135,6 +454,16 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
 	if !strings.HasSuffix(client.Server, "/") {
 		client.Server += "/"
 	}
+	for i, server := range client.Servers {
+		if !strings.HasSuffix(server, "/") {
+			client.Servers[i] = server + "/"
+		}
+	}
+	// send the requests of the Client through the recorder, whatever the
+	// order of the options
+	if client.recorder != nil {
+		client.Client = &http.Client{Transport: client.recorder.WithDoer(client.Client)}
+	}
 	// create httpClient, if not already present
 	if client.Client == nil {
 		client.Client = &http.Client{}
@@ -151,6 +480,26 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 	}
 }
 
+// WithServers makes the client send its requests to several servers, such as
+// the ones declared in the spec, instead of the one given to NewClient. They
+// are picked by policy: with runtime.ServerFailover, the requests fail over
+// to the next servers when one can't be reached, or, for idempotent requests,
+// responds with a 5xx status, runtime.ServerFailoverAnyMethod fails over the
+// requests of any method on 5xx statuses too, and runtime.ServerRoundRobin
+// sends them to each server in turn.
+// The server of a request is picked before the request editors run.
+func WithServers(policy runtime.ServerPolicy, servers ...string) ClientOption {
+	return func(c *Client) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("no servers given")
+		}
+		c.Server = servers[0]
+		c.Servers = append([]string{}, servers...)
+		c.ServerPolicy = policy
+		return nil
+	}
+}
+
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
@@ -160,6 +509,59 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithAccept sets the Accept header of a request, eg, to ask for one of the
+// content types of the responses of its operation, rather than all of them,
+// which the request builders list.
+func WithAccept(accept string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", accept)
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, eg, for a token of the bucket of its operation.
+func WithRateLimiter(limiter runtime.RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRetryAfter makes the client retry the requests which are rate limited,
+// with a 429 status and a Retry-After header, after waiting as long as the
+// server asks, for up to maxWait in total. The response is returned when the
+// server asks to wait longer.
+func WithRetryAfter(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.MaxRetryAfter = maxWait
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies which the client
+// reads to maxSize bytes, so that a misbehaving server can't exhaust its
+// memory. Reading larger bodies, such as when parsing them, fails with a
+// *runtime.ResponseTooLargeError. The operations with x-max-response-size
+// have their own limit.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		c.MaxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithRecorder makes the client send its requests through recorder, which
+// records their responses to golden files, or replays them, in tests. The
+// requests which are recorded are sent with the Doer of WithHTTPClient, if
+// any, unless recorder has a Transport of its own.
+func WithRecorder(recorder *runtime.Recorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// EnsureEverythingIsReferenced request
@@ -189,143 +591,168 @@ type ClientInterface interface {
 	Issue9WithBody(ctx context.Context, params *Issue9Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	Issue9(ctx context.Context, params *Issue9Params, body Issue9JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUuid request
+	GetUuid(ctx context.Context, id uuid.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) EnsureEverythingIsReferenced(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewEnsureEverythingIsReferencedRequest(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "EnsureEverythingIsReferenced", func(server string) (*http.Request, error) {
+		return NewEnsureEverythingIsReferencedRequest(server)
+	}, reqEditors)
 }
 
 func (c *Client) Issue127(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewIssue127Request(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "Issue127", func(server string) (*http.Request, error) {
+		return NewIssue127Request(server)
+	}, reqEditors)
 }
 
 func (c *Client) Issue185WithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewIssue185RequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "Issue185", func(server string) (*http.Request, error) {
+		return NewIssue185RequestWithBody(server, contentType, body)
+	}, reqEditors)
 }
 
 func (c *Client) Issue185(ctx context.Context, body Issue185JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewIssue185Request(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "Issue185", func(server string) (*http.Request, error) {
+		return NewIssue185Request(server, body)
+	}, reqEditors)
 }
 
 func (c *Client) Issue209(ctx context.Context, str StringInPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewIssue209Request(c.Server, str)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "Issue209", func(server string) (*http.Request, error) {
+		return NewIssue209Request(server, str)
+	}, reqEditors)
 }
 
 func (c *Client) Issue30(ctx context.Context, pFallthrough string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewIssue30Request(c.Server, pFallthrough)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "Issue30", func(server string) (*http.Request, error) {
+		return NewIssue30Request(server, pFallthrough)
+	}, reqEditors)
 }
 
 func (c *Client) GetIssues375(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetIssues375Request(c.Server)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "GetIssues375", func(server string) (*http.Request, error) {
+		return NewGetIssues375Request(server)
+	}, reqEditors)
 }
 
 func (c *Client) Issue41(ctx context.Context, n1param N5StartsWithNumber, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewIssue41Request(c.Server, n1param)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "Issue41", func(server string) (*http.Request, error) {
+		return NewIssue41Request(server, n1param)
+	}, reqEditors)
 }
 
 func (c *Client) Issue9WithBody(ctx context.Context, params *Issue9Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewIssue9RequestWithBody(c.Server, params, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "Issue9", func(server string) (*http.Request, error) {
+		return NewIssue9RequestWithBody(server, params, contentType, body)
+	}, reqEditors)
 }
 
 func (c *Client) Issue9(ctx context.Context, params *Issue9Params, body Issue9JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewIssue9Request(c.Server, params, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+	return c.do(ctx, "Issue9", func(server string) (*http.Request, error) {
+		return NewIssue9Request(server, params, body)
+	}, reqEditors)
 }
 
-// NewEnsureEverythingIsReferencedRequest generates requests for EnsureEverythingIsReferenced
-func NewEnsureEverythingIsReferencedRequest(server string) (*http.Request, error) {
-	var err error
+func (c *Client) GetUuid(ctx context.Context, id uuid.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	return c.do(ctx, "GetUuid", func(server string) (*http.Request, error) {
+		return NewGetUuidRequest(server, id)
+	}, reqEditors)
+}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+// NewEnsureEverythingIsReferencedRequest builds the request which EnsureEverythingIsReferenced sends, without
+// sending it.
+func (c *Client) NewEnsureEverythingIsReferencedRequest(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "EnsureEverythingIsReferenced", func(server string) (*http.Request, error) {
+		return NewEnsureEverythingIsReferencedRequest(server)
+	}, reqEditors)
+}
 
-	operationPath := fmt.Sprintf("/ensure-everything-is-referenced")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+// NewIssue127Request builds the request which Issue127 sends, without
+// sending it.
+func (c *Client) NewIssue127Request(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "Issue127", func(server string) (*http.Request, error) {
+		return NewIssue127Request(server)
+	}, reqEditors)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+// NewIssue185RequestWithBody builds the request which Issue185WithBody sends, without
+// sending it.
+func (c *Client) NewIssue185RequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "Issue185", func(server string) (*http.Request, error) {
+		return NewIssue185RequestWithBody(server, contentType, body)
+	}, reqEditors)
+}
+
+// NewIssue185Request builds the request which Issue185 sends, without
+// sending it.
+func (c *Client) NewIssue185Request(ctx context.Context, body Issue185JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "Issue185", func(server string) (*http.Request, error) {
+		return NewIssue185Request(server, body)
+	}, reqEditors)
+}
+
+// NewIssue209Request builds the request which Issue209 sends, without
+// sending it.
+func (c *Client) NewIssue209Request(ctx context.Context, str StringInPath, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "Issue209", func(server string) (*http.Request, error) {
+		return NewIssue209Request(server, str)
+	}, reqEditors)
+}
+
+// NewIssue30Request builds the request which Issue30 sends, without
+// sending it.
+func (c *Client) NewIssue30Request(ctx context.Context, pFallthrough string, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "Issue30", func(server string) (*http.Request, error) {
+		return NewIssue30Request(server, pFallthrough)
+	}, reqEditors)
+}
+
+// NewGetIssues375Request builds the request which GetIssues375 sends, without
+// sending it.
+func (c *Client) NewGetIssues375Request(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetIssues375", func(server string) (*http.Request, error) {
+		return NewGetIssues375Request(server)
+	}, reqEditors)
+}
+
+// NewIssue41Request builds the request which Issue41 sends, without
+// sending it.
+func (c *Client) NewIssue41Request(ctx context.Context, n1param N5StartsWithNumber, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "Issue41", func(server string) (*http.Request, error) {
+		return NewIssue41Request(server, n1param)
+	}, reqEditors)
+}
+
+// NewIssue9RequestWithBody builds the request which Issue9WithBody sends, without
+// sending it.
+func (c *Client) NewIssue9RequestWithBody(ctx context.Context, params *Issue9Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "Issue9", func(server string) (*http.Request, error) {
+		return NewIssue9RequestWithBody(server, params, contentType, body)
+	}, reqEditors)
+}
+
+// NewIssue9Request builds the request which Issue9 sends, without
+// sending it.
+func (c *Client) NewIssue9Request(ctx context.Context, params *Issue9Params, body Issue9JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "Issue9", func(server string) (*http.Request, error) {
+		return NewIssue9Request(server, params, body)
+	}, reqEditors)
+}
+
+// NewGetUuidRequest builds the request which GetUuid sends, without
+// sending it.
+func (c *Client) NewGetUuidRequest(ctx context.Context, id uuid.UUID, reqEditors ...RequestEditorFn) (*http.Request, error) {
+	return c.newRequest(ctx, "GetUuid", func(server string) (*http.Request, error) {
+		return NewGetUuidRequest(server, id)
+	}, reqEditors)
+}
+
+// NewEnsureEverythingIsReferencedRequest generates requests for EnsureEverythingIsReferenced
+func NewEnsureEverythingIsReferencedRequest(server string) (*http.Request, error) {
+	queryURL, err := BuildEnsureEverythingIsReferencedURL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -335,24 +762,14 @@ func NewEnsureEverythingIsReferencedRequest(server string) (*http.Request, error
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "application/json")
+
 	return req, nil
 }
 
 // NewIssue127Request generates requests for Issue127
 func NewIssue127Request(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/127")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildIssue127URL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -362,6 +779,8 @@ func NewIssue127Request(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "application/json, application/xml;q=0.9, text/markdown;q=0.8, text/yaml;q=0.7")
+
 	return req, nil
 }
 
@@ -378,19 +797,7 @@ func NewIssue185Request(server string, body Issue185JSONRequestBody) (*http.Requ
 
 // NewIssue185RequestWithBody generates requests for Issue185 with any type of body
 func NewIssue185RequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/185")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildIssue185URL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -407,26 +814,7 @@ func NewIssue185RequestWithBody(server string, contentType string, body io.Reade
 
 // NewIssue209Request generates requests for Issue209
 func NewIssue209Request(server string, str StringInPath) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "str", runtime.ParamLocationPath, str)
-	if err != nil {
-		return nil, err
-	}
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/209/$%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildIssue209URL(server, str)
 	if err != nil {
 		return nil, err
 	}
@@ -441,26 +829,7 @@ func NewIssue209Request(server string, str StringInPath) (*http.Request, error)
 
 // NewIssue30Request generates requests for Issue30
 func NewIssue30Request(server string, pFallthrough string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "fallthrough", runtime.ParamLocationPath, pFallthrough)
-	if err != nil {
-		return nil, err
-	}
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/30/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildIssue30URL(server, pFallthrough)
 	if err != nil {
 		return nil, err
 	}
@@ -475,19 +844,7 @@ func NewIssue30Request(server string, pFallthrough string) (*http.Request, error
 
 // NewGetIssues375Request generates requests for GetIssues375
 func NewGetIssues375Request(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/375")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildGetIssues375URL(server)
 	if err != nil {
 		return nil, err
 	}
@@ -497,31 +854,14 @@ func NewGetIssues375Request(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	req.Header.Set("Accept", "application/json")
+
 	return req, nil
 }
 
 // NewIssue41Request generates requests for Issue41
 func NewIssue41Request(server string, n1param N5StartsWithNumber) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "1param", runtime.ParamLocationPath, n1param)
-	if err != nil {
-		return nil, err
-	}
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/issues/41/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
+	queryURL, err := BuildIssue41URL(server, n1param)
 	if err != nil {
 		return nil, err
 	}
@@ -547,46 +887,136 @@ func NewIssue9Request(server string, params *Issue9Params, body Issue9JSONReques
 
 // NewIssue9RequestWithBody generates requests for Issue9 with any type of body
 func NewIssue9RequestWithBody(server string, params *Issue9Params, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+	queryURL, err := BuildIssue9URL(server, params)
+	if err != nil {
+		return nil, err
+	}
 
-	serverURL, err := url.Parse(server)
+	req, err := http.NewRequest("GET", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/issues/9")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetUuidRequest generates requests for GetUuid
+func NewGetUuidRequest(server string, id uuid.UUID) (*http.Request, error) {
+	queryURL, err := BuildGetUuidURL(server, id)
+	if err != nil {
+		return nil, err
 	}
 
-	queryURL, err := serverURL.Parse(operationPath)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	queryValues := queryURL.Query()
+	req.Header.Set("Accept", "application/json")
 
-	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "foo", runtime.ParamLocationQuery, params.Foo); err != nil {
-		return nil, err
-	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-		return nil, err
-	} else {
-		for k, v := range parsed {
-			for _, v2 := range v {
-				queryValues.Add(k, v2)
+	return req, nil
+}
+
+// do sends the request built by newRequest for a server, which is picked by
+// the ServerPolicy, after editing it, once the RateLimiter lets the operation
+// through. The context of the request carries the operation ID, for the
+// editors and the Doer. When it fails over, or is retried after being rate limited, the
+// request is built again, with the body of the first one, unless the body
+// can't be read again.
+func (c *Client) do(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Response, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	var first *http.Request
+	build := func(server string) (*http.Request, error) {
+		req, err := newRequest(server)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = req
+		} else if first.GetBody != nil {
+			if req.Body, err = first.GetBody(); err != nil {
+				return nil, err
 			}
+			req.GetBody = first.GetBody
+			req.ContentLength = first.ContentLength
 		}
+		return req.WithContext(ctx), nil
+	}
+	replayable := func() bool {
+		return first.Body == nil || first.Body == http.NoBody || first.GetBody != nil
 	}
 
-	queryURL.RawQuery = queryValues.Encode()
+	var waited time.Duration
+	for {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx, operationID); err != nil {
+				return nil, err
+			}
+		}
+		rsp, err := c.send(ctx, build, replayable, reqEditors)
+		if err != nil || c.MaxRetryAfter <= 0 || !replayable() {
+			return rsp, err
+		}
+		wait, retry := runtime.RetryAfter(rsp, time.Now())
+		if !retry || waited+wait > c.MaxRetryAfter {
+			return rsp, nil
+		}
+		_, _ = io.Copy(ioutil.Discard, rsp.Body)
+		rsp.Body.Close()
+		if err := runtime.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		waited += wait
+	}
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), body)
-	if err != nil {
-		return nil, err
+// send sends the request built by build to the servers, in the order of the
+// ServerPolicy, until one of them doesn't fail over.
+func (c *Client) send(ctx context.Context, build func(server string) (*http.Request, error), replayable func() bool, reqEditors []RequestEditorFn) (*http.Response, error) {
+	servers := []string{c.Server}
+	if len(c.Servers) > 0 {
+		servers = runtime.ServerOrder(c.ServerPolicy, c.Servers, atomic.AddUint32(&c.requests, 1)-1)
+	}
+	for i, server := range servers {
+		req, err := build(server)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+			return nil, err
+		}
+		rsp, err := c.Client.Do(req)
+		if i == len(servers)-1 || !replayable() || !runtime.ShouldFailOver(ctx, c.ServerPolicy, req, rsp, err) {
+			return runtime.LimitResponseBody(rsp, c.maxResponseSize(runtime.OperationIDFromContext(ctx))), err
+		}
+		if rsp != nil {
+			_, _ = io.Copy(ioutil.Discard, rsp.Body)
+			rsp.Body.Close()
+		}
 	}
+	return nil, fmt.Errorf("no servers")
+}
 
-	req.Header.Add("Content-Type", contentType)
+// maxResponseSize returns the maximum size of the response bodies of the
+// operation with the given ID, or zero when they aren't limited.
+func (c *Client) maxResponseSize(operationID string) int64 {
+	return c.MaxResponseSize
+}
 
+// newRequest builds the request of the operation, like do does, for the
+// first server, with ctx, after editing it, without sending it.
+func (c *Client) newRequest(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Request, error) {
+	ctx = runtime.ContextWithOperationID(ctx, operationID)
+	req, err := newRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -619,7 +1049,7 @@ func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithRes
 	return &ClientWithResponses{client}, nil
 }
 
-// WithBaseURL overrides the baseURL.
+// WithBaseURL overrides the baseURL, and the servers set by WithServers.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
@@ -627,6 +1057,7 @@ func WithBaseURL(baseURL string) ClientOption {
 			return err
 		}
 		c.Server = newBaseURL.String()
+		c.Servers = nil
 		return nil
 	}
 }
@@ -660,6 +1091,9 @@ type ClientWithResponsesInterface interface {
 	Issue9WithBodyWithResponse(ctx context.Context, params *Issue9Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*Issue9Response, error)
 
 	Issue9WithResponse(ctx context.Context, params *Issue9Params, body Issue9JSONRequestBody, reqEditors ...RequestEditorFn) (*Issue9Response, error)
+
+	// GetUuid request
+	GetUuidWithResponse(ctx context.Context, id uuid.UUID, reqEditors ...RequestEditorFn) (*GetUuidResponse, error)
 }
 
 type EnsureEverythingIsReferencedResponse struct {
@@ -844,6 +1278,28 @@ func (r Issue9Response) StatusCode() int {
 	return 0
 }
 
+type GetUuidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UuidObject
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUuidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUuidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // EnsureEverythingIsReferencedWithResponse request returning *EnsureEverythingIsReferencedResponse
 func (c *ClientWithResponses) EnsureEverythingIsReferencedWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EnsureEverythingIsReferencedResponse, error) {
 	rsp, err := c.EnsureEverythingIsReferenced(ctx, reqEditors...)
@@ -932,6 +1388,75 @@ func (c *ClientWithResponses) Issue9WithResponse(ctx context.Context, params *Is
 	return ParseIssue9Response(rsp)
 }
 
+// GetUuidWithResponse request returning *GetUuidResponse
+func (c *ClientWithResponses) GetUuidWithResponse(ctx context.Context, id uuid.UUID, reqEditors ...RequestEditorFn) (*GetUuidResponse, error) {
+	rsp, err := c.GetUuid(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUuidResponse(rsp)
+}
+
+// BatchCall is a call of an operation in a batch, which returns its
+// *...Response, eg,
+//
+//	func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+//		return c.FindPetByIdWithResponse(ctx, id)
+//	}
+type BatchCall func(ctx context.Context, client ClientWithResponsesInterface) (interface{}, error)
+
+// BatchResult is the result of a call in a batch.
+type BatchResult struct {
+	Response interface{} // The *...Response of the operation, if it was called
+	Err      error
+}
+
+// Batch makes the calls with the client, with at most concurrency of them at
+// a time, or all at once when concurrency isn't positive, and returns their
+// results in the order of the calls. A failed call doesn't stop the others,
+// but those which haven't started once ctx is done fail with its error.
+func (c *ClientWithResponses) Batch(ctx context.Context, concurrency int, calls ...BatchCall) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	errs := runtime.RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+		var err error
+		results[i].Response, err = calls[i](ctx, c)
+		return err
+	})
+	for i, err := range errs {
+		results[i].Err = err
+	}
+	return results
+}
+
+// ParseOperationResponse parses an HTTP response to a request of the
+// operation with the given ID, such as one built by a New...Request method of
+// the Client, which runtime.OperationIDFromContext tells from the context of
+// the request, into the *...Response of the operation.
+func ParseOperationResponse(operationID string, rsp *http.Response) (interface{}, error) {
+	switch operationID {
+	case "EnsureEverythingIsReferenced":
+		return ParseEnsureEverythingIsReferencedResponse(rsp)
+	case "Issue127":
+		return ParseIssue127Response(rsp)
+	case "Issue185":
+		return ParseIssue185Response(rsp)
+	case "Issue209":
+		return ParseIssue209Response(rsp)
+	case "Issue30":
+		return ParseIssue30Response(rsp)
+	case "GetIssues375":
+		return ParseGetIssues375Response(rsp)
+	case "Issue41":
+		return ParseIssue41Response(rsp)
+	case "Issue9":
+		return ParseIssue9Response(rsp)
+	case "GetUuid":
+		return ParseGetUuidResponse(rsp)
+	}
+	_ = rsp.Body.Close()
+	return nil, fmt.Errorf("unknown operation %s", operationID)
+}
+
 // ParseEnsureEverythingIsReferencedResponse parses an HTTP response from a EnsureEverythingIsReferencedWithResponse call
 func ParseEnsureEverythingIsReferencedResponse(rsp *http.Response) (*EnsureEverythingIsReferencedResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -1125,6 +1650,62 @@ func ParseIssue9Response(rsp *http.Response) (*Issue9Response, error) {
 	return response, nil
 }
 
+// ParseGetUuidResponse parses an HTTP response from a GetUuidWithResponse call
+func ParseGetUuidResponse(rsp *http.Response) (*GetUuidResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUuidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UuidObject
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// Server URLs declared in the OpenAPI specification. They may contain
+// {variable} placeholders, which are filled in by WithServerVariables.
+const (
+	ServerURLOpenapitestDeepmapAi = "http://openapitest.deepmap.ai"
+)
+
+// serverVariables holds the variables declared for each server URL.
+var serverVariables = map[string]map[string]runtime.ServerVariable{
+	ServerURLOpenapitestDeepmapAi: {},
+}
+
+// WithServerVariables substitutes the {variable} placeholders in the server
+// URLs. Variables which aren't given take the default value from the spec, and
+// values are validated against the enums declared in the spec.
+func WithServerVariables(variables map[string]string) ClientOption {
+	return func(c *Client) error {
+		server, err := runtime.SubstituteServerVariables(c.Server, runtime.LookupServerVariables(serverVariables, c.Server), variables)
+		if err != nil {
+			return err
+		}
+		c.Server = server
+		for i, server := range c.Servers {
+			if c.Servers[i], err = runtime.SubstituteServerVariables(server, runtime.LookupServerVariables(serverVariables, server), variables); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
@@ -1151,11 +1732,15 @@ type ServerInterface interface {
 
 	// (GET /issues/9)
 	Issue9(ctx echo.Context, params Issue9Params) error
+
+	// (GET /uuids/{id})
+	GetUuid(ctx echo.Context, id uuid.UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	Handler             ServerInterface
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // EnsureEverythingIsReferenced converts echo context to params.
@@ -1199,7 +1784,8 @@ func (w *ServerInterfaceWrapper) Issue209(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "str", runtime.ParamLocationPath, ctx.Param("str"), &str)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter str: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "str", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter str: %s", err)))
 	}
 
 	ctx.Set(Access_tokenScopes, []string{""})
@@ -1217,7 +1803,8 @@ func (w *ServerInterfaceWrapper) Issue30(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "fallthrough", runtime.ParamLocationPath, ctx.Param("fallthrough"), &pFallthrough)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fallthrough: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "fallthrough", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fallthrough: %s", err)))
 	}
 
 	ctx.Set(Access_tokenScopes, []string{""})
@@ -1246,7 +1833,8 @@ func (w *ServerInterfaceWrapper) Issue41(ctx echo.Context) error {
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "1param", runtime.ParamLocationPath, ctx.Param("1param"), &n1param)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1param: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "1param", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1param: %s", err)))
 	}
 
 	ctx.Set(Access_tokenScopes, []string{""})
@@ -1268,7 +1856,8 @@ func (w *ServerInterfaceWrapper) Issue9(ctx echo.Context) error {
 
 	err = runtime.BindQueryParameter("form", true, true, "foo", ctx.QueryParams(), &params.Foo)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter foo: %s", err))
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "foo", runtime.ParamLocationQuery, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter foo: %s", err)))
 	}
 
 	// Invoke the callback with all the unmarshalled arguments
@@ -1276,6 +1865,25 @@ func (w *ServerInterfaceWrapper) Issue9(ctx echo.Context) error {
 	return err
 }
 
+// GetUuid converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUuid(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "id", runtime.ParamLocationPath, err),
+			echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err)))
+	}
+
+	ctx.Set(Access_tokenScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetUuid(ctx, id)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1291,54 +1899,98 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+	BaseURL string
+	// RouteMiddlewares returns the middlewares of the route of an operation.
+	RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+	// RouteName names the route of an operation, which it's registered
+	// under, for echo.Echo.Reverse.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute func(route runtime.Route)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{})
 }
 
 // Registers handlers, and prepends BaseURL to the paths, so that the paths
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+	RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
 
 	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+		Handler:             si,
+		BindErrorTranslator: options.BindErrorTranslator,
 	}
 
-	router.GET(baseURL+"/ensure-everything-is-referenced", wrapper.EnsureEverythingIsReferenced)
-	router.GET(baseURL+"/issues/127", wrapper.Issue127)
-	router.GET(baseURL+"/issues/185", wrapper.Issue185)
-	router.GET(baseURL+"/issues/209/$:str", wrapper.Issue209)
-	router.GET(baseURL+"/issues/30/:fallthrough", wrapper.Issue30)
-	router.GET(baseURL+"/issues/375", wrapper.GetIssues375)
-	router.GET(baseURL+"/issues/41/:1param", wrapper.Issue41)
-	router.GET(baseURL+"/issues/9", wrapper.Issue9)
+	register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route, handler echo.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []echo.MiddlewareFunc
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		if added := add(route.RouterPath, handler, middlewares...); route.Name != "" {
+			added.Name = route.Name
+		}
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
+	}
+
+	register(runtime.Route{OperationID: "EnsureEverythingIsReferenced", Method: "GET", Path: "/ensure-everything-is-referenced", RouterPath: "/ensure-everything-is-referenced"}, router.GET, wrapper.EnsureEverythingIsReferenced)
+	register(runtime.Route{OperationID: "Issue127", Method: "GET", Path: "/issues/127", RouterPath: "/issues/127"}, router.GET, wrapper.Issue127)
+	register(runtime.Route{OperationID: "Issue185", Method: "GET", Path: "/issues/185", RouterPath: "/issues/185"}, router.GET, wrapper.Issue185)
+	register(runtime.Route{OperationID: "Issue209", Method: "GET", Path: "/issues/209/${str}", RouterPath: "/issues/209/$:str"}, router.GET, wrapper.Issue209)
+	register(runtime.Route{OperationID: "Issue30", Method: "GET", Path: "/issues/30/{fallthrough}", RouterPath: "/issues/30/:fallthrough"}, router.GET, wrapper.Issue30)
+	register(runtime.Route{OperationID: "GetIssues375", Method: "GET", Path: "/issues/375", RouterPath: "/issues/375"}, router.GET, wrapper.GetIssues375)
+	register(runtime.Route{OperationID: "Issue41", Method: "GET", Path: "/issues/41/{1param}", RouterPath: "/issues/41/:1param"}, router.GET, wrapper.Issue41)
+	register(runtime.Route{OperationID: "Issue9", Method: "GET", Path: "/issues/9", RouterPath: "/issues/9"}, router.GET, wrapper.Issue9)
+	register(runtime.Route{OperationID: "GetUuid", Method: "GET", Path: "/uuids/{id}", RouterPath: "/uuids/:id"}, router.GET, wrapper.GetUuid)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RX0W/buA/+Vwj9BvxenDjNNmzNW2/YDT3gtmItsIemD4rNxFptypPotkaQ//1AyamT",
-	"xelt160vjS1R5PeR/ESvVWar2hISezVbq1o7XSGjC0+X7AytzulCcyHPOfrMmZqNJTVTZ+DDOtSaC3i0",
-	"VIkysixvVaJIV6hmyrMsOPzWGIe5mrFrMFE+K7DScjS3dbfN0EptNpvtYgjk9SVrx/6L4eJjUy3QHUZz",
-	"VRgP0QTEJ/hgAveGC9BA0SzZOrKLr5ix2iTqjNqrtsYTNVv3T9MBuN0KOKwdemEMNLUgB47nNKcYQWGb",
-	"MocFgiYwxOiWOsP1Zk7i613j2VaR1qsQyFotras0q5nKwmIfYsdFoh5GVtdmlNkcV0gjfGCnR6xXPppb",
-	"NVML7ZRw9p6a6pw+Lb6e05lzupUdhrGKyXW2RscGw9OdLuUfUlOp2bVaGudZJcpjZilXN8lBSga4617o",
-	"4GqTqA9I6Ez2KW7o09pbfGzKUi9KvNiLZT8yGyiP4X0XRPK4eEb59izZR4+/Y2Ud2PWltz6++HOH7p16",
-	"3f8ePu/mgL9NYLtxhttLKdyIXmcZej9ie4skzwvUDt2f2yr568vVKJYMxJ0Qdo7npLqWERfRqK+lgrmO",
-	"XWVoaQe6Bz1Dpj16WFoHd9oZ23gw3jfhVUM52Dt0wKbCMVyUqD2CznPQwFtbMZ2T9MSiWcHSPGAew2LD",
-	"QmL0conuLoR2h85H7yfjyXgSk4uka6Nm6uV4Mj5RSVCRQEuK5BuHI7xD13JhaDUyfuRwiQ4pi3ldIR8R",
-	"BqS8toYY8MF49uAtcKEZevWDTJO0beZQM+ZgCLgwfk6+xgw05UCWZUPtGsI84JKi1eLmPFcz9T4E+P4x",
-	"vnP/uY9OasLXlnxM8nQykX+ZJUYKQeu6Lk0WTku/ehtS38vjfoPoXrLUC4dLNVP/S3soaaec6aO0bZKt",
-	"zfQHbaZikw3I1VO2B/I2IBrxL1FprK30ZPrmaOr+1rcIQio05Ju6tk4yE0h74CC8HnJL/2eoHWJVM/S7",
-	"wup4IE3n4le8PjMlTxGxr4MCd/esh6p8zlECPq20u83tPT37oFY/Jxo5Jselbkr+jeT9IsTfV97b18dF",
-	"o60RVmIfEMB9gQTbqyfdyjv0bQnaIWzvi+Nl9/Z1dzug5z9s3v4y0gbu1Yh2p8YlvF0CppPT9MXas9sc",
-	"5eFdgdmtB7Ps57sINces1D0FZTsMeDo5VYcxJHtz5vUwsn5LujeHbm52ILycpOulLksunG1WxeYQwWf0",
-	"cuHkcIvtvXX57ohWOwy3lIi9XHlCYBgeO+HoKBnA9XLyI7AG5uCdYH9qHt4D/eZ44coA2CWnq1ztt4Us",
-	"qnhvMpR0coEgo19YNyTTalToOd0XJiu6997kCHYpy2HIG6rsD8iBEy9x/UZRPZhtDzr61Um6Pgk5OF7R",
-	"F9sU7XwlyEdM+E54/EoYSPmrOI78W4Kj/ydz+xTIwy+dzebmyS4+Pd68pUHi2Lk+XIhgKLPOYcZlK7/L",
-	"Jsc8THydJkUaFjZvZeSZU4/3qKadHqHlW4Ou3Sl8a3+u4P+zTnaX0i4TnzrlDsjUkCruzOIBwv4Ufn0j",
-	"8QQh6SA2ruzG6lmadmOrDMLjHLGudD3WRpTqnwAAAP//yJQBrWAPAAA=",
+	"H4sIAAAAAAAC/7RXUW/buA//KoT+A/4vTpx2G7b6rbfbDTngtmJtsYemD4rFxFptyZPopkbg736QZMfJ",
+	"Ynfbdc1LbEsUyR/Jn8gtS3VRaoWKLEu2rOSGF0ho/NslGanWc3XBKXPvAm1qZElSK5awc7B+HUpOGewk",
+	"WcSkW3ZfWcQUL5AlzJJbMPitkgYFS8hUGDGbZlhwdzTVZbtNqjVrmqZb9Ia8viRuyH6RlH2siiWaY2uu",
+	"MmkhiIDTCdaLwEZSBhxUEIs6RXr5FVNiTcTOVX1Vl3jCkm3/djrgbrsCBkuD1iEGXNXgDpwu1EIFCzJd",
+	"5QKWCFyBVIRmxVPcNgvldL2rLOkiwHrlDdmylTYFJ5aw1C+y6DssIvYw0byUk1QLXKOa4AMZPiG+tkFc",
+	"s4QtuWEOs/eqKubq0/LrXJ0bw2u3QxIWIbhGl2hIon+757n7Q1UVLLlhK2kssYhZTLUS7DY6CskAdu0H",
+	"7lU1EfuACo1MP4UNyfZY4mOV53yZ48WBLYeWaQ85z/cO6IyIdovnSnRnuX1q9xwy60iuT73t+OKvHXpw",
+	"6k3/PHze7QB+15UUPViHKEhxkB1VJQUb8EtvFJqf2PmdsVIMGNT48FdGUn3pKikYwtMUrZ2QvkPl3pfI",
+	"DZq/OnV/f7maBN0QdoLfOV0o1tawUxGEerMyojKUuVQrPVDOaAlSbtHCShu450bqyoK0tvKfKiVA36MB",
+	"kgVO4SJHbhG4EMCBOlknulCuSJfVGlbyAUUwiyTl2Gm5RHPvTbtHY4P2k+lsOgvZhoqXkiXs5XQ2PWGR",
+	"pzUPS4zKVgYneI+mpkyq9UTaicEVGlRpSLQ10ghToRKllooAH6QlC1YDZZygp2NIuXI8khrkhAKkAsqk",
+	"XShbYgpcCVCa3IbSVAqF98vlD3dq5oIl7L038P3Ovrn93Fvn0sGWWtkQ5NPZzP2lWhEqbzQvy1ym/rT4",
+	"q9U+9D1fH+Yq7zmUvTC4Ygn7X9y7Egc5G++4tokY32Pan5A5dTLpAH8+JnvEtwMsFn4Ri0NuxSenb0ZD",
+	"9w+/Q3CgQqVsVZbauMh40B7I3wQWhFb/JygNYlES9Lv86nQgTHOn12l9YkgeA+KQmJ27+2c9FPlTjnLO",
+	"xwU3d0Jv1JMPqvlTrHHHCFzxKqdnBO83efx95r19PU4adYmwdvLeA9hkqKC7C+OO2aEvS+AGobvAxtPu",
+	"7ev2ukJLf2hR/zbQBi764O1ejjvz9gE4nZ3FL7aWTDOKw7sM0zsLctU3nMFVgWnOewjyetjh09kZO7Yh",
+	"Omh8b4Y967fEB41xc7vnwstZvF3xPKfM6GqdNccefEbrLhwBd1hvtBH7PWNp0N9SjuzdlecA9N1sSxwt",
+	"JAN+vZz9jFsDjfmesb/UoB84/WY8cV1H2ganzVxuu0R2rLiRKbpwUobgelG/LpVrnwNDL9Qmk2nWfrdS",
+	"IOiVW/Zd51Bmf0DymFhn1zOS6lGzfVTRr07i7YmPwXhGX3Qh2htb3FTlB5fd2DIQ8lehHflRgIP+R2P7",
+	"mJPHo1fT3D5axWfjxZtLVBQq1/oLEaRKtTGYUl6757wSKHzH13JSgGGpRe1anoXq/R3ltLMRWL5VaOq9",
+	"xNf61xL+P/NkeyntI/GpZW7vGRthRdfI23grxXjyBCJqp1xXQk4GunbcIFxfz/+0rmDWkrJqOU11Ea+1",
+	"Xufojx8pn+swQvw4uaR4FMMfzSS3z1ide7NVsyvMbrzx/hwONje3zhzPza2/lcnbSSWJ43YSILQ0FYhl",
+	"wcspl478/x0AyVdbnkQRAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -1361,14 +2013,19 @@ func decodeSpec() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var rawSpec = decodeSpecCached()
+var (
+	rawSpecOnce sync.Once
+	rawSpecData []byte
+	rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+	rawSpecOnce.Do(func() {
+		rawSpecData, rawSpecErr = decodeSpec()
+	})
+	return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
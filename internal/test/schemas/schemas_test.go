@@ -0,0 +1,50 @@
+package schemas
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithServerVariables(t *testing.T) {
+	// The client adds a trailing slash to the server, which is still known
+	// to be the one of the spec, so the variables are validated
+	_, err := NewClient(ServerURLOpenapitestDeepmapAi, WithServerVariables(map[string]string{"zone": "a"}))
+	assert.Error(t, err)
+
+	client, err := NewClient(ServerURLOpenapitestDeepmapAi, WithServerVariables(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, ServerURLOpenapitestDeepmapAi+"/", client.Server)
+}
+
+// uuidServer serves GetUuid, which echoes the UUID of the path.
+type uuidServer struct {
+	ServerInterface
+}
+
+func (uuidServer) GetUuid(ctx echo.Context, id uuid.UUID) error {
+	return ctx.JSON(http.StatusOK, UuidObject{Id: id})
+}
+
+func TestUUIDs(t *testing.T) {
+	e := echo.New()
+	RegisterHandlers(e, uuidServer{})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	client, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+
+	id := uuid.New()
+	rsp, err := client.GetUuidWithResponse(context.Background(), id)
+	require.NoError(t, err)
+	require.NotNil(t, rsp.JSON200)
+	assert.Equal(t, id, rsp.JSON200.Id)
+	assert.Nil(t, rsp.JSON200.Owner)
+}
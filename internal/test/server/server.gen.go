@@ -15,14 +15,14 @@ import (
 
 // EveryTypeOptional defines model for EveryTypeOptional.
 type EveryTypeOptional struct {
-	ArrayInlineField     *[]int              `json:"array_inline_field,omitempty"`
-	ArrayReferencedField *[]SomeObject       `json:"array_referenced_field,omitempty"`
-	BoolField            *bool               `json:"bool_field,omitempty"`
-	ByteField            *[]byte             `json:"byte_field,omitempty"`
-	DateField            *openapi_types.Date `json:"date_field,omitempty"`
-	DateTimeField        *time.Time          `json:"date_time_field,omitempty"`
-	DoubleField          *float64            `json:"double_field,omitempty"`
-	FloatField           *float32            `json:"float_field,omitempty"`
+	ArrayInlineField     *[]int                `json:"array_inline_field,omitempty"`
+	ArrayReferencedField *[]SomeObject         `json:"array_referenced_field,omitempty"`
+	BoolField            *bool                 `json:"bool_field,omitempty"`
+	ByteField            *openapi_types.Base64 `json:"byte_field,omitempty"`
+	DateField            *openapi_types.Date   `json:"date_field,omitempty"`
+	DateTimeField        *time.Time            `json:"date_time_field,omitempty"`
+	DoubleField          *float64              `json:"double_field,omitempty"`
+	FloatField           *float32              `json:"float_field,omitempty"`
 	InlineObjectField    *struct {
 		Name   string `json:"name"`
 		Number int    `json:"number"`
@@ -40,7 +40,7 @@ type EveryTypeRequired struct {
 	ArrayInlineField     []int                `json:"array_inline_field"`
 	ArrayReferencedField []SomeObject         `json:"array_referenced_field"`
 	BoolField            bool                 `json:"bool_field"`
-	ByteField            []byte               `json:"byte_field"`
+	ByteField            openapi_types.Base64 `json:"byte_field"`
 	DateField            openapi_types.Date   `json:"date_field"`
 	DateTimeField        time.Time            `json:"date_time_field"`
 	DoubleField          float64              `json:"double_field"`
@@ -164,9 +164,10 @@ type ServerInterface interface {
 
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	Handler             ServerInterface
+	HandlerMiddlewares  []MiddlewareFunc
+	ErrorHandlerFunc    func(w http.ResponseWriter, r *http.Request, err error)
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
@@ -206,6 +207,7 @@ func (siw *ServerInterfaceWrapper) GetWithArgs(w http.ResponseWriter, r *http.Re
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetWithArgsParams
@@ -217,7 +219,8 @@ func (siw *ServerInterfaceWrapper) GetWithArgs(w http.ResponseWriter, r *http.Re
 
 	err = runtime.BindQueryParameter("form", true, false, "optional_argument", r.URL.Query(), &params.OptionalArgument)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "optional_argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "optional_argument", runtime.ParamLocationQuery, err),
+			&InvalidParamFormatError{ParamName: "optional_argument", Err: err}))
 		return
 	}
 
@@ -225,13 +228,15 @@ func (siw *ServerInterfaceWrapper) GetWithArgs(w http.ResponseWriter, r *http.Re
 	if paramValue := r.URL.Query().Get("required_argument"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "required_argument"})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "required_argument", runtime.ParamLocationQuery, nil),
+			&RequiredParamError{ParamName: "required_argument"}))
 		return
 	}
 
 	err = runtime.BindQueryParameter("form", true, true, "required_argument", r.URL.Query(), &params.RequiredArgument)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "required_argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "required_argument", runtime.ParamLocationQuery, err),
+			&InvalidParamFormatError{ParamName: "required_argument", Err: err}))
 		return
 	}
 
@@ -242,13 +247,15 @@ func (siw *ServerInterfaceWrapper) GetWithArgs(w http.ResponseWriter, r *http.Re
 		var HeaderArgument int32
 		n := len(valueList)
 		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "header_argument", Count: n})
+			siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "header_argument", runtime.ParamLocationHeader, nil),
+				&TooManyValuesForParamError{ParamName: "header_argument", Count: n}))
 			return
 		}
 
 		err = runtime.BindStyledParameterWithLocation("simple", false, "header_argument", runtime.ParamLocationHeader, valueList[0], &HeaderArgument)
 		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "header_argument", Err: err})
+			siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "header_argument", runtime.ParamLocationHeader, err),
+				&InvalidParamFormatError{ParamName: "header_argument", Err: err}))
 			return
 		}
 
@@ -272,13 +279,15 @@ func (siw *ServerInterfaceWrapper) GetWithReferences(w http.ResponseWriter, r *h
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// ------------- Path parameter "global_argument" -------------
 	var globalArgument int64
 
 	err = runtime.BindStyledParameter("simple", false, "global_argument", chi.URLParam(r, "global_argument"), &globalArgument)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "global_argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "global_argument", runtime.ParamLocationPath, err),
+			&InvalidParamFormatError{ParamName: "global_argument", Err: err}))
 		return
 	}
 
@@ -287,7 +296,8 @@ func (siw *ServerInterfaceWrapper) GetWithReferences(w http.ResponseWriter, r *h
 
 	err = runtime.BindStyledParameter("simple", false, "argument", chi.URLParam(r, "argument"), &argument)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "argument", runtime.ParamLocationPath, err),
+			&InvalidParamFormatError{ParamName: "argument", Err: err}))
 		return
 	}
 
@@ -307,13 +317,15 @@ func (siw *ServerInterfaceWrapper) GetWithContentType(w http.ResponseWriter, r *
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// ------------- Path parameter "content_type" -------------
 	var contentType GetWithContentTypeParamsContentType
 
 	err = runtime.BindStyledParameter("simple", false, "content_type", chi.URLParam(r, "content_type"), &contentType)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "content_type", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "content_type", runtime.ParamLocationPath, err),
+			&InvalidParamFormatError{ParamName: "content_type", Err: err}))
 		return
 	}
 
@@ -348,13 +360,15 @@ func (siw *ServerInterfaceWrapper) CreateResource(w http.ResponseWriter, r *http
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// ------------- Path parameter "argument" -------------
 	var argument Argument
 
 	err = runtime.BindStyledParameter("simple", false, "argument", chi.URLParam(r, "argument"), &argument)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "argument", runtime.ParamLocationPath, err),
+			&InvalidParamFormatError{ParamName: "argument", Err: err}))
 		return
 	}
 
@@ -374,13 +388,15 @@ func (siw *ServerInterfaceWrapper) CreateResource2(w http.ResponseWriter, r *htt
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// ------------- Path parameter "inline_argument" -------------
 	var inlineArgument int
 
 	err = runtime.BindStyledParameter("simple", false, "inline_argument", chi.URLParam(r, "inline_argument"), &inlineArgument)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "inline_argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "inline_argument", runtime.ParamLocationPath, err),
+			&InvalidParamFormatError{ParamName: "inline_argument", Err: err}))
 		return
 	}
 
@@ -394,7 +410,8 @@ func (siw *ServerInterfaceWrapper) CreateResource2(w http.ResponseWriter, r *htt
 
 	err = runtime.BindQueryParameter("form", true, false, "inline_query_argument", r.URL.Query(), &params.InlineQueryArgument)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "inline_query_argument", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "inline_query_argument", runtime.ParamLocationQuery, err),
+			&InvalidParamFormatError{ParamName: "inline_query_argument", Err: err}))
 		return
 	}
 
@@ -414,13 +431,15 @@ func (siw *ServerInterfaceWrapper) UpdateResource3(w http.ResponseWriter, r *htt
 	ctx := r.Context()
 
 	var err error
+	_ = err // not every parameter is bound through err
 
 	// ------------- Path parameter "fallthrough" -------------
 	var pFallthrough int
 
 	err = runtime.BindStyledParameter("simple", false, "fallthrough", chi.URLParam(r, "fallthrough"), &pFallthrough)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fallthrough", Err: err})
+		siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "fallthrough", runtime.ParamLocationPath, err),
+			&InvalidParamFormatError{ParamName: "fallthrough", Err: err}))
 		return
 	}
 
@@ -525,10 +544,22 @@ func Handler(si ServerInterface) http.Handler {
 }
 
 type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
+	BaseURL     string
+	BaseRouter  chi.Router
+	Middlewares []MiddlewareFunc
+	// RouteMiddlewares returns the chi middlewares of the route of an
+	// operation, which run before its parameters are bound, and before the
+	// Middlewares.
+	RouteMiddlewares func(route runtime.Route) []func(http.Handler) http.Handler
+	// RouteName names the route of an operation.
+	RouteName func(route runtime.Route) string
+	// OnRoute is told of the route of each operation once it's registered.
+	OnRoute          func(route runtime.Route)
 	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	// BindErrorTranslator translates the errors binding the parameters of
+	// requests, which are reported instead of the default ones, unless it's
+	// nil.
+	BindErrorTranslator runtime.BindErrorTranslator
 }
 
 // HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
@@ -558,41 +589,38 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		}
 	}
 	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+		Handler:             si,
+		HandlerMiddlewares:  options.Middlewares,
+		ErrorHandlerFunc:    options.ErrorHandlerFunc,
+		BindErrorTranslator: options.BindErrorTranslator,
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/every-type-optional", wrapper.GetEveryTypeOptional)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/get-simple", wrapper.GetSimple)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/get-with-args", wrapper.GetWithArgs)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/get-with-references/{global_argument}/{argument}", wrapper.GetWithReferences)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/get-with-type/{content_type}", wrapper.GetWithContentType)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/reserved-keyword", wrapper.GetReservedKeyword)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/resource/{argument}", wrapper.CreateResource)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/resource2/{inline_argument}", wrapper.CreateResource2)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/resource3/{fallthrough}", wrapper.UpdateResource3)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/response-with-reference", wrapper.GetResponseWithReference)
-	})
+	register := func(route runtime.Route, handler http.HandlerFunc) {
+		route.BaseURL = options.BaseURL
+		route.RouterPath = options.BaseURL + route.RouterPath
+		if options.RouteName != nil {
+			route.Name = options.RouteName(route)
+		}
+		var middlewares []func(http.Handler) http.Handler
+		if options.RouteMiddlewares != nil {
+			middlewares = options.RouteMiddlewares(route)
+		}
+		r.With(middlewares...).MethodFunc(route.Method, route.RouterPath, handler)
+		if options.OnRoute != nil {
+			options.OnRoute(route)
+		}
+	}
+
+	register(runtime.Route{OperationID: "GetEveryTypeOptional", Method: "GET", Path: "/every-type-optional", RouterPath: "/every-type-optional"}, wrapper.GetEveryTypeOptional)
+	register(runtime.Route{OperationID: "GetSimple", Method: "GET", Path: "/get-simple", RouterPath: "/get-simple"}, wrapper.GetSimple)
+	register(runtime.Route{OperationID: "GetWithArgs", Method: "GET", Path: "/get-with-args", RouterPath: "/get-with-args"}, wrapper.GetWithArgs)
+	register(runtime.Route{OperationID: "GetWithReferences", Method: "GET", Path: "/get-with-references/{global_argument}/{argument}", RouterPath: "/get-with-references/{global_argument}/{argument}"}, wrapper.GetWithReferences)
+	register(runtime.Route{OperationID: "GetWithContentType", Method: "GET", Path: "/get-with-type/{content_type}", RouterPath: "/get-with-type/{content_type}"}, wrapper.GetWithContentType)
+	register(runtime.Route{OperationID: "GetReservedKeyword", Method: "GET", Path: "/reserved-keyword", RouterPath: "/reserved-keyword"}, wrapper.GetReservedKeyword)
+	register(runtime.Route{OperationID: "CreateResource", Method: "POST", Path: "/resource/{argument}", RouterPath: "/resource/{argument}"}, wrapper.CreateResource)
+	register(runtime.Route{OperationID: "CreateResource2", Method: "POST", Path: "/resource2/{inline_argument}", RouterPath: "/resource2/{inline_argument}"}, wrapper.CreateResource2)
+	register(runtime.Route{OperationID: "UpdateResource3", Method: "PUT", Path: "/resource3/{fallthrough}", RouterPath: "/resource3/{fallthrough}"}, wrapper.UpdateResource3)
+	register(runtime.Route{OperationID: "GetResponseWithReference", Method: "GET", Path: "/response-with-reference", RouterPath: "/response-with-reference"}, wrapper.GetResponseWithReference)
 
 	return r
 }
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 )
 
 func TestParameters(t *testing.T) {
@@ -46,6 +49,32 @@ func TestErrorHandlerFunc(t *testing.T) {
 	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
 }
 
+func TestBindErrorTranslator(t *testing.T) {
+	m := ServerInterfaceMock{}
+
+	var bindErr *runtime.BindError
+	h := HandlerWithOptions(&m, ChiServerOptions{
+		BindErrorTranslator: func(err *runtime.BindError) error {
+			bindErr = err
+			return errors.New("translated")
+		},
+		ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+	})
+
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	rsp, err := http.DefaultClient.Get(s.URL + "/get-with-args")
+	require.NoError(t, err)
+	b, _ := ioutil.ReadAll(rsp.Body)
+	assert.Equal(t, "translated\n", string(b))
+	require.NotNil(t, bindErr)
+	assert.Equal(t, "required_argument", bindErr.ParamName)
+	assert.Equal(t, runtime.BindErrorRequired, bindErr.Kind)
+}
+
 func TestErrorHandlerFuncBackwardsCompatible(t *testing.T) {
 	m := ServerInterfaceMock{}
 
@@ -60,3 +89,52 @@ func TestErrorHandlerFuncBackwardsCompatible(t *testing.T) {
 	assert.Equal(t, "text/plain; charset=utf-8", req.Header.Get("Content-Type"))
 	assert.Equal(t, "Query argument required_argument is required, but not found\n", string(b))
 }
+
+func TestRouteOptions(t *testing.T) {
+	m := ServerInterfaceMock{}
+	m.GetWithReferencesFunc = func(w http.ResponseWriter, r *http.Request, globalArgument int64, argument Argument) {}
+
+	routes := map[string]runtime.Route{}
+	h := HandlerWithOptions(&m, ChiServerOptions{
+		BaseURL: "/api",
+		RouteMiddlewares: func(route runtime.Route) []func(http.Handler) http.Handler {
+			return []func(http.Handler) http.Handler{
+				func(next http.Handler) http.Handler {
+					return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("X-Route", route.Name)
+						next.ServeHTTP(w, r)
+					})
+				},
+			}
+		},
+		RouteName: func(route runtime.Route) string {
+			return "api." + route.OperationID
+		},
+		OnRoute: func(route runtime.Route) {
+			routes[route.Name] = route
+		},
+	})
+
+	route, found := routes["api.GetWithReferences"]
+	require.True(t, found)
+	assert.Equal(t, runtime.Route{
+		OperationID: "GetWithReferences",
+		Name:        "api.GetWithReferences",
+		Method:      "GET",
+		BaseURL:     "/api",
+		Path:        "/get-with-references/{global_argument}/{argument}",
+		RouterPath:  "/api/get-with-references/{global_argument}/{argument}",
+	}, route)
+	assert.Len(t, routes, 10)
+
+	path, err := route.Reverse(int64(1), "x")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/get-with-references/1/x", path)
+
+	req := httptest.NewRequest("GET", path, nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "api.GetWithReferences", rr.Header().Get("X-Route"))
+	assert.Equal(t, 1, len(m.GetWithReferencesCalls()))
+}
@@ -0,0 +1,84 @@
+package codegen
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ParamsBuilderDefinition describes the constructor and the With methods of
+// the Params type of an operation.
+type ParamsBuilderDefinition struct {
+	OperationId string
+	TypeName    string
+	Setters     []ParamsSetterDefinition
+}
+
+// ParamsSetterDefinition describes the With method which sets a parameter.
+type ParamsSetterDefinition struct {
+	FieldName string // The field of the parameter in the Params type
+	ParamName string
+	GoType    string // The type of the values of the parameter
+	Pointer   bool   // Whether the field points to the value
+	Check     string // The statements which check v, the value, if it has constraints
+}
+
+// GenerateParamsBuilders generates constructors for the Params types of the
+// operations, and With methods which set their parameters, check the values
+// against the constraints of their schemas, like Validate methods do, and
+// keep the errors for BuildError, which the clients call.
+func GenerateParamsBuilders(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, opts Options) (string, error) {
+	return newSpecGenerator(swagger, opts).GenerateParamsBuilders(t, swagger, ops)
+}
+
+func (g *generator) GenerateParamsBuilders(t *template.Template, swagger *openapi3.T, ops []OperationDefinition) (string, error) {
+	// The values of types with Validate methods are checked by them, and
+	// the others from the schemas of the parameters.
+	var types []TypeDefinition
+	if g.opts.ValidateMethods {
+		var err error
+		types, err = g.generatedTypeDefinitions(t, swagger, ops, g.opts.ExcludeSchemas)
+		if err != nil {
+			return "", err
+		}
+	}
+	v := newValidator(g, types, g.opts.AliasTypes)
+	v.patternsVar = "paramsPatterns"
+
+	var defs []ParamsBuilderDefinition
+	for _, op := range ops {
+		if !op.RequiresParamObject() {
+			continue
+		}
+		typeName := op.OperationId + "Params"
+		def := ParamsBuilderDefinition{
+			OperationId: op.OperationId,
+			TypeName:    typeName,
+		}
+		for _, td := range op.TypeDefinitions {
+			if td.TypeName != typeName {
+				continue
+			}
+			for _, p := range td.Schema.Properties {
+				setter := ParamsSetterDefinition{
+					FieldName: p.GoFieldName(),
+					ParamName: p.JsonFieldName,
+					GoType:    p.Schema.TypeDecl(),
+					Pointer:   strings.HasPrefix(p.GoTypeDef(), "*"),
+				}
+				if !strings.HasPrefix(setter.GoType, "runtime.") && v.hasConstraints(p.Schema, p.spec, map[string]bool{}) {
+					var w strings.Builder
+					v.value(&w, "v", []pathElem{{name: p.JsonFieldName}}, p.Schema, p.spec, 0)
+					setter.Check = strings.TrimSpace(w.String())
+				}
+				def.Setters = append(def.Setters, setter)
+			}
+		}
+		defs = append(defs, def)
+	}
+	return GenerateTemplates([]string{"params-builders.tmpl"}, t, struct {
+		Patterns []string
+		Types    []ParamsBuilderDefinition
+	}{*v.patterns, defs})
+}
@@ -17,7 +17,9 @@ package codegen
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"runtime/debug"
 	"sort"
 	"strings"
@@ -31,20 +33,166 @@ import (
 
 // Options defines the optional code to generate.
 type Options struct {
-	GenerateChiServer  bool              // GenerateChiServer specifies whether to generate chi server boilerplate
-	GenerateEchoServer bool              // GenerateEchoServer specifies whether to generate echo server boilerplate
-	GenerateGinServer  bool              // GenerateGinServer specifies whether to generate echo server boilerplate
-	GenerateClient     bool              // GenerateClient specifies whether to generate client boilerplate
-	GenerateTypes      bool              // GenerateTypes specifies whether to generate type definitions
-	EmbedSpec          bool              // Whether to embed the swagger spec in the generated code
-	SkipFmt            bool              // Whether to skip go imports on the generated code
-	SkipPrune          bool              // Whether to skip pruning unused components on the generated code
-	AliasTypes         bool              // Whether to alias types if possible
-	IncludeTags        []string          // Only include operations that have one of these tags. Ignored when empty.
-	ExcludeTags        []string          // Exclude operations that have one of these tags. Ignored when empty.
-	UserTemplates      map[string]string // Override built-in templates from user-provided files
-	ImportMapping      map[string]string // ImportMapping specifies the golang package path for each external reference
-	ExcludeSchemas     []string          // Exclude from generation schemas with given names. Ignored when empty.
+	PackageName             string            // PackageName is the package of the generated code, used by Generate
+	GenerateChiServer       bool              // GenerateChiServer specifies whether to generate chi server boilerplate
+	GenerateEchoServer      bool              // GenerateEchoServer specifies whether to generate echo server boilerplate
+	GenerateGinServer       bool              // GenerateGinServer specifies whether to generate echo server boilerplate
+	GenerateIrisServer      bool              // GenerateIrisServer specifies whether to generate iris v12 server boilerplate, which can't be combined with another server
+	GenerateEcho5Server     bool              // GenerateEcho5Server specifies whether to generate echo v5 server boilerplate, which can't be combined with another server
+	ServerRouters           []ServerRouter    // ServerRouters are routers, besides the built in ones, to generate servers for
+	ChiServerContext        bool              // ChiServerContext makes the chi server handlers take the request context as their first argument
+	GenerateServerResponses bool              // GenerateServerResponses specifies whether to generate the types of the responses sent by servers
+	ServerRecovery          bool              // ServerRecovery makes the server wrappers recover from handler panics, and respond to them per operation
+	ServerDeadlines         bool              // ServerDeadlines makes the server wrappers give the handlers the deadline of their x-timeout, see the README for how each router enforces it
+	ParamErrorResponses     bool              // ParamErrorResponses makes the server wrappers respond to missing required parameters with the operations' 400 response schema
+	GenerateClient          bool              // GenerateClient specifies whether to generate client boilerplate
+	GenerateLazyClient      bool              // GenerateLazyClient specifies whether to generate a client returning responses decoded on demand, it requires the client
+	GenerateURLs            bool              // GenerateURLs specifies whether to generate route constants and URL builders, which the client always gets
+	GenerateTypes           bool              // GenerateTypes specifies whether to generate type definitions
+	EmbedSpec               bool              // Whether to embed the swagger spec in the generated code
+	SkipFmt                 bool              // Whether to skip go imports on the generated code
+	SkipPrune               bool              // Whether to skip pruning unused components on the generated code
+	PruneUnreachable        bool              // Whether to prune all components which aren't reachable from an operation
+	AliasTypes              bool              // Whether to alias types if possible
+	IncludeTags             []string          // Only include operations that have one of these tags. Ignored when empty.
+	ExcludeTags             []string          // Exclude operations that have one of these tags. Ignored when empty.
+	UserTemplates           map[string]string // Override built-in templates from user-provided files
+	ImportMapping           map[string]string // ImportMapping specifies the golang package path for each external reference
+	ExcludeSchemas          []string          // Exclude from generation schemas with given names. Ignored when empty.
+	ExcludeDeprecated       bool              // Exclude operations which are marked as deprecated
+
+	// OperationIDCasing is the casing scheme of the operation IDs which are
+	// synthesized for operations which don't have one: "pascal" (the
+	// default), "camel", "snake" or "kebab".
+	OperationIDCasing string
+
+	// NameCollisionStrategy is how operations which end up with the same Go
+	// name are resolved: "fail" (the default), "method" or "path".
+	NameCollisionStrategy string
+
+	EnumStringer bool // Whether to generate String methods for enums
+	EnumText     bool // Whether to generate MarshalText and UnmarshalText methods for enums, which reject unknown values
+	EnumSQL      bool // Whether to generate sql.Scanner and driver.Valuer implementations for enums, which reject unknown values
+
+	YAMLTags         bool // Whether to add yaml tags, matching the json ones, to the fields of generated types
+	MapstructureTags bool // Whether to add mapstructure tags, matching the json ones, to the fields of generated types
+
+	DeepCopy bool // Whether to generate DeepCopy and DeepCopyInto methods for generated types
+
+	// JSONPackage is the import path of the package which the generated code
+	// uses for JSON, instead of encoding/json, such as
+	// github.com/goccy/go-json. It's imported as json, so it must provide the
+	// Marshal, Unmarshal, NewEncoder and RawMessage of encoding/json.
+	JSONPackage string
+
+	// JSONContentTypes are the content types, besides application/json and
+	// the ones with a +json structured suffix, whose bodies are handled as
+	// JSON, eg, "text/json".
+	JSONContentTypes []string
+
+	// ReadWriteModels generates a request and a response variant of the
+	// schemas with readOnly or writeOnly properties, eg, PetRequest and
+	// PetResponse, without them, which the operations send and receive.
+	ReadWriteModels bool
+
+	// NullableType makes the nullable fields of the generated types
+	// runtime.Nullable values, which tell null values from absent ones,
+	// rather than pointers. With a JSONPackage, they're
+	// values of a Nullable type generated along with the types instead,
+	// which encodes them with that package.
+	NullableType bool
+
+	// OptionalValues makes the optional fields of the generated types plain
+	// values, tagged omitempty, rather than pointers, when omitempty omits
+	// their zero value, which it doesn't for structs. The fields with the
+	// x-go-type-skip-optional-pointer extension are values, or pointers,
+	// as it says.
+	OptionalValues bool
+
+	// TimeFormats are the layouts of the times of string, or integer,
+	// schemas, by their format, eg, "yyyymmdd": "20060102". Like the ones
+	// with the x-go-time-format extension, these schemas are types which
+	// embed a time.Time and encode it in the layout. Besides the layouts of
+	// time.Format, "unix" and "unix-millis" encode times as the number of
+	// seconds, or milliseconds, since the Unix epoch.
+	TimeFormats map[string]string
+
+	// ByteEncoding is the base64 encoding of the strings with the byte
+	// format: ByteEncodingStd, the default, makes them
+	// openapi_types.Base64, and ByteEncodingURL openapi_types.Base64URL,
+	// which are []byte encoded in standard, or URL-safe, base64 as JSON,
+	// parameters and form fields.
+	ByteEncoding string
+
+	// TypeMappings are the Go types of the schemas with a type and format,
+	// such as "string/decimal", or a type without a format, such as
+	// "integer". They take precedence over the built in types, such as
+	// int32 for "integer/int32", but not over x-go-type. Strings with the
+	// uuid format are mapped to the UUID of github.com/google/uuid by
+	// default, which "string/uuid" overrides, eg, with another package's
+	// UUID, or with "string" for plain strings.
+	TypeMappings map[string]TypeMapping
+
+	// ApplyDefaults generates ApplyDefaults methods for the types whose
+	// fields have schemas which declare defaults, which set the absent
+	// fields to them. The servers call them on the parameters they bind,
+	// and the clients on the JSON responses they decode. Handlers call
+	// them, or runtime.ApplyDefaults, on the bodies they decode.
+	ApplyDefaults bool
+
+	// ValidateTags adds validate tags, for github.com/go-playground/validator,
+	// to the fields of the generated types, which check the constraints of
+	// their schemas, such as minLength, maximum or enum.
+	ValidateTags bool
+
+	// ValidateMethods generates Validate methods for the types whose schemas
+	// have constraints, which check the lengths and patterns of strings,
+	// the bounds of numbers, enums, the numbers of items of arrays, and
+	// required fields, and return a *runtime.ValidationError with the path
+	// of the invalid value. The servers call them on the parameters they
+	// bind, and respond with a 400 when they fail. Handlers call them, or
+	// runtime.Validate, on the bodies they decode.
+	ValidateMethods bool
+
+	// ValidateClientRequests makes the clients validate the parameters and
+	// JSON bodies of their requests, before sending them, when they have
+	// Validate methods.
+	ValidateClientRequests bool
+
+	// ParamsBuilders generates New...Params constructors for the Params
+	// types of the operations, and With methods which set their parameters,
+	// such as WithLimit, and check the values against the constraints of
+	// their schemas. The errors of invalid values are kept, and returned by
+	// BuildError, which the New...Request functions of the clients fail
+	// with.
+	ParamsBuilders bool
+
+	// EmbedSpecFile is the name of a file, in the directory of the generated
+	// code, which the spec is embedded from with go:embed, rather than
+	// inlined as a gzipped, base64 encoded string. The file holds the spec
+	// as JSON, which is among the Artifacts.Files of Generate, and which the
+	// command writes next to the output file. It requires Go 1.16.
+	EmbedSpecFile string
+
+	// DocsUI generates the ServeSpec and ServeDocs handlers, which serve the
+	// embedded spec as JSON, and a documentation page of it, which is
+	// "swagger-ui" or "redoc". The servers register them at openapi.json and
+	// docs, under their base URL. It requires EmbedSpec.
+	DocsUI string
+
+	// TypesPackage is the import path of the package which the types of the
+	// spec are generated into, by another generation with GenerateTypes.
+	// The code generated without GenerateTypes imports it, and aliases its
+	// types and constants, rather than declaring them, so that the types,
+	// the client and the server can be generated into separate packages.
+	TypesPackage string
+
+	// RegionMarkers delimits the sections of the generated code, such as
+	// the types, the client and the server, and the handlers of the
+	// operations within the server, with region markers, eg,
+	// "//oapi-codegen:region types". UpdateRegions rewrites only the
+	// regions which change when the code is generated again.
+	RegionMarkers bool
 }
 
 // goImport represents a go package to be imported in the generated code
@@ -73,8 +221,6 @@ func (im importMap) GoImports() []string {
 	return goImports
 }
 
-var importMapping importMap
-
 func constructImportMapping(input map[string]string) importMap {
 	var (
 		pathToName = map[string]string{}
@@ -100,25 +246,138 @@ func constructImportMapping(input map[string]string) importMap {
 	return result
 }
 
-// Uses the Go templating engine to generate all of our server wrappers from
-// the descriptions we've built up above from the schema objects.
-// opts defines
-func Generate(swagger *openapi3.T, packageName string, opts Options) (string, error) {
-	importMapping = constructImportMapping(opts.ImportMapping)
+// Artifacts is what Generate produces.
+type Artifacts struct {
+	// Code is the generated Go file.
+	Code string
+
+	// Files are the other files which the code needs, by their names in its
+	// directory, such as the spec which it embeds from Options.EmbedSpecFile.
+	Files map[string][]byte
+}
+
+// Generate generates the Go code for a spec, as configured by opts. Besides
+// the code, it returns diagnostics for the constructs in the spec which are
+// generated in a degraded form, or ignored, like Lint does. Generation stops
+// with the context's error when ctx is done. Like the command does, Generate
+// may modify the spec, eg, when pruning unused components. It neither panics
+// nor writes to stdout or stderr, so it's suitable for embedding the
+// generator in other tools.
+func Generate(ctx context.Context, swagger *openapi3.T, opts Options) (Artifacts, []Diagnostic, error) {
+	diagnostics := lintSpec(swagger, opts).diagnostics
+
+	var out strings.Builder
+	if err := generateTo(ctx, &out, swagger, opts.PackageName, opts); err != nil {
+		return Artifacts{}, diagnostics, err
+	}
+	artifacts := Artifacts{Code: out.String()}
+	if opts.EmbedSpec && opts.EmbedSpecFile != "" {
+		spec, err := EmbeddedSpec(swagger)
+		if err != nil {
+			return Artifacts{}, diagnostics, err
+		}
+		artifacts.Files = map[string][]byte{opts.EmbedSpecFile: spec}
+	}
+	return artifacts, diagnostics, nil
+}
+
+// outputSection generates one part of the output file, such as the client, or
+// the server for one of the routers.
+type outputSection func(w io.Writer) error
 
+// GenerateTo generates the code like Generate does, but writes it to w. Unless
+// formatting is skipped, the whole file has to be held in memory to run
+// goimports on it. When it is skipped, the output of the templates is streamed
+// to w, so memory use doesn't grow with the size of the output.
+func GenerateTo(w io.Writer, swagger *openapi3.T, packageName string, opts Options) error {
+	return generateTo(context.Background(), w, swagger, packageName, opts)
+}
+
+// filterSpec removes the operations and components which aren't generated
+// with the given options from the spec.
+func filterSpec(swagger *openapi3.T, opts Options) error {
 	filterOperationsByTag(swagger, opts)
-	if !opts.SkipPrune {
-		pruneUnusedComponents(swagger)
+	filterDeprecatedOperations(swagger, opts)
+	if opts.SkipPrune {
+		return nil
+	}
+	if err := pruneUnusedComponents(swagger); err != nil {
+		return fmt.Errorf("error pruning unused components: %w", err)
 	}
+	if opts.PruneUnreachable {
+		if err := pruneUnreachableComponents(swagger); err != nil {
+			return fmt.Errorf("error pruning unreachable components: %w", err)
+		}
+	}
+	return nil
+}
 
-	// This creates the golang templates text package
-	TemplateFunctions["opts"] = func() Options { return opts }
-	t := template.New("oapi-codegen").Funcs(TemplateFunctions)
+func generateTo(ctx context.Context, w io.Writer, swagger *openapi3.T, packageName string, opts Options) error {
+	if err := checkByteEncoding(opts.ByteEncoding); err != nil {
+		return err
+	}
+	if err := checkTypeMappings(opts.TypeMappings); err != nil {
+		return err
+	}
+	if err := checkDocsUI(opts); err != nil {
+		return err
+	}
+	if err := checkTypesPackage(opts); err != nil {
+		return err
+	}
+	if opts.EmbedSpecFile != "" && !embedFileName.MatchString(opts.EmbedSpecFile) {
+		return fmt.Errorf("embed spec file %q: expected the name of a file in the directory of the generated code", opts.EmbedSpecFile)
+	}
+	if err := filterSpec(swagger, opts); err != nil {
+		return err
+	}
+	g := newSpecGenerator(swagger, opts)
+
+	routers, err := enabledServerRouters(opts)
+	if err != nil {
+		return err
+	}
+
+	// This creates the golang templates text package.
+	funcs := template.FuncMap{}
+	for name, fn := range TemplateFunctions {
+		funcs[name] = fn
+	}
+	for _, r := range routers {
+		for name, fn := range r.Funcs {
+			funcs[name] = fn
+		}
+	}
+	funcs["opts"] = func() Options { return opts }
+	// The regions within the sections, such as the handlers of operations
+	funcs["region"] = func(name ...string) string {
+		if !opts.RegionMarkers {
+			return ""
+		}
+		return regionStart(strings.Join(name, " "))
+	}
+	funcs["endregion"] = func(name ...string) string {
+		if !opts.RegionMarkers {
+			return ""
+		}
+		return regionEnd(strings.Join(name, " "))
+	}
+	// The types which have Validate methods are known with the operations.
+	var validated map[string]bool
+	funcs["hasValidateMethod"] = func(typeName string) bool { return validated[typeName] }
+	t := template.New("oapi-codegen").Funcs(funcs)
 	// This parses all of our own template files into the template object
 	// above
-	t, err := templates.Parse(t)
+	t, err = templates.Parse(t)
 	if err != nil {
-		return "", fmt.Errorf("error parsing oapi-codegen templates: %w", err)
+		return fmt.Errorf("error parsing oapi-codegen templates: %w", err)
+	}
+	for _, r := range routers {
+		for name, source := range r.Sources {
+			if _, err := t.New(name).Parse(source); err != nil {
+				return fmt.Errorf("error parsing template %q of router %s: %w", name, r.Name, err)
+			}
+		}
 	}
 
 	// Override built-in templates with user-provided versions
@@ -126,187 +385,272 @@ func Generate(swagger *openapi3.T, packageName string, opts Options) (string, er
 		if _, ok := opts.UserTemplates[tpl.Name()]; ok {
 			utpl := t.New(tpl.Name())
 			if _, err := utpl.Parse(opts.UserTemplates[tpl.Name()]); err != nil {
-				return "", fmt.Errorf("error parsing user-provided template %q: %w", tpl.Name(), err)
+				return fmt.Errorf("error parsing user-provided template %q: %w", tpl.Name(), err)
 			}
 		}
 	}
 
-	ops, err := OperationDefinitions(swagger)
+	g.synthesizedNames, err = synthesizeOperationIDs(swagger, opts.OperationIDCasing)
 	if err != nil {
-		return "", fmt.Errorf("error creating operation definitions: %w", err)
+		return err
 	}
 
-	var typeDefinitions, constantDefinitions string
-	if opts.GenerateTypes {
-		typeDefinitions, err = GenerateTypeDefinitions(t, swagger, ops, opts.ExcludeSchemas)
-		if err != nil {
-			return "", fmt.Errorf("error generating type definitions: %w", err)
-		}
-
-		constantDefinitions, err = GenerateConstants(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating constants: %w", err)
-		}
-
+	err = resolveOperationNameCollisions(swagger, opts.NameCollisionStrategy, g.synthesizedNames)
+	if err != nil {
+		return err
 	}
 
-	var echoServerOut string
-	if opts.GenerateEchoServer {
-		echoServerOut, err = GenerateEchoServer(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
+	ops, err := g.OperationDefinitions(swagger)
+	if err != nil {
+		return fmt.Errorf("error creating operation definitions: %w", err)
 	}
-
-	var chiServerOut string
-	if opts.GenerateChiServer {
-		chiServerOut, err = GenerateChiServer(t, ops)
+	if resolveTypeNameCollisions(ops, opts.NameCollisionStrategy, g.synthesizedNames) {
+		// The renamed operations may collide with others in turn
+		err = resolveOperationNameCollisions(swagger, opts.NameCollisionStrategy, g.synthesizedNames)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return err
 		}
-	}
-
-	var ginServerOut string
-	if opts.GenerateGinServer {
-		ginServerOut, err = GenerateGinServer(t, ops)
+		ops, err = g.OperationDefinitions(swagger)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return fmt.Errorf("error creating operation definitions: %w", err)
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
-	var clientOut string
-	if opts.GenerateClient {
-		clientOut, err = GenerateClient(t, ops)
+	if opts.ValidateMethods {
+		validated, err = g.validatedTypeNames(t, swagger, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating client: %w", err)
+			return err
 		}
 	}
 
-	var clientWithResponsesOut string
-	if opts.GenerateClient {
-		clientWithResponsesOut, err = GenerateClientWithResponses(t, ops)
-		if err != nil {
-			return "", fmt.Errorf("error generating client with responses: %w", err)
+	sections := g.outputSections(t, swagger, ops, routers, packageName, opts)
+
+	if opts.SkipFmt {
+		bw := bufio.NewWriter(w)
+		stripper := &bomStripper{w: bw}
+		for _, section := range sections {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := section(stripper); err != nil {
+				return err
+			}
+		}
+		if err := stripper.Flush(); err != nil {
+			return fmt.Errorf("error writing generated code: %w", err)
+		}
+		if err := bw.Flush(); err != nil {
+			return fmt.Errorf("error writing generated code: %w", err)
 		}
+		return nil
 	}
 
-	var inlinedSpec string
-	if opts.EmbedSpec {
-		inlinedSpec, err = GenerateInlinedSpec(t, importMapping, swagger)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+	// The sections don't depend on each other, so they are generated
+	// concurrently, and assembled in a fixed order.
+	outputs := make([]bytes.Buffer, len(sections))
+	err = parallelFor(len(sections), func(i int) error {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
+		stripper := &bomStripper{w: &outputs[i]}
+		if err := sections[i](stripper); err != nil {
+			return err
+		}
+		return stripper.Flush()
+	})
+	if err != nil {
+		return err
 	}
 
+	// goimports needs the whole file, which is assembled in a buffer of its
+	// final size, rather than one grown by copying.
 	var buf bytes.Buffer
-	w := bufio.NewWriter(&buf)
-
-	externalImports := importMapping.GoImports()
-	importsOut, err := GenerateImports(t, externalImports, packageName)
-	if err != nil {
-		return "", fmt.Errorf("error generating imports: %w", err)
+	size := 0
+	for i := range outputs {
+		size += outputs[i].Len()
+	}
+	buf.Grow(size)
+	for i := range outputs {
+		buf.Write(outputs[i].Bytes())
+		// Let the section go as soon as it's copied
+		outputs[i] = bytes.Buffer{}
 	}
 
-	_, err = w.WriteString(importsOut)
+	// The generation code produces unindented horrors. Use the Go Imports
+	// to make it all pretty.
+	outBytes, err := imports.Process(packageName+".go", buf.Bytes(), nil)
 	if err != nil {
-		return "", fmt.Errorf("error writing imports: %w", err)
+		return fmt.Errorf("error formatting Go code: %w", err)
 	}
+	if _, err := w.Write(outBytes); err != nil {
+		return fmt.Errorf("error writing generated code: %w", err)
+	}
+	return nil
+}
 
-	_, err = w.WriteString(constantDefinitions)
-	if err != nil {
-		return "", fmt.Errorf("error writing constants: %w", err)
+// outputSections returns the sections of the output file, in the order in
+// which they are written.
+func (g *generator) outputSections(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, routers []ServerRouter, packageName string, opts Options) []outputSection {
+	// inRegion delimits the output of the section with the markers of the
+	// region with the given name
+	inRegion := func(name string, section outputSection) outputSection {
+		if !opts.RegionMarkers {
+			return section
+		}
+		return func(w io.Writer) error {
+			if _, err := io.WriteString(w, regionStart(name)); err != nil {
+				return err
+			}
+			if err := section(w); err != nil {
+				return err
+			}
+			_, err := io.WriteString(w, regionEnd(name))
+			return err
+		}
+	}
+	// stringSection adapts the generators which return their output as a string
+	stringSection := func(name string, generate func() (string, error), errorMessage string) outputSection {
+		return inRegion(name, func(w io.Writer) error {
+			out, err := generate()
+			if err != nil {
+				return fmt.Errorf("%s: %w", errorMessage, err)
+			}
+			_, err = io.WriteString(w, out)
+			return err
+		})
+	}
+	// templatesSection executes templates straight into the output
+	templatesSection := func(name string, templates []string, data interface{}, errorMessage string) outputSection {
+		return inRegion(name, func(w io.Writer) error {
+			if err := GenerateTemplatesTo(w, templates, t, data); err != nil {
+				return fmt.Errorf("%s: %w", errorMessage, err)
+			}
+			return nil
+		})
 	}
 
-	_, err = w.WriteString(typeDefinitions)
-	if err != nil {
-		return "", fmt.Errorf("error writing type definitions: %w", err)
+	// The WebSocket operations get their own client and server code
+	httpOps, webSocketOps := splitWebSocketOperations(ops)
 
+	sections := []outputSection{
+		stringSection("imports", func() (string, error) {
+			imports := append(g.importMapping.GoImports(), typeMappingImports(opts.TypeMappings)...)
+			imports = append(imports, typesPackageImports(opts)...)
+			return GenerateImports(t, append(imports, serverRouterImports(routers)...), packageName)
+		}, "error generating imports"),
 	}
 
-	if opts.GenerateClient {
-		_, err = w.WriteString(clientOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing client: %w", err)
+	if opts.GenerateTypes {
+		sections = append(sections,
+			stringSection("constants", func() (string, error) {
+				return GenerateConstants(t, ops)
+			}, "error generating constants"),
+			stringSection("types", func() (string, error) {
+				return g.GenerateTypeDefinitions(t, swagger, ops, opts.ExcludeSchemas)
+			}, "error generating type definitions"))
+
+		if g.localNullable() {
+			sections = append(sections, stringSection("nullable", func() (string, error) {
+				return GenerateTemplates([]string{"nullable.tmpl"}, t, nil)
+			}, "error generating nullable type"))
 		}
-		_, err = w.WriteString(clientWithResponsesOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing client: %w", err)
+
+		if opts.DeepCopy {
+			sections = append(sections, stringSection("deep-copy", func() (string, error) {
+				return g.GenerateDeepCopy(t, swagger, ops)
+			}, "error generating deep copy methods"))
+		}
+		if opts.ApplyDefaults {
+			sections = append(sections, stringSection("defaults", func() (string, error) {
+				return g.GenerateDefaults(t, swagger, ops)
+			}, "error generating defaults methods"))
+		}
+		if opts.ValidateMethods {
+			sections = append(sections, stringSection("validate", func() (string, error) {
+				return g.GenerateValidate(t, swagger, ops)
+			}, "error generating validate methods"))
+		}
+		if opts.ParamsBuilders {
+			sections = append(sections, stringSection("params-builders", func() (string, error) {
+				return g.GenerateParamsBuilders(t, swagger, ops)
+			}, "error generating params builders"))
 		}
 	}
 
-	if opts.GenerateEchoServer {
-		_, err = w.WriteString(echoServerOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
-		}
+	if opts.TypesPackage != "" {
+		sections = append(sections, stringSection("type-aliases", func() (string, error) {
+			return g.GenerateTypeAliases(t, swagger, ops)
+		}, "error generating type aliases"))
 	}
 
-	if opts.GenerateChiServer {
-		_, err = w.WriteString(chiServerOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
-		}
+	if opts.GenerateClient || opts.GenerateURLs {
+		sections = append(sections, templatesSection("urls", urlsTemplates, ops, "error generating URL builders"))
 	}
 
-	if opts.GenerateGinServer {
-		_, err = w.WriteString(ginServerOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
-		}
+	if opts.GenerateClient {
+		sections = append(sections,
+			templatesSection("client", clientTemplates, httpOps, "error generating client"),
+			templatesSection("client-with-responses", clientWithResponsesTemplates, httpOps, "error generating client with responses"),
+			stringSection("server-urls", func() (string, error) {
+				return GenerateServerURLs(t, swagger)
+			}, "error generating server URLs"))
 	}
 
-	if opts.EmbedSpec {
-		_, err = w.WriteString(inlinedSpec)
-		if err != nil {
-			return "", fmt.Errorf("error writing inlined spec: %w", err)
-		}
+	if opts.GenerateLazyClient {
+		sections = append(sections, templatesSection("lazy-client", clientLazyTemplates, httpOps, "error generating client with lazy responses"))
 	}
 
-	err = w.Flush()
-	if err != nil {
-		return "", fmt.Errorf("error flushing output buffer: %w", err)
+	for _, r := range routers {
+		sections = append(sections, templatesSection(r.Name+"-server", r.Templates, httpOps, "error generating Go handlers for Paths"))
 	}
 
-	// remove any byte-order-marks which break Go-Code
-	goCode := SanitizeCode(buf.String())
+	if opts.GenerateServerResponses {
+		sections = append(sections, templatesSection("server-responses", serverResponsesTemplates, httpOps, "error generating server responses"))
+	}
 
-	// The generation code produces unindented horrors. Use the Go Imports
-	// to make it all pretty.
-	if opts.SkipFmt {
-		return goCode, nil
+	if opts.ParamErrorResponses && len(routers) > 0 {
+		sections = append(sections, templatesSection("param-errors", paramErrorsTemplates, httpOps, "error generating parameter error responses"))
 	}
 
-	outBytes, err := imports.Process(packageName+".go", []byte(goCode), nil)
-	if err != nil {
-		fmt.Println(goCode)
-		return "", fmt.Errorf("error formatting Go code: %w", err)
+	if len(webSocketOps) > 0 && (opts.GenerateClient || opts.GenerateEchoServer || opts.GenerateChiServer || opts.GenerateGinServer) {
+		sections = append(sections, templatesSection("websockets", webSocketTemplates, webSocketOps, "error generating WebSockets"))
 	}
-	return string(outBytes), nil
-}
 
-func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, excludeSchemas []string) (string, error) {
-	schemaTypes, err := GenerateTypesForSchemas(t, swagger.Components.Schemas, excludeSchemas)
-	if err != nil {
-		return "", fmt.Errorf("error generating Go types for component schemas: %w", err)
+	if opts.EmbedSpec {
+		sections = append(sections, stringSection("spec", func() (string, error) {
+			return generateInlinedSpec(t, g.importMapping, swagger, opts.EmbedSpecFile)
+		}, "error generating Go handlers for Paths"))
 	}
 
-	paramTypes, err := GenerateTypesForParameters(t, swagger.Components.Parameters)
-	if err != nil {
-		return "", fmt.Errorf("error generating Go types for component parameters: %w", err)
+	if opts.DocsUI != "" {
+		sections = append(sections, stringSection("docs", func() (string, error) {
+			return GenerateDocs(t, swagger, opts)
+		}, "error generating docs handlers"))
 	}
-	allTypes := append(schemaTypes, paramTypes...)
 
-	responseTypes, err := GenerateTypesForResponses(t, swagger.Components.Responses)
+	return sections
+}
+
+func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, excludeSchemas []string) (string, error) {
+	return defaultGenerator().GenerateTypeDefinitions(t, swagger, ops, excludeSchemas)
+}
+
+func (g *generator) GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, excludeSchemas []string) (string, error) {
+	allTypes, err := g.componentTypeDefinitions(t, swagger, excludeSchemas)
 	if err != nil {
-		return "", fmt.Errorf("error generating Go types for component responses: %w", err)
+		return "", err
 	}
-	allTypes = append(allTypes, responseTypes...)
 
-	bodyTypes, err := GenerateTypesForRequestBodies(t, swagger.Components.RequestBodies)
-	if err != nil {
-		return "", fmt.Errorf("error generating Go types for component request bodies: %w", err)
+	opTypes := append([]TypeDefinition{}, allTypes...)
+	for _, op := range ops {
+		opTypes = append(opTypes, op.TypeDefinitions...)
+	}
+	if err := checkTypeNameCollisions(opTypes); err != nil {
+		return "", err
 	}
-	allTypes = append(allTypes, bodyTypes...)
 
 	paramTypesOut, err := GenerateTypesForOperations(t, ops)
 	if err != nil {
@@ -328,10 +672,74 @@ func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []Op
 		return "", fmt.Errorf("error generating allOf boilerplate: %w", err)
 	}
 
-	typeDefinitions := strings.Join([]string{enumsOut, typesOut, paramTypesOut, allOfBoilerplate}, "")
+	timeFormatBoilerplate, err := GenerateTimeFormatBoilerplate(t, allTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating time format boilerplate: %w", err)
+	}
+
+	typeDefinitions := strings.Join([]string{enumsOut, typesOut, paramTypesOut, allOfBoilerplate, timeFormatBoilerplate}, "")
 	return typeDefinitions, nil
 }
 
+// componentTypeDefinitions returns the types defined by the components of the
+// spec: its schemas, parameters, responses and request bodies.
+func (g *generator) componentTypeDefinitions(t *template.Template, swagger *openapi3.T, excludeSchemas []string) ([]TypeDefinition, error) {
+	schemaTypes, err := g.GenerateTypesForSchemas(t, swagger.Components.Schemas, excludeSchemas)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Go types for component schemas: %w", err)
+	}
+
+	variantTypes, err := g.generateReadWriteModelTypes(excludeSchemas)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Go types for request and response models: %w", err)
+	}
+	schemaTypes = append(schemaTypes, variantTypes...)
+
+	paramTypes, err := g.GenerateTypesForParameters(t, swagger.Components.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Go types for component parameters: %w", err)
+	}
+	allTypes := append(schemaTypes, paramTypes...)
+
+	responseTypes, err := g.GenerateTypesForResponses(t, swagger.Components.Responses)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Go types for component responses: %w", err)
+	}
+	allTypes = append(allTypes, responseTypes...)
+
+	bodyTypes, err := g.GenerateTypesForRequestBodies(t, swagger.Components.RequestBodies)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Go types for component request bodies: %w", err)
+	}
+	return append(allTypes, bodyTypes...), nil
+}
+
+// generatedTypeDefinitions returns the types defined by the components and
+// the operations, including the request bodies, without duplicates, in
+// order.
+func (g *generator) generatedTypeDefinitions(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, excludeSchemas []string) ([]TypeDefinition, error) {
+	types, err := g.componentTypeDefinitions(t, swagger, excludeSchemas)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		types = append(types, op.TypeDefinitions...)
+		for _, body := range op.Bodies {
+			types = append(types, *body.TypeDef(op.OperationId))
+		}
+	}
+
+	var unique []TypeDefinition
+	seen := map[string]bool{}
+	for _, td := range types {
+		if !seen[td.TypeName] {
+			seen[td.TypeName] = true
+			unique = append(unique, td)
+		}
+	}
+	return unique, nil
+}
+
 // Generates operation ids, context keys, paths, etc. to be exported as constants
 func GenerateConstants(t *template.Template, ops []OperationDefinition) (string, error) {
 	constants := Constants{
@@ -361,30 +769,50 @@ func GenerateConstants(t *template.Template, ops []OperationDefinition) (string,
 // Generates type definitions for any custom types defined in the
 // components/schemas section of the Swagger spec.
 func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.SchemaRef, excludeSchemas []string) ([]TypeDefinition, error) {
+	return defaultGenerator().GenerateTypesForSchemas(t, schemas, excludeSchemas)
+}
+
+func (g *generator) GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.SchemaRef, excludeSchemas []string) ([]TypeDefinition, error) {
 	var excludeSchemasMap = make(map[string]bool)
 	for _, schema := range excludeSchemas {
 		excludeSchemasMap[schema] = true
 	}
-	types := make([]TypeDefinition, 0)
-	// We're going to define Go types for every object under components/schemas
+	var schemaNames []string
 	for _, schemaName := range SortedSchemaKeys(schemas) {
 		if _, ok := excludeSchemasMap[schemaName]; ok {
 			continue
 		}
+		schemaNames = append(schemaNames, schemaName)
+	}
+
+	// We're going to define Go types for every object under components/schemas.
+	// Schemas are independent of each other, so this happens concurrently.
+	schemaTypes := make([][]TypeDefinition, len(schemaNames))
+	err := parallelFor(len(schemaNames), func(i int) error {
+		schemaName := schemaNames[i]
 		schemaRef := schemas[schemaName]
 
-		goSchema, err := GenerateGoSchema(schemaRef, []string{schemaName})
+		// Component schemas are already named, so x-go-type-name doesn't
+		// apply to them.
+		goSchema, err := g.generateGoSchema(schemaRef, []string{schemaName})
 		if err != nil {
-			return nil, fmt.Errorf("error converting Schema %s to Go type: %w", schemaName, err)
+			return fmt.Errorf("error converting Schema %s to Go type: %w", schemaName, err)
 		}
 
-		types = append(types, TypeDefinition{
+		schemaTypes[i] = append([]TypeDefinition{{
 			JsonName: schemaName,
 			TypeName: SchemaNameToTypeName(schemaName),
 			Schema:   goSchema,
-		})
+		}}, goSchema.GetAdditionalTypeDefs()...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		types = append(types, goSchema.GetAdditionalTypeDefs()...)
+	types := make([]TypeDefinition, 0)
+	for _, t := range schemaTypes {
+		types = append(types, t...)
 	}
 	return types, nil
 }
@@ -392,11 +820,15 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.
 // Generates type definitions for any custom types defined in the
 // components/parameters section of the Swagger spec.
 func GenerateTypesForParameters(t *template.Template, params map[string]*openapi3.ParameterRef) ([]TypeDefinition, error) {
+	return defaultGenerator().GenerateTypesForParameters(t, params)
+}
+
+func (g *generator) GenerateTypesForParameters(t *template.Template, params map[string]*openapi3.ParameterRef) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 	for _, paramName := range SortedParameterKeys(params) {
 		paramOrRef := params[paramName]
 
-		goType, err := paramToGoType(paramOrRef.Value, nil)
+		goType, err := g.paramToGoType(paramOrRef.Value, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error generating Go type for schema in parameter %s: %w", paramName, err)
 		}
@@ -409,7 +841,7 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 
 		if paramOrRef.Ref != "" {
 			// Generate a reference type for referenced parameters
-			refType, err := RefPathToGoType(paramOrRef.Ref)
+			refType, err := g.RefPathToGoType(paramOrRef.Ref)
 			if err != nil {
 				return nil, fmt.Errorf("error generating Go type for (%s) in parameter %s: %w", paramOrRef.Ref, paramName, err)
 			}
@@ -424,6 +856,10 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 // Generates type definitions for any custom types defined in the
 // components/responses section of the Swagger spec.
 func GenerateTypesForResponses(t *template.Template, responses openapi3.Responses) ([]TypeDefinition, error) {
+	return defaultGenerator().GenerateTypesForResponses(t, responses)
+}
+
+func (g *generator) GenerateTypesForResponses(t *template.Template, responses openapi3.Responses) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 
 	for _, responseName := range SortedResponsesKeys(responses) {
@@ -435,7 +871,7 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 		response := responseOrRef.Value
 		jsonResponse, found := response.Content["application/json"]
 		if found {
-			goType, err := GenerateGoSchema(jsonResponse.Schema, []string{responseName})
+			goType, err := g.GenerateGoSchema(g.variantSchemaRef(jsonResponse.Schema, responseVariant), []string{responseName})
 			if err != nil {
 				return nil, fmt.Errorf("error generating Go type for schema in response %s: %w", responseName, err)
 			}
@@ -448,7 +884,7 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 
 			if responseOrRef.Ref != "" {
 				// Generate a reference type for referenced parameters
-				refType, err := RefPathToGoType(responseOrRef.Ref)
+				refType, err := g.RefPathToGoType(responseOrRef.Ref)
 				if err != nil {
 					return nil, fmt.Errorf("error generating Go type for (%s) in parameter %s: %w", responseOrRef.Ref, responseName, err)
 				}
@@ -463,6 +899,10 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 // Generates type definitions for any custom types defined in the
 // components/requestBodies section of the Swagger spec.
 func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*openapi3.RequestBodyRef) ([]TypeDefinition, error) {
+	return defaultGenerator().GenerateTypesForRequestBodies(t, bodies)
+}
+
+func (g *generator) GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*openapi3.RequestBodyRef) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 
 	for _, bodyName := range SortedRequestBodyKeys(bodies) {
@@ -473,7 +913,7 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 		response := bodyOrRef.Value
 		jsonBody, found := response.Content["application/json"]
 		if found {
-			goType, err := GenerateGoSchema(jsonBody.Schema, []string{bodyName})
+			goType, err := g.GenerateGoSchema(g.variantSchemaRef(jsonBody.Schema, requestVariant), []string{bodyName})
 			if err != nil {
 				return nil, fmt.Errorf("error generating Go type for schema in body %s: %w", bodyName, err)
 			}
@@ -486,7 +926,7 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 
 			if bodyOrRef.Ref != "" {
 				// Generate a reference type for referenced bodies
-				refType, err := RefPathToGoType(bodyOrRef.Ref)
+				refType, err := g.RefPathToGoType(bodyOrRef.Ref)
 				if err != nil {
 					return nil, fmt.Errorf("error generating Go type for (%s) in body %s: %w", bodyOrRef.Ref, bodyName, err)
 				}
@@ -581,7 +1021,38 @@ func GenerateImports(t *template.Template, externalImports []string, packageName
 		Version:         moduleVersion,
 	}
 
-	return GenerateTemplates([]string{"imports.tmpl"}, t, context)
+	code, err := GenerateTemplates([]string{"imports.tmpl"}, t, context)
+	if err != nil {
+		return "", err
+	}
+	return dedupeImports(code), nil
+}
+
+// dedupeImports removes the imports which are repeated in the import block of
+// the code, such as the packages of the standard library which are both
+// imported by the template and mapped to by a TypeMapping, which wouldn't
+// compile without running goimports.
+func dedupeImports(code string) string {
+	lines := strings.Split(code, "\n")
+	out := lines[:0]
+	seen := map[string]bool{}
+	inBlock := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "import (":
+			inBlock = true
+		case trimmed == ")":
+			inBlock = false
+		case inBlock && trimmed != "":
+			if seen[trimmed] {
+				continue
+			}
+			seen[trimmed] = true
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
 }
 
 // Generate all the glue code which provides the API for interacting with
@@ -613,6 +1084,30 @@ func GenerateAdditionalPropertyBoilerplate(t *template.Template, typeDefs []Type
 
 }
 
+// GenerateTimeFormatBoilerplate generates the methods which encode the times
+// of the types for the schemas with x-go-time-format, or Options.TimeFormats,
+// in their layouts.
+func GenerateTimeFormatBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	var filteredTypes []TypeDefinition
+	m := map[string]bool{}
+	for _, t := range typeDefs {
+		if m[t.TypeName] {
+			continue
+		}
+		m[t.TypeName] = true
+		if t.Schema.TimeFormat != "" {
+			filteredTypes = append(filteredTypes, t)
+		}
+	}
+
+	context := struct {
+		Types []TypeDefinition
+	}{
+		Types: filteredTypes,
+	}
+	return GenerateTemplates([]string{"time-format.tmpl"}, t, context)
+}
+
 // SanitizeCode runs sanitizers across the generated Go code to ensure the
 // generated code will be able to compile.
 func SanitizeCode(goCode string) string {
@@ -620,3 +1115,55 @@ func SanitizeCode(goCode string) string {
 	// See: https://groups.google.com/forum/#!topic/golang-nuts/OToNIPdfkks
 	return strings.Replace(goCode, "\uFEFF", "", -1)
 }
+
+// bomStripper removes byte-order-marks, like SanitizeCode, from everything
+// written through it. A write may end with the start of a mark, which is
+// held until the next write, or Flush.
+type bomStripper struct {
+	w       io.Writer
+	pending []byte
+}
+
+func (b *bomStripper) Write(p []byte) (int, error) {
+	data := p
+	if len(b.pending) > 0 {
+		data = append(b.pending, p...)
+		b.pending = nil
+	}
+	if n := partialBOM(data); n > 0 {
+		b.pending = append([]byte(nil), data[len(data)-n:]...)
+		data = data[:len(data)-n]
+	}
+	if bytes.Contains(data, byteOrderMark) {
+		data = bytes.ReplaceAll(data, byteOrderMark, nil)
+	}
+	if _, err := b.w.Write(data); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes the bytes held at the end of the last write, which weren't
+// the start of a mark after all.
+func (b *bomStripper) Flush() error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+	_, err := b.w.Write(b.pending)
+	b.pending = nil
+	return err
+}
+
+// byteOrderMark is the UTF-8 encoding of U+FEFF.
+var byteOrderMark = []byte("\uFEFF")
+
+// partialBOM returns the length of the start of a byte-order-mark which data
+// ends with.
+func partialBOM(data []byte) int {
+	for n := len(byteOrderMark) - 1; n > 0; n-- {
+		if bytes.HasSuffix(data, byteOrderMark[:n]) {
+			return n
+		}
+	}
+	return 0
+}
@@ -2,16 +2,21 @@ package codegen
 
 import (
 	"bytes"
+	"context"
 	"go/format"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"text/template"
 
 	examplePetstoreClient "github.com/deepmap/oapi-codegen/examples/petstore-expanded"
 	examplePetstore "github.com/deepmap/oapi-codegen/examples/petstore-expanded/echo/api"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/golangci/lint-1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExamplePetStoreCodeGeneration(t *testing.T) {
@@ -30,7 +35,9 @@ func TestExamplePetStoreCodeGeneration(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Run our code generation:
-	code, err := Generate(swagger, packageName, opts)
+	opts.PackageName = packageName
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	code := artifacts.Code
 	assert.NoError(t, err)
 	assert.NotEmpty(t, code)
 
@@ -75,7 +82,9 @@ func TestExamplePetStoreCodeGenerationWithUserTemplates(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Run our code generation:
-	code, err := Generate(swagger, packageName, opts)
+	opts.PackageName = packageName
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	code := artifacts.Code
 	assert.NoError(t, err)
 	assert.NotEmpty(t, code)
 
@@ -126,7 +135,9 @@ func TestExampleOpenAPICodeGeneration(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Run our code generation:
-	code, err := Generate(swagger, packageName, opts)
+	opts.PackageName = packageName
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	code := artifacts.Code
 	assert.NoError(t, err)
 	assert.NotEmpty(t, code)
 
@@ -172,6 +183,264 @@ type GetTestByNameResponse struct {
 	assert.Len(t, problems, 0)
 }
 
+func TestDeprecatedCodeGeneration(t *testing.T) {
+	packageName := "testswagger"
+	opts := Options{
+		GenerateClient:     true,
+		GenerateEchoServer: true,
+		GenerateTypes:      true,
+	}
+
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testDeprecatedDefinition))
+	assert.NoError(t, err)
+
+	opts.PackageName = packageName
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	code := artifacts.Code
+	assert.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Deprecated operations are flagged on the client and the server
+	assert.Contains(t, code, `// Deprecated: use getPetV2 instead
+func (c *Client) GetPet(`)
+	assert.Contains(t, code, `	// (GET /pets/{id})
+	//
+	// Deprecated: use getPetV2 instead
+	GetPet(ctx echo.Context, id string) error`)
+	assert.NotContains(t, code, "Deprecated: this has been marked as deprecated in the OpenAPI specification\nfunc (c *Client) FindPets(")
+
+	// Deprecated parameters and properties are flagged on their fields
+	assert.Contains(t, code, `	// Maximum number of results
+	//
+	// Deprecated: this has been marked as deprecated in the OpenAPI specification
+	Limit *int`)
+	assert.Contains(t, code, `	// Deprecated: this has been marked as deprecated in the OpenAPI specification
+	Tag *string`)
+
+	t.Run("exclude deprecated", func(t *testing.T) {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(testDeprecatedDefinition))
+		assert.NoError(t, err)
+
+		opts.ExcludeDeprecated = true
+		opts.PackageName = packageName
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		code := artifacts.Code
+		assert.NoError(t, err)
+		assert.NotContains(t, code, "GetPet(")
+		assert.Contains(t, code, "FindPets(")
+	})
+}
+
+const testDeprecatedDefinition = `
+openapi: 3.0.1
+info:
+  title: Deprecation test
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      parameters:
+        - name: limit
+          in: query
+          description: Maximum number of results
+          deprecated: true
+          schema:
+            type: integer
+      responses:
+        200:
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /pets/{id}:
+    get:
+      operationId: getPet
+      deprecated: true
+      x-deprecated-reason: use getPetV2 instead
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        200:
+          description: Success
+components:
+  schemas:
+    Pet:
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          deprecated: true
+`
+
+func TestGoTypeNameCodeGeneration(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testGoTypeNameDefinition))
+	assert.NoError(t, err)
+
+	artifacts, _, err := Generate(context.Background(), swagger, Options{PackageName: "testswagger", GenerateClient: true, GenerateTypes: true})
+	code := artifacts.Code
+	assert.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "Kind *PetKind `json:\"kind,omitempty\"`")
+	assert.Contains(t, code, "type PetKind string")
+	assert.Contains(t, code, "type GetPetsJSONRequestBody PetQuery")
+	assert.Contains(t, code, `type PetList struct {
+	Pets *[]PetSummary `)
+	assert.Contains(t, code, "type PetSummary struct {")
+	assert.Contains(t, code, "JSON200      *PetList")
+}
+
+const testGoTypeNameDefinition = `
+openapi: 3.0.1
+info:
+  title: Go type name test
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: getPets
+      parameters:
+        - name: kind
+          in: query
+          schema:
+            type: string
+            x-go-type-name: PetKind
+            enum: [cat, dog]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              x-go-type-name: PetQuery
+              properties:
+                name:
+                  type: string
+      responses:
+        200:
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: object
+                x-go-type-name: PetList
+                properties:
+                  pets:
+                    type: array
+                    items:
+                      x-go-type-name: PetSummary
+                      properties:
+                        name:
+                          type: string
+`
+
+func TestInlineTypeNames(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Inline types
+  version: 1.0.0
+paths:
+  /foo:
+    get:
+      operationId: getFoo
+      parameters:
+        - name: sort
+          in: query
+          schema:
+            type: string
+            enum: [asc, desc]
+        - name: filter
+          in: query
+          schema:
+            type: string
+            enum: [a, b]
+      responses:
+        200:
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  status:
+                    type: string
+                    enum: ["on", "off"]
+                  owner:
+                    type: object
+                    additionalProperties:
+                      type: string
+                    properties:
+                      name:
+                        type: string
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	artifacts, _, err := Generate(context.Background(), swagger, Options{PackageName: "api", GenerateTypes: true})
+	require.NoError(t, err)
+
+	// The types are named after the parameters, responses and properties,
+	// so adding or reordering them doesn't rename the others
+	assert.Contains(t, artifacts.Code, "type GetFooParamsSort string")
+	assert.Contains(t, artifacts.Code, "type GetFooParamsFilter string")
+	assert.Contains(t, artifacts.Code, "type GetFoo200ResponseStatus string")
+	assert.Contains(t, artifacts.Code, "type GetFoo200Response_Owner struct {")
+}
+
+func TestServerURLCodeGeneration(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testServersDefinition))
+	assert.NoError(t, err)
+
+	artifacts, _, err := Generate(context.Background(), swagger, Options{PackageName: "testswagger", GenerateClient: true, GenerateTypes: true})
+	code := artifacts.Code
+	assert.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, `	// Production server
+	ServerURLProductionServer = "https://{region}.api.example.com/v1"`)
+	assert.Contains(t, code, `ServerURLLocalhost8080    = "http://localhost:8080"`)
+	assert.Contains(t, code, `	ServerURLProductionServer: {
+		"region": {Default: "us", Enum: []string{"us", "eu"}},
+	},`)
+	assert.Contains(t, code, "func WithServerVariables(variables map[string]string) ClientOption {")
+}
+
+const testServersDefinition = `
+openapi: 3.0.1
+info:
+  title: Servers test
+  version: 1.0.0
+servers:
+  - url: https://{region}.api.example.com/v1
+    description: Production server
+    variables:
+      region:
+        default: us
+        enum: [us, eu]
+  - url: http://localhost:8080
+  - url: http://localhost:8080
+paths:
+  /ping:
+    get:
+      operationId: ping
+      responses:
+        200:
+          description: Success
+`
+
 const testOpenAPIDefinition = `
 openapi: 3.0.1
 
@@ -312,3 +581,1986 @@ components:
           type: string
           enum: [car, dog, oldage]
 `
+
+func TestGenerateDiagnostics(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(lintTestFixture))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:           "api",
+		GenerateTypes:         true,
+		GenerateClient:        true,
+		NameCollisionStrategy: NameCollisionMethod,
+	}
+	artifacts, diagnostics, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, "package api")
+	assert.Contains(t, diagnostics, Diagnostic{
+		Severity: SeverityWarning,
+		Rule:     "one-of",
+		Location: "#/components/schemas/Pet/properties/kind",
+		Message:  "oneOf is generated as interface{}",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = Generate(ctx, swagger, opts)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRouterImports(t *testing.T) {
+	generate := func(opts Options) string {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+		assert.NoError(t, err)
+		opts.PackageName = "api"
+		// Without formatting, unused imports aren't removed
+		opts.SkipFmt = true
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		assert.NoError(t, err)
+		return artifacts.Code
+	}
+
+	code := generate(Options{GenerateTypes: true, GenerateClient: true})
+	assert.NotContains(t, code, "github.com/labstack/echo")
+	assert.NotContains(t, code, "github.com/go-chi/chi")
+
+	code = generate(Options{GenerateTypes: true, GenerateChiServer: true})
+	assert.NotContains(t, code, "github.com/labstack/echo")
+	assert.Contains(t, code, `"github.com/go-chi/chi/v5"`)
+
+	code = generate(Options{GenerateTypes: true, GenerateEchoServer: true})
+	assert.Contains(t, code, `"github.com/labstack/echo/v4"`)
+}
+
+func TestChiServerContext(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:       "api",
+		GenerateTypes:     true,
+		GenerateChiServer: true,
+		ChiServerContext:  true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "GetTestByName(ctx context.Context, w http.ResponseWriter, r *http.Request, name string, params GetTestByNameParams)")
+	assert.Contains(t, code, "siw.Handler.GetTestByName(r.Context(), w, r, name, params)")
+}
+
+func TestServerResponses(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+	assert.NoError(t, err)
+
+	swagger.Paths["/test/{name}"].Get.Responses["5XX"] = &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().WithDescription("Unavailable"),
+	}
+	swagger.Paths["/test/{name}"].Get.Responses["3xx"] = &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().WithDescription("Moved"),
+	}
+
+	opts := Options{
+		PackageName:             "api",
+		GenerateTypes:           true,
+		GenerateChiServer:       true,
+		GenerateServerResponses: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Fixed statuses are written as they are
+	assert.Contains(t, code, "type GetTestByName200JSONResponse struct {")
+	assert.Contains(t, code, "type GetTestByName200Response struct {")
+	assert.Contains(t, code, "statusCode := 200")
+
+	// Ranged responses take any status in their range
+	assert.Contains(t, code, "func NewGetTestByName5XXResponse(statusCode int) (GetTestByName5XXResponse, error) {")
+	assert.Contains(t, code, "if !(statusCode/100 == 5) {")
+
+	// Ranges are understood in lower case too
+	assert.Contains(t, code, "func NewGetTestByName3XXResponse(statusCode int) (GetTestByName3XXResponse, error) {")
+	assert.Contains(t, code, "if !(statusCode/100 == 3) {")
+
+	// Default responses take any status which isn't declared otherwise
+	assert.Contains(t, code, "func NewGetTestByNameDefaultJSONResponse(statusCode int, body Error) (GetTestByNameDefaultJSONResponse, error) {")
+	assert.Contains(t, code, "statusCode >= 100 && statusCode <= 599 && !(statusCode == 200) && !(statusCode/100 == 3) && !(statusCode == 422) && !(statusCode/100 == 5)")
+
+	// Every response of an operation is one of its response objects
+	assert.Contains(t, code, "type GetTestByNameResponseObject interface {")
+	assert.Contains(t, code, "func (GetTestByName5XXResponse) isGetTestByNameResponse() {}")
+
+	// Other response names are refused
+	swagger.Paths["/test/{name}"].Get.Responses["2X0"] = &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().WithDescription("Unknown"),
+	}
+	_, _, err = Generate(context.Background(), swagger, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid response "2X0" of GetTestByName, it's neither a status code, a range of them nor default`)
+}
+
+func TestLazyClient(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:        "api",
+		GenerateTypes:      true,
+		GenerateClient:     true,
+		GenerateLazyClient: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "func (c *ClientWithLazyResponses) GetTestByNameWithLazyResponse(ctx context.Context, name string, params *GetTestByNameParams, reqEditors ...RequestEditorFn) (*GetTestByNameLazyResponse, error) {")
+	assert.Contains(t, code, "func (r GetTestByNameLazyResponse) ParseJSON200() (*[]Test, error) {")
+	assert.Contains(t, code, "func (r GetTestByNameLazyResponse) ParseJSONDefault() (*Error, error) {")
+}
+
+func TestEnumMethods(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Enums
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Color:
+      type: string
+      enum: [red, green]
+    Level:
+      type: integer
+      enum: [1, 2]
+`
+	generate := func(opts Options) string {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		assert.NoError(t, err)
+		opts.PackageName = "api"
+		opts.GenerateTypes = true
+		opts.SkipPrune = true
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		assert.NoError(t, err)
+		_, err = format.Source([]byte(artifacts.Code))
+		assert.NoError(t, err)
+		return artifacts.Code
+	}
+
+	code := generate(Options{})
+	assert.NotContains(t, code, "func (e Color)")
+
+	code = generate(Options{EnumStringer: true})
+	assert.Contains(t, code, "func (e Color) String() string {")
+	assert.Contains(t, code, "return fmt.Sprint(int(e))")
+	assert.NotContains(t, code, "MarshalText")
+
+	code = generate(Options{EnumText: true})
+	assert.Contains(t, code, "func (e *Color) UnmarshalText(text []byte) error {")
+	assert.NotContains(t, code, "func (e *Color) UnmarshalJSON(data []byte) error {")
+	// Integer enums stay integers in JSON
+	assert.Contains(t, code, "func (e *Level) UnmarshalJSON(data []byte) error {")
+
+	code = generate(Options{EnumSQL: true})
+	assert.Contains(t, code, "func (e *Color) Scan(src interface{}) error {")
+	assert.Contains(t, code, "var v sql.NullInt64")
+	assert.Contains(t, code, `"database/sql/driver"`)
+}
+
+func TestConstFields(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Consts
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Envelope:
+      type: object
+      required: [type, version]
+      properties:
+        type:
+          type: string
+          const: dog
+        version:
+          type: integer
+          enum: [2]
+        color:
+          type: string
+          enum: [red, green]
+    Kind:
+      type: string
+      const: pet
+`
+	generate := func(opts Options) string {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		assert.NoError(t, err)
+		opts.PackageName = "api"
+		opts.GenerateTypes = true
+		opts.SkipPrune = true
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		assert.NoError(t, err)
+		_, err = format.Source([]byte(artifacts.Code))
+		assert.NoError(t, err)
+		return artifacts.Code
+	}
+
+	code := generate(Options{})
+	assert.Regexp(t, "Type +EnvelopeType ", code)
+	assert.Regexp(t, "EnvelopeTypeDog +EnvelopeType = \"dog\"", code)
+	assert.Contains(t, code, "func (e EnvelopeType) MarshalJSON() ([]byte, error) {")
+	assert.Contains(t, code, "return json.Marshal(string(EnvelopeTypeDog))")
+	assert.Contains(t, code, "func (e *EnvelopeVersion) UnmarshalJSON(data []byte) error {")
+	assert.Contains(t, code, "if EnvelopeVersion(v) != EnvelopeVersionN2 {")
+	assert.Contains(t, code, "func (e *Kind) UnmarshalJSON(data []byte) error {")
+	// Enums of several values take any
+	assert.NotContains(t, code, "func (e *EnvelopeColor) UnmarshalJSON(data []byte) error {")
+
+	// The const methods replace the JSON ones of the text methods
+	code = generate(Options{EnumText: true})
+	assert.Equal(t, 1, strings.Count(code, "func (e EnvelopeVersion) MarshalJSON() ([]byte, error) {"))
+	assert.Contains(t, code, "func (e *EnvelopeVersion) UnmarshalText(text []byte) error {")
+}
+
+func TestYAMLAndMapstructureTags(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Tags
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+        color:
+          type: string
+          x-oapi-codegen-extra-tags:
+            yaml: colour
+`
+	generate := func(opts Options) string {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		assert.NoError(t, err)
+		opts.PackageName = "api"
+		opts.GenerateTypes = true
+		opts.SkipPrune = true
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		assert.NoError(t, err)
+		return artifacts.Code
+	}
+
+	code := generate(Options{})
+	assert.Contains(t, code, "`json:\"name\"`")
+	assert.NotContains(t, code, "mapstructure:")
+
+	code = generate(Options{YAMLTags: true})
+	assert.Contains(t, code, "`json:\"name\" yaml:\"name\"`")
+	assert.Contains(t, code, "`json:\"tag,omitempty\" yaml:\"tag,omitempty\"`")
+	assert.Contains(t, code, "`json:\"color,omitempty\" yaml:\"colour\"`")
+	assert.NotContains(t, code, "mapstructure:")
+
+	code = generate(Options{YAMLTags: true, MapstructureTags: true})
+	assert.Contains(t, code, "`json:\"tag,omitempty\" mapstructure:\"tag,omitempty\" yaml:\"tag,omitempty\"`")
+}
+
+func TestXMLTags(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: XML
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Tag:
+      type: object
+      xml:
+        name: tag
+      properties:
+        name:
+          type: string
+    Pet:
+      type: object
+      required: [name]
+      xml:
+        name: pet
+        namespace: http://example.com/schema
+      properties:
+        id:
+          type: integer
+          xml:
+            attribute: true
+        name:
+          type: string
+        photoUrls:
+          type: array
+          xml:
+            name: photoUrl
+          items:
+            type: string
+        tags:
+          type: array
+          xml:
+            wrapped: true
+          items:
+            $ref: '#/components/schemas/Tag'
+    Plain:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+	artifacts, _, err := Generate(context.Background(), swagger, Options{
+		PackageName:   "api",
+		GenerateTypes: true,
+		SkipPrune:     true,
+	})
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	assert.Contains(t, code, "XMLName   xml.Name  `json:\"-\" xml:\"http://example.com/schema pet\"`")
+	assert.Contains(t, code, "*int      `json:\"id,omitempty\" xml:\"id,attr,omitempty\"`")
+	assert.Contains(t, code, "string    `json:\"name\" xml:\"name\"`")
+	assert.Contains(t, code, "*[]string `json:\"photoUrls,omitempty\" xml:\"photoUrl,omitempty\"`")
+	assert.Contains(t, code, "*[]Tag    `json:\"tags,omitempty\" xml:\"tags>tag,omitempty\"`")
+	assert.Contains(t, code, "XMLName xml.Name `json:\"-\" xml:\"tag\"`")
+	assert.NotContains(t, code, "yaml:")
+
+	// Schemas without xml objects keep their json tags only
+	assert.Contains(t, code, "type Plain struct {\n\tName *string `json:\"name,omitempty\"`\n}")
+
+	// With yaml tags, the XML name isn't encoded as YAML either
+	artifacts, _, err = Generate(context.Background(), swagger, Options{
+		PackageName:   "api",
+		GenerateTypes: true,
+		SkipPrune:     true,
+		YAMLTags:      true,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, "XMLName xml.Name `json:\"-\" xml:\"tag\" yaml:\"-\"`")
+}
+
+func TestDeepCopy(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: DeepCopy
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Color:
+      type: string
+    Any: {}
+    Tag:
+      type: object
+      properties:
+        name:
+          type: string
+    Pet:
+      type: object
+      required: [name, tags]
+      properties:
+        name:
+          type: string
+        tags:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tag'
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+        extra: {}
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: object
+          properties:
+            barks:
+              type: boolean
+`
+	generate := func(opts Options) string {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		assert.NoError(t, err)
+		opts.PackageName = "api"
+		opts.GenerateTypes = true
+		opts.SkipPrune = true
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		assert.NoError(t, err)
+		return artifacts.Code
+	}
+
+	code := generate(Options{})
+	assert.NotContains(t, code, "DeepCopy")
+
+	code = generate(Options{DeepCopy: true})
+	assert.Contains(t, code, "func (in *Pet) DeepCopy() *Pet {")
+	assert.Contains(t, code, "func (in *Color) DeepCopyInto(out *Color) {\n\t*out = *in\n}")
+	// Interface types can't have methods
+	assert.NotContains(t, code, "func (in *Any)")
+	// Slices of types with pointers are copied element by element
+	assert.Contains(t, code, `	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]Tag, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}`)
+	assert.Contains(t, code, "**out = runtime.DeepCopyJSONValue(**in)")
+	assert.Contains(t, code, "(*out)[key] = val")
+	// Embedded allOf types copy themselves
+	assert.Contains(t, code, "in.Pet.DeepCopyInto(&out.Pet)")
+	assert.Contains(t, code, "func (in *Pets) DeepCopyInto(out *Pets) {\n\tif *in != nil {")
+
+	// Aliases share the methods of the types they alias
+	code = generate(Options{DeepCopy: true, AliasTypes: true})
+	assert.Contains(t, code, "func (in *Pet) DeepCopy() *Pet {")
+}
+
+func TestMergePatchBodies(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Patch
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    patch:
+      operationId: patchPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/merge-patch+json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          nullable: true
+          x-omitempty: false
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+	artifacts, _, err := Generate(context.Background(), swagger, Options{
+		PackageName:    "api",
+		GenerateTypes:  true,
+		GenerateClient: true,
+	})
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	// Every field of the patch is optional, and tells null from absent
+	assert.Contains(t, code, "type PatchPetMergePatchBody struct {")
+	assert.Contains(t, code, "Name runtime.Opt[string] `json:\"name,omitempty\"`")
+	assert.Contains(t, code, "Tag  runtime.Opt[string] `json:\"tag,omitempty\"`")
+	assert.Contains(t, code, "// PatchPetMergePatchRequestBody defines body for PatchPet for application/merge-patch+json ContentType.")
+
+	assert.Contains(t, code, "func (c *Client) PatchPetWithMergePatchBody(ctx context.Context, id int, body PatchPetMergePatchRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {")
+	assert.Contains(t, code, "return NewPatchPetRequestWithMergePatchBody(server, id, body)")
+	assert.Contains(t, code, `return NewPatchPetRequestWithBody(server, id, "application/merge-patch+json", bodyReader)`)
+}
+
+func TestJSONContentTypes(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Vendored
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      requestBody:
+        content:
+          application/vnd.company.v2+json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/hal+json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '404':
+          description: missing
+          content:
+            text/javascript:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+	artifacts, _, err := Generate(context.Background(), swagger, Options{
+		PackageName:      "api",
+		GenerateTypes:    true,
+		GenerateClient:   true,
+		JSONContentTypes: []string{"text/javascript"},
+	})
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	// The only JSON body is the default one, even when it's vendored
+	assert.Contains(t, code, "// AddPetVndCompanyV2RequestBody defines body for AddPet for application/vnd.company.v2+json ContentType.")
+	assert.Contains(t, code, "func (c *Client) AddPet(ctx context.Context, body AddPetVndCompanyV2RequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {")
+	assert.Contains(t, code, `return NewAddPetRequestWithBody(server, "application/vnd.company.v2+json", bodyReader)`)
+
+	// Responses with several JSON content types get a single field, and
+	// configured types are decoded as JSON too
+	assert.Equal(t, 1, strings.Count(code, "response.JSON200 = &dest"))
+	assert.Contains(t, code, "response.JSON404 = &dest")
+	assert.Contains(t, code, `case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:`)
+	assert.Contains(t, code, `case strings.Contains(rsp.Header.Get("Content-Type"), "text/javascript") && rsp.StatusCode == 404:`)
+}
+
+func TestJSONContentTypesWithSameSubtype(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Problems
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      requestBody:
+        content:
+          application/problem+json:
+            schema:
+              $ref: '#/components/schemas/Problem'
+          text/problem+json:
+            schema:
+              $ref: '#/components/schemas/Problem'
+      responses:
+        '204':
+          description: ok
+components:
+  schemas:
+    Problem:
+      type: object
+      properties:
+        title:
+          type: string
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	artifacts, _, err := Generate(context.Background(), swagger, Options{
+		PackageName:    "api",
+		GenerateTypes:  true,
+		GenerateClient: true,
+	})
+	require.NoError(t, err)
+	code := artifacts.Code
+
+	// The bodies are tagged with their types, so their names don't collide
+	assert.Contains(t, code, "type AddPetApplicationProblemRequestBody AddPetApplicationProblemBody")
+	assert.Contains(t, code, "type AddPetTextProblemRequestBody AddPetTextProblemBody")
+	assert.Contains(t, code, "func (c *Client) AddPetWithApplicationProblemBody(ctx context.Context, body AddPetApplicationProblemRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {")
+
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+}
+
+func TestConcurrentGenerate(t *testing.T) {
+	// Generations with different options don't see each other's options.
+	// Each loads its own spec, since generating updates the operations.
+	generate := func(opts Options) (string, error) {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+		if err != nil {
+			return "", err
+		}
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		if err != nil {
+			return "", err
+		}
+		return artifacts.Code, nil
+	}
+	options := []Options{
+		{PackageName: "api", GenerateTypes: true, GenerateClient: true},
+		{PackageName: "api", GenerateTypes: true, GenerateClient: true, JSONContentTypes: []string{"text/javascript"}, YAMLTags: true, NullableType: true},
+	}
+	expected := make([]string, len(options))
+	for i, opts := range options {
+		code, err := generate(opts)
+		require.NoError(t, err)
+		expected[i] = code
+	}
+	require.NotEqual(t, expected[0], expected[1])
+
+	var wg sync.WaitGroup
+	codes := make([]string, 8)
+	errs := make([]error, len(codes))
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes[i], errs[i] = generate(options[i%len(options)])
+		}(i)
+	}
+	wg.Wait()
+	for i, code := range codes {
+		require.NoError(t, errs[i])
+		assert.Equal(t, expected[i%len(options)], code)
+	}
+}
+
+func TestJSONPackage(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:        "api",
+		GenerateTypes:      true,
+		GenerateClient:     true,
+		GenerateEchoServer: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `"encoding/json"`)
+
+	opts.JSONPackage = "github.com/goccy/go-json"
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `json "github.com/goccy/go-json"`)
+	assert.NotContains(t, artifacts.Code, `"encoding/json"`)
+	assert.Contains(t, artifacts.Code, "json.Unmarshal(bodyBytes, &dest)")
+}
+
+func TestURLBuilders(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:   "api",
+		GenerateTypes: true,
+		GenerateURLs:  true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	assert.Contains(t, code, `const FindPetByIDRoute = "/pets/{id}"`)
+	assert.Contains(t, code, "func BuildFindPetByIDURL(server string, id int64) (*url.URL, error) {")
+	assert.Contains(t, code, "func BuildFindPetsURL(server string, params *FindPetsParams) (*url.URL, error) {")
+	assert.NotContains(t, code, "func NewFindPetsRequest(")
+
+	// The client builds its requests' URLs with them
+	opts.GenerateURLs = false
+	opts.GenerateClient = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code = artifacts.Code
+	assert.Equal(t, 1, strings.Count(code, "func BuildFindPetByIDURL("))
+	assert.Contains(t, code, "queryURL, err := BuildFindPetByIDURL(server, id)")
+}
+
+func TestListHeaderBinding(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Headers
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      parameters:
+        - name: X-Tags
+          in: header
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        '204':
+          description: found
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	// The values of list headers are joined into one, which can't be too many
+	for _, opts := range []Options{{GenerateEchoServer: true}, {GenerateChiServer: true}, {GenerateGinServer: true}} {
+		opts.PackageName = "api"
+		opts.GenerateTypes = true
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		assert.NoError(t, err)
+		assert.Contains(t, artifacts.Code, `valueList = []string{strings.Join(valueList, ",")}`)
+		assert.NotContains(t, artifacts.Code, "runtime.BindErrorTooManyValues")
+	}
+}
+
+func TestServerRecovery(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:        "api",
+		GenerateTypes:      true,
+		GenerateEchoServer: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, artifacts.Code, "ErrorResponder")
+
+	opts.ServerRecovery = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	assert.Contains(t, code, "func (w *ServerInterfaceWrapper) FindPetByID(ctx echo.Context) (err error) {")
+	assert.Contains(t, code, `err = w.respondFindPetByIDError(ctx, runtime.RecoverOperation("FindPetByID", p))`)
+	assert.Contains(t, code, `return w.respondFindPetByIDError(ctx, runtime.NewOperationError("FindPetByID", err))`)
+	assert.Contains(t, code, "func RegisterHandlersWithErrorResponder(router EchoRouter, si ServerInterface, baseURL string, responder ErrorResponder) {")
+	// The default responses of the operations have the Error schema
+	assert.Contains(t, code, "var body Error\n\tbody.Message = err.Error()\n\tbody.Code = http.StatusInternalServerError\n")
+
+	opts.GenerateEchoServer = false
+	opts.GenerateChiServer = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, "ErrorResponder:      options.ErrorResponder,")
+	assert.Contains(t, artifacts.Code, `siw.respondFindPetByIDError(w, r, runtime.RecoverOperation("FindPetByID", p))`)
+}
+
+func TestParamErrorResponses(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:        "api",
+		GenerateTypes:      true,
+		GenerateEchoServer: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, artifacts.Code, "ParamErrorBody")
+
+	opts.ParamErrorResponses = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	// The default responses of the operations have the Error schema
+	assert.Contains(t, code, "func DefaultParamErrorBody(operationID string, err *runtime.BindError) interface{} {")
+	assert.Contains(t, code, "ParamErrorBody func(operationID string, err *runtime.BindError) interface{}")
+	assert.Contains(t, code, "options.ParamErrorBody = DefaultParamErrorBody")
+	assert.Contains(t, code, "if body := w.ParamErrorBody(operationID, err); body != nil {")
+	assert.NotContains(t, code, "var ParamErrorBody")
+	assert.Contains(t, code, "\tcase \"FindPetByID\":\n\t\tvar body Error\n\t\tbody.Message = err.Error()\n\t\tbody.Code = http.StatusBadRequest\n\t\treturn body\n")
+	assert.Contains(t, code, `if ctx.Param("id") == "" {`)
+	assert.Contains(t, code, `return w.paramError(ctx, "FindPetByID", runtime.NewBindError(runtime.BindErrorRequired, "id", runtime.ParamLocationPath, nil),`)
+	assert.Contains(t, code, `return w.paramError(ctx, "FindPets", runtime.NewBindError(runtime.BindErrorFormat, "tags", runtime.ParamLocationQuery, err),`)
+
+	opts.GenerateEchoServer = false
+	opts.GenerateGinServer = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `siw.paramError(c, "FindPetByID", runtime.NewBindError(runtime.BindErrorRequired, "id", runtime.ParamLocationPath, nil),`)
+}
+
+func TestWebSocketOperations(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Chat
+  version: 1.0.0
+paths:
+  /rooms/{room}/chat:
+    get:
+      operationId: chat
+      x-websocket: true
+      parameters:
+        - name: room
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Say'
+      responses:
+        101:
+          description: Messages
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Said'
+  /rooms:
+    get:
+      operationId: listRooms
+      responses:
+        200:
+          description: Rooms
+components:
+  schemas:
+    Say:
+      type: object
+      properties:
+        text:
+          type: string
+    Said:
+      type: object
+      properties:
+        text:
+          type: string
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:       "api",
+		GenerateTypes:     true,
+		GenerateClient:    true,
+		GenerateChiServer: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	// The WebSocket isn't a plain GET anymore
+	assert.Contains(t, code, "ListRooms(w http.ResponseWriter, r *http.Request)")
+	assert.NotContains(t, code, "Chat(w http.ResponseWriter, r *http.Request")
+	assert.NotContains(t, code, "func NewChatRequest(")
+
+	assert.Contains(t, code, "Chat(r *http.Request, conn *ChatServerConn, room int)")
+	assert.Contains(t, code, "func (c *ChatServerConn) Receive() (ChatJSONRequestBody, error) {")
+	assert.Contains(t, code, "func (c *ChatServerConn) Send(msg Said) error {")
+	assert.Contains(t, code, "func HandleWebSockets(router chi.Router, si WebSocketServerInterface, upgrader runtime.WebSocketUpgrader) {")
+	assert.Contains(t, code, "func (c *ChatClientConn) Send(msg ChatJSONRequestBody) error {")
+	assert.Contains(t, code, "func (c *Client) DialChat(ctx context.Context, dial runtime.WebSocketDialer, room int, reqEditors ...RequestEditorFn) (*ChatClientConn, error) {")
+
+	// Only GET requests can be upgraded
+	swagger.Paths["/rooms/{room}/chat"].Post = swagger.Paths["/rooms/{room}/chat"].Get
+	swagger.Paths["/rooms/{room}/chat"].Get = nil
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+}
+
+func TestServerRouters(t *testing.T) {
+	swagger, err := examplePetstore.GetSwagger()
+	assert.NoError(t, err)
+
+	mux := ServerRouter{
+		Name:      "mux",
+		Imports:   []string{`"github.com/gorilla/mux"`},
+		Templates: []string{"mux-register.tmpl"},
+		Sources: map[string]string{
+			"mux-register.tmpl": `func RegisterHandlers(r *mux.Router) {
+{{range .}}    r.Path("{{muxPath .Path}}").Methods("{{.Method}}")
+{{end}}}`,
+		},
+		Funcs: template.FuncMap{"muxPath": func(path string) string { return "/mux" + path }},
+	}
+	opts := Options{
+		PackageName:   "api",
+		GenerateTypes: true,
+		ServerRouters: []ServerRouter{mux},
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `"github.com/gorilla/mux"`)
+	assert.NotContains(t, artifacts.Code, `"github.com/labstack/echo/v4"`)
+	assert.Contains(t, artifacts.Code, `r.Path("/mux/pets/{id}").Methods("GET")`)
+
+	// The routers of an option don't leak into other generations
+	artifacts, _, err = Generate(context.Background(), swagger, Options{PackageName: "api", GenerateTypes: true})
+	assert.NoError(t, err)
+	assert.NotContains(t, artifacts.Code, "RegisterHandlers")
+
+	// The routers which aren't built in can't be combined with others
+	opts.GenerateEchoServer = true
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+
+	opts.GenerateEchoServer = false
+	opts.ServerRouters = []ServerRouter{{Name: "empty"}}
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+
+	// Nor can iris and echo v5
+	artifacts, _, err = Generate(context.Background(), swagger, Options{PackageName: "api", GenerateTypes: true, GenerateIrisServer: true})
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `"github.com/kataras/iris/v12"`)
+	assert.Contains(t, artifacts.Code, "FindPetByID(ctx iris.Context, id int64)")
+
+	artifacts, _, err = Generate(context.Background(), swagger, Options{PackageName: "api", GenerateTypes: true, GenerateEcho5Server: true})
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `"github.com/labstack/echo/v5"`)
+	assert.NotContains(t, artifacts.Code, `"github.com/labstack/echo/v4"`)
+	assert.Contains(t, artifacts.Code, "FindPetByID(ctx *echo.Context, id int64) error")
+
+	_, _, err = Generate(context.Background(), swagger, Options{PackageName: "api", GenerateTypes: true, GenerateEcho5Server: true, GenerateChiServer: true})
+	assert.Error(t, err)
+	_, _, err = Generate(context.Background(), swagger, Options{PackageName: "api", GenerateTypes: true, GenerateEcho5Server: true, GenerateIrisServer: true})
+	assert.Error(t, err)
+}
+
+func TestReadWriteModels(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        201:
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /owners:
+    get:
+      operationId: listOwners
+      responses:
+        200:
+          description: The owners
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Owner'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name, password]
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        password:
+          type: string
+          writeOnly: true
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:    "api",
+		GenerateTypes:  true,
+		GenerateClient: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, artifacts.Code, "type PetRequest")
+
+	opts.ReadWriteModels = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	// The full models are still generated
+	assert.Contains(t, code, "type Pet struct {\n\tId       int    `json:\"id\"`\n\tName     string `json:\"name\"`\n\tPassword string `json:\"password\"`\n}")
+	assert.Contains(t, code, "type PetRequest struct {\n\tName     string `json:\"name\"`\n\tPassword string `json:\"password\"`\n}")
+	assert.Contains(t, code, "type PetResponse struct {\n\tId   int    `json:\"id\"`\n\tName string `json:\"name\"`\n}")
+	// The schemas referring to them have variants too
+	assert.Contains(t, code, "type OwnerResponse struct {\n\tPets *[]PetResponse `json:\"pets,omitempty\"`\n}")
+	assert.Contains(t, code, "type AddPetJSONBody PetRequest")
+	assert.Contains(t, code, "JSON201      *PetResponse")
+	assert.Contains(t, code, "JSON200      *[]OwnerResponse")
+
+	// The variants can't take the names of other schemas
+	swagger.Components.Schemas["PetRequest"] = swagger.Components.Schemas["Owner"]
+	opts.SkipPrune = true
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+}
+
+func TestNullableType(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, nick]
+      properties:
+        name:
+          type: string
+        nick:
+          type: string
+          nullable: true
+        tag:
+          type: string
+          nullable: true
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:   "api",
+		GenerateTypes: true,
+		SkipPrune:     true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, "Tag  *string `json:\"tag\"`")
+
+	opts.NullableType = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	// Absent optional values are omitted, and absent required ones are null
+	assert.Contains(t, code, "Nick runtime.Nullable[string] `json:\"nick\"`")
+	assert.Contains(t, code, "Tag  runtime.Nullable[string] `json:\"tag,omitempty\"`")
+	assert.NotContains(t, code, "type Nullable[T any]")
+
+	// With another JSON package, they're of a generated type which uses it
+	opts.JSONPackage = "github.com/goccy/go-json"
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code = artifacts.Code
+	assert.Contains(t, code, "Nick Nullable[string] `json:\"nick\"`")
+	assert.Contains(t, code, "type Nullable[T any] runtime.Nullable[T]")
+	assert.Contains(t, code, "return json.Marshal(value)")
+	assert.Contains(t, code, `json "github.com/goccy/go-json"`)
+}
+
+func TestOptionalValues(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tag:
+          type: string
+        toys:
+          type: array
+          items:
+            type: string
+        owner:
+          type: object
+          properties:
+            name:
+              type: string
+        nick:
+          type: string
+          nullable: true
+        age:
+          type: integer
+          x-go-type-skip-optional-pointer: false
+      additionalProperties:
+        type: string
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:   "api",
+		GenerateTypes: true,
+		SkipPrune:     true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Regexp(t, "Tag +\\*string", artifacts.Code)
+
+	opts.OptionalValues = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	assert.Regexp(t, "Tag +string +`json:\"tag,omitempty\"`", code)
+	assert.Regexp(t, "Toys +\\[\\]string +`json:\"toys,omitempty\"`", code)
+	assert.Regexp(t, "Name +string +`json:\"name,omitempty\"`", code)
+	// omitempty doesn't omit structs, and null values need a pointer
+	assert.Contains(t, code, "Owner *struct {")
+	assert.Regexp(t, "Nick +\\*string +`json:\"nick\"`", code)
+	// The extension takes precedence
+	assert.Regexp(t, "Age +\\*int +`json:\"age,omitempty\"`", code)
+	// The additional properties are marshaled with the same conditions
+	assert.Contains(t, code, "if a.Tag != \"\" {")
+	assert.Contains(t, code, "if a.Toys != nil {")
+}
+
+func TestTimeFormats(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Birthday:
+      type: string
+      x-go-time-format: "20060102"
+    Pet:
+      type: object
+      properties:
+        born:
+          $ref: '#/components/schemas/Birthday'
+        seen:
+          type: integer
+          x-go-time-format: unix-millis
+        since:
+          type: string
+          format: yyyymm
+        day:
+          type: string
+          format: date
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:    "api",
+		GenerateTypes:  true,
+		SkipPrune:      true,
+		OptionalValues: true,
+		TimeFormats:    map[string]string{"yyyymm": "200601"},
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+
+	assert.Contains(t, code, "type Birthday struct {\n\ttime.Time\n}")
+	assert.Contains(t, code, "type PetSeen struct {\n\ttime.Time\n}")
+	assert.Contains(t, code, "type PetSince struct {\n\ttime.Time\n}")
+	// The types are structs, which omitempty doesn't omit
+	assert.Regexp(t, "Born +\\*Birthday", code)
+	assert.Regexp(t, "Seen +\\*PetSeen", code)
+	assert.Regexp(t, "Day +\\*openapi_types.Date", code)
+
+	assert.Contains(t, code, "func (t Birthday) TimeLayout() string {\n\treturn \"20060102\"\n}")
+	assert.Contains(t, code, "func (t PetSeen) TimeLayout() string {\n\treturn \"unix-millis\"\n}")
+	assert.Contains(t, code, "func (t PetSince) TimeLayout() string {\n\treturn \"200601\"\n}")
+	assert.Contains(t, code, "func (t *PetSince) UnmarshalJSON(data []byte) error {")
+	assert.Contains(t, code, "func (t *PetSince) Bind(src string) error {")
+}
+
+func TestUUIDTypeMapping(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+      responses:
+        '204':
+          description: found
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          type: string
+          format: uuid
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:    "api",
+		GenerateTypes:  true,
+		GenerateClient: true,
+		SkipPrune:      true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	assert.Contains(t, code, `"github.com/google/uuid"`)
+	assert.Regexp(t, "Owner +\\*uuid.UUID", code)
+	assert.Contains(t, code, "func NewGetPetRequest(server string, id uuid.UUID) (*http.Request, error) {")
+
+	// UUIDs are arrays, which omitempty doesn't omit
+	opts.OptionalValues = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Regexp(t, "Owner +\\*uuid.UUID", artifacts.Code)
+
+	// The mapping of uuid strings can be overridden, eg, to plain strings
+	opts.TypeMappings = map[string]TypeMapping{"string/uuid": {GoType: "string"}}
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Regexp(t, "Owner +\\*string", artifacts.Code)
+	assert.NotContains(t, artifacts.Code, "uuid")
+
+	opts.TypeMappings = map[string]TypeMapping{"string/uuid": {GoType: "uuid.UUID", Import: "github.com/gofrs/uuid"}}
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `"github.com/gofrs/uuid"`)
+	assert.NotContains(t, artifacts.Code, `"github.com/google/uuid"`)
+}
+
+func TestByteEncoding(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Keys
+  version: 1.0.0
+paths:
+  /keys:
+    get:
+      operationId: findKeys
+      parameters:
+        - name: fingerprint
+          in: query
+          schema:
+            type: string
+            format: byte
+      responses:
+        '200':
+          description: found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Key'
+components:
+  schemas:
+    Key:
+      type: object
+      required: [data]
+      properties:
+        data:
+          type: string
+          format: byte
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:        "api",
+		GenerateTypes:      true,
+		GenerateClient:     true,
+		GenerateEchoServer: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Regexp(t, "Data +openapi_types.Base64 ", artifacts.Code)
+	assert.Regexp(t, "Fingerprint +\\*openapi_types.Base64 ", artifacts.Code)
+
+	opts.ByteEncoding = ByteEncodingURL
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Regexp(t, "Data +openapi_types.Base64URL ", artifacts.Code)
+	assert.Regexp(t, "Fingerprint +\\*openapi_types.Base64URL ", artifacts.Code)
+
+	opts.ByteEncoding = "hex"
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.EqualError(t, err, "unknown byte encoding hex, valid options: std, url")
+}
+
+func TestTypeMappings(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{price}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: price
+          in: path
+          required: true
+          schema:
+            type: string
+            format: decimal
+      responses:
+        '204':
+          description: found
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        price:
+          type: string
+          format: decimal
+        age:
+          type: integer
+        weight:
+          type: integer
+          format: int32
+        wait:
+          type: string
+          format: duration
+          x-go-type: string
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:    "api",
+		GenerateTypes:  true,
+		GenerateClient: true,
+		SkipPrune:      true,
+		TypeMappings: map[string]TypeMapping{
+			"string/decimal":  {GoType: "decimal.Decimal", Import: "github.com/shopspring/decimal"},
+			"integer":         {GoType: "int64"},
+			"string/duration": {GoType: "time.Duration", Import: "time"},
+		},
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	assert.Contains(t, code, `"github.com/shopspring/decimal"`)
+	assert.Regexp(t, "Price +\\*decimal.Decimal", code)
+	assert.Regexp(t, "Age +\\*int64", code)
+	// The format doesn't match the mapping of the type alone
+	assert.Regexp(t, "Weight +\\*int32", code)
+	// x-go-type takes precedence
+	assert.Regexp(t, "Wait +\\*string", code)
+	assert.Contains(t, code, "func NewGetPetRequest(server string, price decimal.Decimal) (*http.Request, error) {")
+
+	// Without goimports, the packages of the standard library are imported
+	// too, once.
+	opts.SkipFmt = true
+	opts.TypeMappings["number/big"] = TypeMapping{GoType: "*big.Float", Import: "math/big"}
+	opts.TypeMappings["string/duration"] = TypeMapping{GoType: "time.Duration", Import: "time"}
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code = artifacts.Code
+	assert.Contains(t, code, "\t\"math/big\"\n")
+	assert.Equal(t, 1, strings.Count(code, "\t\"time\"\n"))
+	assert.Contains(t, code, "\t\"github.com/shopspring/decimal\"\n")
+	opts.SkipFmt = false
+
+	opts.TypeMappings = map[string]TypeMapping{"array/ints": {GoType: "[]int"}}
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.EqualError(t, err, `type mapping "array/ints": expected a type/format of a string, integer, number or boolean`)
+
+	opts.TypeMappings = map[string]TypeMapping{"string/decimal": {GoType: "Decimal", Import: "github.com/shopspring/decimal"}}
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.EqualError(t, err, `type mapping "string/decimal": go-type Decimal isn't qualified by the name of package github.com/shopspring/decimal`)
+}
+
+func TestApplyDefaults(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            format: int32
+            default: 20
+        - name: fields
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          default: dog
+        born:
+          type: string
+          format: date-time
+          default: '2020-01-01T00:00:00Z'
+        owner:
+          type: object
+          properties:
+            city:
+              type: string
+              default: Berlin
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:       "api",
+		GenerateTypes:     true,
+		GenerateClient:    true,
+		GenerateChiServer: true,
+		SkipPrune:         true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, artifacts.Code, "ApplyDefaults")
+
+	opts.ApplyDefaults = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	assert.Contains(t, code, `func (v *Pet) ApplyDefaults() {
+	if v.Owner != nil {
+		if v.Owner.City == nil {
+			value := "Berlin"
+			v.Owner.City = &value
+		}
+	}
+	if v.Tag == nil {
+		value := "dog"
+		v.Tag = &value
+	}
+}`)
+	assert.Contains(t, code, "value := int32(20)")
+	assert.Contains(t, code, "params.ApplyDefaults()")
+	assert.Contains(t, code, "runtime.ApplyDefaults(&dest)")
+
+	// Optional values can't tell absent fields from zero ones
+	opts.OptionalValues = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `	if v.Tag == "" {
+		v.Tag = "dog"
+	}`)
+}
+
+func TestValidateTags(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+      responses:
+        '204':
+          description: pets
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, age]
+      properties:
+        name:
+          type: string
+          minLength: 1
+          maxLength: 10
+        age:
+          type: integer
+          minimum: 0
+          exclusiveMaximum: true
+          maximum: 30
+        kind:
+          type: string
+          enum: [cat, dog]
+        tags:
+          type: array
+          minItems: 1
+          items:
+            type: string
+            maxLength: 3
+        born:
+          type: string
+          format: date-time
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:   "api",
+		GenerateTypes: true,
+		SkipPrune:     true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, artifacts.Code, "validate:")
+
+	opts.ValidateTags = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	assert.Contains(t, code, "`json:\"name\" validate:\"required,min=1,max=10\"`")
+	// Zero numbers are valid values
+	assert.Contains(t, code, "`json:\"age\" validate:\"gte=0,lt=30\"`")
+	assert.Contains(t, code, "`json:\"kind,omitempty\" validate:\"omitempty,oneof=cat dog\"`")
+	assert.Contains(t, code, "`json:\"tags,omitempty\" validate:\"omitempty,min=1,dive,max=3\"`")
+	assert.Regexp(t, "Born +\\*time.Time +`json:\"born,omitempty\"`", code)
+	assert.Contains(t, code, "`json:\"limit,omitempty\" validate:\"omitempty,gte=1,lte=100\"`")
+}
+
+func TestValidateMethods(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+      responses:
+        '204':
+          description: pets
+    post:
+      operationId: addPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: added
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, tags]
+      properties:
+        name:
+          type: string
+          minLength: 1
+          pattern: '^[a-z]+$'
+        code:
+          type: string
+          pattern: '^(?!x)'
+        kind:
+          type: string
+          enum: [cat, dog]
+        tags:
+          type: array
+          items:
+            type: string
+            maxLength: 3
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:        "api",
+		GenerateTypes:      true,
+		GenerateClient:     true,
+		GenerateEchoServer: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.NotContains(t, artifacts.Code, "Validate()")
+
+	opts.ValidateMethods = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	assert.Contains(t, code, `regexp.MustCompile("^[a-z]+$"),`)
+	// Lookaheads aren't Go regular expressions
+	assert.NotContains(t, code, "?!x")
+	assert.Contains(t, code, `func (v *Pet) Validate() error {
+	if v.Kind != nil {
+		if err := v.Kind.Validate(); err != nil {
+			return runtime.PrefixValidationError(err, "kind")
+		}
+	}
+	if utf8.RuneCountInString(v.Name) < 1 {
+		return runtime.NewValidationError("name", "length must be at least 1")
+	}
+	if !validationPatterns[0].MatchString(v.Name) {
+		return runtime.NewValidationError("name", "must match ^[a-z]+$")
+	}
+	if v.Tags == nil {
+		return runtime.NewValidationError("tags", "is required")
+	}
+	for i := range v.Tags {
+		if utf8.RuneCountInString(v.Tags[i]) > 3 {
+			return runtime.NewValidationError("tags["+strconv.Itoa(i)+"]", "length must be at most 3")
+		}
+	}
+	return nil
+}`)
+	assert.Contains(t, code, `case "cat", "dog":`)
+	assert.Contains(t, code, `bindErr := runtime.NewParamValidationError(err, map[string]runtime.ParamLocation{"limit": runtime.ParamLocationQuery})`)
+	assert.NotContains(t, code, "body.Validate()")
+
+	opts.ValidateClientRequests = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, "body.Validate()")
+}
+
+func TestParamsBuilders(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            format: int32
+            minimum: 1
+            maximum: 100
+        - name: offset
+          in: query
+          schema:
+            type: integer
+        - name: sort
+          in: query
+          schema:
+            type: string
+            enum: [name, age]
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+              maxLength: 3
+        - name: code
+          in: query
+          schema:
+            type: string
+            pattern: '^[a-z]+$'
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: string
+        - name: X-Filter
+          in: header
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  species:
+                    type: string
+      responses:
+        '204':
+          description: found
+`
+	generate := func(opts Options) string {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		assert.NoError(t, err)
+		opts.PackageName = "api"
+		opts.GenerateTypes = true
+		opts.GenerateClient = true
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		assert.NoError(t, err)
+		return artifacts.Code
+	}
+
+	code := generate(Options{})
+	assert.NotContains(t, code, "NewFindPetsParams")
+	assert.NotContains(t, code, "runtime.ValidationErrors")
+
+	code = generate(Options{ParamsBuilders: true})
+	// The errors are kept behind a pointer, so that the parameters stay
+	// comparable
+	assert.Regexp(t, "errs +\\*runtime.ValidationErrors\n}", code)
+	assert.Contains(t, code, "func NewFindPetsParams() *FindPetsParams {")
+	assert.Contains(t, code, "func (p *FindPetsParams) WithLimit(value int32) *FindPetsParams {")
+	assert.Contains(t, code, `return runtime.NewValidationError("limit", "must be at most 100")`)
+	assert.Contains(t, code, "p.Limit = &value")
+	assert.Contains(t, code, "p.XRequestId = value")
+	assert.Contains(t, code, `return runtime.NewValidationError("sort", "must be one of name, age")`)
+	assert.Contains(t, code, `return runtime.NewValidationError("tags["+strconv.Itoa(i)+"]", "length must be at most 3")`)
+	assert.Contains(t, code, "if !paramsPatterns[0].MatchString(v) {")
+	// Parameters without constraints are set without checks
+	assert.Contains(t, code, "func (p *FindPetsParams) WithOffset(value int) *FindPetsParams {\n\tp.Offset = &value")
+	// Inline objects have types of their own
+	assert.Contains(t, code, "type FindPetsParams_XFilter struct {")
+	assert.Contains(t, code, "func (p *FindPetsParams) WithXFilter(value FindPetsParams_XFilter) *FindPetsParams {")
+	// The requests fail with the errors
+	assert.Contains(t, code, "if err := params.BuildError(); err != nil {")
+
+	// Types with Validate methods check their values with them
+	code = generate(Options{ParamsBuilders: true, ValidateMethods: true})
+	assert.Contains(t, code, `return runtime.PrefixValidationError(err, "sort")`)
+}
+
+func TestEmbedSpecFile(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:   "api",
+		GenerateTypes: true,
+		EmbedSpec:     true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, "var swaggerSpec = []string{")
+	assert.Contains(t, artifacts.Code, "rawSpecOnce.Do(")
+	assert.Empty(t, artifacts.Files)
+
+	opts.EmbedSpecFile = "api.gen.json"
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `_ "embed"`)
+	assert.Contains(t, artifacts.Code, "//go:embed api.gen.json\nvar swaggerSpec []byte")
+	assert.NotContains(t, artifacts.Code, "gzip")
+	spec, err := openapi3.NewLoader().LoadFromData(artifacts.Files["api.gen.json"])
+	assert.NoError(t, err)
+	assert.Equal(t, swagger.Info.Title, spec.Info.Title)
+
+	opts.EmbedSpecFile = "../api.json"
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+}
+
+func TestBOMStripper(t *testing.T) {
+	// Marks are removed wherever the writes split them
+	code := []byte("package api\uFEFF\n// \uFEFFPet\n")
+	for i := 0; i <= len(code); i++ {
+		for j := i; j <= len(code); j++ {
+			var out bytes.Buffer
+			stripper := &bomStripper{w: &out}
+			for _, part := range [][]byte{code[:i], code[i:j], code[j:]} {
+				n, err := stripper.Write(part)
+				require.NoError(t, err)
+				assert.Equal(t, len(part), n)
+			}
+			require.NoError(t, stripper.Flush())
+			assert.Equal(t, "package api\n// Pet\n", out.String())
+		}
+	}
+
+	// Bytes which only look like the start of a mark are kept
+	var out bytes.Buffer
+	stripper := &bomStripper{w: &out}
+	_, err := stripper.Write([]byte("a\xef\xbb"))
+	require.NoError(t, err)
+	assert.Equal(t, "a", out.String())
+	require.NoError(t, stripper.Flush())
+	assert.Equal(t, "a\xef\xbb", out.String())
+}
+
+func TestClientWithoutSpec(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+	assert.NoError(t, err)
+
+	// Without the spec, the code doesn't depend on kin-openapi, even when
+	// it's not formatted, which removes the unused imports.
+	for _, skipFmt := range []bool{false, true} {
+		opts := Options{
+			PackageName:    "api",
+			GenerateTypes:  true,
+			GenerateClient: true,
+			SkipFmt:        skipFmt,
+		}
+		artifacts, _, err := Generate(context.Background(), swagger, opts)
+		assert.NoError(t, err)
+		assert.NotContains(t, artifacts.Code, "kin-openapi")
+		assert.NotContains(t, artifacts.Code, "compress/gzip")
+		assert.NotContains(t, artifacts.Code, "GetSwagger")
+	}
+}
+
+func TestDocsUI(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+	assert.NoError(t, err)
+	swagger.Info.Title = "Pets <`v1`>"
+
+	opts := Options{
+		PackageName:       "api",
+		GenerateTypes:     true,
+		GenerateChiServer: true,
+		EmbedSpec:         true,
+		DocsUI:            DocsUIRedoc,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	assert.Contains(t, code, `<redoc spec-url="openapi.json"></redoc>`)
+	assert.Contains(t, code, "<title>Pets &lt;&#96;v1&#96;&gt;</title>")
+	assert.Contains(t, code, "func ServeSpec(w http.ResponseWriter, r *http.Request) {")
+	assert.Contains(t, code, `r.Get(options.BaseURL+"/openapi.json", ServeSpec)`)
+	assert.Contains(t, code, `r.Get(options.BaseURL+"/docs", ServeDocs)`)
+
+	opts.DocsUI = DocsUISwaggerUI
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, `SwaggerUIBundle({url: "openapi.json", dom_id: "#swagger-ui"})`)
+
+	opts.DocsUI = "rapidoc"
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+
+	opts.DocsUI = DocsUISwaggerUI
+	opts.EmbedSpec = false
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+}
+
+func TestTypesPackage(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:    "client",
+		GenerateClient: true,
+		TypesPackage:   "example.com/api/types",
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	code := artifacts.Code
+	assert.Contains(t, code, `types "example.com/api/types"`)
+	assert.Contains(t, code, "Test                = types.Test")
+	assert.Contains(t, code, "GetTestByNameParams = types.GetTestByNameParams")
+	assert.Contains(t, code, "CatDeadCauseCar    = types.CatDeadCauseCar")
+	assert.NotContains(t, code, "type Test struct")
+	assert.Contains(t, code, "func (c *Client) GetTestByName(ctx context.Context, name string, params *GetTestByNameParams")
+
+	// The types can't be declared, and imported as well
+	opts.GenerateTypes = true
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+}
+
+func TestOperationTimeout(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Reports
+  version: 1.0.0
+paths:
+  /reports:
+    get:
+      operationId: listReports
+      x-timeout: 1m30s
+      responses:
+        '204':
+          description: reports
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	assert.NoError(t, err)
+
+	opts := Options{
+		PackageName:       "api",
+		GenerateClient:    true,
+		GenerateChiServer: true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, "ctx, cancel := context.WithTimeout(ctx, 90*time.Second)")
+	assert.Contains(t, artifacts.Code, "return runtime.CancelOnClose(rsp, err, cancel)")
+	assert.NotContains(t, artifacts.Code, "http.TimeoutHandler")
+
+	opts.ServerDeadlines = true
+	artifacts, _, err = Generate(context.Background(), swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, artifacts.Code, "handler = http.TimeoutHandler(http.HandlerFunc(handler), 90*time.Second, \"\").ServeHTTP")
+
+	swagger, err = openapi3.NewLoader().LoadFromData([]byte(strings.Replace(spec, "1m30s", "soon", 1)))
+	assert.NoError(t, err)
+	_, _, err = Generate(context.Background(), swagger, opts)
+	assert.Error(t, err)
+}
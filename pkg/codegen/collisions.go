@@ -0,0 +1,186 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// These are the strategies for resolving operations which end up with the
+// same Go name.
+const (
+	// NameCollisionFail reports every collision in an error, this is the
+	// default.
+	NameCollisionFail = "fail"
+	// NameCollisionMethod suffixes colliding operations with their HTTP
+	// method, eg, GetPetPost.
+	NameCollisionMethod = "method"
+	// NameCollisionPath suffixes colliding operations with the static
+	// segments of their path, eg, GetPetV2Pets.
+	NameCollisionPath = "path"
+)
+
+// collidingOperation is an operation which shares its Go name with others.
+type collidingOperation struct {
+	method string
+	path   string
+	op     *openapi3.Operation
+}
+
+// operationGoNames groups all the operations in the spec by the Go name they
+// will be generated with, which is given by synthesized for the operations
+// whose IDs were synthesized.
+func operationGoNames(swagger *openapi3.T, synthesized map[*openapi3.Operation]string) (map[string][]collidingOperation, error) {
+	names := map[string][]collidingOperation{}
+	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
+		pathOps := swagger.Paths[requestPath].Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			op := pathOps[method]
+			name, ok := synthesized[op]
+			if !ok {
+				name = ToCamelCase(op.OperationID)
+			}
+			if op.OperationID == "" {
+				var err error
+				name, err = generateDefaultOperationID(method, requestPath)
+				if err != nil {
+					return nil, fmt.Errorf("error generating default OperationID for %s/%s: %s",
+						method, requestPath, err)
+				}
+			}
+			names[name] = append(names[name], collidingOperation{method: method, path: requestPath, op: op})
+		}
+	}
+	return names, nil
+}
+
+// collisionSuffix returns the suffix to add to a colliding operation under
+// the given strategy.
+func collisionSuffix(strategy string, c collidingOperation) string {
+	if strategy == NameCollisionMethod {
+		return ToCamelCase(strings.ToLower(c.method))
+	}
+	var segments []string
+	for _, segment := range strings.Split(c.path, "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			segments = append(segments, segment)
+		}
+	}
+	return ToCamelCase(strings.Join(segments, "-"))
+}
+
+// resolveOperationNameCollisions looks for operations which would be
+// generated with the same Go name, which would result in duplicate
+// declarations. Depending on the strategy, the colliding operations are
+// renamed, or an error listing all the collisions is returned. synthesized
+// are the Go names of the operations whose IDs were synthesized, which the
+// renamed ones are removed from.
+func resolveOperationNameCollisions(swagger *openapi3.T, strategy string, synthesized map[*openapi3.Operation]string) error {
+	switch strategy {
+	case "", NameCollisionFail, NameCollisionMethod, NameCollisionPath:
+	default:
+		return fmt.Errorf("unknown name collision strategy '%s', valid options: %s, %s, %s",
+			strategy, NameCollisionFail, NameCollisionMethod, NameCollisionPath)
+	}
+
+	names, err := operationGoNames(swagger, synthesized)
+	if err != nil {
+		return err
+	}
+
+	if strategy == NameCollisionMethod || strategy == NameCollisionPath {
+		for name, ops := range names {
+			if len(ops) < 2 {
+				continue
+			}
+			for _, c := range ops {
+				c.op.OperationID = name + collisionSuffix(strategy, c)
+				delete(synthesized, c.op)
+			}
+		}
+		// Suffixing may not be enough to tell the operations apart, so check
+		// again, and report whatever is left over.
+		names, err = operationGoNames(swagger, synthesized)
+		if err != nil {
+			return err
+		}
+	}
+
+	var collisions []string
+	for name, ops := range names {
+		if len(ops) < 2 {
+			continue
+		}
+		var locations []string
+		for _, c := range ops {
+			locations = append(locations, c.method+" "+c.path)
+		}
+		collisions = append(collisions, fmt.Sprintf("%s: %s", name, strings.Join(locations, ", ")))
+	}
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return fmt.Errorf("operations with colliding names:\n\t%s", strings.Join(collisions, "\n\t"))
+	}
+	return nil
+}
+
+// resolveTypeNameCollisions renames, with the suffix of the strategy, the
+// operations which define types colliding with the ones of other operations,
+// when these types are named after the operation, so that they get other
+// names. It returns whether any operation was renamed, in which case the
+// operations have to be described again. The collisions which are left, such
+// as the ones of types named with x-go-type-name, are reported by
+// checkTypeNameCollisions.
+func resolveTypeNameCollisions(ops []OperationDefinition, strategy string, synthesized map[*openapi3.Operation]string) bool {
+	if strategy != NameCollisionMethod && strategy != NameCollisionPath {
+		return false
+	}
+	definedBy := map[string]map[int]bool{}
+	for i, op := range ops {
+		for _, td := range op.TypeDefinitions {
+			if definedBy[td.TypeName] == nil {
+				definedBy[td.TypeName] = map[int]bool{}
+			}
+			definedBy[td.TypeName][i] = true
+		}
+	}
+	renamed := map[int]bool{}
+	for name, indexes := range definedBy {
+		if len(indexes) < 2 {
+			continue
+		}
+		for i := range indexes {
+			if strings.HasPrefix(name, ops[i].OperationId) {
+				renamed[i] = true
+			}
+		}
+	}
+	for i := range renamed {
+		op := ops[i]
+		op.Spec.OperationID = op.OperationId + collisionSuffix(strategy, collidingOperation{method: op.Method, path: op.Path})
+		delete(synthesized, op.Spec)
+	}
+	return len(renamed) > 0
+}
+
+// checkTypeNameCollisions returns an error listing all the type names which
+// are declared more than once.
+func checkTypeNameCollisions(types []TypeDefinition) error {
+	seen := map[string]int{}
+	for _, t := range types {
+		seen[t.TypeName]++
+	}
+	var collisions []string
+	for name, count := range seen {
+		if count > 1 {
+			collisions = append(collisions, name)
+		}
+	}
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return fmt.Errorf("types with colliding names: %s", strings.Join(collisions, ", "))
+	}
+	return nil
+}
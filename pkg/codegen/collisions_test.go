@@ -0,0 +1,174 @@
+package codegen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveOperationNameCollisions(t *testing.T) {
+	load := func(t *testing.T) *openapi3.T {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(collisionsTestFixture))
+		require.NoError(t, err)
+		return swagger
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		err := resolveOperationNameCollisions(load(t), "", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "GetPet: GET /pets/{id}, POST /pets/{id}, GET /v2/pets/{id}")
+		assert.Contains(t, err.Error(), "ListPets: GET /pets, GET /v2/pets")
+	})
+
+	t.Run("method", func(t *testing.T) {
+		swagger := load(t)
+		err := resolveOperationNameCollisions(swagger, NameCollisionMethod, nil)
+		require.Error(t, err)
+		// Only the operations which share a method are left colliding
+		assert.Contains(t, err.Error(), "GetPetGet: GET /pets/{id}, GET /v2/pets/{id}")
+		assert.NotContains(t, err.Error(), "GetPetPost")
+	})
+
+	t.Run("path", func(t *testing.T) {
+		swagger := load(t)
+		err := resolveOperationNameCollisions(swagger, NameCollisionPath, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "GetPetPets: GET /pets/{id}, POST /pets/{id}")
+		assert.Equal(t, "ListPetsV2Pets", swagger.Paths["/v2/pets"].Get.OperationID)
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		err := resolveOperationNameCollisions(load(t), "random", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckTypeNameCollisions(t *testing.T) {
+	assert.NoError(t, checkTypeNameCollisions([]TypeDefinition{{TypeName: "Pet"}, {TypeName: "Owner"}}))
+	err := checkTypeNameCollisions([]TypeDefinition{{TypeName: "Pet"}, {TypeName: "Owner"}, {TypeName: "Pet"}})
+	assert.EqualError(t, err, "types with colliding names: Pet")
+}
+
+func TestResolveTypeNameCollisions(t *testing.T) {
+	generate := func(t *testing.T, strategy string) (Artifacts, error) {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(typeCollisionsTestFixture))
+		require.NoError(t, err)
+		artifacts, _, err := Generate(context.Background(), swagger, Options{
+			PackageName:           "api",
+			GenerateTypes:         true,
+			GenerateClient:        true,
+			NameCollisionStrategy: strategy,
+		})
+		return artifacts, err
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		_, err := generate(t, NameCollisionFail)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "types with colliding names: GetStoreParams")
+	})
+
+	t.Run("method", func(t *testing.T) {
+		artifacts, err := generate(t, NameCollisionMethod)
+		require.NoError(t, err)
+		// Only the operation the colliding type is named after is renamed
+		assert.Contains(t, artifacts.Code, "type GetStoreGetParams struct {")
+		assert.Contains(t, artifacts.Code, "type GetStoreParams struct {")
+		assert.Contains(t, artifacts.Code, "GetStoreGet(ctx context.Context, params *GetStoreGetParams")
+		assert.Contains(t, artifacts.Code, "AddInventory(ctx context.Context, body AddInventoryJSONRequestBody")
+	})
+
+	t.Run("path", func(t *testing.T) {
+		artifacts, err := generate(t, NameCollisionPath)
+		require.NoError(t, err)
+		assert.Contains(t, artifacts.Code, "type GetStoreStoreParams struct {")
+	})
+}
+
+// The body of addInventory is named like the parameters of getStore.
+const typeCollisionsTestFixture = `
+openapi: 3.0.1
+info:
+  title: Type collisions
+  version: 1.0.0
+paths:
+  /inventory:
+    post:
+      operationId: addInventory
+      requestBody:
+        content:
+          application/json:
+            schema:
+              x-go-type-name: GetStoreParams
+              properties:
+                name:
+                  type: string
+      responses:
+        204:
+          description: ok
+  /store:
+    get:
+      operationId: getStore
+      parameters:
+        - name: name
+          in: query
+          schema:
+            type: string
+      responses:
+        204:
+          description: ok
+`
+
+const collisionsTestFixture = `
+openapi: 3.0.1
+
+info:
+  title: OpenAPI-CodeGen Test
+  version: 1.0.0
+
+paths:
+  /pets:
+    get:
+      operationId: list_pets
+      responses:
+        200:
+          description: Success
+  /v2/pets:
+    get:
+      operationId: listPets
+      responses:
+        200:
+          description: Success
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getPet
+      responses:
+        200:
+          description: Success
+    post:
+      operationId: getPet
+      responses:
+        200:
+          description: Success
+  /v2/pets/{id}:
+    get:
+      operationId: GetPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        200:
+          description: Success
+`
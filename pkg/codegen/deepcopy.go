@@ -0,0 +1,288 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DeepCopyDefinition describes the DeepCopy and DeepCopyInto methods of a
+// generated type.
+type DeepCopyDefinition struct {
+	TypeName string
+	Body     string // The statements of DeepCopyInto, which copy *in into *out
+}
+
+// GenerateDeepCopy generates DeepCopy and DeepCopyInto methods for the types
+// of the components and operations. Aliased types are skipped, since they
+// share the methods of the types they alias, as are interface types, which
+// can't have methods.
+func GenerateDeepCopy(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, opts Options) (string, error) {
+	return newSpecGenerator(swagger, opts).GenerateDeepCopy(t, swagger, ops)
+}
+
+func (g *generator) GenerateDeepCopy(t *template.Template, swagger *openapi3.T, ops []OperationDefinition) (string, error) {
+	types, err := g.generatedTypeDefinitions(t, swagger, ops, g.opts.ExcludeSchemas)
+	if err != nil {
+		return "", err
+	}
+
+	c := deepCopier{
+		g:          g,
+		types:      make(map[string]TypeDefinition),
+		aliasTypes: g.opts.AliasTypes,
+	}
+	for _, td := range types {
+		c.types[td.TypeName] = td
+	}
+
+	var defs []DeepCopyDefinition
+	for _, td := range types {
+		if !c.hasMethods(td) {
+			continue
+		}
+		defs = append(defs, DeepCopyDefinition{
+			TypeName: td.TypeName,
+			Body:     strings.TrimSpace(c.typeBody(td)),
+		})
+	}
+	return GenerateTemplates([]string{"deepcopy.tmpl"}, t, defs)
+}
+
+// deepCopier generates the code which deep copies values of generated types,
+// following the structure of their schemas. Types which aren't generated,
+// such as those of x-go-type, are copied by assignment.
+type deepCopier struct {
+	g          *generator
+	types      map[string]TypeDefinition
+	aliasTypes bool
+}
+
+func (c deepCopier) isAlias(td TypeDefinition) bool {
+	return c.aliasTypes && td.CanAlias()
+}
+
+// hasMethods tells whether the generated type has its own DeepCopyInto.
+func (c deepCopier) hasMethods(td TypeDefinition) bool {
+	if c.isAlias(td) {
+		return false
+	}
+	// Interface types can't have methods
+	s := td.Schema
+	seen := map[string]bool{td.TypeName: true}
+	for {
+		def, found := c.definition(s)
+		if !found || seen[def.TypeName] {
+			return s.TypeDecl() != "interface{}"
+		}
+		seen[def.TypeName] = true
+		s = def.Schema
+	}
+}
+
+// definition returns the generated type which the schema refers to by name.
+func (c deepCopier) definition(s Schema) (TypeDefinition, bool) {
+	td, found := c.types[s.TypeDecl()]
+	return td, found
+}
+
+// needsCopy tells whether assigning a value of the schema's type shares
+// memory with the original.
+func (c deepCopier) needsCopy(s Schema, seen map[string]bool) bool {
+	if td, found := c.definition(s); found {
+		if seen[td.TypeName] {
+			return true
+		}
+		seen[td.TypeName] = true
+		return c.needsCopy(td.Schema, seen)
+	}
+
+	goType := s.TypeDecl()
+	switch {
+	case s.ArrayType != nil, strings.HasPrefix(goType, "[]"),
+		strings.HasPrefix(goType, "map["), strings.HasPrefix(goType, "runtime.Opt["),
+		isNullableType(goType),
+		goType == "interface{}", goType == "json.RawMessage":
+		return true
+	case strings.HasPrefix(goType, "struct"):
+		if s.HasAdditionalProperties {
+			return true
+		}
+		for _, p := range s.Properties {
+			if strings.HasPrefix(p.GoTypeDef(), "*") || c.needsCopy(p.Schema, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typeBody returns the body of DeepCopyInto for a generated type.
+func (c deepCopier) typeBody(td TypeDefinition) string {
+	s := td.Schema
+	// The type is defined from another generated type, which is resolved
+	// through aliases, since those don't have methods of their own.
+	for {
+		def, found := c.definition(s)
+		if !found || def.TypeName == td.TypeName {
+			break
+		}
+		if c.hasMethods(def) {
+			if !c.needsCopy(s, map[string]bool{}) {
+				return "*out = *in"
+			}
+			return fmt.Sprintf("(*%s)(in).DeepCopyInto((*%s)(out))", def.TypeName, def.TypeName)
+		}
+		s = def.Schema
+	}
+
+	var w strings.Builder
+	if strings.HasPrefix(s.TypeDecl(), "struct") {
+		// Structs are copied, and then their fields which share memory are
+		// replaced.
+		w.WriteString("*out = *in\n")
+		c.structFields(&w, "in", "out", s)
+	} else {
+		c.value(&w, "*in", "*out", s)
+	}
+	return w.String()
+}
+
+// value writes the code which deep copies the value of in into out, which are
+// addressable expressions of the schema's type.
+func (c deepCopier) value(w *strings.Builder, in, out string, s Schema) {
+	if !c.needsCopy(s, map[string]bool{}) {
+		fmt.Fprintf(w, "%s = %s\n", out, in)
+		return
+	}
+
+	if td, found := c.definition(s); found {
+		if c.hasMethods(td) {
+			fmt.Fprintf(w, "%s.DeepCopyInto(%s)\n", receiver(in), addressOf(out))
+			return
+		}
+		s = td.Schema
+	}
+
+	goType := s.TypeDecl()
+	switch {
+	case goType == "interface{}":
+		fmt.Fprintf(w, "%s = runtime.DeepCopyJSONValue(%s)\n", out, in)
+	case strings.HasPrefix(goType, "runtime.Opt["), isNullableType(goType):
+		// The options of merge patches, and nullable fields, whose values
+		// are copied by assignment.
+		fmt.Fprintf(w, "if %s != nil {\n", in)
+		shadow(w, in, out)
+		fmt.Fprintf(w, "*out = make(%s, len(*in))\n", goType)
+		w.WriteString("for key, val := range *in {\n(*out)[key] = val\n}\n")
+		w.WriteString("}\n")
+	case strings.HasPrefix(goType, "map["):
+		// Untyped objects, whose values were decoded from JSON.
+		fmt.Fprintf(w, "if %s != nil {\n", in)
+		shadow(w, in, out)
+		fmt.Fprintf(w, "*out = make(%s, len(*in))\n", goType)
+		w.WriteString("for key, val := range *in {\n(*out)[key] = runtime.DeepCopyJSONValue(val)\n}\n")
+		w.WriteString("}\n")
+	case s.ArrayType != nil, strings.HasPrefix(goType, "[]"), goType == "json.RawMessage":
+		fmt.Fprintf(w, "if %s != nil {\n", in)
+		shadow(w, in, out)
+		fmt.Fprintf(w, "*out = make(%s, len(*in))\n", goType)
+		if s.ArrayType != nil && c.needsCopy(*s.ArrayType, map[string]bool{}) {
+			w.WriteString("for i := range *in {\n")
+			c.value(w, "(*in)[i]", "(*out)[i]", *s.ArrayType)
+			w.WriteString("}\n")
+		} else {
+			w.WriteString("copy(*out, *in)\n")
+		}
+		w.WriteString("}\n")
+	case strings.HasPrefix(goType, "struct"):
+		fmt.Fprintf(w, "%s = %s\n", out, in)
+		w.WriteString("{\n")
+		shadow(w, in, out)
+		c.structFields(w, "in", "out", s)
+		w.WriteString("}\n")
+	default:
+		fmt.Fprintf(w, "%s = %s\n", out, in)
+	}
+}
+
+// structFields writes the code which replaces the fields of the struct out,
+// which is a shallow copy of in, that share memory with in. Both are pointers
+// to the struct.
+func (c deepCopier) structFields(w *strings.Builder, in, out string, s Schema) {
+	// Structs merged from allOf embed the schemas they reference, whose
+	// fields aren't among the properties.
+	if s.OAPISchema != nil {
+		for _, sref := range s.OAPISchema.AllOf {
+			if !IsGoTypeReference(sref.Ref) {
+				continue
+			}
+			goType, err := c.g.RefPathToGoType(sref.Ref)
+			if err != nil {
+				continue
+			}
+			embedded := Schema{GoType: goType}
+			if c.needsCopy(embedded, map[string]bool{}) {
+				field := goType[strings.LastIndex(goType, ".")+1:]
+				c.value(w, in+"."+field, out+"."+field, embedded)
+			}
+		}
+	}
+
+	for _, p := range s.Properties {
+		field := p.GoFieldName()
+		if strings.HasPrefix(p.GoTypeDef(), "*") {
+			fmt.Fprintf(w, "if %s.%s != nil {\n", in, field)
+			fmt.Fprintf(w, "in, out := &%s.%s, &%s.%s\n", in, field, out, field)
+			fmt.Fprintf(w, "*out = new(%s)\n", p.Schema.TypeDecl())
+			c.value(w, "**in", "**out", p.Schema)
+			w.WriteString("}\n")
+		} else if c.needsCopy(p.Schema, map[string]bool{}) {
+			c.value(w, in+"."+field, out+"."+field, p.Schema)
+		}
+	}
+
+	if s.HasAdditionalProperties {
+		addl := *s.AdditionalPropertiesType
+		fmt.Fprintf(w, "if %s.AdditionalProperties != nil {\n", in)
+		fmt.Fprintf(w, "in, out := &%s.AdditionalProperties, &%s.AdditionalProperties\n", in, out)
+		fmt.Fprintf(w, "*out = make(map[string]%s, len(*in))\n", addl.TypeDecl())
+		w.WriteString("for key, val := range *in {\n")
+		if c.needsCopy(addl, map[string]bool{}) {
+			fmt.Fprintf(w, "var outVal %s\n", addl.TypeDecl())
+			c.value(w, "val", "outVal", addl)
+			w.WriteString("(*out)[key] = outVal\n")
+		} else {
+			w.WriteString("(*out)[key] = val\n")
+		}
+		w.WriteString("}\n}\n")
+	}
+}
+
+// shadow writes the declaration of in and out as the addresses of the
+// expressions in and out, unless they already are.
+func shadow(w *strings.Builder, in, out string) {
+	if addressOf(in) == "in" && addressOf(out) == "out" {
+		return
+	}
+	fmt.Fprintf(w, "in, out := %s, %s\n", addressOf(in), addressOf(out))
+}
+
+// addressOf returns an expression of the address of expr, which is simplified
+// for dereferences, eg, *in becomes in.
+func addressOf(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return expr[1:]
+	}
+	return "&" + expr
+}
+
+// receiver returns expr as the receiver of a pointer method.
+func receiver(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return "(" + expr + ")"
+	}
+	return expr
+}
@@ -0,0 +1,349 @@
+package codegen
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DefaultsDefinition describes the ApplyDefaults method of a generated type.
+type DefaultsDefinition struct {
+	TypeName string
+	Body     string // The statements of ApplyDefaults, which set the fields of v
+}
+
+// GenerateDefaults generates ApplyDefaults methods for the types of the
+// components and operations whose schemas, or the schemas of their fields,
+// declare defaults. Aliased types are skipped, like for DeepCopy.
+func GenerateDefaults(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, opts Options) (string, error) {
+	return newSpecGenerator(swagger, opts).GenerateDefaults(t, swagger, ops)
+}
+
+func (g *generator) GenerateDefaults(t *template.Template, swagger *openapi3.T, ops []OperationDefinition) (string, error) {
+	types, err := g.generatedTypeDefinitions(t, swagger, ops, g.opts.ExcludeSchemas)
+	if err != nil {
+		return "", err
+	}
+	d := newDefaulter(g, types, g.opts.AliasTypes)
+
+	var defs []DefaultsDefinition
+	for _, td := range types {
+		if !d.hasMethod(td) {
+			continue
+		}
+		defs = append(defs, DefaultsDefinition{
+			TypeName: td.TypeName,
+			Body:     strings.TrimSpace(d.typeBody(td)),
+		})
+	}
+	return GenerateTemplates([]string{"defaults.tmpl"}, t, defs)
+}
+
+// defaulter generates the code which sets the absent fields of values of
+// generated types to the defaults of their schemas. Fields are absent when
+// they're nil pointers, or zero values for the optional fields which aren't
+// pointers, which can't tell absent from zero.
+type defaulter struct {
+	g          *generator
+	types      map[string]TypeDefinition
+	aliasTypes bool
+}
+
+func newDefaulter(g *generator, types []TypeDefinition, aliasTypes bool) defaulter {
+	d := defaulter{
+		g:          g,
+		types:      make(map[string]TypeDefinition),
+		aliasTypes: aliasTypes,
+	}
+	for _, td := range types {
+		d.types[td.TypeName] = td
+	}
+	return d
+}
+
+// definition returns the generated type which the schema refers to by name.
+func (d defaulter) definition(s Schema) (TypeDefinition, bool) {
+	td, found := d.types[s.TypeDecl()]
+	return td, found
+}
+
+// hasMethod tells whether the generated type has its own ApplyDefaults.
+func (d defaulter) hasMethod(td TypeDefinition) bool {
+	if d.aliasTypes && td.CanAlias() {
+		return false
+	}
+	return d.hasDefaults(td.Schema, map[string]bool{td.TypeName: true})
+}
+
+// hasDefaults tells whether values of the schema's type have fields, at any
+// depth, which take defaults.
+func (d defaulter) hasDefaults(s Schema, seen map[string]bool) bool {
+	if td, found := d.definition(s); found {
+		if seen[td.TypeName] {
+			return false
+		}
+		seen[td.TypeName] = true
+		return d.hasDefaults(td.Schema, seen)
+	}
+
+	switch {
+	case s.ArrayType != nil:
+		return d.hasDefaults(*s.ArrayType, seen)
+	case strings.HasPrefix(s.TypeDecl(), "struct"):
+		for _, embedded := range d.g.embeddedTypes(s) {
+			if d.hasDefaults(embedded, seen) {
+				return true
+			}
+		}
+		for _, p := range s.Properties {
+			if d.g.defaultLiteral(p) != "" || d.hasDefaults(p.Schema, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// embeddedTypes returns the generated types which structs merged from allOf
+// embed, whose fields aren't among the properties.
+func (g *generator) embeddedTypes(s Schema) []Schema {
+	if s.OAPISchema == nil {
+		return nil
+	}
+	var embedded []Schema
+	for _, sref := range s.OAPISchema.AllOf {
+		if !IsGoTypeReference(sref.Ref) {
+			continue
+		}
+		goType, err := g.RefPathToGoType(sref.Ref)
+		if err != nil {
+			continue
+		}
+		embedded = append(embedded, Schema{GoType: goType})
+	}
+	return embedded
+}
+
+// typeBody returns the body of ApplyDefaults for a generated type.
+func (d defaulter) typeBody(td TypeDefinition) string {
+	s := td.Schema
+	// The type is defined from another generated type, whose method it
+	// calls, unless that's an alias.
+	for {
+		def, found := d.definition(s)
+		if !found || def.TypeName == td.TypeName {
+			break
+		}
+		if d.hasMethod(def) {
+			return fmt.Sprintf("(*%s)(v).ApplyDefaults()", def.TypeName)
+		}
+		s = def.Schema
+	}
+
+	var w strings.Builder
+	d.value(&w, "*v", s, 0)
+	return w.String()
+}
+
+// value writes the code which applies the defaults to the value of expr, an
+// addressable expression of the schema's type, at the given depth of arrays.
+func (d defaulter) value(w *strings.Builder, expr string, s Schema, depth int) {
+	if td, found := d.definition(s); found {
+		if d.hasMethod(td) {
+			fmt.Fprintf(w, "%s.ApplyDefaults()\n", selector(expr))
+			return
+		}
+		s = td.Schema
+	}
+
+	switch {
+	case s.ArrayType != nil:
+		index := fmt.Sprintf("i%d", depth)
+		if depth == 0 {
+			index = "i"
+		}
+		fmt.Fprintf(w, "for %s := range %s {\n", index, expr)
+		d.value(w, fmt.Sprintf("%s[%s]", receiver(expr), index), *s.ArrayType, depth+1)
+		w.WriteString("}\n")
+	case strings.HasPrefix(s.TypeDecl(), "struct"):
+		d.structFields(w, selector(expr), s, depth)
+	}
+}
+
+// structFields writes the code which applies the defaults to the fields of
+// the struct v, which is a struct, or a pointer to one.
+func (d defaulter) structFields(w *strings.Builder, v string, s Schema, depth int) {
+	for _, embedded := range d.g.embeddedTypes(s) {
+		if d.hasDefaults(embedded, map[string]bool{}) {
+			goType := embedded.GoType
+			d.value(w, v+"."+goType[strings.LastIndex(goType, ".")+1:], embedded, depth)
+		}
+	}
+
+	for _, p := range s.Properties {
+		field := v + "." + p.GoFieldName()
+		pointer := strings.HasPrefix(p.GoTypeDef(), "*")
+		if literal := d.g.defaultLiteral(p); literal != "" {
+			if pointer {
+				fmt.Fprintf(w, "if %s == nil {\n", field)
+				fmt.Fprintf(w, "value := %s\n", literal)
+				fmt.Fprintf(w, "%s = &value\n", field)
+				w.WriteString("}\n")
+			} else if absent := p.absenceCheck(field); absent != "" && !isZeroDefault(p.spec.Default) {
+				fmt.Fprintf(w, "if %s {\n", absent)
+				fmt.Fprintf(w, "%s = %s\n", field, literal)
+				w.WriteString("}\n")
+			}
+		} else if d.hasDefaults(p.Schema, map[string]bool{}) {
+			if pointer {
+				fmt.Fprintf(w, "if %s != nil {\n", field)
+				d.value(w, "*"+field, p.Schema, depth)
+				w.WriteString("}\n")
+			} else {
+				d.value(w, field, p.Schema, depth)
+			}
+		}
+	}
+}
+
+// absenceCheck returns the condition under which the optional field, which
+// isn't a pointer, is absent, or an empty string when it can't tell.
+func (p Property) absenceCheck(field string) string {
+	switch p.presence {
+	case "":
+		return ""
+	case "%s":
+		return "!" + field
+	default:
+		return strings.Replace(fmt.Sprintf(p.presence, field), " != ", " == ", 1)
+	}
+}
+
+// isZeroDefault tells whether the default is the zero value of its type,
+// which the fields which aren't pointers already have when they're absent.
+func isZeroDefault(value interface{}) bool {
+	if f, ok := numberValue(value); ok {
+		return f == 0
+	}
+	return value == false || value == ""
+}
+
+// defaultLiteral returns the Go expression of the default of the optional
+// field, or an empty string when it has none. Only the defaults of strings,
+// numbers, booleans, and arrays of them, which aren't of types with their own
+// encodings, are applied.
+func (g *generator) defaultLiteral(p Property) string {
+	if p.Required || p.spec == nil || p.spec.Default == nil {
+		return ""
+	}
+	goType := p.Schema.TypeDecl()
+	if strings.HasPrefix(goType, "runtime.") {
+		return ""
+	}
+	if p.spec.Type == "array" {
+		if p.spec.Items == nil || p.spec.Items.Value == nil {
+			return ""
+		}
+		values, ok := p.spec.Default.([]interface{})
+		if !ok {
+			return ""
+		}
+		items := make([]string, len(values))
+		for i, value := range values {
+			literal, _, ok := g.primitiveLiteral(p.spec.Items.Value, value)
+			if !ok {
+				return ""
+			}
+			items[i] = literal
+		}
+		return fmt.Sprintf("%s{%s}", goType, strings.Join(items, ", "))
+	}
+
+	literal, literalType, ok := g.primitiveLiteral(p.spec, p.spec.Default)
+	if !ok {
+		return ""
+	}
+	if goType != literalType {
+		return fmt.Sprintf("%s(%s)", goType, literal)
+	}
+	return literal
+}
+
+// primitiveLiteral returns the untyped Go constant of a value of the schema,
+// and its default type, unless the schema's values aren't plain strings,
+// numbers or booleans.
+func (g *generator) primitiveLiteral(schema *openapi3.Schema, value interface{}) (string, string, bool) {
+	if !g.isPlainSchema(schema) {
+		return "", "", false
+	}
+	switch schema.Type {
+	case "string":
+		s, ok := value.(string)
+		return strconv.Quote(s), "string", ok
+	case "boolean":
+		b, ok := value.(bool)
+		return strconv.FormatBool(b), "bool", ok
+	case "integer":
+		f, ok := numberValue(value)
+		if !ok || f != math.Trunc(f) {
+			return "", "", false
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), "int", true
+	case "number":
+		f, ok := numberValue(value)
+		if !ok {
+			return "", "", false
+		}
+		literal := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(literal, ".e") {
+			literal += ".0"
+		}
+		return literal, "float64", true
+	}
+	return "", "", false
+}
+
+// isPlainSchema tells whether the values of the schema are Go strings,
+// numbers, booleans or slices, rather than types with their own encodings,
+// such as times or the types of x-go-type.
+func (g *generator) isPlainSchema(schema *openapi3.Schema) bool {
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return false
+	}
+	if layout, _ := g.schemaTimeFormat(schema); layout != "" {
+		return false
+	}
+	if _, ok := g.schemaTypeMapping(schema); ok {
+		return false
+	}
+	if schema.Type == "string" {
+		switch schema.Format {
+		case "byte", "date", "date-time", "json":
+			return false
+		}
+	}
+	return true
+}
+
+// numberValue returns the number, as it's decoded from the spec.
+func numberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// selector returns the expression of the value of expr whose fields and
+// methods are selected, which drops the dereference of pointers.
+func selector(expr string) string {
+	return strings.TrimPrefix(expr, "*")
+}
@@ -0,0 +1,348 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// APIChange is a difference between the Go APIs generated from two versions
+// of a spec.
+type APIChange struct {
+	// Breaking is whether code using the old API may not compile against the
+	// new one.
+	Breaking bool `json:"breaking"`
+	// Symbol is the changed declaration, eg, "Pet.Name" for a field or a
+	// method.
+	Symbol  string `json:"symbol"`
+	Message string `json:"message"`
+}
+
+func (c APIChange) String() string {
+	kind := "compatible"
+	if c.Breaking {
+		kind = "breaking"
+	}
+	return fmt.Sprintf("%s: %s: %s", kind, c.Symbol, c.Message)
+}
+
+// SemverBump returns the version bump which the changes call for: "major"
+// when any is breaking, "minor" when there are any, and "patch" otherwise.
+func SemverBump(changes []APIChange) string {
+	bump := "patch"
+	for _, c := range changes {
+		if c.Breaking {
+			return "major"
+		}
+		bump = "minor"
+	}
+	return bump
+}
+
+// apiType is the API of an exported type.
+type apiType struct {
+	kind string // "struct", "interface" or "type"
+	// fields are the field types of structs, or the method signatures of
+	// interfaces.
+	fields map[string]string
+	// expr is the definition of other types.
+	expr string
+}
+
+// apiConst is an exported constant, typed constants being enum values.
+type apiConst struct {
+	typ   string
+	value string
+}
+
+// apiSurface is the exported API of a generated file.
+type apiSurface struct {
+	types  map[string]apiType
+	funcs  map[string]string // functions, and methods as "Type.Method", to their signature
+	consts map[string]apiConst
+	vars   map[string]string
+}
+
+// DiffAPI compares the exported API of two versions of generated code, and
+// returns the changes from the old one to the new one, ordered by symbol.
+// Removed declarations, changed types and signatures, and enum values which
+// are gone are breaking changes. Additions are compatible, including methods
+// added to interfaces for new operations, as otherwise any new operation
+// would call for a major version.
+func DiffAPI(oldCode string, newCode string) ([]APIChange, error) {
+	oldAPI, err := parseAPISurface(oldCode)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing old code: %w", err)
+	}
+	newAPI, err := parseAPISurface(newCode)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing new code: %w", err)
+	}
+
+	var changes []APIChange
+	add := func(breaking bool, symbol string, format string, args ...interface{}) {
+		changes = append(changes, APIChange{Breaking: breaking, Symbol: symbol, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for name, oldType := range oldAPI.types {
+		newType, found := newAPI.types[name]
+		if !found {
+			add(true, name, "type removed")
+			continue
+		}
+		if oldType.kind != newType.kind || oldType.expr != newType.expr {
+			add(true, name, "type changed from %s to %s", oldType.describe(), newType.describe())
+			continue
+		}
+		member := "field"
+		if oldType.kind == "interface" {
+			member = "method"
+		}
+		for field, oldFieldType := range oldType.fields {
+			newFieldType, found := newType.fields[field]
+			if !found {
+				add(true, name+"."+field, "%s removed", member)
+			} else if oldFieldType != newFieldType {
+				add(true, name+"."+field, "%s type changed from %s to %s", member, oldFieldType, newFieldType)
+			}
+		}
+		for field, newFieldType := range newType.fields {
+			if _, found := oldType.fields[field]; found {
+				continue
+			}
+			add(false, name+"."+field, "%s of type %s added", member, newFieldType)
+		}
+	}
+	for name, newType := range newAPI.types {
+		if _, found := oldAPI.types[name]; !found {
+			add(false, name, "type %s added", newType.describe())
+		}
+	}
+
+	for name, oldSig := range oldAPI.funcs {
+		newSig, found := newAPI.funcs[name]
+		if !found {
+			add(true, name, "removed")
+		} else if oldSig != newSig {
+			add(true, name, "signature changed from %s to %s", oldSig, newSig)
+		}
+	}
+	for name, newSig := range newAPI.funcs {
+		if _, found := oldAPI.funcs[name]; !found {
+			add(false, name, "added with signature %s", newSig)
+		}
+	}
+
+	for name, oldConst := range oldAPI.consts {
+		newConst, found := newAPI.consts[name]
+		switch {
+		case !found && oldConst.typ != "":
+			add(true, name, "value %s of enum %s removed", oldConst.value, oldConst.typ)
+		case !found:
+			add(true, name, "constant removed")
+		case oldConst != newConst:
+			add(true, name, "constant changed from %s to %s", oldConst.describe(), newConst.describe())
+		}
+	}
+	for name, newConst := range newAPI.consts {
+		if _, found := oldAPI.consts[name]; found {
+			continue
+		}
+		if newConst.typ != "" {
+			add(false, name, "value %s of enum %s added", newConst.value, newConst.typ)
+		} else {
+			add(false, name, "constant %s added", newConst.value)
+		}
+	}
+
+	for name, oldVar := range oldAPI.vars {
+		newVar, found := newAPI.vars[name]
+		if !found {
+			add(true, name, "variable removed")
+		} else if oldVar != newVar {
+			add(true, name, "variable type changed from %s to %s", oldVar, newVar)
+		}
+	}
+	for name := range newAPI.vars {
+		if _, found := oldAPI.vars[name]; !found {
+			add(false, name, "variable added")
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Symbol < changes[j].Symbol
+	})
+	return changes, nil
+}
+
+func (t apiType) describe() string {
+	if t.kind == "type" {
+		return t.expr
+	}
+	return t.kind
+}
+
+func (c apiConst) describe() string {
+	if c.typ == "" {
+		return c.value
+	}
+	return c.typ + " " + c.value
+}
+
+// parseAPISurface collects the exported declarations of a Go file.
+func parseAPISurface(code string) (*apiSurface, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "api.go", code, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	api := &apiSurface{
+		types:  map[string]apiType{},
+		funcs:  map[string]string{},
+		consts: map[string]apiConst{},
+		vars:   map[string]string{},
+	}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			name := decl.Name.Name
+			if !ast.IsExported(name) {
+				continue
+			}
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				recv := strings.TrimPrefix(types.ExprString(decl.Recv.List[0].Type), "*")
+				if !ast.IsExported(recv) {
+					continue
+				}
+				name = recv + "." + name
+			}
+			api.funcs[name] = signature(decl.Type)
+		case *ast.GenDecl:
+			// Constants without a type or value in a block repeat the last
+			// ones, like iota does.
+			var lastType, lastValue string
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if ast.IsExported(spec.Name.Name) {
+						api.types[spec.Name.Name] = describeType(spec)
+					}
+				case *ast.ValueSpec:
+					typ := ""
+					if spec.Type != nil {
+						typ = types.ExprString(spec.Type)
+					}
+					if decl.Tok == token.CONST && spec.Type == nil && len(spec.Values) == 0 {
+						typ = lastType
+					}
+					for i, name := range spec.Names {
+						value := lastValue
+						if i < len(spec.Values) {
+							value = types.ExprString(spec.Values[i])
+						}
+						lastType, lastValue = typ, value
+						if !ast.IsExported(name.Name) {
+							continue
+						}
+						if decl.Tok == token.CONST {
+							api.consts[name.Name] = apiConst{typ: typ, value: value}
+						} else {
+							api.vars[name.Name] = typ
+						}
+					}
+				}
+			}
+		}
+	}
+	return api, nil
+}
+
+func describeType(spec *ast.TypeSpec) apiType {
+	assign := ""
+	if spec.Assign.IsValid() {
+		assign = "= "
+	}
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		fields := map[string]string{}
+		for _, field := range t.Fields.List {
+			typ := types.ExprString(field.Type)
+			if len(field.Names) == 0 {
+				if name := embeddedFieldName(field.Type); ast.IsExported(name) {
+					fields[name] = typ
+				}
+			}
+			for _, name := range field.Names {
+				if ast.IsExported(name.Name) {
+					fields[name.Name] = typ
+				}
+			}
+		}
+		return apiType{kind: "struct", fields: fields, expr: assign}
+	case *ast.InterfaceType:
+		methods := map[string]string{}
+		for _, method := range t.Methods.List {
+			if len(method.Names) == 0 {
+				// Embedded interfaces
+				typ := types.ExprString(method.Type)
+				methods[typ] = typ
+				continue
+			}
+			if funcType, ok := method.Type.(*ast.FuncType); ok {
+				methods[method.Names[0].Name] = signature(funcType)
+			}
+		}
+		return apiType{kind: "interface", fields: methods, expr: assign}
+	default:
+		return apiType{kind: "type", expr: assign + types.ExprString(spec.Type)}
+	}
+}
+
+// embeddedFieldName returns the name by which an embedded field is selected,
+// which is its type name without a pointer or package, eg, "Pet" for
+// "*api.Pet".
+func embeddedFieldName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedFieldName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// signature returns the types of the parameters and results of a function,
+// without their names, eg, "func(context.Context, string) (*Pet, error)".
+func signature(funcType *ast.FuncType) string {
+	fieldTypes := func(fields *ast.FieldList) []string {
+		var list []string
+		if fields == nil {
+			return list
+		}
+		for _, field := range fields.List {
+			typ := types.ExprString(field.Type)
+			list = append(list, typ)
+			for i := 1; i < len(field.Names); i++ {
+				list = append(list, typ)
+			}
+		}
+		return list
+	}
+
+	sig := "func(" + strings.Join(fieldTypes(funcType.Params), ", ") + ")"
+	results := fieldTypes(funcType.Results)
+	switch len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
@@ -0,0 +1,154 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffAPI(t *testing.T) {
+	const oldCode = `package api
+
+const (
+	PetKindCat PetKind = "cat"
+	PetKindDog PetKind = "dog"
+)
+
+type PetKind string
+
+type Pet struct {
+	Kind PetKind ` + "`json:\"kind\"`" + `
+	Name string  ` + "`json:\"name\"`" + `
+	Age  int     ` + "`json:\"age\"`" + `
+}
+
+type ClientInterface interface {
+	GetPet(ctx context.Context, id string) (*http.Response, error)
+	ListPets(ctx context.Context) (*http.Response, error)
+}
+
+func NewGetPetRequest(server string, id string) (*http.Request, error) {
+	return nil, nil
+}
+
+func (c *Client) ListPets(ctx context.Context) (*http.Response, error) {
+	return nil, nil
+}
+`
+	const newCode = `package api
+
+const (
+	PetKindDog  PetKind = "dog"
+	PetKindFish PetKind = "fish"
+)
+
+type PetKind string
+
+type Pet struct {
+	Kind  PetKind ` + "`json:\"kind\"`" + `
+	Name  *string ` + "`json:\"name,omitempty\"`" + `
+	Owner string  ` + "`json:\"owner\"`" + `
+}
+
+type ClientInterface interface {
+	GetPet(ctx context.Context, id string) (*http.Response, error)
+	ListPets(ctx context.Context) (*http.Response, error)
+	DeletePet(ctx context.Context, id string) (*http.Response, error)
+}
+
+func NewGetPetRequest(server string, id int) (*http.Request, error) {
+	return nil, nil
+}
+`
+	changes, err := DiffAPI(oldCode, newCode)
+	require.NoError(t, err)
+
+	var descriptions []string
+	for _, c := range changes {
+		descriptions = append(descriptions, c.String())
+	}
+	assert.Equal(t, []string{
+		"breaking: Client.ListPets: removed",
+		"compatible: ClientInterface.DeletePet: method of type func(context.Context, string) (*http.Response, error) added",
+		"breaking: NewGetPetRequest: signature changed from func(string, string) (*http.Request, error) to func(string, int) (*http.Request, error)",
+		"breaking: Pet.Age: field removed",
+		"breaking: Pet.Name: field type changed from string to *string",
+		"compatible: Pet.Owner: field of type string added",
+		"breaking: PetKindCat: value \"cat\" of enum PetKind removed",
+		"compatible: PetKindFish: value \"fish\" of enum PetKind added",
+	}, descriptions)
+	assert.Equal(t, "major", SemverBump(changes))
+
+	changes, err = DiffAPI(oldCode, oldCode)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, "patch", SemverBump(changes))
+	assert.Equal(t, "minor", SemverBump([]APIChange{{Symbol: "Pet.Owner"}}))
+}
+
+func TestDiffAPIOperationAdded(t *testing.T) {
+	const oldCode = `package api
+
+type ServerInterface interface {
+	GetPet(w http.ResponseWriter, r *http.Request, id string)
+}
+`
+	const newCode = `package api
+
+type ServerInterface interface {
+	GetPet(w http.ResponseWriter, r *http.Request, id string)
+	DeletePet(w http.ResponseWriter, r *http.Request, id string)
+}
+
+type DeletePetParams struct {
+	Force *bool
+}
+`
+	changes, err := DiffAPI(oldCode, newCode)
+	require.NoError(t, err)
+	for _, c := range changes {
+		assert.False(t, c.Breaking, c.String())
+	}
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "minor", SemverBump(changes))
+}
+
+func TestDiffAPIEmbeddedFields(t *testing.T) {
+	const oldCode = `package api
+
+type Pet struct {
+	Animal
+	*externalRef0.Owner
+}
+`
+	changes, err := DiffAPI(oldCode, `package api
+
+type Pet struct {
+	*Animal
+	externalRef1.Owner
+}
+`)
+	require.NoError(t, err)
+	var descriptions []string
+	for _, c := range changes {
+		descriptions = append(descriptions, c.String())
+	}
+	// The embedded fields are still selected as Pet.Animal and Pet.Owner
+	assert.Equal(t, []string{
+		"breaking: Pet.Animal: field type changed from Animal to *Animal",
+		"breaking: Pet.Owner: field type changed from *externalRef0.Owner to externalRef1.Owner",
+	}, descriptions)
+
+	changes, err = DiffAPI(oldCode, `package api
+
+type Pet struct {
+	Animal
+	*externalRef0.Owner
+	Tags
+}
+`)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "compatible: Pet.Tags: field of type Tags added", changes[0].String())
+}
@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The documentation pages which Options.DocsUI can be.
+const (
+	DocsUISwaggerUI = "swagger-ui"
+	DocsUIRedoc     = "redoc"
+)
+
+// checkDocsUI returns an error when the documentation page can't be served
+// with the given options.
+func checkDocsUI(opts Options) error {
+	switch opts.DocsUI {
+	case "":
+		return nil
+	case DocsUISwaggerUI, DocsUIRedoc:
+	default:
+		return fmt.Errorf("unknown docs UI %s, valid options: %s, %s", opts.DocsUI, DocsUISwaggerUI, DocsUIRedoc)
+	}
+	if !opts.EmbedSpec {
+		return fmt.Errorf("the %s docs UI requires the spec to be embedded", opts.DocsUI)
+	}
+	return nil
+}
+
+// GenerateDocs generates the handlers which serve the embedded spec, and the
+// documentation page of opts.DocsUI, which the servers register.
+func GenerateDocs(t *template.Template, swagger *openapi3.T, opts Options) (string, error) {
+	title := ""
+	if swagger.Info != nil {
+		title = swagger.Info.Title
+	}
+	// The page is a raw string literal, which can't hold backquotes.
+	title = strings.ReplaceAll(html.EscapeString(title), "`", "&#96;")
+
+	return GenerateTemplates([]string{"docs.tmpl"}, t, struct {
+		UI    string
+		Title string
+	}{
+		UI:    opts.DocsUI,
+		Title: title,
+	})
+}
@@ -3,12 +3,34 @@ package codegen
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 const (
 	extPropGoType    = "x-go-type"
 	extPropOmitEmpty = "x-omitempty"
 	extPropExtraTags = "x-oapi-codegen-extra-tags"
+	// x-go-type-name names the Go type generated for an inline schema
+	extPropGoTypeName = "x-go-type-name"
+	// x-deprecated-reason allows a spec to explain why something was deprecated
+	extDeprecationReason = "x-deprecated-reason"
+	// x-websocket marks a GET operation which is upgraded to a WebSocket
+	extWebSocket = "x-websocket"
+	// x-go-type-skip-optional-pointer makes an optional field a plain value,
+	// or a pointer when it's false
+	extPropSkipOptionalPointer = "x-go-type-skip-optional-pointer"
+	// x-go-time-format is the layout of the time of a string, or integer,
+	// schema, which isn't RFC 3339
+	extPropTimeFormat = "x-go-time-format"
+	// x-timeout is the deadline of an operation, such as "5s", which the
+	// client, and optionally the server, hold its requests to
+	extTimeout = "x-timeout"
+	// x-max-response-size is the maximum size of the response bodies of an
+	// operation, in bytes, which the client reads
+	extMaxResponseSize = "x-max-response-size"
+	// const is the JSON Schema keyword of a schema with a single value, which
+	// OpenAPI 3.0 lacks, so it's kept among the extensions
+	propConst = "const"
 )
 
 func extTypeName(extPropValue interface{}) (string, error) {
@@ -49,3 +71,101 @@ func extExtraTags(extPropValue interface{}) (map[string]string, error) {
 	}
 	return tags, nil
 }
+
+func extParseDeprecationReason(extPropValue interface{}) (string, error) {
+	raw, ok := extPropValue.(json.RawMessage)
+	if !ok {
+		return "", fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	var reason string
+	if err := json.Unmarshal(raw, &reason); err != nil {
+		return "", fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	return reason, nil
+}
+
+func extParseWebSocket(extPropValue interface{}) (bool, error) {
+	raw, ok := extPropValue.(json.RawMessage)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	var isWebSocket bool
+	if err := json.Unmarshal(raw, &isWebSocket); err != nil {
+		return false, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	return isWebSocket, nil
+}
+
+func extParseSkipOptionalPointer(extPropValue interface{}) (bool, error) {
+	raw, ok := extPropValue.(json.RawMessage)
+	if !ok {
+		return false, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	var skip bool
+	if err := json.Unmarshal(raw, &skip); err != nil {
+		return false, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	return skip, nil
+}
+
+func extParseTimeFormat(extPropValue interface{}) (string, error) {
+	raw, ok := extPropValue.(json.RawMessage)
+	if !ok {
+		return "", fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	var layout string
+	if err := json.Unmarshal(raw, &layout); err != nil {
+		return "", fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	return layout, nil
+}
+
+func extParseTimeout(extPropValue interface{}) (time.Duration, error) {
+	raw, ok := extPropValue.(json.RawMessage)
+	if !ok {
+		return 0, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("timeout %s isn't positive", value)
+	}
+	return timeout, nil
+}
+
+func extParseMaxResponseSize(extPropValue interface{}) (int64, error) {
+	raw, ok := extPropValue.(json.RawMessage)
+	if !ok {
+		return 0, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	var size int64
+	if err := json.Unmarshal(raw, &size); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	if size <= 0 {
+		return 0, fmt.Errorf("size %d isn't positive", size)
+	}
+	return size, nil
+}
+
+func extParseConst(extPropValue interface{}) (interface{}, error) {
+	raw, ok := extPropValue.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("failed to convert type: %T", extPropValue)
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	switch value.(type) {
+	case string, float64, bool:
+		return value, nil
+	}
+	return nil, fmt.Errorf("const must be a string, number or boolean, got %s", raw)
+}
@@ -11,6 +11,21 @@ func filterOperationsByTag(swagger *openapi3.T, opts Options) {
 	}
 }
 
+// filterDeprecatedOperations removes all operations which are marked as
+// deprecated, when requested via ExcludeDeprecated.
+func filterDeprecatedOperations(swagger *openapi3.T, opts Options) {
+	if !opts.ExcludeDeprecated {
+		return
+	}
+	for _, pathItem := range swagger.Paths {
+		for name, op := range pathItem.Operations() {
+			if op.Deprecated {
+				pathItem.SetOperation(name, nil)
+			}
+		}
+	}
+}
+
 func excludeOperationsWithTags(paths openapi3.Paths, tags []string) {
 	includeOperationsWithTags(paths, tags, true)
 }
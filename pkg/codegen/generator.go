@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// generator holds the options of one generation, and the state derived from
+// them, which the code generating the types and the operations, and the
+// template functions, read. Each generation has its own, so that concurrent
+// generations don't share any state.
+type generator struct {
+	opts Options
+
+	// importMapping is the import of the package of each external reference
+	importMapping importMap
+
+	// readWriteModels are the request and response variants of the component
+	// schemas, by name and suffix, eg, "PetRequest". A schema has variants
+	// when it, or one of the schemas it refers to, has readOnly or writeOnly
+	// properties. It's only set with Options.ReadWriteModels.
+	readWriteModels map[string]*openapi3.SchemaRef
+
+	// synthesizedNames are the Go names of the operations whose IDs were
+	// synthesized, which don't depend on the casing of the IDs.
+	synthesizedNames map[*openapi3.Operation]string
+
+	// mirroredFieldTags are struct tag keys which are given the same value as
+	// the json tag of each generated field, such as "yaml" or "mapstructure".
+	mirroredFieldTags []string
+}
+
+// newGenerator returns the generator of the given options.
+func newGenerator(opts Options) *generator {
+	g := &generator{
+		opts:          opts,
+		importMapping: constructImportMapping(opts.ImportMapping),
+	}
+	if opts.YAMLTags {
+		g.mirroredFieldTags = append(g.mirroredFieldTags, "yaml")
+	}
+	if opts.MapstructureTags {
+		g.mirroredFieldTags = append(g.mirroredFieldTags, "mapstructure")
+	}
+	return g
+}
+
+// newSpecGenerator returns the generator of the given options for the spec,
+// which must have been filtered with them already.
+func newSpecGenerator(swagger *openapi3.T, opts Options) *generator {
+	g := newGenerator(opts)
+	if opts.ReadWriteModels && swagger.Components.Schemas != nil {
+		g.readWriteModels = newReadWriteModels(swagger.Components.Schemas)
+	}
+	return g
+}
+
+// defaultGenerator returns the generator of the default options, which the
+// exported functions, which don't take options, generate with.
+func defaultGenerator() *generator {
+	return newGenerator(Options{})
+}
@@ -17,7 +17,9 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"text/template"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -26,6 +28,36 @@ import (
 // This generates a gzipped, base64 encoded JSON representation of the
 // swagger definition, which we embed inside the generated code.
 func GenerateInlinedSpec(t *template.Template, importMapping importMap, swagger *openapi3.T) (string, error) {
+	return generateInlinedSpec(t, importMapping, swagger, "")
+}
+
+// EmbeddedSpec returns the JSON representation of the swagger definition
+// which the generated code embeds from the file of Options.EmbedSpecFile.
+func EmbeddedSpec(swagger *openapi3.T) ([]byte, error) {
+	encoded, err := json.MarshalIndent(swagger, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling swagger: %s", err)
+	}
+	return append(encoded, '\n'), nil
+}
+
+// generateInlinedSpec generates the embedded swagger definition, which is
+// embedded with go:embed from embedFile when it's set, rather than inlined.
+func generateInlinedSpec(t *template.Template, importMapping importMap, swagger *openapi3.T, embedFile string) (string, error) {
+	if embedFile != "" {
+		return GenerateTemplates(
+			[]string{"inline.tmpl"},
+			t,
+			struct {
+				SpecParts     []string
+				EmbedFile     string
+				ImportMapping importMap
+			}{
+				EmbedFile:     embedFile,
+				ImportMapping: importMapping,
+			})
+	}
+
 	// Marshal to json
 	encoded, err := swagger.MarshalJSON()
 	if err != nil {
@@ -66,9 +98,14 @@ func GenerateInlinedSpec(t *template.Template, importMapping importMap, swagger
 		t,
 		struct {
 			SpecParts     []string
+			EmbedFile     string
 			ImportMapping importMap
 		}{
 			SpecParts:     parts,
 			ImportMapping: importMapping,
 		})
 }
+
+// embedFileName matches the names of the files of Options.EmbedSpecFile,
+// which go:embed takes unquoted, in the directory of the generated code.
+var embedFileName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
@@ -0,0 +1,264 @@
+package codegen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// These are the severities of diagnostics.
+const (
+	// SeverityWarning is a construct which is generated in a degraded form, or
+	// ignored.
+	SeverityWarning = "warning"
+	// SeverityError is a problem which makes generation fail.
+	SeverityError = "error"
+)
+
+// Diagnostic is a construct in a spec which doesn't generate cleanly.
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	// Rule identifies the kind of issue, eg, "one-of".
+	Rule string `json:"rule"`
+	// Location is the position of the construct in the spec, as a JSON
+	// pointer, eg, "#/components/schemas/Pet/properties/kind".
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", d.Severity, d.Location, d.Message, d.Rule)
+}
+
+// linter collects the diagnostics found while walking a spec.
+type linter struct {
+	g           *generator
+	diagnostics []Diagnostic
+}
+
+func (l *linter) add(severity string, rule string, location string, format string, args ...interface{}) {
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		Severity: severity,
+		Rule:     rule,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Lint reports every construct in the spec which would be generated in a
+// degraded form, or ignored, with the given options, as well as anything
+// which would make generation fail, without generating any code. Like
+// Generate, it may modify the spec.
+func Lint(swagger *openapi3.T, opts Options) []Diagnostic {
+	l := lintSpec(swagger, opts)
+
+	// Anything else which makes generation fail, such as types with colliding
+	// names, is found by generating the code. This is only worth it when
+	// nothing has been found to fail already, since it stops at the first
+	// error.
+	if !l.hasErrors() {
+		if err := GenerateTo(ioutil.Discard, swagger, opts.PackageName, opts); err != nil {
+			l.add(SeverityError, "generation", "#", "%s", err)
+		}
+	}
+	return l.diagnostics
+}
+
+// lintSpec walks the parts of the spec which are generated with the given
+// options.
+func lintSpec(swagger *openapi3.T, opts Options) *linter {
+	l := &linter{g: newGenerator(opts)}
+
+	if err := filterSpec(swagger, opts); err != nil {
+		l.add(SeverityError, "generation", "#", "%s", err)
+		return l
+	}
+
+	for _, name := range SortedSchemaKeys(swagger.Components.Schemas) {
+		l.lintSchema(swagger.Components.Schemas[name], "#/components/schemas/"+escapeJSONPointer(name))
+	}
+	for _, name := range SortedParameterKeys(swagger.Components.Parameters) {
+		l.lintParameter(swagger.Components.Parameters[name], "#/components/parameters/"+escapeJSONPointer(name))
+	}
+	for _, name := range SortedRequestBodyKeys(swagger.Components.RequestBodies) {
+		l.lintRequestBody(swagger.Components.RequestBodies[name], "#/components/requestBodies/"+escapeJSONPointer(name))
+	}
+	for _, name := range SortedResponsesKeys(swagger.Components.Responses) {
+		l.lintResponse(swagger.Components.Responses[name], "#/components/responses/"+escapeJSONPointer(name))
+	}
+
+	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
+		pathItem := swagger.Paths[requestPath]
+		pathLocation := "#/paths/" + escapeJSONPointer(requestPath)
+		for i, param := range pathItem.Parameters {
+			l.lintParameter(param, pathLocation+"/parameters/"+strconv.Itoa(i))
+		}
+
+		pathOps := pathItem.Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			op := pathOps[method]
+			location := pathLocation + "/" + strings.ToLower(method)
+			if op.OperationID == "" {
+				name, err := generateDefaultOperationID(method, requestPath)
+				if err == nil {
+					l.add(SeverityWarning, "missing-operation-id", location,
+						"operation has no operationId, it is generated as %s", name)
+				}
+			}
+			for i, param := range op.Parameters {
+				l.lintParameter(param, location+"/parameters/"+strconv.Itoa(i))
+			}
+			l.lintRequestBody(op.RequestBody, location+"/requestBody")
+			for _, name := range SortedResponsesKeys(op.Responses) {
+				l.lintResponse(op.Responses[name], location+"/responses/"+escapeJSONPointer(name))
+			}
+		}
+	}
+
+	l.lintOperationNames(swagger, opts.NameCollisionStrategy)
+	return l
+}
+
+func (l *linter) hasErrors() bool {
+	for _, d := range l.diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// lintSchema checks a schema, and the schemas inline in it. Referenced
+// schemas are checked where they are defined.
+func (l *linter) lintSchema(sref *openapi3.SchemaRef, location string) {
+	if sref == nil || sref.Ref != "" || sref.Value == nil {
+		return
+	}
+	schema := sref.Value
+
+	// A custom type replaces whatever the schema would generate
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return
+	}
+	if schema.AnyOf != nil {
+		l.add(SeverityWarning, "any-of", location, "anyOf is generated as interface{}")
+		return
+	}
+	if schema.OneOf != nil {
+		l.add(SeverityWarning, "one-of", location, "oneOf is generated as interface{}")
+		return
+	}
+	if schema.Not != nil {
+		l.add(SeverityWarning, "not", location, "not is ignored")
+	}
+
+	for i, s := range schema.AllOf {
+		l.lintSchema(s, location+"/allOf/"+strconv.Itoa(i))
+	}
+	for _, name := range SortedSchemaKeys(schema.Properties) {
+		l.lintSchema(schema.Properties[name], location+"/properties/"+escapeJSONPointer(name))
+	}
+	l.lintSchema(schema.Items, location+"/items")
+	l.lintSchema(schema.AdditionalProperties, location+"/additionalProperties")
+}
+
+func (l *linter) lintParameter(pref *openapi3.ParameterRef, location string) {
+	if pref == nil || pref.Ref != "" || pref.Value == nil {
+		return
+	}
+	param := pref.Value
+	l.lintSchema(param.Schema, location+"/schema")
+
+	if len(param.Content) == 0 {
+		return
+	}
+	// The same as the generator, which only decodes a single JSON content
+	if l.g.jsonParamContent(param) == nil {
+		l.add(SeverityWarning, "parameter-content-type", location,
+			"parameter %s is passed through as a string, only a single JSON content is decoded", param.Name)
+		return
+	}
+	for _, contentType := range SortedContentKeys(param.Content) {
+		l.lintSchema(param.Content[contentType].Schema, location+"/content/"+escapeJSONPointer(contentType)+"/schema")
+	}
+}
+
+func (l *linter) lintRequestBody(bref *openapi3.RequestBodyRef, location string) {
+	if bref == nil || bref.Ref != "" || bref.Value == nil {
+		return
+	}
+	for _, contentType := range SortedContentKeys(bref.Value.Content) {
+		contentLocation := location + "/content/" + escapeJSONPointer(contentType)
+		if !l.g.isJSONContentType(contentType) {
+			l.add(SeverityWarning, "request-content-type", contentLocation,
+				"no typed request body is generated for %s, it can only be sent as a raw body", contentType)
+			continue
+		}
+		l.lintSchema(bref.Value.Content[contentType].Schema, contentLocation+"/schema")
+	}
+}
+
+func (l *linter) lintResponse(rref *openapi3.ResponseRef, location string) {
+	if rref == nil || rref.Ref != "" {
+		return
+	}
+	if rref.Value == nil {
+		l.add(SeverityWarning, "response-value", location, "response has no value, it is ignored")
+		return
+	}
+	for _, contentType := range SortedContentKeys(rref.Value.Content) {
+		content := rref.Value.Content[contentType]
+		contentLocation := location + "/content/" + escapeJSONPointer(contentType)
+		if content.Schema == nil {
+			continue
+		}
+		if !l.g.isJSONContentType(contentType) && !StringInArray(contentType, contentTypesYAML) &&
+			!StringInArray(contentType, contentTypesXML) {
+			l.add(SeverityWarning, "response-content-type", contentLocation,
+				"no typed response is generated for %s, it is only available as raw bytes", contentType)
+			continue
+		}
+		l.lintSchema(content.Schema, contentLocation+"/schema")
+	}
+}
+
+// lintOperationNames reports the operations which end up with the same Go
+// name, which are errors unless a strategy renames them.
+func (l *linter) lintOperationNames(swagger *openapi3.T, strategy string) {
+	names, err := operationGoNames(swagger, nil)
+	if err != nil {
+		l.add(SeverityError, "name-collision", "#/paths", "%s", err)
+		return
+	}
+	var colliding []string
+	for name, ops := range names {
+		if len(ops) > 1 {
+			colliding = append(colliding, name)
+		}
+	}
+	sort.Strings(colliding)
+
+	for _, name := range colliding {
+		ops := names[name]
+		var locations []string
+		for _, c := range ops {
+			locations = append(locations, c.method+" "+c.path)
+		}
+		location := "#/paths/" + escapeJSONPointer(ops[0].path) + "/" + strings.ToLower(ops[0].method)
+		if strategy == NameCollisionMethod || strategy == NameCollisionPath {
+			l.add(SeverityWarning, "name-collision", location,
+				"operations %s are all named %s, they are renamed by their %s", strings.Join(locations, ", "), name, strategy)
+		} else {
+			l.add(SeverityError, "name-collision", location,
+				"operations %s are all named %s", strings.Join(locations, ", "), name)
+		}
+	}
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
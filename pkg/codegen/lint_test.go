@@ -0,0 +1,122 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	load := func(t *testing.T) *openapi3.T {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(lintTestFixture))
+		require.NoError(t, err)
+		return swagger
+	}
+	rules := func(issues []Diagnostic) map[string]Diagnostic {
+		byRule := map[string]Diagnostic{}
+		for _, issue := range issues {
+			byRule[issue.Rule] = issue
+		}
+		return byRule
+	}
+
+	issues := rules(Lint(load(t), Options{PackageName: "api", GenerateTypes: true, GenerateClient: true}))
+	assert.Len(t, issues, 5)
+	assert.Equal(t, Diagnostic{
+		Severity: SeverityWarning,
+		Rule:     "one-of",
+		Location: "#/components/schemas/Pet/properties/kind",
+		Message:  "oneOf is generated as interface{}",
+	}, issues["one-of"])
+	assert.Equal(t, "#/paths/~1pets/get", issues["missing-operation-id"].Location)
+	assert.Equal(t, "#/paths/~1pets/get/responses/200/content/text~1csv", issues["response-content-type"].Location)
+	assert.Equal(t, "#/paths/~1pets/post/requestBody/content/application~1xml", issues["request-content-type"].Location)
+	assert.Equal(t, SeverityError, issues["name-collision"].Severity)
+	assert.Equal(t, "operations GET /pets, POST /pets are all named GetPets", issues["name-collision"].Message)
+
+	// Once the collision is resolved, the spec generates, with warnings
+	issues = rules(Lint(load(t), Options{PackageName: "api", GenerateTypes: true, GenerateClient: true, NameCollisionStrategy: NameCollisionMethod}))
+	assert.Equal(t, SeverityWarning, issues["name-collision"].Severity)
+	assert.NotContains(t, issues, "generation")
+}
+
+func TestLintParameterContent(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: Parameter content
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: getPets
+      parameters:
+        - name: filter
+          in: query
+          content:
+            application/vnd.pets+json:
+              schema:
+                type: object
+        - name: sort
+          in: query
+          content:
+            text/plain:
+              schema:
+                type: string
+      responses:
+        '204':
+          description: ok
+`
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	var locations []string
+	for _, issue := range Lint(swagger, Options{PackageName: "api", GenerateTypes: true, GenerateClient: true}) {
+		if issue.Rule == "parameter-content-type" {
+			locations = append(locations, issue.Location)
+		}
+	}
+	// JSON content types besides application/json are decoded too
+	assert.Equal(t, []string{"#/paths/~1pets/get/parameters/1"}, locations)
+}
+
+const lintTestFixture = `
+openapi: 3.0.1
+info:
+  title: OpenAPI-CodeGen Test
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            text/csv:
+              schema:
+                type: string
+    post:
+      operationId: getPets
+      requestBody:
+        content:
+          application/xml:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        kind:
+          oneOf:
+            - type: string
+            - type: integer
+`
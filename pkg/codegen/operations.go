@@ -17,8 +17,12 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 	"unicode"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -30,6 +34,17 @@ type ParameterDefinition struct {
 	Required  bool   // Is this a required parameter?
 	Spec      *openapi3.Parameter
 	Schema    Schema
+
+	g *generator // The generator which described the parameter
+}
+
+// gen returns the generator which described the parameter, or the default
+// one.
+func (pd ParameterDefinition) gen() *generator {
+	if pd.g != nil {
+		return pd.g
+	}
+	return defaultGenerator()
 }
 
 // This function is here as an adapter after a large refactoring so that I don't
@@ -51,13 +66,10 @@ func (pd *ParameterDefinition) JsonTag() string {
 	}
 }
 
+// IsJson tells whether the parameter has JSON content, rather than a schema,
+// so that its value is encoded as JSON.
 func (pd *ParameterDefinition) IsJson() bool {
-	p := pd.Spec
-	if len(p.Content) == 1 {
-		_, found := p.Content["application/json"]
-		return found
-	}
-	return false
+	return pd.gen().jsonParamContent(pd.Spec) != nil
 }
 
 func (pd *ParameterDefinition) IsPassThrough() bool {
@@ -76,6 +88,21 @@ func (pd *ParameterDefinition) IsStyled() bool {
 	return p.Schema != nil
 }
 
+// IsList tells whether the parameter is a styled array or object, whose
+// values are comma separated lists. Such header parameters may be sent as
+// several header lines, which are joined into a single list.
+func (pd *ParameterDefinition) IsList() bool {
+	p := pd.Spec
+	if p.Schema == nil || p.Schema.Value == nil {
+		return false
+	}
+	switch p.Schema.Value.Type {
+	case "array", "object":
+		return true
+	}
+	return p.Schema.Value.Type == "" && len(p.Schema.Value.Properties) > 0
+}
+
 func (pd *ParameterDefinition) Style() string {
 	style := pd.Spec.Style
 	if style == "" {
@@ -83,10 +110,10 @@ func (pd *ParameterDefinition) Style() string {
 		switch in {
 		case "path", "header":
 			return "simple"
-		case "query", "cookie":
-			return "form"
 		default:
-			panic("unknown parameter format")
+			// query and cookie, the only other locations which
+			// DescribeParameters accepts
+			return "form"
 		}
 	}
 	return style
@@ -98,10 +125,10 @@ func (pd *ParameterDefinition) Explode() bool {
 		switch in {
 		case "path", "header":
 			return false
-		case "query", "cookie":
-			return true
 		default:
-			panic("unknown parameter format")
+			// query and cookie, the only other locations which
+			// DescribeParameters accepts
+			return true
 		}
 	}
 	return *pd.Spec.Explode
@@ -141,11 +168,21 @@ func (p ParameterDefinitions) FindByName(name string) *ParameterDefinition {
 // descriptors into a flat list. This makes it a lot easier to traverse the
 // data in the template engine.
 func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterDefinition, error) {
+	return defaultGenerator().DescribeParameters(params, path)
+}
+
+func (g *generator) DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterDefinition, error) {
 	outParams := make([]ParameterDefinition, 0)
 	for _, paramOrRef := range params {
 		param := paramOrRef.Value
 
-		goType, err := paramToGoType(param, append(path, param.Name))
+		switch param.In {
+		case "path", "header", "query", "cookie":
+		default:
+			return nil, fmt.Errorf("unknown location '%s' for param (%s)", param.In, param.Name)
+		}
+
+		goType, err := g.paramToGoType(param, append(path, param.Name))
 		if err != nil {
 			return nil, fmt.Errorf("error generating type for param (%s): %s",
 				param.Name, err)
@@ -157,13 +194,14 @@ func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterD
 			Required:  param.Required,
 			Spec:      param,
 			Schema:    goType,
+			g:         g,
 		}
 
 		// If this is a reference to a predefined type, simply use the reference
 		// name as the type. $ref: "#/components/schemas/custom_type" becomes
 		// "CustomType".
 		if IsGoTypeReference(paramOrRef.Ref) {
-			goType, err := RefPathToGoType(paramOrRef.Ref)
+			goType, err := g.RefPathToGoType(paramOrRef.Ref)
 			if err != nil {
 				return nil, fmt.Errorf("error dereferencing (%s) for param (%s): %s",
 					paramOrRef.Ref, param.Name, err)
@@ -209,6 +247,20 @@ type OperationDefinition struct {
 	Method              string                  // GET, POST, DELETE, etc.
 	Path                string                  // The Swagger path for the operation, like /resource/{id}
 	Spec                *openapi3.Operation
+	WebSocket           *WebSocketDefinition // Set for the operations marked with x-websocket
+	Timeout             time.Duration        // The deadline of the requests, from x-timeout, or zero
+	MaxResponseSize     int64                // The maximum size of the response bodies, from x-max-response-size, or zero
+
+	g *generator // The generator which described the operation
+}
+
+// gen returns the generator which described the operation, or the default
+// one.
+func (o OperationDefinition) gen() *generator {
+	if o.g != nil {
+		return o.g
+	}
+	return defaultGenerator()
 }
 
 // Returns the list of all parameters except Path parameters. Path parameters
@@ -234,6 +286,13 @@ func (o *OperationDefinition) RequiresParamObject() bool {
 	return len(o.Params()) > 0
 }
 
+// ParamsHaveDefaults tells whether the parameter object has an ApplyDefaults
+// method, which sets the absent parameters to their defaults.
+func (o *OperationDefinition) ParamsHaveDefaults() bool {
+	td := o.gen().GenerateParamsTypes(*o)
+	return newDefaulter(o.gen(), nil, false).hasDefaults(td[len(td)-1].Schema, map[string]bool{})
+}
+
 // This is called by the template engine to determine whether to generate body
 // marshaling code on the client. This is true for all body types, whether or
 // not we generate types for them.
@@ -254,6 +313,15 @@ func (o *OperationDefinition) SummaryAsComment() string {
 	return strings.Join(parts, "\n")
 }
 
+// DeprecationComment returns a "Deprecated:" comment if the operation is
+// marked as deprecated, or an empty string otherwise.
+func (o *OperationDefinition) DeprecationComment() string {
+	if !o.Spec.Deprecated {
+		return ""
+	}
+	return DeprecationComment(o.Spec.Extensions)
+}
+
 // Produces a list of type definitions for a given Operation for the response
 // types which we know how to parse. These will be turned into fields on a
 // response object for automatic deserialization of responses in the generated
@@ -268,19 +336,22 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 
 		// We can only generate a type if we have a value:
 		if responseRef.Value != nil {
-			sortedContentKeys := SortedContentKeys(responseRef.Value.Content)
-			for _, contentTypeName := range sortedContentKeys {
+			// Responses with several JSON content types, eg, application/json
+			// and application/hal+json, get a single JSON field.
+			typeNames := map[string]bool{}
+			for _, contentTypeName := range jsonFirstContentKeys(responseRef.Value.Content) {
 				contentType := responseRef.Value.Content[contentTypeName]
 				// We can only generate a type if we have a schema:
 				if contentType.Schema != nil {
-					responseSchema, err := GenerateGoSchema(contentType.Schema, []string{responseName})
+					schemaRef := o.gen().variantSchemaRef(contentType.Schema, responseVariant)
+					responseSchema, err := o.gen().GenerateGoSchema(schemaRef, []string{o.OperationId + ToCamelCase(responseName) + "Response"})
 					if err != nil {
 						return nil, fmt.Errorf("Unable to determine Go type for %s.%s: %w", o.OperationId, contentTypeName, err)
 					}
 
 					var typeName string
 					switch {
-					case StringInArray(contentTypeName, contentTypesJSON):
+					case o.gen().isJSONContentType(contentTypeName):
 						typeName = fmt.Sprintf("JSON%s", ToCamelCase(responseName))
 					// YAML:
 					case StringInArray(contentTypeName, contentTypesYAML):
@@ -291,6 +362,10 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 					default:
 						continue
 					}
+					if typeNames[typeName] {
+						continue
+					}
+					typeNames[typeName] = true
 
 					td := ResponseTypeDefinition{
 						TypeDefinition: TypeDefinition{
@@ -300,8 +375,8 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 						ResponseName:    responseName,
 						ContentTypeName: contentTypeName,
 					}
-					if IsGoTypeReference(contentType.Schema.Ref) {
-						refType, err := RefPathToGoType(contentType.Schema.Ref)
+					if IsGoTypeReference(schemaRef.Ref) {
+						refType, err := o.gen().RefPathToGoType(schemaRef.Ref)
 						if err != nil {
 							return nil, fmt.Errorf("error dereferencing response Ref: %w", err)
 						}
@@ -315,6 +390,264 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 	return tds, nil
 }
 
+// ServerResponseDefinition describes a response which server handlers may
+// send, for which a type is generated. See "server-responses.tmpl".
+type ServerResponseDefinition struct {
+	TypeName string
+
+	// ResponseName is the key of the response in the spec, a status code,
+	// a range of them like "4XX", or "default".
+	ResponseName string
+
+	// ContentType is the content type of the body, when it's typed as JSON,
+	// and empty otherwise.
+	ContentType string
+
+	// Schema is the type of the body, when it's JSON.
+	Schema Schema
+
+	// HasRawBody is set for responses with content which isn't typed, the
+	// body is then an io.Reader.
+	HasRawBody bool
+
+	// statusCondition is the Go condition which a status code must satisfy,
+	// with a %[1]s verb for the variable holding it, for ranged and default
+	// responses.
+	statusCondition string
+}
+
+// IsJSON returns whether the response has a typed JSON body.
+func (r ServerResponseDefinition) IsJSON() bool {
+	return r.ContentType != ""
+}
+
+// HasStatusCode returns whether the status code isn't fixed by the spec, and
+// is then a field of the response, which is the case of ranged and default
+// responses.
+func (r ServerResponseDefinition) HasStatusCode() bool {
+	return r.statusCondition != ""
+}
+
+// StatusCondition returns the condition which the status code held by
+// statusCodeVar must satisfy.
+func (r ServerResponseDefinition) StatusCondition(statusCodeVar string) string {
+	return fmt.Sprintf(r.statusCondition, statusCodeVar)
+}
+
+// GetServerResponses returns the responses which the operation's handlers
+// may send. There is one with a typed body for responses with JSON content,
+// and one with a raw body for the others, or no body at all when they have no
+// content.
+func (o *OperationDefinition) GetServerResponses() ([]ServerResponseDefinition, error) {
+	var responses []ServerResponseDefinition
+
+	responseNames := SortedResponsesKeys(o.Spec.Responses)
+	for _, responseName := range responseNames {
+		responseRef := o.Spec.Responses[responseName]
+		if responseRef.Value == nil {
+			continue
+		}
+
+		typeName := responseName
+		statusCondition := ""
+		switch {
+		case isStatusRange(responseName):
+			typeName = strings.ToUpper(responseName)
+			statusCondition = getConditionOfResponseName("%[1]s", responseName)
+		case responseName == "default":
+			// The default response covers all the statuses which aren't
+			// declared by the others
+			conditions := []string{"%[1]s >= 100", "%[1]s <= 599"}
+			for _, other := range responseNames {
+				if other != "default" {
+					conditions = append(conditions, "!("+getConditionOfResponseName("%[1]s", other)+")")
+				}
+			}
+			statusCondition = strings.Join(conditions, " && ")
+		default:
+			if _, err := strconv.Atoi(responseName); err != nil || len(responseName) != 3 {
+				return nil, fmt.Errorf("invalid response %q of %s, it's neither a status code, a range of them nor default", responseName, o.OperationId)
+			}
+		}
+
+		name := o.OperationId + ToCamelCase(typeName)
+		hasRawBody := len(responseRef.Value.Content) == 0
+		hasJSONBody := false
+		for _, contentTypeName := range jsonFirstContentKeys(responseRef.Value.Content) {
+			contentType := responseRef.Value.Content[contentTypeName]
+			if hasJSONBody || !o.gen().isJSONContentType(contentTypeName) || contentType.Schema == nil {
+				hasRawBody = true
+				continue
+			}
+			schemaRef := o.gen().variantSchemaRef(contentType.Schema, responseVariant)
+			schema, err := o.gen().GenerateGoSchema(schemaRef, []string{name + "Response"})
+			if err != nil {
+				return nil, fmt.Errorf("Unable to determine Go type for %s.%s: %w", o.OperationId, contentTypeName, err)
+			}
+			if IsGoTypeReference(schemaRef.Ref) {
+				refType, err := o.gen().RefPathToGoType(schemaRef.Ref)
+				if err != nil {
+					return nil, fmt.Errorf("error dereferencing response Ref: %w", err)
+				}
+				schema.RefType = refType
+			}
+			responses = append(responses, ServerResponseDefinition{
+				TypeName:        name + "JSONResponse",
+				ResponseName:    responseName,
+				ContentType:     contentTypeName,
+				Schema:          schema,
+				statusCondition: statusCondition,
+			})
+			hasJSONBody = true
+		}
+
+		if hasRawBody {
+			responses = append(responses, ServerResponseDefinition{
+				TypeName:        name + "Response",
+				ResponseName:    responseName,
+				HasRawBody:      len(responseRef.Value.Content) > 0,
+				statusCondition: statusCondition,
+			})
+		}
+	}
+	return responses, nil
+}
+
+// WebSocketDefinition describes the WebSocket of an operation marked with
+// x-websocket, whose GET request is upgraded to a connection on which JSON
+// messages are exchanged.
+type WebSocketDefinition struct {
+	ClientMessage string // The Go type of the messages sent by the client
+	ServerMessage string // The Go type of the messages sent by the server
+}
+
+// describeWebSocket returns the WebSocket of an operation: the client sends
+// messages of its JSON request body, and the server, of its 101, or else 200,
+// JSON response. Messages without a schema are left as json.RawMessage.
+func describeWebSocket(op OperationDefinition, responses []ResponseTypeDefinition) *WebSocketDefinition {
+	ws := WebSocketDefinition{
+		ClientMessage: "json.RawMessage",
+		ServerMessage: "json.RawMessage",
+	}
+	for _, body := range op.Bodies {
+		if body.ContentType == "application/json" {
+			ws.ClientMessage = body.TypeDef(op.OperationId).TypeName
+		}
+	}
+	for _, responseName := range []string{"101", "200"} {
+		for _, response := range responses {
+			if response.ResponseName == responseName && response.ContentTypeName == "application/json" {
+				ws.ServerMessage = response.Schema.TypeDecl()
+				return &ws
+			}
+		}
+	}
+	return &ws
+}
+
+// splitWebSocketOperations separates the operations marked with
+// x-websocket, which get their own client and server code, from the others.
+func splitWebSocketOperations(ops []OperationDefinition) (httpOps, webSocketOps []OperationDefinition) {
+	for _, op := range ops {
+		if op.WebSocket != nil {
+			webSocketOps = append(webSocketOps, op)
+		} else {
+			httpOps = append(httpOps, op)
+		}
+	}
+	return httpOps, webSocketOps
+}
+
+// ErrorResponseDefinition describes the JSON body of an error response of an
+// operation, which the server wrappers fill with the errors they respond to:
+// the 500, or default, response for the errors of its handler, when they
+// recover from them, and the 400, or default, response for its parameters.
+type ErrorResponseDefinition struct {
+	TypeName string
+	message  Property // The message property, which holds the error
+	code     *Property
+	status   string // The status code constant, which the code property holds
+}
+
+// Build returns the statements which declare the variable body, holding the
+// error in the variable err.
+func (r ErrorResponseDefinition) Build() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "var body %s\n", r.TypeName)
+	if strings.HasPrefix(r.message.GoTypeDef(), "*") {
+		fmt.Fprintf(&b, "message := err.Error()\nbody.%s = &message\n", r.message.GoFieldName())
+	} else {
+		fmt.Fprintf(&b, "body.%s = err.Error()\n", r.message.GoFieldName())
+	}
+	if r.code != nil {
+		if strings.HasPrefix(r.code.GoTypeDef(), "*") {
+			fmt.Fprintf(&b, "code := %s(%s)\nbody.%s = &code\n", r.code.Schema.TypeDecl(), r.status, r.code.GoFieldName())
+		} else {
+			fmt.Fprintf(&b, "body.%s = %s\n", r.code.GoFieldName(), r.status)
+		}
+	}
+	return b.String()
+}
+
+// GetErrorResponse returns the error response of the operation: its 500, or
+// else default, JSON response, when it's a named object with a string
+// "message" property, and maybe an integer "code" one. It returns nil
+// otherwise.
+func (o *OperationDefinition) GetErrorResponse() (*ErrorResponseDefinition, error) {
+	return o.errorResponse("500", "http.StatusInternalServerError")
+}
+
+// GetParamErrorResponse returns the response of the operation to invalid
+// parameters, like GetErrorResponse, from its 400, or else default, JSON
+// response.
+func (o *OperationDefinition) GetParamErrorResponse() (*ErrorResponseDefinition, error) {
+	return o.errorResponse("400", "http.StatusBadRequest")
+}
+
+// errorResponse returns the error response of the operation for the given
+// status code, or else its default response, whose code property is set to
+// the status constant.
+func (o *OperationDefinition) errorResponse(statusCode, status string) (*ErrorResponseDefinition, error) {
+	var responseRef *openapi3.ResponseRef
+	for _, name := range []string{statusCode, "default"} {
+		if responseRef = o.Spec.Responses[name]; responseRef != nil && responseRef.Value != nil {
+			break
+		}
+	}
+	if responseRef == nil || responseRef.Value == nil {
+		return nil, nil
+	}
+	content := responseRef.Value.Content.Get("application/json")
+	if content == nil || content.Schema == nil || !IsGoTypeReference(content.Schema.Ref) {
+		return nil, nil
+	}
+	typeName, err := o.gen().RefPathToGoType(content.Schema.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("error dereferencing error response Ref: %w", err)
+	}
+	// The properties of the referenced type
+	schema, err := o.gen().GenerateGoSchema(&openapi3.SchemaRef{Value: content.Schema.Value}, []string{typeName})
+	if err != nil {
+		return nil, fmt.Errorf("error generating error response of %s: %w", o.OperationId, err)
+	}
+
+	r := ErrorResponseDefinition{TypeName: typeName, status: status}
+	hasMessage := false
+	for i, p := range schema.Properties {
+		switch {
+		case p.JsonFieldName == "message" && p.Schema.TypeDecl() == "string":
+			r.message = p
+			hasMessage = true
+		case p.JsonFieldName == "code" && StringInArray(p.Schema.TypeDecl(), []string{"int", "int32", "int64"}):
+			r.code = &schema.Properties[i]
+		}
+	}
+	if !hasMessage {
+		return nil, nil
+	}
+	return &r, nil
+}
+
 // This describes a request body
 type RequestBodyDefinition struct {
 	// Is this body required, or optional?
@@ -375,13 +708,24 @@ func FilterParameterDefinitionByType(params []ParameterDefinition, in string) []
 
 // OperationDefinitions returns all operations for a swagger definition.
 func OperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, error) {
-	var operations []OperationDefinition
+	return defaultGenerator().OperationDefinitions(swagger)
+}
+
+func (g *generator) OperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, error) {
+	type operationJob struct {
+		requestPath  string
+		opName       string
+		op           *openapi3.Operation
+		pathItem     *openapi3.PathItem
+		globalParams []ParameterDefinition
+	}
+	var jobs []operationJob
 
 	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
 		pathItem := swagger.Paths[requestPath]
 		// These are parameters defined for all methods on a given path. They
 		// are shared by all methods.
-		globalParams, err := DescribeParameters(pathItem.Parameters, nil)
+		globalParams, err := g.DescribeParameters(pathItem.Parameters, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error describing global parameters for %s: %s",
 				requestPath, err)
@@ -390,87 +734,252 @@ func OperationDefinitions(swagger *openapi3.T) ([]OperationDefinition, error) {
 		// Each path can have a number of operations, POST, GET, OPTIONS, etc.
 		pathOps := pathItem.Operations()
 		for _, opName := range SortedOperationsKeys(pathOps) {
-			op := pathOps[opName]
-			if pathItem.Servers != nil {
-				op.Servers = &pathItem.Servers
-			}
-			// We rely on OperationID to generate function names, it's required
-			if op.OperationID == "" {
-				op.OperationID, err = generateDefaultOperationID(opName, requestPath)
-				if err != nil {
-					return nil, fmt.Errorf("error generating default OperationID for %s/%s: %s",
-						opName, requestPath, err)
-				}
-				op.OperationID = op.OperationID
-			} else {
-				op.OperationID = ToCamelCase(op.OperationID)
-			}
+			jobs = append(jobs, operationJob{
+				requestPath:  requestPath,
+				opName:       opName,
+				op:           pathOps[opName],
+				pathItem:     pathItem,
+				globalParams: globalParams,
+			})
+		}
+	}
 
-			// These are parameters defined for the specific path method that
-			// we're iterating over.
-			localParams, err := DescribeParameters(op.Parameters, []string{op.OperationID + "Params"})
-			if err != nil {
-				return nil, fmt.Errorf("error describing global parameters for %s/%s: %s",
-					opName, requestPath, err)
-			}
-			// All the parameters required by a handler are the union of the
-			// global parameters and the local parameters.
-			allParams := append(globalParams, localParams...)
-
-			// Order the path parameters to match the order as specified in
-			// the path, not in the swagger spec, and validate that the parameter
-			// names match, as downstream code depends on that.
-			pathParams := FilterParameterDefinitionByType(allParams, "path")
-			pathParams, err = SortParamsByPath(requestPath, pathParams)
-			if err != nil {
-				return nil, err
-			}
+	// Operations are described independently of each other, so this happens
+	// concurrently, which matters for specs with many operations.
+	operations := make([]OperationDefinition, len(jobs))
+	err := parallelFor(len(jobs), func(i int) error {
+		job := jobs[i]
+		opDef, err := g.describeOperation(swagger, job.requestPath, job.opName, job.op, job.pathItem, job.globalParams)
+		if err != nil {
+			return err
+		}
+		operations[i] = opDef
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return operations, nil
+}
 
-			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(op.OperationID, op.RequestBody)
-			if err != nil {
-				return nil, fmt.Errorf("error generating body definitions: %w", err)
+// describeOperation builds the definition of a single operation.
+func (g *generator) describeOperation(swagger *openapi3.T, requestPath string, opName string, op *openapi3.Operation,
+	pathItem *openapi3.PathItem, globalParams []ParameterDefinition) (OperationDefinition, error) {
+	var err error
+	var operationID string
+	if pathItem.Servers != nil {
+		op.Servers = &pathItem.Servers
+	}
+	// We rely on OperationID to generate function names, it's required. The
+	// synthesized IDs are named in Go like the pascal cased ones, whatever
+	// their casing, which is kept in the spec.
+	if goName, ok := g.synthesizedNames[op]; ok {
+		operationID = goName
+	} else if op.OperationID == "" {
+		op.OperationID, err = generateDefaultOperationID(opName, requestPath)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("error generating default OperationID for %s/%s: %s",
+				opName, requestPath, err)
+		}
+		operationID = op.OperationID
+	} else {
+		op.OperationID = ToCamelCase(op.OperationID)
+		operationID = op.OperationID
+	}
+
+	// These are parameters defined for the specific path method that
+	// we're iterating over.
+	localParams, err := g.DescribeParameters(op.Parameters, []string{operationID + "Params"})
+	if err != nil {
+		return OperationDefinition{}, fmt.Errorf("error describing global parameters for %s/%s: %s",
+			opName, requestPath, err)
+	}
+	// All the parameters required by a handler are the union of the
+	// global parameters and the local parameters. The global parameters are
+	// shared by all the operations on the path, so they are copied.
+	allParams := append(append([]ParameterDefinition{}, globalParams...), localParams...)
+
+	// Order the path parameters to match the order as specified in
+	// the path, not in the swagger spec, and validate that the parameter
+	// names match, as downstream code depends on that.
+	pathParams := FilterParameterDefinitionByType(allParams, "path")
+	pathParams, err = SortParamsByPath(requestPath, pathParams)
+	if err != nil {
+		return OperationDefinition{}, err
+	}
+
+	bodyDefinitions, typeDefinitions, err := g.GenerateBodyDefinitions(operationID, op.RequestBody)
+	if err != nil {
+		return OperationDefinition{}, fmt.Errorf("error generating body definitions: %w", err)
+	}
+
+	opDef := OperationDefinition{
+		PathParams:   pathParams,
+		HeaderParams: FilterParameterDefinitionByType(allParams, "header"),
+		QueryParams:  FilterParameterDefinitionByType(allParams, "query"),
+		CookieParams: FilterParameterDefinitionByType(allParams, "cookie"),
+		OperationId:  operationID,
+		g:            g,
+		// Replace newlines in summary.
+		Summary:         op.Summary,
+		Method:          opName,
+		Path:            requestPath,
+		Spec:            op,
+		Bodies:          bodyDefinitions,
+		TypeDefinitions: typeDefinitions,
+	}
+
+	// check for overrides of SecurityDefinitions.
+	// See: "Step 2. Applying security:" from the spec:
+	// https://swagger.io/docs/specification/authentication/
+	if op.Security != nil {
+		opDef.SecurityDefinitions = DescribeSecurityDefinition(*op.Security)
+	} else {
+		// use global securityDefinitions
+		// globalSecurityDefinitions contains the top-level securityDefinitions.
+		// They are the default securityPermissions which are injected into each
+		// path, except for the case where a path explicitly overrides them.
+		opDef.SecurityDefinitions = DescribeSecurityDefinition(swagger.Security)
+
+	}
+
+	if op.RequestBody != nil {
+		opDef.BodyRequired = op.RequestBody.Value.Required
+	}
+
+	// Generate all the type definitions needed for this operation
+	opDef.TypeDefinitions = append(opDef.TypeDefinitions, g.GenerateTypeDefsForOperation(opDef)...)
+
+	// Responses may need types of their own, such as inline schemas
+	// named with x-go-type-name. The same schema may be used for
+	// several content types, so only define them once.
+	responseDefinitions, err := opDef.GetResponseTypeDefinitions()
+	if err != nil {
+		return OperationDefinition{}, fmt.Errorf("error generating response definitions: %w", err)
+	}
+	for _, rd := range responseDefinitions {
+		for _, td := range rd.Schema.GetAdditionalTypeDefs() {
+			if !typeDefinitionsContain(opDef.TypeDefinitions, td.TypeName) {
+				opDef.TypeDefinitions = append(opDef.TypeDefinitions, td)
 			}
+		}
+	}
 
-			opDef := OperationDefinition{
-				PathParams:   pathParams,
-				HeaderParams: FilterParameterDefinitionByType(allParams, "header"),
-				QueryParams:  FilterParameterDefinitionByType(allParams, "query"),
-				CookieParams: FilterParameterDefinitionByType(allParams, "cookie"),
-				OperationId:  ToCamelCase(op.OperationID),
-				// Replace newlines in summary.
-				Summary:         op.Summary,
-				Method:          opName,
-				Path:            requestPath,
-				Spec:            op,
-				Bodies:          bodyDefinitions,
-				TypeDefinitions: typeDefinitions,
+	if extension, ok := op.Extensions[extWebSocket]; ok {
+		isWebSocket, err := extParseWebSocket(extension)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q: %w", extWebSocket, err)
+		}
+		if isWebSocket {
+			if opName != "GET" {
+				return OperationDefinition{}, fmt.Errorf("%s of %s %s: only GET operations can be WebSockets", extWebSocket, opName, requestPath)
 			}
+			opDef.WebSocket = describeWebSocket(opDef, responseDefinitions)
+		}
+	}
 
-			// check for overrides of SecurityDefinitions.
-			// See: "Step 2. Applying security:" from the spec:
-			// https://swagger.io/docs/specification/authentication/
-			if op.Security != nil {
-				opDef.SecurityDefinitions = DescribeSecurityDefinition(*op.Security)
-			} else {
-				// use global securityDefinitions
-				// globalSecurityDefinitions contains the top-level securityDefinitions.
-				// They are the default securityPermissions which are injected into each
-				// path, except for the case where a path explicitly overrides them.
-				opDef.SecurityDefinitions = DescribeSecurityDefinition(swagger.Security)
+	if extension, ok := op.Extensions[extTimeout]; ok {
+		opDef.Timeout, err = extParseTimeout(extension)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q of %s %s: %w", extTimeout, opName, requestPath, err)
+		}
+	}
 
-			}
+	if extension, ok := op.Extensions[extMaxResponseSize]; ok {
+		opDef.MaxResponseSize, err = extParseMaxResponseSize(extension)
+		if err != nil {
+			return OperationDefinition{}, fmt.Errorf("invalid value for %q of %s %s: %w", extMaxResponseSize, opName, requestPath, err)
+		}
+	}
+
+	return opDef, nil
+}
 
-			if op.RequestBody != nil {
-				opDef.BodyRequired = op.RequestBody.Value.Required
+// TimeoutLiteral returns the Go expression of the Timeout of the operation,
+// such as 5 * time.Second.
+func (o OperationDefinition) TimeoutLiteral() string {
+	units := []struct {
+		duration time.Duration
+		name     string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, unit := range units {
+		if o.Timeout%unit.duration != 0 {
+			continue
+		}
+		if n := o.Timeout / unit.duration; n != 1 {
+			return fmt.Sprintf("%d * %s", n, unit.name)
+		}
+		return unit.name
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(o.Timeout))
+}
+
+// AcceptHeader returns the Accept header of the requests of the operation,
+// which lists the content types of its responses, or an empty string when
+// they have none. The plain JSON types come first, then the other JSON ones,
+// which the client decodes, then the rest, with decreasing q-values.
+func (o OperationDefinition) AcceptHeader() string {
+	if o.Spec == nil {
+		return ""
+	}
+	var contentTypes []string
+	seen := map[string]bool{}
+	for _, responseName := range SortedResponsesKeys(o.Spec.Responses) {
+		response := o.Spec.Responses[responseName]
+		if response == nil || response.Value == nil {
+			continue
+		}
+		for _, contentType := range SortedContentKeys(response.Value.Content) {
+			if !seen[contentType] {
+				seen[contentType] = true
+				contentTypes = append(contentTypes, contentType)
 			}
+		}
+	}
 
-			// Generate all the type definitions needed for this operation
-			opDef.TypeDefinitions = append(opDef.TypeDefinitions, GenerateTypeDefsForOperation(opDef)...)
+	rank := func(contentType string) int {
+		switch {
+		case StringInArray(contentType, contentTypesJSON):
+			return 0
+		case o.gen().isJSONContentType(contentType):
+			return 1
+		case strings.Contains(contentType, "*"):
+			return 3
+		}
+		return 2
+	}
+	sort.SliceStable(contentTypes, func(i, j int) bool {
+		return rank(contentTypes[i]) < rank(contentTypes[j])
+	})
+
+	accept := make([]string, len(contentTypes))
+	for i, contentType := range contentTypes {
+		accept[i] = contentType
+		if i > 0 {
+			// From 0.9 down to 0.1
+			q := 10 - i
+			if q < 1 {
+				q = 1
+			}
+			accept[i] += fmt.Sprintf(";q=0.%d", q)
+		}
+	}
+	return strings.Join(accept, ", ")
+}
 
-			operations = append(operations, opDef)
+func typeDefinitionsContain(typeDefs []TypeDefinition, typeName string) bool {
+	for _, td := range typeDefs {
+		if td.TypeName == typeName {
+			return true
 		}
 	}
-	return operations, nil
+	return false
 }
 
 func generateDefaultOperationID(opName string, requestPath string) (string, error) {
@@ -493,9 +1002,87 @@ func generateDefaultOperationID(opName string, requestPath string) (string, erro
 	return ToCamelCase(operationId), nil
 }
 
+// These are the casing schemes for synthesized operation IDs. They only affect
+// the operationId recorded in the spec, the generated Go names are always the
+// pascal cased ones.
+const (
+	OperationIDCasingPascal = "pascal" // GetPetsId, the default
+	OperationIDCasingCamel  = "camel"  // getPetsId
+	OperationIDCasingSnake  = "snake"  // get_pets_id
+	OperationIDCasingKebab  = "kebab"  // get-pets-id
+)
+
+// synthesizeOperationIDs gives every operation which doesn't have an
+// operationId one derived from its method and path, using the given casing
+// scheme, and returns the Go names of these operations, which are the pascal
+// cased IDs whatever the scheme. The IDs only depend on the method and path,
+// so they are stable across runs.
+func synthesizeOperationIDs(swagger *openapi3.T, casing string) (map[*openapi3.Operation]string, error) {
+	switch casing {
+	case "", OperationIDCasingPascal, OperationIDCasingCamel, OperationIDCasingSnake, OperationIDCasingKebab:
+	default:
+		return nil, fmt.Errorf("unknown operation ID casing '%s', valid options: %s, %s, %s, %s", casing,
+			OperationIDCasingPascal, OperationIDCasingCamel, OperationIDCasingSnake, OperationIDCasingKebab)
+	}
+
+	goNames := map[*openapi3.Operation]string{}
+	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
+		pathOps := swagger.Paths[requestPath].Operations()
+		for _, opName := range SortedOperationsKeys(pathOps) {
+			op := pathOps[opName]
+			if op.OperationID != "" {
+				continue
+			}
+			goName, err := generateDefaultOperationID(opName, requestPath)
+			if err != nil {
+				return nil, fmt.Errorf("error generating default OperationID for %s/%s: %s",
+					opName, requestPath, err)
+			}
+			operationID := goName
+			switch casing {
+			case OperationIDCasingCamel:
+				operationID = LowercaseFirstCharacter(goName)
+			case OperationIDCasingSnake:
+				operationID = strings.Join(operationIDWords(goName), "_")
+			case OperationIDCasingKebab:
+				operationID = strings.Join(operationIDWords(goName), "-")
+			}
+			op.OperationID = operationID
+			goNames[op] = goName
+		}
+	}
+	return goNames, nil
+}
+
+// operationIDWords splits a pascal cased operation ID into its lower case
+// words, on the case boundaries, eg, GetPetStorePetId into get, pet, store,
+// pet and id. Acronyms are kept whole, eg, GetHTTPServer is split into get,
+// http and server.
+func operationIDWords(operationID string) []string {
+	runes := []rune(operationID)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prev := runes[i-1]
+		acronymEnd := unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if !unicode.IsUpper(prev) || acronymEnd {
+			words = append(words, strings.ToLower(string(runes[start:i])))
+			start = i
+		}
+	}
+	return append(words, strings.ToLower(string(runes[start:])))
+}
+
 // This function turns the Swagger body definitions into a list of our body
 // definitions which will be used for code generation.
 func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
+	return defaultGenerator().GenerateBodyDefinitions(operationID, bodyOrRef)
+}
+
+func (g *generator) GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
 	if bodyOrRef == nil {
 		return nil, nil, nil
 	}
@@ -504,28 +1091,81 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 	var bodyDefinitions []RequestBodyDefinition
 	var typeDefinitions []TypeDefinition
 
-	for contentType, content := range body.Content {
+	// A vendored JSON body, eg, application/vnd.company.v2+json, is the
+	// default one when it's the only JSON body.
+	var vendoredJSON []string
+	for contentType := range body.Content {
+		if contentType != "application/json" && contentType != "application/merge-patch+json" && g.isJSONContentType(contentType) {
+			vendoredJSON = append(vendoredJSON, contentType)
+		}
+	}
+	_, hasJSON := body.Content["application/json"]
+
+	// The vendored JSON bodies whose subtypes are the same, such as
+	// application/problem+json and text/problem+json, are tagged with their
+	// types too, and numbered when that isn't enough.
+	tagged := map[string]int{}
+	if hasJSON {
+		tagged["JSON"]++
+	}
+	if _, ok := body.Content["application/merge-patch+json"]; ok {
+		tagged["MergePatch"]++
+	}
+	for _, contentType := range vendoredJSON {
+		tagged[jsonContentTypeTag(contentType, false)]++
+	}
+	usedTags := map[string]bool{}
+
+	for _, contentType := range SortedContentKeys(body.Content) {
+		content := body.Content[contentType]
 		var tag string
 		var defaultBody bool
 
-		switch contentType {
-		case "application/json":
+		switch {
+		case contentType == "application/json":
 			tag = "JSON"
 			defaultBody = true
+		case contentType == "application/merge-patch+json":
+			tag = "MergePatch"
+		case g.isJSONContentType(contentType):
+			tag = jsonContentTypeTag(contentType, false)
+			if tagged[tag] > 1 {
+				tag = jsonContentTypeTag(contentType, true)
+			}
+			for i, base := 2, tag; usedTags[tag]; i++ {
+				tag = fmt.Sprintf("%s%d", base, i)
+			}
+			defaultBody = !hasJSON && len(vendoredJSON) == 1
 		default:
 			continue
 		}
+		usedTags[tag] = true
 
 		bodyTypeName := operationID + tag + "Body"
-		bodySchema, err := GenerateGoSchema(content.Schema, []string{bodyTypeName})
+		schemaRef := g.variantSchemaRef(content.Schema, requestVariant)
+		bodySchema, err := g.GenerateGoSchema(schemaRef, []string{bodyTypeName})
 		if err != nil {
 			return nil, nil, fmt.Errorf("error generating request body definition: %w", err)
 		}
 
+		// Merge patches get their own type, even for pre-defined schemas,
+		// since their fields must tell absent values from null ones.
+		isMergePatch := false
+		if tag == "MergePatch" {
+			patchSchema, ok, err := g.mergePatchSchema(schemaRef, []string{bodyTypeName})
+			if err != nil {
+				return nil, nil, fmt.Errorf("error generating merge patch body definition: %w", err)
+			}
+			if ok {
+				bodySchema = patchSchema
+				isMergePatch = true
+			}
+		}
+
 		// If the body is a pre-defined type
-		if IsGoTypeReference(bodyOrRef.Ref) {
+		if IsGoTypeReference(bodyOrRef.Ref) && !isMergePatch {
 			// Convert the reference path to Go type
-			refType, err := RefPathToGoType(bodyOrRef.Ref)
+			refType, err := g.RefPathToGoType(bodyOrRef.Ref)
 			if err != nil {
 				return nil, nil, fmt.Errorf("error turning reference (%s) into a Go type: %w", bodyOrRef.Ref, err)
 			}
@@ -557,11 +1197,76 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 	return bodyDefinitions, typeDefinitions, nil
 }
 
+// jsonContentTypeTag names the bodies of a JSON content type other than
+// application/json after its subtype, eg, application/vnd.company.v2+json
+// bodies are tagged VndCompanyV2, and text/json ones TextJSON. Qualified tags
+// are prefixed with the type too, eg, ApplicationVndCompanyV2.
+func jsonContentTypeTag(contentType string, qualified bool) string {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	typ, subtype := "", mediaType
+	if i := strings.Index(mediaType, "/"); i >= 0 {
+		typ, subtype = mediaType[:i], mediaType[i+1:]
+	}
+	subtype = strings.TrimSuffix(subtype, "+json")
+	if subtype == "json" {
+		return ToCamelCase(typ) + "JSON"
+	}
+	if qualified {
+		return ToCamelCase(typ) + ToCamelCase(subtype)
+	}
+	return ToCamelCase(subtype)
+}
+
+// mergePatchSchema turns the schema of a JSON merge patch body into a struct
+// whose fields are runtime.Opt, which tell absent fields from null ones. It
+// returns false for schemas which aren't plain objects, which are handled
+// like any other body.
+func (g *generator) mergePatchSchema(sref *openapi3.SchemaRef, path []string) (Schema, bool, error) {
+	if sref == nil || sref.Value == nil || len(sref.Value.AllOf) != 0 {
+		return Schema{}, false, nil
+	}
+	// Referenced schemas are generated from their properties, rather than as
+	// a reference to their type.
+	schema, err := g.GenerateGoSchema(&openapi3.SchemaRef{Value: sref.Value}, path)
+	if err != nil {
+		return Schema{}, false, err
+	}
+	if len(schema.Properties) == 0 || schema.HasAdditionalProperties {
+		return Schema{}, false, nil
+	}
+
+	// The types of the properties are still needed by their options.
+	schema.AdditionalTypes = schema.GetAdditionalTypeDefs()
+	for i, p := range schema.Properties {
+		// Absent fields must be omitted, whatever x-omitempty says.
+		extensions := make(map[string]interface{})
+		for k, v := range p.ExtensionProps.Extensions {
+			if k != extPropOmitEmpty {
+				extensions[k] = v
+			}
+		}
+		p.ExtensionProps = &openapi3.ExtensionProps{Extensions: extensions}
+		p.Schema = Schema{
+			GoType:              fmt.Sprintf("runtime.Opt[%s]", p.Schema.TypeDecl()),
+			SkipOptionalPointer: true,
+		}
+		p.Required = false
+		p.Nullable = false
+		schema.Properties[i] = p
+	}
+	schema.GoType = g.GenStructFromSchema(schema)
+	return schema, true, nil
+}
+
 func GenerateTypeDefsForOperation(op OperationDefinition) []TypeDefinition {
+	return defaultGenerator().GenerateTypeDefsForOperation(op)
+}
+
+func (g *generator) GenerateTypeDefsForOperation(op OperationDefinition) []TypeDefinition {
 	var typeDefs []TypeDefinition
 	// Start with the params object itself
 	if len(op.Params()) != 0 {
-		typeDefs = append(typeDefs, GenerateParamsTypes(op)...)
+		typeDefs = append(typeDefs, g.GenerateParamsTypes(op)...)
 	}
 
 	// Now, go through all the additional types we need to declare.
@@ -578,6 +1283,10 @@ func GenerateTypeDefsForOperation(op OperationDefinition) []TypeDefinition {
 // This defines the schema for a parameters definition object which encapsulates
 // all the query, header and cookie parameters for an operation.
 func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
+	return defaultGenerator().GenerateParamsTypes(op)
+}
+
+func (g *generator) GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
 	var typeDefs []TypeDefinition
 
 	objectParams := op.QueryParams
@@ -589,7 +1298,9 @@ func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
 	s := Schema{}
 	for _, param := range objectParams {
 		pSchema := param.Schema
-		if pSchema.HasAdditionalProperties {
+		// Objects get a type of their own, so that their values can be
+		// spelled out, eg, for the With methods of the parameters
+		if pSchema.HasAdditionalProperties || (!pSchema.IsRef() && strings.HasPrefix(pSchema.GoType, "struct")) {
 			propRefName := strings.Join([]string{typeName, param.GoName()}, "_")
 			pSchema.RefType = propRefName
 			typeDefs = append(typeDefs, TypeDefinition{
@@ -601,14 +1312,23 @@ func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
 			Description:    param.Spec.Description,
 			JsonFieldName:  param.ParamName,
 			Required:       param.Required,
+			Deprecated:     param.Spec.Deprecated,
 			Schema:         pSchema,
 			ExtensionProps: &param.Spec.ExtensionProps,
 		}
+		if param.Spec.Schema != nil {
+			prop.spec = param.Spec.Schema.Value
+		}
 		s.Properties = append(s.Properties, prop)
 	}
 
 	s.Description = op.Spec.Description
-	s.GoType = GenStructFromSchema(s)
+	if g.opts.ParamsBuilders {
+		// The With methods keep the errors of the invalid values they set,
+		// behind a pointer, so that the parameters stay comparable
+		s.UnexportedFields = []string{"errs *runtime.ValidationErrors"}
+	}
+	s.GoType = g.GenStructFromSchema(s)
 
 	td := TypeDefinition{
 		TypeName: typeName,
@@ -650,6 +1370,15 @@ func GenerateTypesForOperations(t *template.Template, ops []OperationDefinition)
 		return "", fmt.Errorf("error generating additional properties boilerplate for operations: %w", err)
 	}
 
+	timeFormats, err := GenerateTimeFormatBoilerplate(t, td)
+	if err != nil {
+		return "", fmt.Errorf("error generating time format boilerplate for operations: %w", err)
+	}
+
+	if _, err := w.WriteString(timeFormats); err != nil {
+		return "", fmt.Errorf("error generating time format boilerplate for operations: %w", err)
+	}
+
 	if err = w.Flush(); err != nil {
 		return "", fmt.Errorf("error flushing output buffer for server interface: %w", err)
 	}
@@ -659,49 +1388,95 @@ func GenerateTypesForOperations(t *template.Template, ops []OperationDefinition)
 
 // GenerateChiServer This function generates all the go code for the ServerInterface as well as
 // all the wrapper functions around our handlers.
+// These are the templates for the code of each target.
+var (
+	chiServerTemplates           = []string{"chi-interface.tmpl", "chi-middleware.tmpl", "chi-handler.tmpl"}
+	echoServerTemplates          = []string{"echo-interface.tmpl", "echo-wrappers.tmpl", "echo-register.tmpl"}
+	ginServerTemplates           = []string{"gin-interface.tmpl", "gin-wrappers.tmpl", "gin-register.tmpl"}
+	irisServerTemplates          = []string{"iris-interface.tmpl", "iris-wrappers.tmpl", "iris-register.tmpl"}
+	echo5ServerTemplates         = []string{"echo-interface.tmpl", "echo-wrappers.tmpl", "echo5-register.tmpl"}
+	clientTemplates              = []string{"client.tmpl"}
+	clientWithResponsesTemplates = []string{"client-with-responses.tmpl"}
+	serverResponsesTemplates     = []string{"server-responses.tmpl"}
+	clientLazyTemplates          = []string{"client-lazy.tmpl"}
+	urlsTemplates                = []string{"urls.tmpl"}
+	paramErrorsTemplates         = []string{"param-errors.tmpl"}
+	webSocketTemplates           = []string{"websocket.tmpl"}
+)
+
 func GenerateChiServer(t *template.Template, operations []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"chi-interface.tmpl", "chi-middleware.tmpl", "chi-handler.tmpl"}, t, operations)
+	return GenerateTemplates(chiServerTemplates, t, operations)
 }
 
 // GenerateEchoServer This function generates all the go code for the ServerInterface as well as
 // all the wrapper functions around our handlers.
 func GenerateEchoServer(t *template.Template, operations []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"echo-interface.tmpl", "echo-wrappers.tmpl", "echo-register.tmpl"}, t, operations)
+	return GenerateTemplates(echoServerTemplates, t, operations)
 }
 
 // GenerateGinServer This function generates all the go code for the ServerInterface as well as
 // all the wrapper functions around our handlers.
 func GenerateGinServer(t *template.Template, operations []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"gin-interface.tmpl", "gin-wrappers.tmpl", "gin-register.tmpl"}, t, operations)
+	return GenerateTemplates(ginServerTemplates, t, operations)
+}
+
+// GenerateClientWithLazyResponses generates a client which extends the basic
+// client by returning responses which are only decoded on demand.
+func GenerateClientWithLazyResponses(t *template.Template, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates(clientLazyTemplates, t, ops)
+}
+
+// GenerateURLs generates the route constants of the operations, and the
+// functions which build their URLs.
+func GenerateURLs(t *template.Template, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates(urlsTemplates, t, ops)
+}
+
+// GenerateParamErrors generates DefaultParamErrorBody, which builds the
+// bodies of the responses of the server wrappers to missing required parameters.
+func GenerateParamErrors(t *template.Template, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates(paramErrorsTemplates, t, ops)
+}
+
+// GenerateServerResponses generates the types of the responses which server
+// handlers may send, whatever the router.
+func GenerateServerResponses(t *template.Template, operations []OperationDefinition) (string, error) {
+	return GenerateTemplates(serverResponsesTemplates, t, operations)
 }
 
 // Uses the template engine to generate the function which registers our wrappers
 // as Echo path handlers.
 func GenerateClient(t *template.Template, ops []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"client.tmpl"}, t, ops)
+	return GenerateTemplates(clientTemplates, t, ops)
 }
 
 // This generates a client which extends the basic client which does response
 // unmarshaling.
 func GenerateClientWithResponses(t *template.Template, ops []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"client-with-responses.tmpl"}, t, ops)
+	return GenerateTemplates(clientWithResponsesTemplates, t, ops)
 }
 
 // GenerateTemplates used to generate templates
 func GenerateTemplates(templates []string, t *template.Template, ops interface{}) (string, error) {
-	var generatedTemplates []string
-	for _, tmpl := range templates {
-		var buf bytes.Buffer
-		w := bufio.NewWriter(&buf)
+	var buf bytes.Buffer
+	if err := GenerateTemplatesTo(&buf, templates, t, ops); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
 
-		if err := t.ExecuteTemplate(w, tmpl, ops); err != nil {
-			return "", fmt.Errorf("error generating %s: %s", tmpl, err)
+// GenerateTemplatesTo executes the templates straight into w, separated by
+// blank lines, without buffering their output.
+func GenerateTemplatesTo(w io.Writer, templates []string, t *template.Template, ops interface{}) error {
+	for i, tmpl := range templates {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return fmt.Errorf("error writing %s: %s", tmpl, err)
+			}
 		}
-		if err := w.Flush(); err != nil {
-			return "", fmt.Errorf("error flushing output buffer for %s: %s", tmpl, err)
+		if err := t.ExecuteTemplate(w, tmpl, ops); err != nil {
+			return fmt.Errorf("error generating %s: %s", tmpl, err)
 		}
-		generatedTemplates = append(generatedTemplates, buf.String())
 	}
-
-	return strings.Join(generatedTemplates, "\n"), nil
+	return nil
 }
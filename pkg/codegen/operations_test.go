@@ -16,6 +16,10 @@ package codegen
 import (
 	"net/http"
 	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenerateDefaultOperationID(t *testing.T) {
@@ -79,3 +83,93 @@ func TestGenerateDefaultOperationID(t *testing.T) {
 		}
 	}
 }
+
+func TestSynthesizeOperationIDs(t *testing.T) {
+	spec := `
+openapi: 3.0.1
+info:
+  title: OpenAPI-CodeGen Test
+  version: 1.0.0
+paths:
+  /pet-store/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        200:
+          description: Success
+    put:
+      operationId: updatePet
+      responses:
+        200:
+          description: Success
+`
+	suite := map[string]string{
+		"":                      "GetPetStorePetId",
+		OperationIDCasingPascal: "GetPetStorePetId",
+		OperationIDCasingCamel:  "getPetStorePetId",
+		OperationIDCasingSnake:  "get_pet_store_pet_id",
+		OperationIDCasingKebab:  "get-pet-store-pet-id",
+	}
+
+	for casing, want := range suite {
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		goNames, err := synthesizeOperationIDs(swagger, casing)
+		require.NoError(t, err)
+		get := swagger.Paths["/pet-store/{petId}"].Get
+		assert.Equal(t, want, get.OperationID)
+		assert.Equal(t, "updatePet", swagger.Paths["/pet-store/{petId}"].Put.OperationID)
+
+		// The Go names don't depend on the casing
+		assert.Equal(t, map[*openapi3.Operation]string{get: "GetPetStorePetId"}, goNames)
+		g := newSpecGenerator(swagger, Options{})
+		g.synthesizedNames = goNames
+		ops, err := g.OperationDefinitions(swagger)
+		require.NoError(t, err)
+		var names []string
+		for _, op := range ops {
+			names = append(names, op.OperationId)
+		}
+		assert.ElementsMatch(t, []string{"GetPetStorePetId", "UpdatePet"}, names)
+	}
+
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	_, err = synthesizeOperationIDs(swagger, "shouting")
+	assert.Error(t, err)
+}
+
+func TestOperationIDWords(t *testing.T) {
+	assert.Equal(t, []string{"get", "pet", "store", "pet", "id"}, operationIDWords("GetPetStorePetId"))
+	assert.Equal(t, []string{"get", "http", "server"}, operationIDWords("GetHTTPServer"))
+	assert.Equal(t, []string{"get", "v2", "pets"}, operationIDWords("GetV2Pets"))
+	assert.Equal(t, []string{"delete"}, operationIDWords("Delete"))
+}
+
+func TestAcceptHeader(t *testing.T) {
+	response := func(contentTypes ...string) *openapi3.ResponseRef {
+		content := openapi3.Content{}
+		for _, contentType := range contentTypes {
+			content[contentType] = openapi3.NewMediaType()
+		}
+		return &openapi3.ResponseRef{Value: openapi3.NewResponse().WithContent(content)}
+	}
+
+	op := OperationDefinition{Spec: &openapi3.Operation{Responses: openapi3.Responses{
+		"200":     response("text/plain", "application/vnd.pets+json", "application/json"),
+		"404":     response("application/json"),
+		"default": response("*/*", "application/xml"),
+	}}}
+	assert.Equal(t, "application/json, application/vnd.pets+json;q=0.9, text/plain;q=0.8, application/xml;q=0.7, */*;q=0.6", op.AcceptHeader())
+
+	op = OperationDefinition{Spec: &openapi3.Operation{Responses: openapi3.Responses{
+		"204": &openapi3.ResponseRef{Value: openapi3.NewResponse()},
+	}}}
+	assert.Equal(t, "", op.AcceptHeader())
+}
@@ -0,0 +1,73 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// typesPackageName is the name which the types package of
+// Options.TypesPackage is imported as.
+const typesPackageName = "types"
+
+// checkTypesPackage returns an error when the types can't be imported from
+// the types package with the given options.
+func checkTypesPackage(opts Options) error {
+	if opts.TypesPackage != "" && opts.GenerateTypes {
+		return fmt.Errorf("the types package %s can't be imported by the code which declares the types", opts.TypesPackage)
+	}
+	return nil
+}
+
+// typesPackageImports returns the import of the types package, if any.
+func typesPackageImports(opts Options) []string {
+	if opts.TypesPackage == "" {
+		return nil
+	}
+	return []string{goImport{Name: typesPackageName, Path: opts.TypesPackage}.String()}
+}
+
+// TypeAliasesDefinition describes the declarations of the types package
+// which the code generated without the types aliases.
+type TypeAliasesDefinition struct {
+	Package   string   // The name which the types package is imported as
+	Types     []string // The exported types
+	Constants []string // The exported constants, such as the values of enums
+}
+
+// GenerateTypeAliases generates the aliases of the types and constants which
+// the types package, generated from the same spec with the types target,
+// declares, so that the client and server code, which refers to them by their
+// names, can be generated into other packages which import it.
+func GenerateTypeAliases(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, opts Options) (string, error) {
+	return newSpecGenerator(swagger, opts).GenerateTypeAliases(t, swagger, ops)
+}
+
+func (g *generator) GenerateTypeAliases(t *template.Template, swagger *openapi3.T, ops []OperationDefinition) (string, error) {
+	constants, err := GenerateConstants(t, ops)
+	if err != nil {
+		return "", err
+	}
+	typeDefinitions, err := g.GenerateTypeDefinitions(t, swagger, ops, g.opts.ExcludeSchemas)
+	if err != nil {
+		return "", err
+	}
+	api, err := parseAPISurface(strings.Join([]string{"package " + typesPackageName, constants, typeDefinitions}, "\n"))
+	if err != nil {
+		return "", fmt.Errorf("error parsing type definitions: %w", err)
+	}
+
+	aliases := TypeAliasesDefinition{Package: typesPackageName}
+	for name := range api.types {
+		aliases.Types = append(aliases.Types, name)
+	}
+	for name := range api.consts {
+		aliases.Constants = append(aliases.Constants, name)
+	}
+	sort.Strings(aliases.Types)
+	sort.Strings(aliases.Constants)
+	return GenerateTemplates([]string{"type-aliases.tmpl"}, t, aliases)
+}
@@ -0,0 +1,43 @@
+package codegen
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelFor calls fn for every index from 0 to n-1, spread over as many
+// goroutines as there are CPUs. Callers store results by index, so that the
+// output doesn't depend on scheduling, and for the same reason, the error
+// returned is the one of the lowest failing index.
+func parallelFor(n int, fn func(i int) error) error {
+	errs := make([]error, n)
+	indexes := make(chan int)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package codegen
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelFor(t *testing.T) {
+	results := make([]int, 100)
+	err := parallelFor(len(results), func(i int) error {
+		results[i] = i * i
+		return nil
+	})
+	assert.NoError(t, err)
+	for i, r := range results {
+		assert.Equal(t, i*i, r)
+	}
+
+	// The error of the lowest index is returned, whatever the scheduling
+	err = parallelFor(100, func(i int) error {
+		if i%10 == 7 {
+			return fmt.Errorf("error %d", i)
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "error 7")
+
+	assert.NoError(t, parallelFor(0, func(i int) error { return nil }))
+}
@@ -26,17 +26,30 @@ func walkSwagger(swagger *openapi3.T, doFn func(RefWrapper) (bool, error)) error
 		return nil
 	}
 
-	for _, p := range swagger.Paths {
+	if err := walkPaths(swagger.Paths, doFn); err != nil {
+		return err
+	}
+	if err := walkComponents(&swagger.Components, doFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func walkPaths(paths openapi3.Paths, doFn func(RefWrapper) (bool, error)) error {
+	for _, p := range paths {
 		for _, param := range p.Parameters {
-			walkParameterRef(param, doFn)
+			if err := walkParameterRef(param, doFn); err != nil {
+				return err
+			}
 		}
 		for _, op := range p.Operations() {
-			walkOperation(op, doFn)
+			if err := walkOperation(op, doFn); err != nil {
+				return err
+			}
 		}
 	}
 
-	walkComponents(&swagger.Components, doFn)
-
 	return nil
 }
 
@@ -47,17 +60,25 @@ func walkOperation(op *openapi3.Operation, doFn func(RefWrapper) (bool, error))
 	}
 
 	for _, param := range op.Parameters {
-		_ = walkParameterRef(param, doFn)
+		if err := walkParameterRef(param, doFn); err != nil {
+			return err
+		}
 	}
 
-	_ = walkRequestBodyRef(op.RequestBody, doFn)
+	if err := walkRequestBodyRef(op.RequestBody, doFn); err != nil {
+		return err
+	}
 
 	for _, response := range op.Responses {
-		walkResponseRef(response, doFn)
+		if err := walkResponseRef(response, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, callback := range op.Callbacks {
-		walkCallbackRef(callback, doFn)
+		if err := walkCallbackRef(callback, doFn); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -70,39 +91,57 @@ func walkComponents(components *openapi3.Components, doFn func(RefWrapper) (bool
 	}
 
 	for _, schema := range components.Schemas {
-		_ = walkSchemaRef(schema, doFn)
+		if err := walkSchemaRef(schema, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, param := range components.Parameters {
-		_ = walkParameterRef(param, doFn)
+		if err := walkParameterRef(param, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, header := range components.Headers {
-		_ = walkHeaderRef(header, doFn)
+		if err := walkHeaderRef(header, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, requestBody := range components.RequestBodies {
-		_ = walkRequestBodyRef(requestBody, doFn)
+		if err := walkRequestBodyRef(requestBody, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, response := range components.Responses {
-		_ = walkResponseRef(response, doFn)
+		if err := walkResponseRef(response, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, securityScheme := range components.SecuritySchemes {
-		_ = walkSecuritySchemeRef(securityScheme, doFn)
+		if err := walkSecuritySchemeRef(securityScheme, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, example := range components.Examples {
-		_ = walkExampleRef(example, doFn)
+		if err := walkExampleRef(example, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, link := range components.Links {
-		_ = walkLinkRef(link, doFn)
+		if err := walkLinkRef(link, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, callback := range components.Callbacks {
-		_ = walkCallbackRef(callback, doFn)
+		if err := walkCallbackRef(callback, doFn); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -126,25 +165,39 @@ func walkSchemaRef(ref *openapi3.SchemaRef, doFn func(RefWrapper) (bool, error))
 	}
 
 	for _, ref := range ref.Value.OneOf {
-		walkSchemaRef(ref, doFn)
+		if err := walkSchemaRef(ref, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, ref := range ref.Value.AnyOf {
-		walkSchemaRef(ref, doFn)
+		if err := walkSchemaRef(ref, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, ref := range ref.Value.AllOf {
-		walkSchemaRef(ref, doFn)
+		if err := walkSchemaRef(ref, doFn); err != nil {
+			return err
+		}
 	}
 
-	walkSchemaRef(ref.Value.Not, doFn)
-	walkSchemaRef(ref.Value.Items, doFn)
+	if err := walkSchemaRef(ref.Value.Not, doFn); err != nil {
+		return err
+	}
+	if err := walkSchemaRef(ref.Value.Items, doFn); err != nil {
+		return err
+	}
 
 	for _, ref := range ref.Value.Properties {
-		walkSchemaRef(ref, doFn)
+		if err := walkSchemaRef(ref, doFn); err != nil {
+			return err
+		}
 	}
 
-	walkSchemaRef(ref.Value.AdditionalProperties, doFn)
+	if err := walkSchemaRef(ref.Value.AdditionalProperties, doFn); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -166,20 +219,28 @@ func walkParameterRef(ref *openapi3.ParameterRef, doFn func(RefWrapper) (bool, e
 		return nil
 	}
 
-	walkSchemaRef(ref.Value.Schema, doFn)
+	if err := walkSchemaRef(ref.Value.Schema, doFn); err != nil {
+		return err
+	}
 
 	for _, example := range ref.Value.Examples {
-		walkExampleRef(example, doFn)
+		if err := walkExampleRef(example, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, mediaType := range ref.Value.Content {
 		if mediaType == nil {
 			continue
 		}
-		walkSchemaRef(mediaType.Schema, doFn)
+		if err := walkSchemaRef(mediaType.Schema, doFn); err != nil {
+			return err
+		}
 
 		for _, example := range mediaType.Examples {
-			walkExampleRef(example, doFn)
+			if err := walkExampleRef(example, doFn); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -207,10 +268,14 @@ func walkRequestBodyRef(ref *openapi3.RequestBodyRef, doFn func(RefWrapper) (boo
 		if mediaType == nil {
 			continue
 		}
-		walkSchemaRef(mediaType.Schema, doFn)
+		if err := walkSchemaRef(mediaType.Schema, doFn); err != nil {
+			return err
+		}
 
 		for _, example := range mediaType.Examples {
-			walkExampleRef(example, doFn)
+			if err := walkExampleRef(example, doFn); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -235,22 +300,30 @@ func walkResponseRef(ref *openapi3.ResponseRef, doFn func(RefWrapper) (bool, err
 	}
 
 	for _, header := range ref.Value.Headers {
-		walkHeaderRef(header, doFn)
+		if err := walkHeaderRef(header, doFn); err != nil {
+			return err
+		}
 	}
 
 	for _, mediaType := range ref.Value.Content {
 		if mediaType == nil {
 			continue
 		}
-		walkSchemaRef(mediaType.Schema, doFn)
+		if err := walkSchemaRef(mediaType.Schema, doFn); err != nil {
+			return err
+		}
 
 		for _, example := range mediaType.Examples {
-			walkExampleRef(example, doFn)
+			if err := walkExampleRef(example, doFn); err != nil {
+				return err
+			}
 		}
 	}
 
 	for _, link := range ref.Value.Links {
-		walkLinkRef(link, doFn)
+		if err := walkLinkRef(link, doFn); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -275,17 +348,37 @@ func walkCallbackRef(ref *openapi3.CallbackRef, doFn func(RefWrapper) (bool, err
 
 	for _, pathItem := range *ref.Value {
 		for _, parameter := range pathItem.Parameters {
-			walkParameterRef(parameter, doFn)
+			if err := walkParameterRef(parameter, doFn); err != nil {
+				return err
+			}
+		}
+		if err := walkOperation(pathItem.Connect, doFn); err != nil {
+			return err
+		}
+		if err := walkOperation(pathItem.Delete, doFn); err != nil {
+			return err
+		}
+		if err := walkOperation(pathItem.Get, doFn); err != nil {
+			return err
+		}
+		if err := walkOperation(pathItem.Head, doFn); err != nil {
+			return err
+		}
+		if err := walkOperation(pathItem.Options, doFn); err != nil {
+			return err
+		}
+		if err := walkOperation(pathItem.Patch, doFn); err != nil {
+			return err
+		}
+		if err := walkOperation(pathItem.Post, doFn); err != nil {
+			return err
+		}
+		if err := walkOperation(pathItem.Put, doFn); err != nil {
+			return err
+		}
+		if err := walkOperation(pathItem.Trace, doFn); err != nil {
+			return err
 		}
-		walkOperation(pathItem.Connect, doFn)
-		walkOperation(pathItem.Delete, doFn)
-		walkOperation(pathItem.Get, doFn)
-		walkOperation(pathItem.Head, doFn)
-		walkOperation(pathItem.Options, doFn)
-		walkOperation(pathItem.Patch, doFn)
-		walkOperation(pathItem.Post, doFn)
-		walkOperation(pathItem.Put, doFn)
-		walkOperation(pathItem.Trace, doFn)
 	}
 
 	return nil
@@ -308,7 +401,9 @@ func walkHeaderRef(ref *openapi3.HeaderRef, doFn func(RefWrapper) (bool, error))
 		return nil
 	}
 
-	walkSchemaRef(ref.Value.Schema, doFn)
+	if err := walkSchemaRef(ref.Value.Schema, doFn); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -377,10 +472,10 @@ func walkExampleRef(ref *openapi3.ExampleRef, doFn func(RefWrapper) (bool, error
 	return nil
 }
 
-func findComponentRefs(swagger *openapi3.T) []string {
+func findComponentRefs(swagger *openapi3.T) ([]string, error) {
 	refs := []string{}
 
-	walkSwagger(swagger, func(ref RefWrapper) (bool, error) {
+	err := walkSwagger(swagger, func(ref RefWrapper) (bool, error) {
 		if ref.Ref != "" {
 			refs = append(refs, ref.Ref)
 			return false, nil
@@ -388,7 +483,7 @@ func findComponentRefs(swagger *openapi3.T) []string {
 		return true, nil
 	})
 
-	return refs
+	return refs, err
 }
 
 func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
@@ -472,12 +567,53 @@ func removeOrphanedComponents(swagger *openapi3.T, refs []string) int {
 	return countRemoved
 }
 
-func pruneUnusedComponents(swagger *openapi3.T) {
+func pruneUnusedComponents(swagger *openapi3.T) error {
 	for {
-		refs := findComponentRefs(swagger)
+		refs, err := findComponentRefs(swagger)
+		if err != nil {
+			return err
+		}
 		countRemoved := removeOrphanedComponents(swagger, refs)
 		if countRemoved < 1 {
-			break
+			return nil
 		}
 	}
 }
+
+// findReachableComponentRefs returns the refs of all the components which can
+// be reached from the operations in the spec, following references between
+// components as well. Unlike findComponentRefs, components which are only
+// referenced by other unreachable components aren't included.
+func findReachableComponentRefs(swagger *openapi3.T) ([]string, error) {
+	refs := []string{}
+	seen := map[string]bool{}
+
+	err := walkPaths(swagger.Paths, func(ref RefWrapper) (bool, error) {
+		if ref.Ref == "" {
+			return true, nil
+		}
+		if seen[ref.Ref] {
+			return false, nil
+		}
+		seen[ref.Ref] = true
+		refs = append(refs, ref.Ref)
+		// The loader resolves references, so walking into the value of a
+		// ref walks the component it points to.
+		return true, nil
+	})
+
+	return refs, err
+}
+
+// pruneUnreachableComponents removes all the components which can't be
+// reached from any operation. This goes further than pruneUnusedComponents,
+// which keeps components that reference each other, even when no operation
+// uses them.
+func pruneUnreachableComponents(swagger *openapi3.T) error {
+	refs, err := findReachableComponentRefs(swagger)
+	if err != nil {
+		return err
+	}
+	removeOrphanedComponents(swagger, refs)
+	return nil
+}
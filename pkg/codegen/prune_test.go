@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -12,7 +13,8 @@ func TestFindReferences(t *testing.T) {
 		swagger, err := openapi3.NewLoader().LoadFromData([]byte(pruneSpecTestFixture))
 		assert.NoError(t, err)
 
-		refs := findComponentRefs(swagger)
+		refs, err := findComponentRefs(swagger)
+		assert.NoError(t, err)
 		assert.Len(t, refs, 14)
 	})
 	t.Run("only cat", func(t *testing.T) {
@@ -24,7 +26,8 @@ func TestFindReferences(t *testing.T) {
 
 		filterOperationsByTag(swagger, opts)
 
-		refs := findComponentRefs(swagger)
+		refs, err := findComponentRefs(swagger)
+		assert.NoError(t, err)
 		assert.Len(t, refs, 7)
 	})
 	t.Run("only dog", func(t *testing.T) {
@@ -37,7 +40,8 @@ func TestFindReferences(t *testing.T) {
 
 		filterOperationsByTag(swagger, opts)
 
-		refs := findComponentRefs(swagger)
+		refs, err := findComponentRefs(swagger)
+		assert.NoError(t, err)
 		assert.Len(t, refs, 7)
 	})
 }
@@ -51,21 +55,23 @@ func TestFilterOnlyCat(t *testing.T) {
 		IncludeTags: []string{"cat"},
 	}
 
-	refs := findComponentRefs(swagger)
+	refs, err := findComponentRefs(swagger)
+	assert.NoError(t, err)
 	assert.Len(t, refs, 14)
 
 	assert.Len(t, swagger.Components.Schemas, 5)
 
 	filterOperationsByTag(swagger, opts)
 
-	refs = findComponentRefs(swagger)
+	refs, err = findComponentRefs(swagger)
+	assert.NoError(t, err)
 	assert.Len(t, refs, 7)
 
 	assert.NotEmpty(t, swagger.Paths["/cat"], "/cat path should still be in spec")
 	assert.NotEmpty(t, swagger.Paths["/cat"].Get, "GET /cat operation should still be in spec")
 	assert.Empty(t, swagger.Paths["/dog"].Get, "GET /dog should have been removed from spec")
 
-	pruneUnusedComponents(swagger)
+	assert.NoError(t, pruneUnusedComponents(swagger))
 
 	assert.Len(t, swagger.Components.Schemas, 3)
 }
@@ -79,12 +85,14 @@ func TestFilterOnlyDog(t *testing.T) {
 		IncludeTags: []string{"dog"},
 	}
 
-	refs := findComponentRefs(swagger)
+	refs, err := findComponentRefs(swagger)
+	assert.NoError(t, err)
 	assert.Len(t, refs, 14)
 
 	filterOperationsByTag(swagger, opts)
 
-	refs = findComponentRefs(swagger)
+	refs, err = findComponentRefs(swagger)
+	assert.NoError(t, err)
 	assert.Len(t, refs, 7)
 
 	assert.Len(t, swagger.Components.Schemas, 5)
@@ -93,7 +101,7 @@ func TestFilterOnlyDog(t *testing.T) {
 	assert.NotEmpty(t, swagger.Paths["/dog"].Get)
 	assert.Empty(t, swagger.Paths["/cat"].Get)
 
-	pruneUnusedComponents(swagger)
+	assert.NoError(t, pruneUnusedComponents(swagger))
 
 	assert.Len(t, swagger.Components.Schemas, 3)
 }
@@ -113,7 +121,7 @@ func TestPruningUnusedComponents(t *testing.T) {
 	assert.Len(t, swagger.Components.Links, 1)
 	assert.Len(t, swagger.Components.Callbacks, 1)
 
-	pruneUnusedComponents(swagger)
+	assert.NoError(t, pruneUnusedComponents(swagger))
 
 	assert.Len(t, swagger.Components.Schemas, 0)
 	assert.Len(t, swagger.Components.Parameters, 0)
@@ -128,6 +136,44 @@ func TestPruningUnusedComponents(t *testing.T) {
 	assert.Len(t, swagger.Components.Callbacks, 0)
 }
 
+func TestPruningUnreachableComponents(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(pruneUnreachableTestFixture))
+	assert.NoError(t, err)
+
+	// The Node and Edge schemas refer to each other, so they survive the
+	// regular pruning even though no operation uses them.
+	assert.NoError(t, pruneUnusedComponents(swagger))
+	assert.Len(t, swagger.Components.Schemas, 4)
+
+	assert.NoError(t, pruneUnreachableComponents(swagger))
+	assert.Len(t, swagger.Components.Schemas, 2)
+	assert.Contains(t, swagger.Components.Schemas, "Pet")
+	assert.Contains(t, swagger.Components.Schemas, "Owner")
+}
+
+func TestWalkErrors(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(pruneUnreachableTestFixture))
+	assert.NoError(t, err)
+
+	// Errors from deep down the spec make it out of the walk
+	walkErr := errors.New("walk error")
+	err = walkSwagger(swagger, func(ref RefWrapper) (bool, error) {
+		if ref.Ref == "#/components/schemas/Owner" {
+			return false, walkErr
+		}
+		return true, nil
+	})
+	assert.Equal(t, walkErr, err)
+
+	err = walkPaths(swagger.Paths, func(ref RefWrapper) (bool, error) {
+		if ref.Ref == "#/components/schemas/Owner" {
+			return false, walkErr
+		}
+		return true, nil
+	})
+	assert.Equal(t, walkErr, err)
+}
+
 const pruneComprehensiveTestFixture = `
 openapi: 3.0.1
 
@@ -422,3 +468,46 @@ components:
           enum: [car, cat, oldage]
 
 `
+
+const pruneUnreachableTestFixture = `
+openapi: 3.0.1
+
+info:
+  title: OpenAPI-CodeGen Test
+  version: 1.0.0
+
+paths:
+  /pet:
+    get:
+      operationId: getPet
+      responses:
+        200:
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+
+components:
+  schemas:
+    Pet:
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+    Node:
+      properties:
+        edges:
+          type: array
+          items:
+            $ref: '#/components/schemas/Edge'
+    Edge:
+      properties:
+        to:
+          $ref: '#/components/schemas/Node'
+`
@@ -0,0 +1,186 @@
+package codegen
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The suffixes of the names of the variants of the schemas with readOnly or
+// writeOnly properties, which are sent in requests and responses.
+const (
+	requestVariant  = "Request"
+	responseVariant = "Response"
+)
+
+// newReadWriteModels returns the request and response variants of schemas.
+// The variants refer to each other, rather than to the schemas they're made
+// from, whatever the cycles between them.
+func newReadWriteModels(schemas openapi3.Schemas) map[string]*openapi3.SchemaRef {
+	found := map[string]bool{}
+	var names []string
+	for _, name := range SortedSchemaKeys(schemas) {
+		if hasReadWriteOnly(schemas[name], found, map[string]bool{}) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	models := map[string]*openapi3.SchemaRef{}
+	for _, name := range names {
+		for _, variant := range []string{requestVariant, responseVariant} {
+			models[name+variant] = &openapi3.SchemaRef{
+				Ref:   "#/components/schemas/" + name + variant,
+				Value: &openapi3.Schema{},
+			}
+		}
+	}
+	for _, name := range names {
+		for _, variant := range []string{requestVariant, responseVariant} {
+			*models[name+variant].Value = *variantSchema(schemas[name].Value, variant, models)
+		}
+	}
+	return models
+}
+
+// hasReadWriteOnly tells whether the schema, or one of the schemas it refers
+// to, has readOnly or writeOnly properties. The component schemas which do
+// are recorded in found, and the ones being looked into in visiting.
+func hasReadWriteOnly(sref *openapi3.SchemaRef, found, visiting map[string]bool) bool {
+	if sref == nil || sref.Value == nil {
+		return false
+	}
+	if strings.HasPrefix(sref.Ref, "#/components/schemas/") {
+		name := strings.TrimPrefix(sref.Ref, "#/components/schemas/")
+		if result, ok := found[name]; ok {
+			return result
+		}
+		if visiting[name] {
+			return false
+		}
+		visiting[name] = true
+		result := hasReadWriteOnly(&openapi3.SchemaRef{Value: sref.Value}, found, visiting)
+		delete(visiting, name)
+		found[name] = result
+		return result
+	} else if sref.Ref != "" {
+		return false
+	}
+
+	schema := sref.Value
+	for _, p := range schema.Properties {
+		if p.Ref == "" && p.Value != nil && (p.Value.ReadOnly || p.Value.WriteOnly) {
+			return true
+		}
+	}
+	var refs []*openapi3.SchemaRef
+	for _, p := range schema.Properties {
+		refs = append(refs, p)
+	}
+	refs = append(refs, schema.Items, schema.AdditionalProperties)
+	refs = append(refs, schema.AllOf...)
+	refs = append(refs, schema.AnyOf...)
+	refs = append(refs, schema.OneOf...)
+	for _, ref := range refs {
+		if hasReadWriteOnly(ref, found, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+// variantSchemaRef returns the variant of the schema which is sent in
+// requests, or in responses, when Options.ReadWriteModels is set: the one
+// without the readOnly, or the writeOnly, properties, which refers to the
+// variants of the schemas which have them.
+func (g *generator) variantSchemaRef(sref *openapi3.SchemaRef, variant string) *openapi3.SchemaRef {
+	if g.readWriteModels == nil {
+		return sref
+	}
+	return variantRef(sref, variant, g.readWriteModels)
+}
+
+// variantRef returns the variant of the schema in models, when it refers to
+// one, or else a copy of the inline schema made by variantSchema.
+func variantRef(sref *openapi3.SchemaRef, variant string, models map[string]*openapi3.SchemaRef) *openapi3.SchemaRef {
+	if sref == nil || sref.Value == nil {
+		return sref
+	}
+	if sref.Ref != "" {
+		if name := strings.TrimPrefix(sref.Ref, "#/components/schemas/"); name != sref.Ref {
+			if model, ok := models[name+variant]; ok {
+				return model
+			}
+		}
+		return sref
+	}
+	return &openapi3.SchemaRef{Value: variantSchema(sref.Value, variant, models)}
+}
+
+// variantSchema returns a copy of the inline schema, without the properties
+// which aren't sent in the variant, and referring to the variants in models.
+func variantSchema(schema *openapi3.Schema, variant string, models map[string]*openapi3.SchemaRef) *openapi3.Schema {
+	out := *schema
+	ref := func(sref *openapi3.SchemaRef) *openapi3.SchemaRef {
+		return variantRef(sref, variant, models)
+	}
+	refs := func(srefs []*openapi3.SchemaRef) []*openapi3.SchemaRef {
+		if srefs == nil {
+			return nil
+		}
+		out := make([]*openapi3.SchemaRef, len(srefs))
+		for i, sref := range srefs {
+			out[i] = ref(sref)
+		}
+		return out
+	}
+
+	if schema.Properties != nil {
+		out.Properties = openapi3.Schemas{}
+		out.Required = nil
+		for name, p := range schema.Properties {
+			if p.Ref == "" && p.Value != nil &&
+				(variant == requestVariant && p.Value.ReadOnly || variant == responseVariant && p.Value.WriteOnly) {
+				continue
+			}
+			out.Properties[name] = ref(p)
+			if StringInArray(name, schema.Required) {
+				out.Required = append(out.Required, name)
+			}
+		}
+	}
+	out.Items = ref(schema.Items)
+	out.AdditionalProperties = ref(schema.AdditionalProperties)
+	out.AllOf = refs(schema.AllOf)
+	out.AnyOf = refs(schema.AnyOf)
+	out.OneOf = refs(schema.OneOf)
+	return &out
+}
+
+// generateReadWriteModelTypes returns the types of the request and response
+// variants of the component schemas, unless they're excluded.
+func (g *generator) generateReadWriteModelTypes(excludeSchemas []string) ([]TypeDefinition, error) {
+	var types []TypeDefinition
+	for _, name := range SortedSchemaKeys(g.readWriteModels) {
+		base := strings.TrimSuffix(name, responseVariant)
+		if strings.HasSuffix(name, requestVariant) {
+			base = strings.TrimSuffix(name, requestVariant)
+		}
+		if StringInArray(base, excludeSchemas) {
+			continue
+		}
+		goSchema, err := g.generateGoSchema(&openapi3.SchemaRef{Value: g.readWriteModels[name].Value}, []string{name})
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, TypeDefinition{
+			JsonName: name,
+			TypeName: SchemaNameToTypeName(name),
+			Schema:   goSchema,
+		})
+		types = append(types, goSchema.GetAdditionalTypeDefs()...)
+	}
+	return types, nil
+}
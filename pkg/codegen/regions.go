@@ -0,0 +1,210 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// The markers which delimit the regions of the code generated with
+// Options.RegionMarkers. They are directives, so that they don't show up in
+// the documentation.
+const (
+	regionMarker    = "//oapi-codegen:region "
+	endRegionMarker = "//oapi-codegen:endregion "
+)
+
+// regionStart returns the marker which opens the region with the given name.
+// It's separated by blank lines from the code, so it doesn't become a part
+// of the doc comments.
+func regionStart(name string) string {
+	return "\n" + regionMarker + name + "\n\n"
+}
+
+// regionEnd returns the marker which closes the region with the given name.
+func regionEnd(name string) string {
+	return "\n" + endRegionMarker + name + "\n\n"
+}
+
+// region is a part of the code delimited by region markers, which may
+// contain other regions.
+type region struct {
+	name       string
+	start, end int // The offsets of the region, markers included
+	children   []*region
+}
+
+// content returns the code of r, markers included.
+func (r *region) content(src []byte) []byte {
+	return src[r.start:r.end]
+}
+
+// outline returns the code of r without its children, and without the
+// blank lines around them, which tells whether r changed besides its
+// children being changed, added or removed.
+func (r *region) outline(src []byte) []byte {
+	var parts [][]byte
+	pos := r.start
+	for _, child := range r.children {
+		parts = append(parts, src[pos:child.start])
+		pos = child.end
+	}
+	parts = append(parts, src[pos:r.end])
+
+	var outline [][]byte
+	for _, part := range parts {
+		if part = bytes.Trim(part, "\n"); len(part) > 0 {
+			outline = append(outline, part)
+		}
+	}
+	return bytes.Join(outline, []byte("\n"))
+}
+
+// child returns the child of r with the given name, or nil.
+func (r *region) child(name string) *region {
+	for _, child := range r.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// parseRegions returns the regions of src as the children of a region which
+// spans all of it.
+func parseRegions(src []byte) (*region, error) {
+	root := &region{end: len(src)}
+	stack := []*region{root}
+	for pos := 0; pos < len(src); {
+		lineEnd := bytes.IndexByte(src[pos:], '\n') + 1
+		if lineEnd == 0 {
+			lineEnd = len(src) - pos
+		}
+		line := strings.TrimSpace(string(src[pos : pos+lineEnd]))
+		parent := stack[len(stack)-1]
+
+		switch {
+		case strings.HasPrefix(line, regionMarker):
+			name := strings.TrimPrefix(line, regionMarker)
+			if parent.child(name) != nil {
+				return nil, fmt.Errorf("duplicate region %q", name)
+			}
+			r := &region{name: name, start: pos}
+			parent.children = append(parent.children, r)
+			stack = append(stack, r)
+		case strings.HasPrefix(line, endRegionMarker):
+			name := strings.TrimPrefix(line, endRegionMarker)
+			if parent == root || parent.name != name {
+				return nil, fmt.Errorf("unexpected end of region %q", name)
+			}
+			parent.end = pos + lineEnd
+			stack = stack[:len(stack)-1]
+		}
+		pos += lineEnd
+	}
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("region %q isn't closed", stack[len(stack)-1].name)
+	}
+	return root, nil
+}
+
+// UpdateRegions updates the code generated with region markers, oldCode,
+// to newCode, the code generated again from a changed spec, and returns the
+// names of the regions which changed. Only the top level regions whose code
+// changed are rewritten: the other regions, and the code outside of the
+// regions, are kept byte for byte. Regions which are gone are removed, and
+// new ones are inserted after the region which precedes them in newCode.
+// The nested regions, such as the handlers of the operations within the
+// server, are named after the regions which contain them, eg, "chi-server/
+// handler FindPets". When oldCode has no regions, it's replaced with
+// newCode.
+func UpdateRegions(oldCode, newCode []byte) ([]byte, []string, error) {
+	oldRoot, err := parseRegions(oldCode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing the regions of the old code: %w", err)
+	}
+	newRoot, err := parseRegions(newCode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing the regions of the new code: %w", err)
+	}
+	if len(oldRoot.children) == 0 {
+		return newCode, changedRegions("", nil, oldCode, newRoot, newCode), nil
+	}
+
+	// The regions are separated by blank lines, which go with the regions
+	// which are inserted or removed.
+	var out bytes.Buffer
+	written := map[string]bool{}
+	// writeUpTo writes the new regions which come before the region with
+	// the given name, or all of them, which aren't written yet.
+	writeUpTo := func(name string) {
+		for _, r := range newRoot.children {
+			if r.name == name {
+				return
+			}
+			if !written[r.name] && oldRoot.child(r.name) == nil {
+				written[r.name] = true
+				if name == "" {
+					out.WriteByte('\n')
+				}
+				out.Write(r.content(newCode))
+				if name != "" {
+					out.WriteByte('\n')
+				}
+			}
+		}
+	}
+
+	pos := 0
+	for _, old := range oldRoot.children {
+		out.Write(oldCode[pos:old.start])
+		pos = old.end
+		r := newRoot.child(old.name)
+		if r == nil {
+			if pos < len(oldCode) && oldCode[pos] == '\n' {
+				pos++
+			}
+			continue
+		}
+		writeUpTo(r.name)
+		written[r.name] = true
+		if bytes.Equal(old.content(oldCode), r.content(newCode)) {
+			out.Write(old.content(oldCode))
+		} else {
+			out.Write(r.content(newCode))
+		}
+	}
+	writeUpTo("")
+	out.Write(oldCode[pos:])
+
+	return out.Bytes(), changedRegions("", oldRoot, oldCode, newRoot, newCode), nil
+}
+
+// changedRegions returns the names of the innermost regions which differ
+// between the children of oldRoot and newRoot, prefixed with the given path.
+func changedRegions(path string, oldRoot *region, oldCode []byte, newRoot *region, newCode []byte) []string {
+	var changed []string
+	if oldRoot != nil {
+		for _, old := range oldRoot.children {
+			if newRoot.child(old.name) == nil {
+				changed = append(changed, path+old.name)
+			}
+		}
+	}
+	for _, r := range newRoot.children {
+		var old *region
+		if oldRoot != nil {
+			old = oldRoot.child(r.name)
+		}
+		switch {
+		case old == nil:
+			changed = append(changed, path+r.name)
+		case bytes.Equal(old.content(oldCode), r.content(newCode)):
+		case len(r.children) > 0 && bytes.Equal(old.outline(oldCode), r.outline(newCode)):
+			changed = append(changed, changedRegions(path+r.name+"/", old, oldCode, r, newCode)...)
+		default:
+			changed = append(changed, path+r.name)
+		}
+	}
+	return changed
+}
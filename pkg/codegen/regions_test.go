@@ -0,0 +1,109 @@
+package codegen
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// regionsCode joins the given regions, which are pairs of names and code, as
+// they're formatted.
+func regionsCode(regions ...string) string {
+	var code []string
+	for i := 0; i < len(regions); i += 2 {
+		code = append(code, regionMarker+regions[i]+"\n\n"+regions[i+1]+"\n"+endRegionMarker+regions[i]+"\n")
+	}
+	return strings.Join(code, "\n")
+}
+
+func TestUpdateRegions(t *testing.T) {
+	server := func(handlers ...string) string {
+		return "type ServerInterface interface{}\n\n" + regionsCode(handlers...)
+	}
+	oldCode := regionsCode(
+		"types", "type Pet struct{}\n",
+		"chi-server", server("handler FindPets", "func FindPets() {}\n", "handler AddPet", "func AddPet() {}\n"),
+		"spec", "var spec = 1\n",
+	) + "\n// Hand written\nvar extra = 1\n"
+
+	t.Run("unchanged", func(t *testing.T) {
+		code, changed, err := UpdateRegions([]byte(oldCode), []byte(oldCode))
+		require.NoError(t, err)
+		assert.Equal(t, oldCode, string(code))
+		assert.Empty(t, changed)
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		newCode := regionsCode(
+			"types", "type Pet struct{ Name string }\n",
+			"chi-server", server("handler FindPets", "func FindPets(limit int) {}\n", "handler AddPet", "func AddPet() {}\n"),
+			"spec", "var spec = 1\n",
+		)
+		code, changed, err := UpdateRegions([]byte(oldCode), []byte(newCode))
+		require.NoError(t, err)
+		assert.Equal(t, newCode+"\n// Hand written\nvar extra = 1\n", string(code))
+		assert.Equal(t, []string{"types", "chi-server/handler FindPets"}, changed)
+	})
+
+	t.Run("added and removed", func(t *testing.T) {
+		newCode := regionsCode(
+			"types", "type Pet struct{}\n",
+			"client", "type Client struct{}\n",
+			"chi-server", server("handler AddPet", "func AddPet() {}\n"),
+		)
+		code, changed, err := UpdateRegions([]byte(oldCode), []byte(newCode))
+		require.NoError(t, err)
+		assert.Equal(t, newCode+"\n// Hand written\nvar extra = 1\n", string(code))
+		assert.Equal(t, []string{"spec", "client", "chi-server/handler FindPets"}, changed)
+	})
+
+	t.Run("without regions", func(t *testing.T) {
+		code, changed, err := UpdateRegions([]byte("package api\n"), []byte(oldCode))
+		require.NoError(t, err)
+		assert.Equal(t, oldCode, string(code))
+		assert.Equal(t, []string{"types", "chi-server", "spec"}, changed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := UpdateRegions([]byte(regionMarker+"types\n"), []byte(oldCode))
+		assert.EqualError(t, err, `error parsing the regions of the old code: region "types" isn't closed`)
+
+		_, _, err = UpdateRegions([]byte(oldCode), []byte(regionsCode("types", "", "types", "")))
+		assert.EqualError(t, err, `error parsing the regions of the new code: duplicate region "types"`)
+
+		_, _, err = UpdateRegions([]byte(endRegionMarker+"types\n"), []byte(oldCode))
+		assert.EqualError(t, err, `error parsing the regions of the old code: unexpected end of region "types"`)
+	})
+}
+
+func TestRegionMarkers(t *testing.T) {
+	loader := openapi3.NewLoader()
+	swagger, err := loader.LoadFromData([]byte(testOpenAPIDefinition))
+	require.NoError(t, err)
+
+	opts := Options{
+		PackageName:       "api",
+		GenerateTypes:     true,
+		GenerateClient:    true,
+		GenerateChiServer: true,
+		RegionMarkers:     true,
+	}
+	artifacts, _, err := Generate(context.Background(), swagger, opts)
+	require.NoError(t, err)
+
+	root, err := parseRegions([]byte(artifacts.Code))
+	require.NoError(t, err)
+	var names []string
+	for _, r := range root.children {
+		names = append(names, r.name)
+	}
+	assert.Equal(t, []string{"imports", "constants", "types", "urls", "client", "client-with-responses", "server-urls", "chi-server"}, names)
+	server := root.child("chi-server")
+	require.NotEmpty(t, server.children)
+	assert.Equal(t, "handler GetCatStatus", server.children[0].name)
+	assert.True(t, strings.HasPrefix(artifacts.Code, regionMarker+"imports\n\n// Package api"))
+}
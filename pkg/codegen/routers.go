@@ -0,0 +1,84 @@
+package codegen
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// ServerRouter describes how the server code is generated for a router. The
+// router specific parts of the server, such as how paths are written, how
+// the parameters are taken from the router's context, and how the handlers
+// are registered, live in its templates and template functions, so that
+// routers can be added without changing the others.
+type ServerRouter struct {
+	// Name identifies the router in errors, eg, "mux".
+	Name string
+
+	// Imports are the imports of the router's packages in the generated
+	// code, eg, `"github.com/gorilla/mux"`.
+	Imports []string
+
+	// Templates are the names of the templates which generate the server,
+	// in order. They are executed with the operations.
+	Templates []string
+
+	// Sources are the texts of the templates which aren't built in, by
+	// name.
+	Sources map[string]string
+
+	// Funcs are the template functions of the router, such as the one
+	// converting the paths of the spec to the router's syntax. They replace
+	// the functions of the same name.
+	Funcs template.FuncMap
+}
+
+// enabledServerRouters returns the routers which opts generate servers for.
+// Each router declares its own ServerInterface, so only echo v4, chi and gin,
+// whose interfaces are alike, can be combined.
+func enabledServerRouters(opts Options) ([]ServerRouter, error) {
+	var routers []ServerRouter
+	var exclusive int
+	if opts.GenerateEchoServer {
+		routers = append(routers, ServerRouter{Name: "echo", Imports: []string{`"github.com/labstack/echo/v4"`}, Templates: echoServerTemplates})
+	}
+	if opts.GenerateChiServer {
+		routers = append(routers, ServerRouter{Name: "chi", Imports: []string{`"github.com/go-chi/chi/v5"`}, Templates: chiServerTemplates})
+	}
+	if opts.GenerateGinServer {
+		routers = append(routers, ServerRouter{Name: "gin", Imports: []string{`"github.com/gin-gonic/gin"`}, Templates: ginServerTemplates})
+	}
+	if opts.GenerateIrisServer {
+		routers = append(routers, ServerRouter{Name: "iris", Imports: []string{`"github.com/kataras/iris/v12"`}, Templates: irisServerTemplates})
+		exclusive++
+	}
+	if opts.GenerateEcho5Server {
+		// The echo v4 templates serve v5 too, whose handlers take a pointer
+		routers = append(routers, ServerRouter{
+			Name:      "echo5",
+			Imports:   []string{`"github.com/labstack/echo/v5"`},
+			Templates: echo5ServerTemplates,
+			Funcs:     template.FuncMap{"echoContext": func() string { return "*echo.Context" }},
+		})
+		exclusive++
+	}
+	for _, r := range opts.ServerRouters {
+		if r.Name == "" || len(r.Templates) == 0 {
+			return nil, fmt.Errorf("server router %q has no name or no templates", r.Name)
+		}
+		routers = append(routers, r)
+		exclusive++
+	}
+	if len(routers) > 1 && exclusive > 0 {
+		return nil, fmt.Errorf("servers can only be generated for one router at a time, got %d", len(routers))
+	}
+	return routers, nil
+}
+
+// serverRouterImports returns the imports of the routers.
+func serverRouterImports(routers []ServerRouter) []string {
+	var imports []string
+	for _, r := range routers {
+		imports = append(imports, r.Imports...)
+	}
+	return imports
+}
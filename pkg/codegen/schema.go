@@ -18,12 +18,17 @@ type Schema struct {
 	EnumValues map[string]string // Enum values
 
 	Properties               []Property       // For an object, the fields with names
+	EmbeddedProperties       []Property       // For an allOf, the fields of the structs it embeds
 	HasAdditionalProperties  bool             // Whether we support additional properties
 	AdditionalPropertiesType *Schema          // And if we do, their type
 	AdditionalTypes          []TypeDefinition // We may need to generate auxiliary helper types, stored here
 
 	SkipOptionalPointer bool // Some types don't need a * in front when they're optional
 
+	UnexportedFields []string // Declarations of the unexported fields of a struct, which aren't in the schema
+
+	TimeFormat string // The layout of a time which isn't RFC 3339, which the type embedding it encodes
+
 	Description string // The description of the element
 
 	// The original OpenAPIv3 Schema.
@@ -52,6 +57,49 @@ func (s *Schema) MergeProperty(p Property) error {
 	return nil
 }
 
+// JSONProperties returns the properties which are (un)marshaled alongside the
+// additional properties: the fields of the type, and the ones promoted from
+// the structs it embeds.
+func (s Schema) JSONProperties() []Property {
+	return append(append([]Property{}, s.Properties...), s.EmbeddedProperties...)
+}
+
+// nameAdditionalPropertiesType defines a type named after path for s, when
+// it's an anonymous struct with additional properties, since only named types
+// can have the methods which (un)marshal them.
+func nameAdditionalPropertiesType(s *Schema, path []string) {
+	if !s.HasAdditionalProperties || s.RefType != "" {
+		return
+	}
+	path = append([]string{}, path...)
+	typeName := PathToTypeName(path)
+	typeDef := TypeDefinition{
+		TypeName: typeName,
+		JsonName: strings.Join(path, "."),
+		Schema:   *s,
+	}
+	s.AdditionalTypes = append(s.AdditionalTypes, typeDef)
+	s.RefType = typeName
+}
+
+// nameTimeFormatType defines a type named after path for s, when it's an
+// anonymous struct embedding a time in another layout, since only named types
+// can have the methods which (un)marshal it.
+func nameTimeFormatType(s *Schema, path []string) {
+	if s.TimeFormat == "" || s.RefType != "" {
+		return
+	}
+	path = append([]string{}, path...)
+	typeName := SchemaNameToTypeName(PathToTypeName(path))
+	typeDef := TypeDefinition{
+		TypeName: typeName,
+		JsonName: strings.Join(path, "."),
+		Schema:   *s,
+	}
+	s.AdditionalTypes = append(s.AdditionalTypes, typeDef)
+	s.RefType = typeName
+}
+
 func (s Schema) GetAdditionalTypeDefs() []TypeDefinition {
 	var result []TypeDefinition
 	for _, p := range s.Properties {
@@ -67,13 +115,40 @@ type Property struct {
 	Schema         Schema
 	Required       bool
 	Nullable       bool
+	Deprecated     bool
 	ExtensionProps *openapi3.ExtensionProps
+	XML            *XMLObject // The xml object of the property, if it declares one
+	XMLItems       *XMLObject // The xml object of the items of an array property
+
+	// presence is the condition, with a %s verb for the field, under which
+	// an optional field which is a plain value is encoded, when it's not nil.
+	// Fields of struct types are always encoded.
+	presence string
+	isStruct bool
+
+	// spec is the schema of the property in the spec, whose default and
+	// constraints apply to the field.
+	spec *openapi3.Schema
 }
 
 func (p Property) GoFieldName() string {
 	return SchemaNameToTypeName(p.JsonFieldName)
 }
 
+// PresenceCheck returns the condition under which the field of the struct v
+// is encoded, as omitempty tells, or an empty string when it always is.
+func (p Property) PresenceCheck(v string) string {
+	field := v + "." + p.GoFieldName()
+	switch {
+	case p.Required, p.isStruct:
+		return ""
+	case p.presence != "":
+		return fmt.Sprintf(p.presence, field)
+	default:
+		return field + " != nil"
+	}
+}
+
 func (p Property) GoTypeDef() string {
 	typeDef := p.Schema.TypeDecl()
 	if !p.Schema.SkipOptionalPointer && (!p.Required || p.Nullable) {
@@ -89,6 +164,48 @@ type EnumDefinition struct {
 	ValueWrapper string
 }
 
+// IsString returns whether the enum values are strings.
+func (e EnumDefinition) IsString() bool {
+	return e.Schema.GoType == "string"
+}
+
+// IsConst returns whether the enum has a single value, as a const, which the
+// type always encodes in JSON, and accepts alone.
+func (e EnumDefinition) IsConst() bool {
+	return len(e.Schema.EnumValues) == 1
+}
+
+// ConstName returns the name of the constant of the single value of the enum.
+func (e EnumDefinition) ConstName() string {
+	for name := range e.Schema.EnumValues {
+		return name
+	}
+	return ""
+}
+
+// SQLNullType returns the sql.Null type which scans the enum values, without
+// its "Null" prefix, eg, "Int64", or an empty string when there is none.
+func (e EnumDefinition) SQLNullType() string {
+	switch e.Schema.GoType {
+	case "string":
+		return "String"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "Int64"
+	case "float32", "float64":
+		return "Float64"
+	case "bool":
+		return "Bool"
+	default:
+		return ""
+	}
+}
+
+// SQLValueType returns the type of the driver values of the enum, which is
+// the type of the field of its sql.Null type.
+func (e EnumDefinition) SQLValueType() string {
+	return strings.ToLower(e.SQLNullType())
+}
+
 type Constants struct {
 	// SecuritySchemeProviderNames holds all provider names for security schemes.
 	SecuritySchemeProviderNames []string
@@ -138,7 +255,50 @@ func PropertiesEqual(a, b Property) bool {
 	return a.JsonFieldName == b.JsonFieldName && a.Schema.TypeDecl() == b.Schema.TypeDecl() && a.Required == b.Required
 }
 
+// GenerateGoSchema turns an OpenAPI schema into a Go schema. Inline schemas
+// which have the x-go-type-name extension become a named type, which is
+// referred to from the parent schema.
 func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
+	return defaultGenerator().GenerateGoSchema(sref, path)
+}
+
+func (g *generator) GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
+	if sref != nil && sref.Value != nil && !IsGoTypeReference(sref.Ref) {
+		if extension, ok := sref.Value.Extensions[extPropGoTypeName]; ok {
+			typeName, err := extTypeName(extension)
+			if err != nil {
+				return Schema{}, fmt.Errorf("invalid value for %q: %w", extPropGoTypeName, err)
+			}
+			return g.generateNamedGoSchema(sref, typeName)
+		}
+	}
+	return g.generateGoSchema(sref, path)
+}
+
+// generateNamedGoSchema generates a type with the given name for an inline
+// schema. The types nested in the schema are named after it as well, rather
+// than after the path to the schema, so they don't change when the schema is
+// moved around.
+func (g *generator) generateNamedGoSchema(sref *openapi3.SchemaRef, typeName string) (Schema, error) {
+	namedSchema, err := g.generateGoSchema(sref, []string{typeName})
+	if err != nil {
+		return Schema{}, err
+	}
+	typeDef := TypeDefinition{
+		TypeName: typeName,
+		JsonName: typeName,
+		Schema:   namedSchema,
+	}
+	return Schema{
+		GoType:          typeName,
+		RefType:         typeName,
+		Description:     namedSchema.Description,
+		OAPISchema:      namedSchema.OAPISchema,
+		AdditionalTypes: append(namedSchema.GetAdditionalTypeDefs(), typeDef),
+	}, nil
+}
+
+func (g *generator) generateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 	// Add a fallback value in case the sref is nil.
 	// i.e. the parent schema defines a type:array, but the array has
 	// no items defined. Therefore we have at least valid Go-Code.
@@ -152,7 +312,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 	// another type. We're not de-referencing, so simply use the referenced type.
 	if IsGoTypeReference(sref.Ref) {
 		// Convert the reference path to Go type
-		refType, err := RefPathToGoType(sref.Ref)
+		refType, err := g.RefPathToGoType(sref.Ref)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error turning reference (%s) into a Go type: %s",
 				sref.Ref, err)
@@ -184,7 +344,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 	// so that in a RESTful paradigm, the Create operation can return
 	// (object, id), so that other operations can refer to (id)
 	if schema.AllOf != nil {
-		mergedSchema, err := MergeSchemas(schema.AllOf, path)
+		mergedSchema, err := g.MergeSchemas(schema.AllOf, path)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error merging schemas: %w", err)
 		}
@@ -202,6 +362,26 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 		return outSchema, nil
 	}
 
+	// Times in other layouts than RFC 3339 are structs which embed a
+	// time.Time, and have methods which encode it in the layout.
+	timeFormat, err := g.schemaTimeFormat(schema)
+	if err != nil {
+		return outSchema, err
+	}
+	if timeFormat != "" {
+		outSchema.GoType = "struct {\ntime.Time\n}"
+		outSchema.TimeFormat = timeFormat
+		if len(path) > 1 { // like enums, top level types are named already
+			nameTimeFormatType(&outSchema, path)
+		}
+		return outSchema, nil
+	}
+
+	enum, err := schemaEnum(schema)
+	if err != nil {
+		return outSchema, err
+	}
+
 	// Schema type and format, eg. string / binary
 	t := schema.Type
 	// Handle objects and empty schemas first as a special case
@@ -226,40 +406,72 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 			for _, pName := range SortedSchemaKeys(schema.Properties) {
 				p := schema.Properties[pName]
 				propertyPath := append(path, pName)
-				pSchema, err := GenerateGoSchema(p, propertyPath)
+				pSchema, err := g.GenerateGoSchema(p, propertyPath)
 				if err != nil {
 					return Schema{}, fmt.Errorf("error generating Go schema for property '%s': %w", pName, err)
 				}
 
 				required := StringInArray(pName, schema.Required)
 
-				if pSchema.HasAdditionalProperties && pSchema.RefType == "" {
-					// If we have fields present which have additional properties,
-					// but are not a pre-defined type, we need to define a type
-					// for them, which will be based on the field names we followed
-					// to get to the type.
-					typeName := PathToTypeName(propertyPath)
-
-					typeDef := TypeDefinition{
-						TypeName: typeName,
-						JsonName: strings.Join(propertyPath, "."),
-						Schema:   pSchema,
+				// If we have fields present which have additional properties,
+				// but are not a pre-defined type, we need to define a type
+				// for them, which will be based on the field names we followed
+				// to get to the type.
+				nameAdditionalPropertiesType(&pSchema, propertyPath)
+
+				// Nullable fields tell null values from absent ones with
+				// runtime.Nullable, rather than a pointer, when asked to.
+				nullable := p.Value.Nullable
+				if nullable && g.opts.NullableType {
+					pSchema = Schema{
+						GoType:              fmt.Sprintf("%s[%s]", g.nullableType(), pSchema.TypeDecl()),
+						SkipOptionalPointer: true,
+						AdditionalTypes:     pSchema.GetAdditionalTypeDefs(),
+						Description:         pSchema.Description,
+						OAPISchema:          pSchema.OAPISchema,
+					}
+					nullable = false
+				}
+				// Optional fields may be plain values rather than pointers.
+				var presence string
+				var isStruct bool
+				if !required && !nullable && !pSchema.SkipOptionalPointer {
+					valueField, check, err := g.optionalValueField(p.Value)
+					if err != nil {
+						return Schema{}, fmt.Errorf("invalid value for %q of property '%s': %w", extPropSkipOptionalPointer, pName, err)
+					}
+					if valueField {
+						pSchema.SkipOptionalPointer = true
+						presence = check
+						isStruct = check == ""
 					}
-					pSchema.AdditionalTypes = append(pSchema.AdditionalTypes, typeDef)
-
-					pSchema.RefType = typeName
 				}
 				description := ""
 				if p.Value != nil {
 					description = p.Value.Description
 				}
+				// A referenced schema's xml object names the root element
+				// of the type, not the property.
+				var xmlObject, xmlItems *XMLObject
+				if p.Ref == "" {
+					xmlObject = schemaXMLObject(p.Value)
+				}
+				if p.Value.Items != nil {
+					xmlItems = schemaXMLObject(p.Value.Items.Value)
+				}
 				prop := Property{
 					JsonFieldName:  pName,
 					Schema:         pSchema,
 					Required:       required,
 					Description:    description,
-					Nullable:       p.Value.Nullable,
+					Nullable:       nullable,
+					Deprecated:     p.Value.Deprecated,
 					ExtensionProps: &p.Value.ExtensionProps,
+					XML:            xmlObject,
+					XMLItems:       xmlItems,
+					presence:       presence,
+					isStruct:       isStruct,
+					spec:           p.Value,
 				}
 				outSchema.Properties = append(outSchema.Properties, prop)
 			}
@@ -269,23 +481,25 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 				GoType: "interface{}",
 			}
 			if schema.AdditionalProperties != nil {
-				additionalSchema, err := GenerateGoSchema(schema.AdditionalProperties, path)
+				additionalSchema, err := g.GenerateGoSchema(schema.AdditionalProperties, path)
 				if err != nil {
 					return Schema{}, fmt.Errorf("error generating type for additional properties: %w", err)
 				}
+				nameAdditionalPropertiesType(&additionalSchema, append(path, "AdditionalProperties"))
+				outSchema.AdditionalTypes = append(outSchema.AdditionalTypes, additionalSchema.GetAdditionalTypeDefs()...)
 				outSchema.AdditionalPropertiesType = &additionalSchema
 			}
 
-			outSchema.GoType = GenStructFromSchema(outSchema)
+			outSchema.GoType = g.GenStructFromSchema(outSchema)
 		}
 		return outSchema, nil
-	} else if len(schema.Enum) > 0 {
-		err := resolveType(schema, path, &outSchema)
+	} else if len(enum) > 0 {
+		err := g.resolveType(schema, path, &outSchema)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error resolving primitive type: %w", err)
 		}
-		enumValues := make([]string, len(schema.Enum))
-		for i, enumValue := range schema.Enum {
+		enumValues := make([]string, len(enum))
+		for i, enumValue := range enum {
 			enumValues[i] = fmt.Sprintf("%v", enumValue)
 		}
 
@@ -312,7 +526,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 		}
 		//outSchema.RefType = typeName
 	} else {
-		err := resolveType(schema, path, &outSchema)
+		err := g.resolveType(schema, path, &outSchema)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error resolving primitive type")
 		}
@@ -320,19 +534,43 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string) (Schema, error) {
 	return outSchema, nil
 }
 
+// schemaEnum returns the values of the enum of the schema, or the single value
+// of its const.
+func schemaEnum(schema *openapi3.Schema) ([]interface{}, error) {
+	if len(schema.Enum) > 0 {
+		return schema.Enum, nil
+	}
+	extension, ok := schema.Extensions[propConst]
+	if !ok {
+		return nil, nil
+	}
+	value, err := extParseConst(extension)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %q: %w", propConst, err)
+	}
+	return []interface{}{value}, nil
+}
+
 // resolveType resolves primitive  type or array for schema
-func resolveType(schema *openapi3.Schema, path []string, outSchema *Schema) error {
+func (g *generator) resolveType(schema *openapi3.Schema, path []string, outSchema *Schema) error {
 	f := schema.Format
 	t := schema.Type
 
+	if mapping, ok := g.schemaTypeMapping(schema); ok {
+		outSchema.GoType = mapping.GoType
+		return nil
+	}
+
 	switch t {
 	case "array":
 		// For arrays, we'll get the type of the Items and throw a
 		// [] in front of it.
-		arrayType, err := GenerateGoSchema(schema.Items, path)
+		arrayType, err := g.GenerateGoSchema(schema.Items, path)
 		if err != nil {
 			return fmt.Errorf("error generating type for array: %w", err)
 		}
+		nameAdditionalPropertiesType(&arrayType, append(path, "Item"))
+		nameTimeFormatType(&arrayType, append(path, "Item"))
 		outSchema.ArrayType = &arrayType
 		outSchema.GoType = "[]" + arrayType.TypeDecl()
 		outSchema.AdditionalTypes = arrayType.AdditionalTypes
@@ -382,7 +620,11 @@ func resolveType(schema *openapi3.Schema, path []string, outSchema *Schema) erro
 		// Special case string formats here.
 		switch f {
 		case "byte":
-			outSchema.GoType = "[]byte"
+			if g.opts.ByteEncoding == ByteEncodingURL {
+				outSchema.GoType = "openapi_types.Base64URL"
+			} else {
+				outSchema.GoType = "openapi_types.Base64"
+			}
 		case "email":
 			outSchema.GoType = "openapi_types.Email"
 		case "date":
@@ -417,9 +659,124 @@ type FieldDescriptor struct {
 	IsRef    bool   // Is this schema a reference to predefined object?
 }
 
+// localNullable tells whether nullable fields are of a Nullable type generated
+// along with the types, since runtime.Nullable encodes its values with
+// encoding/json, rather than the JSONPackage.
+func (g *generator) localNullable() bool {
+	return g.opts.NullableType && g.opts.JSONPackage != ""
+}
+
+// nullableType returns the generic type of the nullable fields.
+func (g *generator) nullableType() string {
+	if g.localNullable() {
+		return "Nullable"
+	}
+	return "runtime.Nullable"
+}
+
+// isNullableType tells whether a Go type is one of nullableType.
+func isNullableType(goType string) bool {
+	return strings.HasPrefix(goType, "runtime.Nullable[") || strings.HasPrefix(goType, "Nullable[")
+}
+
+// optionalValueField tells whether the optional field of the schema is a plain
+// value, rather than a pointer, as x-go-type-skip-optional-pointer says, or
+// else Options.OptionalValues, for the types whose zero value omitempty omits.
+// It returns the condition under which the field is encoded, with a %s verb
+// for it, which is empty for the structs, since omitempty doesn't omit them.
+func (g *generator) optionalValueField(schema *openapi3.Schema) (bool, string, error) {
+	presence, omittable := g.omitEmptyPresence(schema)
+	if extension, ok := schema.Extensions[extPropSkipOptionalPointer]; ok {
+		skip, err := extParseSkipOptionalPointer(extension)
+		if err != nil {
+			return false, "", err
+		}
+		return skip, presence, nil
+	}
+	return g.opts.OptionalValues && omittable, presence, nil
+}
+
+// omitEmptyPresence returns the condition under which omitempty encodes a
+// value of the schema's type, with a %s verb for it, and whether it omits
+// any, which it doesn't for structs.
+func (g *generator) omitEmptyPresence(schema *openapi3.Schema) (string, bool) {
+	if _, ok := schema.Extensions[extPropGoType]; ok {
+		return "", false
+	}
+	if layout, _ := g.schemaTimeFormat(schema); layout != "" {
+		return "", false
+	}
+	if _, ok := g.schemaTypeMapping(schema); ok {
+		return "", false
+	}
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date", "date-time":
+			return "", false
+		case "byte":
+			return "%s != nil", true
+		}
+		return `%s != ""`, true
+	case "integer", "number":
+		return "%s != 0", true
+	case "boolean":
+		return "%s", true
+	case "array":
+		return "%s != nil", true
+	case "", "object":
+		if len(schema.Properties) == 0 && !SchemaHasAdditionalProperties(schema) && len(schema.AllOf) == 0 {
+			return "%s != nil", true
+		}
+	}
+	return "", false
+}
+
+// The base64 encodings which Options.ByteEncoding can be.
+const (
+	ByteEncodingStd = "std"
+	ByteEncodingURL = "url"
+)
+
+// checkByteEncoding returns an error when the base64 encoding isn't known.
+func checkByteEncoding(encoding string) error {
+	switch encoding {
+	case "", ByteEncodingStd, ByteEncodingURL:
+		return nil
+	}
+	return fmt.Errorf("unknown byte encoding %s, valid options: %s, %s", encoding, ByteEncodingStd, ByteEncodingURL)
+}
+
+// schemaTimeFormat returns the layout of the time of a string, or integer,
+// schema, as x-go-time-format, or else Options.TimeFormats, says, or an empty
+// string when it's not such a time.
+func (g *generator) schemaTimeFormat(schema *openapi3.Schema) (string, error) {
+	if schema.Type != "string" && schema.Type != "integer" {
+		return "", nil
+	}
+	if extension, ok := schema.Extensions[extPropTimeFormat]; ok {
+		layout, err := extParseTimeFormat(extension)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %q: %w", extPropTimeFormat, err)
+		}
+		return layout, nil
+	}
+	return g.opts.TimeFormats[schema.Format], nil
+}
+
 // Given a list of schema descriptors, produce corresponding field names with
 // JSON annotations
 func GenFieldsFromProperties(props []Property) []string {
+	return defaultGenerator().GenFieldsFromProperties(props)
+}
+
+func (g *generator) GenFieldsFromProperties(props []Property) []string {
+	return g.genFieldsFromProperties(props, propertiesDeclareXML(props))
+}
+
+// genFieldsFromProperties is GenFieldsFromProperties, which also adds xml
+// tags to the fields when xmlTags is set.
+func (g *generator) genFieldsFromProperties(props []Property, xmlTags bool) []string {
 	var fields []string
 	for i, p := range props {
 		field := ""
@@ -432,6 +789,14 @@ func GenFieldsFromProperties(props []Property) []string {
 			}
 			field += fmt.Sprintf("%s\n", StringToGoComment(p.Description))
 		}
+		if p.Deprecated {
+			if p.Description != "" {
+				field += "//\n"
+			} else if i != 0 {
+				field += "\n"
+			}
+			field += fmt.Sprintf("%s\n", DeprecationComment(p.ExtensionProps.Extensions))
+		}
 		field += fmt.Sprintf("    %s %s", p.GoFieldName(), p.GoTypeDef())
 
 		// Support x-omitempty
@@ -449,6 +814,18 @@ func GenFieldsFromProperties(props []Property) []string {
 		} else {
 			fieldTags["json"] = p.JsonFieldName + ",omitempty"
 		}
+		// Mirror the json tag for the other encodings we were asked for.
+		for _, k := range g.mirroredFieldTags {
+			fieldTags[k] = fieldTags["json"]
+		}
+		if xmlTags {
+			fieldTags["xml"] = p.XMLTag(!(p.Required || p.Nullable || !omitEmpty))
+		}
+		if g.opts.ValidateTags {
+			if tag := g.validateTag(p); tag != "" {
+				fieldTags["validate"] = tag
+			}
+		}
 		if extension, ok := p.ExtensionProps.Extensions[extPropExtraTags]; ok {
 			if tags, err := extExtraTags(extension); err == nil {
 				keys := SortedStringKeys(tags)
@@ -470,10 +847,32 @@ func GenFieldsFromProperties(props []Property) []string {
 }
 
 func GenStructFromSchema(schema Schema) string {
+	return defaultGenerator().GenStructFromSchema(schema)
+}
+
+func (g *generator) GenStructFromSchema(schema Schema) string {
 	// Start out with struct {
 	objectParts := []string{"struct {"}
+	// A schema which declares an xml name has its fields, and root element,
+	// named accordingly.
+	xmlObject := schemaXMLObject(schema.OAPISchema)
+	if xmlObject != nil && xmlObject.Name != "" {
+		xmlName := xmlObject.Name
+		if xmlObject.Namespace != "" {
+			xmlName = xmlObject.Namespace + " " + xmlName
+		}
+		// Like the other fields, it only has a yaml tag when they're asked
+		// for.
+		yamlTag := ""
+		if g.opts.YAMLTags {
+			yamlTag = ` yaml:"-"`
+		}
+		objectParts = append(objectParts,
+			fmt.Sprintf("XMLName xml.Name `json:\"-\" xml:\"%s\"%s`", xmlName, yamlTag))
+	}
 	// Append all the field definitions
-	objectParts = append(objectParts, GenFieldsFromProperties(schema.Properties)...)
+	objectParts = append(objectParts, g.genFieldsFromProperties(schema.Properties,
+		xmlObject != nil || propertiesDeclareXML(schema.Properties))...)
 	// Close the struct
 	if schema.HasAdditionalProperties {
 		addPropsType := schema.AdditionalPropertiesType.GoType
@@ -484,31 +883,53 @@ func GenStructFromSchema(schema Schema) string {
 		objectParts = append(objectParts,
 			fmt.Sprintf("AdditionalProperties map[string]%s `json:\"-\"`", addPropsType))
 	}
+	objectParts = append(objectParts, schema.UnexportedFields...)
 	objectParts = append(objectParts, "}")
 	return strings.Join(objectParts, "\n")
 }
 
 // Merge all the fields in the schemas supplied into one giant schema.
 func MergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
+	return defaultGenerator().MergeSchemas(allOf, path)
+}
+
+func (g *generator) MergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
 	var outSchema Schema
+	// The types of the additional properties of the embedded structs
+	var embeddedAdditional []*Schema
 	for _, schemaOrRef := range allOf {
 		ref := schemaOrRef.Ref
 
 		var refType string
 		var err error
 		if IsGoTypeReference(ref) {
-			refType, err = RefPathToGoType(ref)
+			refType, err = g.RefPathToGoType(ref)
 			if err != nil {
 				return Schema{}, fmt.Errorf("error converting reference path to a go type: %w", err)
 			}
 		}
 
-		schema, err := GenerateGoSchema(schemaOrRef, path)
+		schema, err := g.GenerateGoSchema(schemaOrRef, path)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error generating Go schema in allOf: %w", err)
 		}
 		schema.RefType = refType
 
+		if refType != "" && schemaOrRef.Value != nil {
+			// The fields of the embedded struct are promoted, so they're
+			// (un)marshaled with the others when there are additional
+			// properties. Its own types are named after it, as they are
+			// when it's generated.
+			embedded, err := g.GenerateGoSchema(&openapi3.SchemaRef{Value: schemaOrRef.Value}, []string{refType})
+			if err != nil {
+				return Schema{}, fmt.Errorf("error generating Go schema in allOf: %w", err)
+			}
+			outSchema.EmbeddedProperties = append(outSchema.EmbeddedProperties, embedded.JSONProperties()...)
+			if embedded.HasAdditionalProperties && !strings.Contains(refType, ".") {
+				embeddedAdditional = append(embeddedAdditional, embedded.AdditionalPropertiesType)
+			}
+		}
+
 		for _, p := range schema.Properties {
 			err = outSchema.MergeProperty(p)
 			if err != nil {
@@ -532,19 +953,48 @@ func MergeSchemas(allOf []*openapi3.SchemaRef, path []string) (Schema, error) {
 		}
 	}
 
+	// The additional properties of a single embedded struct are promoted as
+	// well, and need the methods of the merged type to be kept with its
+	// fields. The fields declared inline hide the embedded ones.
+	if !outSchema.HasAdditionalProperties && len(embeddedAdditional) == 1 {
+		outSchema.HasAdditionalProperties = true
+		outSchema.AdditionalPropertiesType = embeddedAdditional[0]
+	}
+	var embeddedProperties []Property
+	for _, p := range outSchema.EmbeddedProperties {
+		if !propertyDeclared(embeddedProperties, p.JsonFieldName) && !propertyDeclared(outSchema.Properties, p.JsonFieldName) {
+			embeddedProperties = append(embeddedProperties, p)
+		}
+	}
+	outSchema.EmbeddedProperties = embeddedProperties
+
 	// Now, we generate the struct which merges together all the fields.
 	var err error
-	outSchema.GoType, err = GenStructFromAllOf(allOf, path)
+	outSchema.GoType, err = g.GenStructFromAllOf(allOf, path)
 	if err != nil {
 		return Schema{}, fmt.Errorf("unable to generate aggregate type for AllOf: %w", err)
 	}
 	return outSchema, nil
 }
 
+// propertyDeclared tells whether one of props has the given JSON name.
+func propertyDeclared(props []Property, jsonName string) bool {
+	for _, p := range props {
+		if p.JsonFieldName == jsonName {
+			return true
+		}
+	}
+	return false
+}
+
 // This function generates an object that is the union of the objects in the
 // input array. In the case of Ref objects, we use an embedded struct, otherwise,
 // we inline the fields.
 func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, error) {
+	return defaultGenerator().GenStructFromAllOf(allOf, path)
+}
+
+func (g *generator) GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, error) {
 	// Start out with struct {
 	objectParts := []string{"struct {"}
 	for _, schemaOrRef := range allOf {
@@ -556,7 +1006,7 @@ func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, err
 			//   InlinedMember
 			//   ...
 			// }
-			goType, err := RefPathToGoType(ref)
+			goType, err := g.RefPathToGoType(ref)
 			if err != nil {
 				return "", err
 			}
@@ -567,12 +1017,12 @@ func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, err
 		} else {
 			// Inline all the fields from the schema into the output struct,
 			// just like in the simple case of generating an object.
-			goSchema, err := GenerateGoSchema(schemaOrRef, path)
+			goSchema, err := g.GenerateGoSchema(schemaOrRef, path)
 			if err != nil {
 				return "", err
 			}
 			objectParts = append(objectParts, "   // Embedded fields due to inline allOf schema")
-			objectParts = append(objectParts, GenFieldsFromProperties(goSchema.Properties)...)
+			objectParts = append(objectParts, g.GenFieldsFromProperties(goSchema.Properties)...)
 
 			if goSchema.HasAdditionalProperties {
 				addPropsType := goSchema.AdditionalPropertiesType.GoType
@@ -593,30 +1043,22 @@ func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string) (string, err
 
 // This constructs a Go type for a parameter, looking at either the schema or
 // the content, whichever is available
-func paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
+func (g *generator) paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
 	if param.Content == nil && param.Schema == nil {
 		return Schema{}, fmt.Errorf("parameter '%s' has no schema or content", param.Name)
 	}
 
 	// We can process the schema through the generic schema processor
 	if param.Schema != nil {
-		return GenerateGoSchema(param.Schema, path)
-	}
-
-	// At this point, we have a content type. We know how to deal with
-	// application/json, but if multiple formats are present, we can't do anything,
-	// so we'll return the parameter as a string, not bothering to decode it.
-	if len(param.Content) > 1 {
-		return Schema{
-			GoType:      "string",
-			Description: StringToGoComment(param.Description),
-		}, nil
+		return g.GenerateGoSchema(param.Schema, path)
 	}
 
-	// Otherwise, look for application/json in there
-	mt, found := param.Content["application/json"]
-	if !found {
-		// If we don't have json, it's a string
+	// At this point, we have a content type. We know how to deal with JSON,
+	// but if multiple formats, or another one, are present, we can't do
+	// anything, so we'll return the parameter as a string, not bothering to
+	// decode it.
+	mt := g.jsonParamContent(param)
+	if mt == nil {
 		return Schema{
 			GoType:      "string",
 			Description: StringToGoComment(param.Description),
@@ -624,5 +1066,20 @@ func paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
 	}
 
 	// For json, we go through the standard schema mechanism
-	return GenerateGoSchema(mt.Schema, path)
+	return g.GenerateGoSchema(mt.Schema, path)
+}
+
+// jsonParamContent returns the content of a parameter which is encoded as
+// JSON, such as application/json or a type with a +json suffix, or nil when
+// it has another content type, or several.
+func (g *generator) jsonParamContent(param *openapi3.Parameter) *openapi3.MediaType {
+	if len(param.Content) != 1 {
+		return nil
+	}
+	for contentType, mt := range param.Content {
+		if g.isJSONContentType(contentType) {
+			return mt
+		}
+	}
+	return nil
 }
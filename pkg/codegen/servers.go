@@ -0,0 +1,80 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ServerDefinition describes a server from the servers section of the spec.
+type ServerDefinition struct {
+	ConstName string                     // Name of the generated constant, eg, ServerURLProduction
+	URL       string                     // The URL, which may contain {variable} placeholders
+	Comment   string                     // The description of the server as a Go comment
+	Variables []ServerVariableDefinition // The variables used in the URL, sorted by name
+}
+
+// ServerVariableDefinition describes a variable of a server URL template.
+type ServerVariableDefinition struct {
+	Name    string
+	Default string
+	Enum    []string
+}
+
+// DescribeServers turns the servers in the spec into a list of server
+// definitions. Servers are named after their description, or their URL when
+// they have none, minus the scheme, and servers with duplicate URLs are only described once.
+func DescribeServers(servers openapi3.Servers) []ServerDefinition {
+	var defs []ServerDefinition
+	seenURLs := map[string]bool{}
+	seenNames := map[string]bool{}
+
+	for _, server := range servers {
+		// Clients look the servers up ignoring trailing slashes
+		if server == nil || server.URL == "" || seenURLs[strings.TrimSuffix(server.URL, "/")] {
+			continue
+		}
+		seenURLs[strings.TrimSuffix(server.URL, "/")] = true
+
+		name := server.Description
+		if name == "" {
+			name = strings.TrimPrefix(strings.TrimPrefix(server.URL, "https://"), "http://")
+		}
+		constName := "ServerURL" + SchemaNameToTypeName(name)
+		for i := 1; seenNames[constName]; i++ {
+			constName = fmt.Sprintf("ServerURL%s%d", SchemaNameToTypeName(name), i)
+		}
+		seenNames[constName] = true
+
+		def := ServerDefinition{
+			ConstName: constName,
+			URL:       server.URL,
+			Comment:   StringToGoComment(server.Description),
+		}
+
+		var varNames []string
+		for varName := range server.Variables {
+			varNames = append(varNames, varName)
+		}
+		sort.Strings(varNames)
+		for _, varName := range varNames {
+			v := server.Variables[varName]
+			def.Variables = append(def.Variables, ServerVariableDefinition{
+				Name:    varName,
+				Default: v.Default,
+				Enum:    v.Enum,
+			})
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+// GenerateServerURLs generates constants for the servers declared in the spec,
+// along with a client option for substituting their variables.
+func GenerateServerURLs(t *template.Template, swagger *openapi3.T) (string, error) {
+	return GenerateTemplates([]string{"servers.tmpl"}, t, DescribeServers(swagger.Servers))
+}
@@ -16,11 +16,11 @@ package codegen
 import (
 	"bytes"
 	"fmt"
-	"os"
+	"sort"
 	"strings"
 	"text/template"
 
-	"github.com/labstack/echo/v4"
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 const (
@@ -29,12 +29,49 @@ const (
 	responseTypeSuffix                                          = "Response"
 )
 
+// These are defined here rather than taken from a router, so that the
+// generator, and the code it generates, only depend on the routers which are
+// generated for.
+const (
+	mimeApplicationJSON = "application/json"
+	mimeApplicationXML  = "application/xml"
+	mimeTextXML         = "text/xml"
+	headerContentType   = "Content-Type"
+)
+
 var (
-	contentTypesJSON = []string{echo.MIMEApplicationJSON, "text/x-json"}
+	contentTypesJSON = []string{mimeApplicationJSON, "text/x-json"}
 	contentTypesYAML = []string{"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"}
-	contentTypesXML  = []string{echo.MIMEApplicationXML, echo.MIMETextXML}
+	contentTypesXML  = []string{mimeApplicationXML, mimeTextXML}
 )
 
+// isJSONContentType tells whether bodies of the given content type are JSON,
+// which includes the types with a +json structured suffix (RFC 6839), such as
+// application/hal+json or application/vnd.company.v2+json.
+func (g *generator) isJSONContentType(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	if StringInArray(mediaType, contentTypesJSON) || strings.HasSuffix(mediaType, "+json") {
+		return true
+	}
+	for _, t := range g.opts.JSONContentTypes {
+		if strings.EqualFold(mediaType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonFirstContentKeys returns the content types of content in sorted order,
+// except that the plain JSON ones come first, so that they are preferred when
+// a response also has vendored JSON content types.
+func jsonFirstContentKeys(content openapi3.Content) []string {
+	keys := SortedContentKeys(content)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return StringInArray(keys[i], contentTypesJSON) && !StringInArray(keys[j], contentTypesJSON)
+	})
+	return keys
+}
+
 // This function takes an array of Parameter definition, and generates a valid
 // Go parameter declaration from them, eg:
 // ", foo int, bar string, baz float32". The preceding comma is there to save
@@ -94,19 +131,20 @@ func genResponsePayload(operationID string) string {
 }
 
 // genResponseUnmarshal generates unmarshaling steps for structured response payloads
-func genResponseUnmarshal(op *OperationDefinition) string {
+func genResponseUnmarshal(op *OperationDefinition) (string, error) {
+	g := op.gen()
 	var handledCaseClauses = make(map[string]string)
 	var unhandledCaseClauses = make(map[string]string)
 
 	// Get the type definitions from the operation:
 	typeDefinitions, err := op.GetResponseTypeDefinitions()
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
 	if len(typeDefinitions) == 0 {
 		// No types.
-		return ""
+		return "", nil
 	}
 
 	// Add a case for each possible response:
@@ -119,9 +157,8 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 			continue
 		}
 
-		// We can't do much without a value:
+		// We can't do much without a value, Generate reports it
 		if responseRef.Value == nil {
-			fmt.Fprintf(os.Stderr, "Response %s.%s has nil value\n", op.OperationId, typeDefinition.ResponseName)
 			continue
 		}
 
@@ -147,7 +184,7 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 			switch {
 
 			// JSON:
-			case StringInArray(contentTypeName, contentTypesJSON):
+			case g.isJSONContentType(contentTypeName):
 				if typeDefinition.ContentTypeName == contentTypeName {
 					var caseAction string
 
@@ -155,11 +192,19 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 						"if err := json.Unmarshal(bodyBytes, &dest); err != nil { \n"+
 						" return nil, err \n"+
 						"}\n"+
+						"%s"+
 						"response.%s = &dest",
 						typeDefinition.Schema.TypeDecl(),
+						g.defaultsCall("&dest"),
 						typeDefinition.TypeName)
 
-					caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, "json")
+					// Configured JSON content types may not mention json,
+					// then the response has to have that very type.
+					match := "json"
+					if !strings.Contains(strings.ToLower(contentTypeName), match) {
+						match = contentTypeName
+					}
+					caseKey, caseClause := buildUnmarshalCase(typeDefinition, caseAction, match)
 					handledCaseClauses[caseKey] = caseClause
 				}
 
@@ -204,7 +249,7 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 
 	if len(handledCaseClauses)+len(unhandledCaseClauses) == 0 {
 		// switch would be empty.
-		return ""
+		return "", nil
 	}
 
 	// Now build the switch statement in order of most-to-least specific:
@@ -221,14 +266,14 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 	}
 	fmt.Fprintf(buffer, "}\n")
 
-	return buffer.String()
+	return buffer.String(), nil
 }
 
 // buildUnmarshalCase builds an unmarshalling case clause for different content-types:
 func buildUnmarshalCase(typeDefinition ResponseTypeDefinition, caseAction string, contentType string) (caseKey string, caseClause string) {
 	caseKey = fmt.Sprintf("%s.%s.%s", prefixLeastSpecific, contentType, typeDefinition.ResponseName)
 	caseClauseKey := getConditionOfResponseName("rsp.StatusCode", typeDefinition.ResponseName)
-	caseClause = fmt.Sprintf("case strings.Contains(rsp.Header.Get(\"%s\"), \"%s\") && %s:\n%s\n", echo.HeaderContentType, contentType, caseClauseKey, caseAction)
+	caseClause = fmt.Sprintf("case strings.Contains(rsp.Header.Get(\"%s\"), \"%s\") && %s:\n%s\n", headerContentType, contentType, caseClauseKey, caseAction)
 	return caseKey, caseClause
 }
 
@@ -237,26 +282,32 @@ func genResponseTypeName(operationID string) string {
 	return fmt.Sprintf("%s%s", UppercaseFirstCharacter(operationID), responseTypeSuffix)
 }
 
-func getResponseTypeDefinitions(op *OperationDefinition) []ResponseTypeDefinition {
-	td, err := op.GetResponseTypeDefinitions()
-	if err != nil {
-		panic(err)
-	}
-	return td
+func getResponseTypeDefinitions(op *OperationDefinition) ([]ResponseTypeDefinition, error) {
+	return op.GetResponseTypeDefinitions()
 }
 
 // Return the statusCode comparison clause from the response name.
 func getConditionOfResponseName(statusCodeVar, responseName string) string {
-	switch responseName {
-	case "default":
+	switch {
+	case responseName == "default":
 		return "true"
-	case "1XX", "2XX", "3XX", "4XX", "5XX":
+	case isStatusRange(responseName):
 		return fmt.Sprintf("%s / 100 == %s", statusCodeVar, responseName[:1])
 	default:
 		return fmt.Sprintf("%s == %s", statusCodeVar, responseName)
 	}
 }
 
+// isStatusRange tells whether a response name is a range of status codes,
+// such as "4XX", which the spec allows in lower case too.
+func isStatusRange(responseName string) bool {
+	switch strings.ToUpper(responseName) {
+	case "1XX", "2XX", "3XX", "4XX", "5XX":
+		return true
+	}
+	return false
+}
+
 // This outputs a string array
 func toStringArray(sarr []string) string {
 	return `[]string{"` + strings.Join(sarr, `","`) + `"}`
@@ -273,10 +324,13 @@ var TemplateFunctions = template.FuncMap{
 	"genParamArgs":               genParamArgs,
 	"genParamTypes":              genParamTypes,
 	"genParamNames":              genParamNames,
+	"genParamLocations":          genParamLocations,
 	"genParamFmtString":          ReplacePathParamsWithStr,
 	"swaggerUriToEchoUri":        SwaggerUriToEchoUri,
 	"swaggerUriToChiUri":         SwaggerUriToChiUri,
 	"swaggerUriToGinUri":         SwaggerUriToGinUri,
+	"swaggerUriToIrisUri":        SwaggerUriToIrisUri,
+	"echoContext":                func() string { return "echo.Context" },
 	"lcFirst":                    LowercaseFirstCharacter,
 	"ucFirst":                    UppercaseFirstCharacter,
 	"camelCase":                  ToCamelCase,
@@ -284,9 +338,30 @@ var TemplateFunctions = template.FuncMap{
 	"genResponseTypeName":        genResponseTypeName,
 	"genResponseUnmarshal":       genResponseUnmarshal,
 	"getResponseTypeDefinitions": getResponseTypeDefinitions,
+	"statusCondition":            getConditionOfResponseName,
 	"toStringArray":              toStringArray,
 	"lower":                      strings.ToLower,
 	"title":                      strings.Title,
 	"stripNewLines":              stripNewLines,
 	"sanitizeGoIdentity":         SanitizeGoIdentity,
 }
+
+// defaultsCall returns the statement which applies the defaults to the value
+// which ptr points to, with Options.ApplyDefaults, or else an empty string.
+func (g *generator) defaultsCall(ptr string) string {
+	if !g.opts.ApplyDefaults {
+		return ""
+	}
+	return fmt.Sprintf("runtime.ApplyDefaults(%s)\n", ptr)
+}
+
+// genParamLocations returns the map literal of the locations of the
+// parameters of the operation's parameter object, by name, as
+// runtime.NewParamValidationError takes them.
+func genParamLocations(op OperationDefinition) string {
+	var entries []string
+	for _, param := range op.Params() {
+		entries = append(entries, fmt.Sprintf("%q: runtime.ParamLocation%s", param.ParamName, UppercaseFirstCharacter(param.In)))
+	}
+	return "map[string]runtime.ParamLocation{" + strings.Join(entries, ", ") + "}"
+}
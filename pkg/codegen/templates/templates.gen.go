@@ -28,7 +28,7 @@ func (a *{{.TypeName}}) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
-{{range .Schema.Properties}}
+{{range .Schema.JSONProperties}}
     if raw, found := object["{{.JsonFieldName}}"]; found {
         err = json.Unmarshal(raw, &a.{{.GoFieldName}})
         if err != nil {
@@ -55,13 +55,13 @@ func (a *{{.TypeName}}) UnmarshalJSON(b []byte) error {
 func (a {{.TypeName}}) MarshalJSON() ([]byte, error) {
     var err error
     object := make(map[string]json.RawMessage)
-{{range .Schema.Properties}}
-{{if not .Required}}if a.{{.GoFieldName}} != nil { {{end}}
+{{range .Schema.JSONProperties}}{{$presence := .PresenceCheck "a"}}
+{{if $presence}}if {{$presence}} { {{end}}
     object["{{.JsonFieldName}}"], err = json.Marshal(a.{{.GoFieldName}})
     if err != nil {
         return nil, fmt.Errorf("error marshaling '{{.JsonFieldName}}': %w", err)
     }
-{{if not .Required}} }{{end}}
+{{if $presence}} }{{end}}
 {{end}}
     for fieldName, field := range a.AdditionalProperties {
 		object[fieldName], err = json.Marshal(field)
@@ -82,7 +82,28 @@ type ChiServerOptions struct {
     BaseURL string
     BaseRouter chi.Router
     Middlewares []MiddlewareFunc
+    // RouteMiddlewares returns the chi middlewares of the route of an
+    // operation, which run before its parameters are bound, and before the
+    // Middlewares.
+    RouteMiddlewares func(route runtime.Route) []func(http.Handler) http.Handler
+    // RouteName names the route of an operation.
+    RouteName func(route runtime.Route) string
+    // OnRoute is told of the route of each operation once it's registered.
+    OnRoute func(route runtime.Route)
     ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+    // BindErrorTranslator translates the errors binding the parameters of
+    // requests, which are reported instead of the default ones, unless it's
+    // nil.
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    // ParamErrorBody builds the body of the 400 response to a request
+    // missing a required parameter of an operation, or returns nil for the
+    // usual error. It's DefaultParamErrorBody when nil.
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    ErrorResponder ErrorResponder
+{{- end}}
 }
 
 // HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
@@ -111,16 +132,44 @@ if options.ErrorHandlerFunc == nil {
         http.Error(w, err.Error(), http.StatusBadRequest)
     }
 }
-{{if .}}wrapper := ServerInterfaceWrapper{
+{{if .}}{{if opts.ParamErrorResponses}}if options.ParamErrorBody == nil {
+    options.ParamErrorBody = DefaultParamErrorBody
+}
+{{end}}wrapper := ServerInterfaceWrapper{
 Handler: si,
 HandlerMiddlewares: options.Middlewares,
 ErrorHandlerFunc: options.ErrorHandlerFunc,
+BindErrorTranslator: options.BindErrorTranslator,
+{{- if opts.ParamErrorResponses}}
+ParamErrorBody: options.ParamErrorBody,
+{{- end}}
+{{- if opts.ServerRecovery}}
+ErrorResponder: options.ErrorResponder,
+{{- end}}
+}
+{{end}}
+{{if .}}register := func(route runtime.Route, handler http.HandlerFunc) {
+    route.BaseURL = options.BaseURL
+    route.RouterPath = options.BaseURL + route.RouterPath
+    if options.RouteName != nil {
+        route.Name = options.RouteName(route)
+    }
+    var middlewares []func(http.Handler) http.Handler
+    if options.RouteMiddlewares != nil {
+        middlewares = options.RouteMiddlewares(route)
+    }
+    r.With(middlewares...).MethodFunc(route.Method, route.RouterPath, handler)
+    if options.OnRoute != nil {
+        options.OnRoute(route)
+    }
 }
 {{end}}
-{{range .}}r.Group(func(r chi.Router) {
-r.{{.Method | lower | title }}(options.BaseURL+"{{.Path | swaggerUriToChiUri}}", wrapper.{{.OperationId}})
-})
+{{range .}}register(runtime.Route{OperationID: "{{.OperationId}}", Method: "{{.Method}}", Path: "{{.Path}}", RouterPath: "{{.Path | swaggerUriToChiUri}}"}, wrapper.{{.OperationId}})
 {{end}}
+{{- if opts.DocsUI}}
+r.Get(options.BaseURL+"/openapi.json", ServeSpec)
+r.Get(options.BaseURL+"/docs", ServeDocs)
+{{- end}}
 return r
 }
 `,
@@ -128,7 +177,9 @@ return r
 type ServerInterface interface {
 {{range .}}{{.SummaryAsComment }}
 // ({{.Method}} {{.Path}})
-{{.OperationId}}(w http.ResponseWriter, r *http.Request{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}})
+{{with .DeprecationComment}}//
+{{.}}
+{{end}}{{.OperationId}}({{if opts.ChiServerContext}}ctx context.Context, {{end}}w http.ResponseWriter, r *http.Request{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}})
 {{end}}
 }
 `,
@@ -137,36 +188,81 @@ type ServerInterfaceWrapper struct {
     Handler ServerInterface
     HandlerMiddlewares []MiddlewareFunc
     ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    ErrorResponder ErrorResponder
+{{- end}}
+}
+{{if opts.ServerRecovery}}
+// ErrorResponder responds to the panics of the handlers.
+type ErrorResponder func(w http.ResponseWriter, r *http.Request, err *runtime.OperationError)
+{{end}}
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+{{if opts.ParamErrorResponses}}
+// paramError responds to a request missing a required parameter of
+// operationID with a 400 and the body built by the ParamErrorBody, unless
+// it's nil. Otherwise, it passes err as translated by the
+// BindErrorTranslator, or defaultErr, to the ErrorHandlerFunc.
+func (siw *ServerInterfaceWrapper) paramError(w http.ResponseWriter, r *http.Request, operationID string, err *runtime.BindError, defaultErr error) {
+    if err.Kind == runtime.BindErrorRequired {
+        if body := siw.ParamErrorBody(operationID, err); body != nil {
+            w.Header().Set("Content-Type", "application/json")
+            w.WriteHeader(http.StatusBadRequest)
+            _ = json.NewEncoder(w).Encode(body)
+            return
+        }
+    }
+    siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, err, defaultErr))
+}
+{{end}}
 
-{{range .}}{{$opid := .OperationId}}
+{{range .}}{{$opid := .OperationId}}{{region "handler" $opid}}
 
 // {{$opid}} operation middleware
 func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Request) {
+{{- if opts.ServerRecovery}}
+  defer func() {
+    if p := recover(); p != nil {
+      siw.respond{{$opid}}Error(w, r, runtime.RecoverOperation("{{$opid}}", p))
+    }
+  }()
+{{- end}}
   ctx := r.Context()
   {{if or .RequiresParamObject (gt (len .PathParams) 0) }}
   var err error
+  _ = err // not every parameter is bound through err
   {{end}}
 
   {{range .PathParams}}// ------------- Path parameter "{{.ParamName}}" -------------
   var {{$varName := .GoVariableName}}{{$varName}} {{.TypeDef}}
+  {{- if opts.ParamErrorResponses}}
+  if chi.URLParam(r, "{{.ParamName}}") == "" {
+    siw.paramError(w, r, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationPath, nil),
+      &RequiredParamError{ParamName: "{{.ParamName}}"})
+    return
+  }
+  {{- end}}
 
   {{if .IsPassThrough}}
   {{$varName}} = chi.URLParam(r, "{{.ParamName}}")
   {{end}}
   {{if .IsJson}}
-  err = json.Unmarshal([]byte(chi.URLParam(r, "{{.ParamName}}")), &{{$varName}})
+  err = runtime.BindJSONParameter("{{.ParamName}}", runtime.ParamLocationPath, chi.URLParam(r, "{{.ParamName}}"), &{{$varName}})
   if err != nil {
-    siw.ErrorHandlerFunc(w, r, &UnmarshalingParamError{ParamName: "{{.ParamName}}", Err: err})
+    siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationPath, err),
+      &UnmarshalingParamError{ParamName: "{{.ParamName}}", Err: err}))
     return
   }
   {{end}}
   {{if .IsStyled}}
   err = runtime.BindStyledParameter("{{.Style}}",{{.Explode}}, "{{.ParamName}}", chi.URLParam(r, "{{.ParamName}}"), &{{$varName}})
   if err != nil {
-    siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err})
+    siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationPath, err),
+      &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err}))
     return
   }
   {{end}}
@@ -192,20 +288,33 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
         var value {{.TypeDef}}
         err = json.Unmarshal([]byte(paramValue), &value)
         if err != nil {
-          siw.ErrorHandlerFunc(w, r, &UnmarshalingParamError{ParamName: "{{.ParamName}}", Err: err})
+          siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+            &UnmarshalingParamError{ParamName: "{{.ParamName}}", Err: err}))
           return
         }
 
         params.{{.GoName}} = {{if not .Required}}&{{end}}value
       {{end}}
       }{{if .Required}} else {
-          siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "{{.ParamName}}"})
+          {{- if opts.ParamErrorResponses}}
+          siw.paramError(w, r, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationQuery, nil),
+            &RequiredParamError{ParamName: "{{.ParamName}}"})
+          {{- else}}
+          siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationQuery, nil),
+            &RequiredParamError{ParamName: "{{.ParamName}}"}))
+          {{- end}}
           return
       }{{end}}
       {{if .IsStyled}}
       err = runtime.BindQueryParameter("{{.Style}}", {{.Explode}}, {{.Required}}, "{{.ParamName}}", r.URL.Query(), &params.{{.GoName}})
       if err != nil {
-        siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err})
+        {{- if opts.ParamErrorResponses}}
+        siw.paramError(w, r, "{{$opid}}", runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+          &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err})
+        {{- else}}
+        siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+          &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err}))
+        {{- end}}
         return
       }
       {{end}}
@@ -217,11 +326,16 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
       {{range .HeaderParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} header parameter "{{.ParamName}}" -------------
         if valueList, found := headers[http.CanonicalHeaderKey("{{.ParamName}}")]; found {
           var {{.GoName}} {{.TypeDef}}
+{{- if .IsList}}
+          valueList = []string{strings.Join(valueList, ",")}
+{{- else}}
           n := len(valueList)
           if n != 1 {
-            siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "{{.ParamName}}", Count: n})
+            siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+              &TooManyValuesForParamError{ParamName: "{{.ParamName}}", Count: n}))
             return
           }
+{{- end}}
 
         {{if .IsPassThrough}}
           params.{{.GoName}} = {{if not .Required}}&{{end}}valueList[0]
@@ -230,7 +344,8 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
         {{if .IsJson}}
           err = json.Unmarshal([]byte(valueList[0]), &{{.GoName}})
           if err != nil {
-            siw.ErrorHandlerFunc(w, r, &UnmarshalingParamError{ParamName: "{{.ParamName}}", Err: err})
+            siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+              &UnmarshalingParamError{ParamName: "{{.ParamName}}", Err: err}))
             return
           }
         {{end}}
@@ -238,7 +353,8 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
         {{if .IsStyled}}
           err = runtime.BindStyledParameterWithLocation("{{.Style}}",{{.Explode}}, "{{.ParamName}}", runtime.ParamLocationHeader, valueList[0], &{{.GoName}})
           if err != nil {
-            siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err})
+            siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+              &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err}))
             return
           }
         {{end}}
@@ -247,7 +363,13 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
 
         } {{if .Required}}else {
             err := fmt.Errorf("Header parameter {{.ParamName}} is required, but not found")
-            siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "{{.ParamName}}", Err: err})
+            {{- if opts.ParamErrorResponses}}
+            siw.paramError(w, r, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+              &RequiredHeaderError{ParamName: "{{.ParamName}}", Err: err})
+            {{- else}}
+            siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+              &RequiredHeaderError{ParamName: "{{.ParamName}}", Err: err}))
+            {{- end}}
             return
         }{{end}}
 
@@ -255,9 +377,7 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
     {{end}}
 
     {{range .CookieParams}}
-      var cookie *http.Cookie
-
-      if cookie, err = r.Cookie("{{.ParamName}}"); err == nil {
+      if cookie, err := r.Cookie("{{.ParamName}}"); err == nil {
 
       {{- if .IsPassThrough}}
         params.{{.GoName}} = {{if not .Required}}&{{end}}cookie.Value
@@ -269,13 +389,15 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
         decoded, err := url.QueryUnescape(cookie.Value)
         if err != nil {
           err = fmt.Errorf("Error unescaping cookie parameter '{{.ParamName}}'")
-          siw.ErrorHandlerFunc(w, r, &UnescapedCookieParamError{ParamName: "{{.ParamName}}", Err: err})
+          siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            &UnescapedCookieParamError{ParamName: "{{.ParamName}}", Err: err}))
           return
         }
 
         err = json.Unmarshal([]byte(decoded), &value)
         if err != nil {
-          siw.ErrorHandlerFunc(w, r, &UnmarshalingParamError{ParamName: "{{.ParamName}}", Err: err})
+          siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            &UnmarshalingParamError{ParamName: "{{.ParamName}}", Err: err}))
           return
         }
 
@@ -286,7 +408,8 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
         var value {{.TypeDef}}
         err = runtime.BindStyledParameter("simple",{{.Explode}}, "{{.ParamName}}", cookie.Value, &value)
         if err != nil {
-          siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err})
+          siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            &InvalidParamFormatError{ParamName: "{{.ParamName}}", Err: err}))
           return
         }
         params.{{.GoName}} = {{if not .Required}}&{{end}}value
@@ -295,24 +418,68 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(w http.ResponseWriter, r *http.Requ
       }
 
       {{- if .Required}} else {
-        siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "{{.ParamName}}"})
+        {{- if opts.ParamErrorResponses}}
+        siw.paramError(w, r, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationCookie, nil),
+          &RequiredParamError{ParamName: "{{.ParamName}}"})
+        {{- else}}
+        siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationCookie, nil),
+          &RequiredParamError{ParamName: "{{.ParamName}}"}))
+        {{- end}}
         return
       }
       {{- end}}
     {{end}}
+{{- if and opts.ValidateMethods (hasValidateMethod (printf "%sParams" .OperationId))}}
+  if err := params.Validate(); err != nil {
+    bindErr := runtime.NewParamValidationError(err, {{genParamLocations .}})
+    {{- if opts.ParamErrorResponses}}
+    siw.paramError(w, r, "{{$opid}}", bindErr, &InvalidParamFormatError{ParamName: bindErr.ParamName, Err: err})
+    {{- else}}
+    siw.ErrorHandlerFunc(w, r, runtime.TranslateBindError(siw.BindErrorTranslator, bindErr, &InvalidParamFormatError{ParamName: bindErr.ParamName, Err: err}))
+    {{- end}}
+    return
+  }
+{{- end}}
   {{end}}
 
   var handler = func(w http.ResponseWriter, r *http.Request) {
-    siw.Handler.{{.OperationId}}(w, r{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}})
+{{- if and opts.ApplyDefaults .ParamsHaveDefaults}}
+    params.ApplyDefaults()
+{{- end}}
+    siw.Handler.{{.OperationId}}({{if opts.ChiServerContext}}r.Context(), {{end}}w, r{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}})
 }
 
   for _, middleware := range siw.HandlerMiddlewares {
     handler = middleware(handler)
   }
+{{- if and opts.ServerDeadlines .Timeout}}
+
+  // The deadline of x-timeout, after which http.TimeoutHandler responds
+  // with a 503
+  handler = http.TimeoutHandler(http.HandlerFunc(handler), {{.TimeoutLiteral}}, "").ServeHTTP
+{{- end}}
 
   handler(w, r.WithContext(ctx))
 }
+{{if opts.ServerRecovery}}
+// respond{{$opid}}Error responds to the panics of {{$opid}} with the
+// ErrorResponder, or else with a 500 response.
+func (siw *ServerInterfaceWrapper) respond{{$opid}}Error(w http.ResponseWriter, r *http.Request, err *runtime.OperationError) {
+    if siw.ErrorResponder != nil {
+        siw.ErrorResponder(w, r, err)
+        return
+    }
+{{- with .GetErrorResponse}}
+    {{.Build}}
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusInternalServerError)
+    _ = json.NewEncoder(w).Encode(body)
+{{- else}}
+    http.Error(w, err.Error(), http.StatusInternalServerError)
+{{- end}}
+}
 {{end}}
+{{endregion "handler" .OperationId}}{{end}}
 
 type UnescapedCookieParamError struct {
     ParamName string
@@ -382,6 +549,76 @@ type TooManyValuesForParamError struct {
 func (e *TooManyValuesForParamError) Error() string {
     return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
 }
+`,
+	"client-lazy.tmpl": `// ClientWithLazyResponses builds on ClientInterface to return responses which
+// are only decoded on demand
+type ClientWithLazyResponses struct {
+    ClientInterface
+}
+
+// NewClientWithLazyResponses creates a new ClientWithLazyResponses, which
+// wraps Client with lazy return type handling
+func NewClientWithLazyResponses(server string, opts ...ClientOption) (*ClientWithLazyResponses, error) {
+    client, err := NewClient(server, opts...)
+    if err != nil {
+        return nil, err
+    }
+    return &ClientWithLazyResponses{client}, nil
+}
+
+{{range .}}{{$opid := .OperationId}}
+// {{$opid | ucFirst}}LazyResponse is a response of {{$opid}}, whose body is
+// only decoded by its parsers, or runtime.ParseAs.
+type {{$opid | ucFirst}}LazyResponse struct {
+    *runtime.LazyResponse
+}
+{{range getResponseTypeDefinitions .}}
+// Parse{{.TypeName}} decodes the body of the {{.ResponseName}} {{.ContentTypeName}} response{{if ne .ResponseName "default"}}, it
+// fails when the response has another status{{end}}.
+func (r {{$opid | ucFirst}}LazyResponse) Parse{{.TypeName}}() (*{{.Schema.TypeDecl}}, error) {
+{{- if ne .ResponseName "default"}}
+    if !({{statusCondition "r.StatusCode()" .ResponseName}}) {
+        return nil, fmt.Errorf("status %d is not a {{.ResponseName}} response of {{$opid}}", r.StatusCode())
+    }
+{{- end}}
+    var dest {{.Schema.TypeDecl}}
+    if err := r.Decode(&dest); err != nil {
+        return nil, err
+    }
+    return &dest, nil
+}
+{{end}}
+{{end}}
+
+{{range .}}
+{{$opid := .OperationId -}}
+{{$deprecated := .DeprecationComment -}}
+
+// {{$opid}}{{if .HasBody}}WithBody{{end}}WithLazyResponse request{{if .HasBody}} with arbitrary body{{end}} returning *{{$opid}}LazyResponse
+{{with $deprecated}}//
+{{.}}
+{{end}}func (c *ClientWithLazyResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithLazyResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*{{$opid | ucFirst}}LazyResponse, error){
+    rsp, err := c.{{$opid}}{{if .HasBody}}WithBody{{end}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}}{{if .HasBody}}, contentType, body{{end}}, reqEditors...)
+    if err != nil {
+        return nil, err
+    }
+    return &{{$opid | ucFirst}}LazyResponse{runtime.NewLazyResponse(rsp)}, nil
+}
+
+{{$hasParams := .RequiresParamObject -}}
+{{$pathParams := .PathParams -}}
+{{range .Bodies}}
+{{with $deprecated}}{{.}}
+{{end}}func (c *ClientWithLazyResponses) {{$opid}}{{.Suffix}}WithLazyResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*{{$opid | ucFirst}}LazyResponse, error) {
+    rsp, err := c.{{$opid}}{{.Suffix}}(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body, reqEditors...)
+    if err != nil {
+        return nil, err
+    }
+    return &{{$opid | ucFirst}}LazyResponse{runtime.NewLazyResponse(rsp)}, nil
+}
+{{end}}
+
+{{end}}{{/* operations */}}
 `,
 	"client-with-responses.tmpl": `// ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
@@ -398,7 +635,7 @@ func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithRes
     return &ClientWithResponses{client}, nil
 }
 
-// WithBaseURL overrides the baseURL.
+// WithBaseURL overrides the baseURL, and the servers set by WithServers.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
@@ -406,6 +643,7 @@ func WithBaseURL(baseURL string) ClientOption {
 			return err
 		}
 		c.Server = newBaseURL.String()
+		c.Servers = nil
 		return nil
 	}
 }
@@ -416,10 +654,14 @@ type ClientWithResponsesInterface interface {
 {{$hasParams := .RequiresParamObject -}}
 {{$pathParams := .PathParams -}}
 {{$opid := .OperationId -}}
+{{$deprecated := .DeprecationComment -}}
     // {{$opid}} request{{if .HasBody}} with any body{{end}}
-    {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*{{genResponseTypeName $opid}}, error)
+{{with $deprecated}}    //
+{{.}}
+{{end}}    {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*{{genResponseTypeName $opid}}, error)
 {{range .Bodies}}
-    {{$opid}}{{.Suffix}}WithResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*{{genResponseTypeName $opid}}, error)
+{{with $deprecated}}{{.}}
+{{end}}    {{$opid}}{{.Suffix}}WithResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*{{genResponseTypeName $opid}}, error)
 {{end}}{{/* range .Bodies */}}
 {{end}}{{/* range . $opid := .OperationId */}}
 }
@@ -453,10 +695,13 @@ func (r {{$opid | ucFirst}}Response) StatusCode() int {
 
 {{range .}}
 {{$opid := .OperationId -}}
+{{$deprecated := .DeprecationComment -}}
 {{/* Generate client methods (with responses)*/}}
 
 // {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse request{{if .HasBody}} with arbitrary body{{end}} returning *{{$opid}}Response
-func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*{{genResponseTypeName $opid}}, error){
+{{with $deprecated}}//
+{{.}}
+{{end}}func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*{{genResponseTypeName $opid}}, error){
     rsp, err := c.{{$opid}}{{if .HasBody}}WithBody{{end}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}}{{if .HasBody}}, contentType, body{{end}}, reqEditors...)
     if err != nil {
         return nil, err
@@ -468,7 +713,8 @@ func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithRespons
 {{$pathParams := .PathParams -}}
 {{$bodyRequired := .BodyRequired -}}
 {{range .Bodies}}
-func (c *ClientWithResponses) {{$opid}}{{.Suffix}}WithResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*{{genResponseTypeName $opid}}, error) {
+{{with $deprecated}}{{.}}
+{{end}}func (c *ClientWithResponses) {{$opid}}{{.Suffix}}WithResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*{{genResponseTypeName $opid}}, error) {
     rsp, err := c.{{$opid}}{{.Suffix}}(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body, reqEditors...)
     if err != nil {
         return nil, err
@@ -479,6 +725,52 @@ func (c *ClientWithResponses) {{$opid}}{{.Suffix}}WithResponse(ctx context.Conte
 
 {{end}}{{/* operations */}}
 
+// BatchCall is a call of an operation in a batch, which returns its
+// *...Response, eg,
+//
+//	func(ctx context.Context, c ClientWithResponsesInterface) (interface{}, error) {
+//		return c.FindPetByIdWithResponse(ctx, id)
+//	}
+type BatchCall func(ctx context.Context, client ClientWithResponsesInterface) (interface{}, error)
+
+// BatchResult is the result of a call in a batch.
+type BatchResult struct {
+    Response interface{} // The *...Response of the operation, if it was called
+    Err      error
+}
+
+// Batch makes the calls with the client, with at most concurrency of them at
+// a time, or all at once when concurrency isn't positive, and returns their
+// results in the order of the calls. A failed call doesn't stop the others,
+// but those which haven't started once ctx is done fail with its error.
+func (c *ClientWithResponses) Batch(ctx context.Context, concurrency int, calls ...BatchCall) []BatchResult {
+    results := make([]BatchResult, len(calls))
+    errs := runtime.RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+        var err error
+        results[i].Response, err = calls[i](ctx, c)
+        return err
+    })
+    for i, err := range errs {
+        results[i].Err = err
+    }
+    return results
+}
+
+// ParseOperationResponse parses an HTTP response to a request of the
+// operation with the given ID, such as one built by a New...Request method of
+// the Client, which runtime.OperationIDFromContext tells from the context of
+// the request, into the *...Response of the operation.
+func ParseOperationResponse(operationID string, rsp *http.Response) (interface{}, error) {
+    switch operationID {
+{{- range .}}
+    case "{{.OperationId}}":
+        return Parse{{genResponseTypeName .OperationId | ucFirst}}(rsp)
+{{- end}}
+    }
+    _ = rsp.Body.Close()
+    return nil, fmt.Errorf("unknown operation %s", operationID)
+}
+
 {{/* Generate parse functions for responses*/}}
 {{range .}}{{$opid := .OperationId}}
 
@@ -517,6 +809,17 @@ type Client struct {
 	// paths in the swagger spec will be appended to the server.
 	Server string
 
+	// Servers are the endpoints which the requests are sent to, as picked by
+	// the ServerPolicy, when there are several, which WithServers sets up.
+	// Server is the first one.
+	Servers []string
+
+	// ServerPolicy is how the servers of the requests are picked.
+	ServerPolicy runtime.ServerPolicy
+
+	// requests counts the requests, for picking their servers in turn.
+	requests uint32
+
 	// Doer for performing requests, typically a *http.Client with any
 	// customized settings, such as certificate chains.
 	Client HttpRequestDoer
@@ -524,6 +827,26 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// RateLimiter, when set, is waited on before sending each request, with
+	// the ID of its operation.
+	RateLimiter runtime.RateLimiter
+
+	// MaxRetryAfter is how long, in total, a request which is rate limited,
+	// with a 429 status and a Retry-After header, is retried for, after
+	// waiting as long as the server asks, and at least
+	// runtime.MinRetryAfter. It isn't retried when it's zero.
+	MaxRetryAfter time.Duration
+
+	// MaxResponseSize is the maximum size of the response bodies, in bytes,
+	// beyond which reading them fails with a *runtime.ResponseTooLargeError,
+	// unless the operation has its own, from x-max-response-size. The
+	// bodies aren't limited when it's zero.
+	MaxResponseSize int64
+
+	// recorder, which WithRecorder sets, sends the requests through the
+	// Client once all the options are applied.
+	recorder *runtime.Recorder
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -545,6 +868,16 @@ func NewClient(server string, opts ...ClientOption) (*Client, error) {
     if !strings.HasSuffix(client.Server, "/") {
         client.Server += "/"
     }
+    for i, server := range client.Servers {
+        if !strings.HasSuffix(server, "/") {
+            client.Servers[i] = server + "/"
+        }
+    }
+    // send the requests of the Client through the recorder, whatever the
+    // order of the options
+    if client.recorder != nil {
+        client.Client = &http.Client{Transport: client.recorder.WithDoer(client.Client)}
+    }
     // create httpClient, if not already present
     if client.Client == nil {
         client.Client = &http.Client{}
@@ -561,6 +894,26 @@ func WithHTTPClient(doer HttpRequestDoer) ClientOption {
 	}
 }
 
+// WithServers makes the client send its requests to several servers, such as
+// the ones declared in the spec, instead of the one given to NewClient. They
+// are picked by policy: with runtime.ServerFailover, the requests fail over
+// to the next servers when one can't be reached, or, for idempotent requests,
+// responds with a 5xx status, runtime.ServerFailoverAnyMethod fails over the
+// requests of any method on 5xx statuses too, and runtime.ServerRoundRobin
+// sends them to each server in turn.
+// The server of a request is picked before the request editors run.
+func WithServers(policy runtime.ServerPolicy, servers ...string) ClientOption {
+	return func(c *Client) error {
+		if len(servers) == 0 {
+			return fmt.Errorf("no servers given")
+		}
+		c.Server = servers[0]
+		c.Servers = append([]string{}, servers...)
+		c.ServerPolicy = policy
+		return nil
+	}
+}
+
 // WithRequestEditorFn allows setting up a callback function, which will be
 // called right before sending the request. This can be used to mutate the request.
 func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
@@ -570,16 +923,73 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithAccept sets the Accept header of a request, eg, to ask for one of the
+// content types of the responses of its operation, rather than all of them,
+// which the request builders list.
+func WithAccept(accept string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", accept)
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before sending each
+// request, eg, for a token of the bucket of its operation.
+func WithRateLimiter(limiter runtime.RateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRetryAfter makes the client retry the requests which are rate limited,
+// with a 429 status and a Retry-After header, after waiting as long as the
+// server asks, for up to maxWait in total. The response is returned when the
+// server asks to wait longer.
+func WithRetryAfter(maxWait time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.MaxRetryAfter = maxWait
+		return nil
+	}
+}
+
+// WithMaxResponseSize limits the size of the response bodies which the client
+// reads to maxSize bytes, so that a misbehaving server can't exhaust its
+// memory. Reading larger bodies, such as when parsing them, fails with a
+// *runtime.ResponseTooLargeError. The operations with x-max-response-size
+// have their own limit.
+func WithMaxResponseSize(maxSize int64) ClientOption {
+	return func(c *Client) error {
+		c.MaxResponseSize = maxSize
+		return nil
+	}
+}
+
+// WithRecorder makes the client send its requests through recorder, which
+// records their responses to golden files, or replays them, in tests. The
+// requests which are recorded are sent with the Doer of WithHTTPClient, if
+// any, unless recorder has a Transport of its own.
+func WithRecorder(recorder *runtime.Recorder) ClientOption {
+	return func(c *Client) error {
+		c.recorder = recorder
+		return nil
+	}
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 {{range . -}}
 {{$hasParams := .RequiresParamObject -}}
 {{$pathParams := .PathParams -}}
 {{$opid := .OperationId -}}
+{{$deprecated := .DeprecationComment -}}
     // {{$opid}} request{{if .HasBody}} with any body{{end}}
-    {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*http.Response, error)
+{{with $deprecated}}    //
+{{.}}
+{{end}}    {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*http.Response, error)
 {{range .Bodies}}
-    {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*http.Response, error)
+{{with $deprecated}}{{.}}
+{{end}}    {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*http.Response, error)
 {{end}}{{/* range .Bodies */}}
 {{end}}{{/* range . $opid := .OperationId */}}
 }
@@ -590,30 +1000,66 @@ type ClientInterface interface {
 {{$hasParams := .RequiresParamObject -}}
 {{$pathParams := .PathParams -}}
 {{$opid := .OperationId -}}
+{{$deprecated := .DeprecationComment -}}
+
+{{$timeout := .Timeout -}}
+{{$timeoutLiteral := .TimeoutLiteral -}}
+
+{{with $deprecated}}{{.}}
+{{end}}func (c *Client) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*http.Response, error) {
+{{- if $timeout}}
+    // The deadline of x-timeout, unless ctx has an earlier one
+    ctx, cancel := context.WithTimeout(ctx, {{$timeoutLiteral}})
+    rsp, err := c.do(ctx, "{{$opid}}", func(server string) (*http.Request, error) {
+        return New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server{{genParamNames .PathParams}}{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
+    }, reqEditors)
+    return runtime.CancelOnClose(rsp, err, cancel)
+{{- else}}
+    return c.do(ctx, "{{$opid}}", func(server string) (*http.Request, error) {
+        return New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server{{genParamNames .PathParams}}{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
+    }, reqEditors)
+{{- end}}
+}
 
-func (c *Client) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*http.Response, error) {
-    req, err := New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(c.Server{{genParamNames .PathParams}}{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
-    if err != nil {
-        return nil, err
-    }
-    req = req.WithContext(ctx)
-    if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-        return nil, err
-    }
-    return c.Client.Do(req)
+{{range .Bodies}}
+{{with $deprecated}}{{.}}
+{{end}}func (c *Client) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*http.Response, error) {
+{{- if $timeout}}
+    // The deadline of x-timeout, unless ctx has an earlier one
+    ctx, cancel := context.WithTimeout(ctx, {{$timeoutLiteral}})
+    rsp, err := c.do(ctx, "{{$opid}}", func(server string) (*http.Request, error) {
+        return New{{$opid}}Request{{.Suffix}}(server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body)
+    }, reqEditors)
+    return runtime.CancelOnClose(rsp, err, cancel)
+{{- else}}
+    return c.do(ctx, "{{$opid}}", func(server string) (*http.Request, error) {
+        return New{{$opid}}Request{{.Suffix}}(server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body)
+    }, reqEditors)
+{{- end}}
 }
+{{end}}{{/* range .Bodies */}}
+{{end}}
 
+{{/* Generate the request builders of the client */}}
+{{range . -}}
+{{$hasParams := .RequiresParamObject -}}
+{{$pathParams := .PathParams -}}
+{{$opid := .OperationId -}}
+
+// New{{$opid}}Request{{if .HasBody}}WithBody{{end}} builds the request which {{$opid}}{{if .HasBody}}WithBody{{end}} sends, without
+// sending it.
+func (c *Client) New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}, reqEditors... RequestEditorFn) (*http.Request, error) {
+    return c.newRequest(ctx, "{{$opid}}", func(server string) (*http.Request, error) {
+        return New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
+    }, reqEditors)
+}
 {{range .Bodies}}
-func (c *Client) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*http.Response, error) {
-    req, err := New{{$opid}}{{.Suffix}}Request(c.Server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body)
-    if err != nil {
-        return nil, err
-    }
-    req = req.WithContext(ctx)
-    if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-        return nil, err
-    }
-    return c.Client.Do(req)
+// New{{$opid}}Request{{.Suffix}} builds the request which {{$opid}}{{.Suffix}} sends, without
+// sending it.
+func (c *Client) New{{$opid}}Request{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody, reqEditors... RequestEditorFn) (*http.Request, error) {
+    return c.newRequest(ctx, "{{$opid}}", func(server string) (*http.Request, error) {
+        return New{{$opid}}Request{{.Suffix}}(server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body)
+    }, reqEditors)
 }
 {{end}}{{/* range .Bodies */}}
 {{end}}
@@ -624,10 +1070,18 @@ func (c *Client) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathPar
 {{$pathParams := .PathParams -}}
 {{$bodyRequired := .BodyRequired -}}
 {{$opid := .OperationId -}}
+{{$deprecated := .DeprecationComment -}}
 
 {{range .Bodies}}
 // New{{$opid}}Request{{.Suffix}} calls the generic {{$opid}} builder with {{.ContentType}} body
-func New{{$opid}}Request{{.Suffix}}(server string{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody) (*http.Request, error) {
+{{with $deprecated}}//
+{{.}}
+{{end}}func New{{$opid}}Request{{.Suffix}}(server string{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{$opid}}{{.NameTag}}RequestBody) (*http.Request, error) {
+{{- if and opts.ValidateClientRequests (hasValidateMethod (printf "%s%sRequestBody" $opid .NameTag))}}
+    if err := body.Validate(); err != nil {
+        return nil, err
+    }
+{{- end}}
     var bodyReader io.Reader
     buf, err := json.Marshal(body)
     if err != nil {
@@ -639,81 +1093,33 @@ func New{{$opid}}Request{{.Suffix}}(server string{{genParamArgs $pathParams}}{{i
 {{end}}
 
 // New{{$opid}}Request{{if .HasBody}}WithBody{{end}} generates requests for {{$opid}}{{if .HasBody}} with any type of body{{end}}
-func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Request, error) {
-    var err error
-{{range $paramIdx, $param := .PathParams}}
-    var pathParam{{$paramIdx}} string
-    {{if .IsPassThrough}}
-    pathParam{{$paramIdx}} = {{.GoVariableName}}
-    {{end}}
-    {{if .IsJson}}
-    var pathParamBuf{{$paramIdx}} []byte
-    pathParamBuf{{$paramIdx}}, err = json.Marshal({{.GoVariableName}})
-    if err != nil {
-        return nil, err
-    }
-    pathParam{{$paramIdx}} = string(pathParamBuf{{$paramIdx}})
-    {{end}}
-    {{if .IsStyled}}
-    pathParam{{$paramIdx}}, err = runtime.StyleParamWithLocation("{{.Style}}", {{.Explode}}, "{{.ParamName}}", runtime.ParamLocationPath, {{.GoVariableName}})
-    if err != nil {
+{{with $deprecated}}//
+{{.}}
+{{end}}func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Request, error) {
+{{- if and $hasParams opts.ParamsBuilders}}
+    if err := params.BuildError(); err != nil {
         return nil, err
     }
-    {{end}}
-{{end}}
-    serverURL, err := url.Parse(server)
-    if err != nil {
+{{- end}}
+{{- if and $hasParams opts.ValidateClientRequests (hasValidateMethod (printf "%sParams" $opid))}}
+    if err := params.Validate(); err != nil {
         return nil, err
     }
-
-    operationPath := fmt.Sprintf("{{genParamFmtString .Path}}"{{range $paramIdx, $param := .PathParams}}, pathParam{{$paramIdx}}{{end}})
-    if operationPath[0] == '/' {
-        operationPath = "." + operationPath
-    }
-
-    queryURL, err := serverURL.Parse(operationPath)
+{{- end}}
+    queryURL, err := Build{{$opid}}URL(server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}})
     if err != nil {
         return nil, err
     }
 
-{{if .QueryParams}}
-    queryValues := queryURL.Query()
-{{range $paramIdx, $param := .QueryParams}}
-    {{if not .Required}} if params.{{.GoName}} != nil { {{end}}
-    {{if .IsPassThrough}}
-    queryValues.Add("{{.ParamName}}", {{if not .Required}}*{{end}}params.{{.GoName}})
-    {{end}}
-    {{if .IsJson}}
-    if queryParamBuf, err := json.Marshal({{if not .Required}}*{{end}}params.{{.GoName}}); err != nil {
-        return nil, err
-    } else {
-        queryValues.Add("{{.ParamName}}", string(queryParamBuf))
-    }
-
-    {{end}}
-    {{if .IsStyled}}
-    if queryFrag, err := runtime.StyleParamWithLocation("{{.Style}}", {{.Explode}}, "{{.ParamName}}", runtime.ParamLocationQuery, {{if not .Required}}*{{end}}params.{{.GoName}}); err != nil {
-        return nil, err
-    } else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-       return nil, err
-    } else {
-       for k, v := range parsed {
-           for _, v2 := range v {
-               queryValues.Add(k, v2)
-           }
-       }
-    }
-    {{end}}
-    {{if not .Required}}}{{end}}
-{{end}}
-    queryURL.RawQuery = queryValues.Encode()
-{{end}}{{/* if .QueryParams */}}
     req, err := http.NewRequest("{{.Method}}", queryURL.String(), {{if .HasBody}}body{{else}}nil{{end}})
     if err != nil {
         return nil, err
     }
 
     {{if .HasBody}}req.Header.Add("Content-Type", contentType){{end}}
+{{- with .AcceptHeader}}
+    req.Header.Set("Accept", {{printf "%q" .}})
+{{- end}}
 {{range $paramIdx, $param := .HeaderParams}}
     {{if not .Required}} if params.{{.GoName}} != nil { {{end}}
     var headerParam{{$paramIdx}} string
@@ -770,6 +1176,112 @@ func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamAr
 
 {{end}}{{/* Range */}}
 
+// do sends the request built by newRequest for a server, which is picked by
+// the ServerPolicy, after editing it, once the RateLimiter lets the operation
+// through. The context of the request carries the operation ID, for the
+// editors and the Doer. When it fails over, or is retried after being rate limited, the
+// request is built again, with the body of the first one, unless the body
+// can't be read again.
+func (c *Client) do(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Response, error) {
+    ctx = runtime.ContextWithOperationID(ctx, operationID)
+    var first *http.Request
+    build := func(server string) (*http.Request, error) {
+        req, err := newRequest(server)
+        if err != nil {
+            return nil, err
+        }
+        if first == nil {
+            first = req
+        } else if first.GetBody != nil {
+            if req.Body, err = first.GetBody(); err != nil {
+                return nil, err
+            }
+            req.GetBody = first.GetBody
+            req.ContentLength = first.ContentLength
+        }
+        return req.WithContext(ctx), nil
+    }
+    replayable := func() bool {
+        return first.Body == nil || first.Body == http.NoBody || first.GetBody != nil
+    }
+
+    var waited time.Duration
+    for {
+        if c.RateLimiter != nil {
+            if err := c.RateLimiter.Wait(ctx, operationID); err != nil {
+                return nil, err
+            }
+        }
+        rsp, err := c.send(ctx, build, replayable, reqEditors)
+        if err != nil || c.MaxRetryAfter <= 0 || !replayable() {
+            return rsp, err
+        }
+        wait, retry := runtime.RetryAfter(rsp, time.Now())
+        if !retry || waited+wait > c.MaxRetryAfter {
+            return rsp, nil
+        }
+        _, _ = io.Copy(ioutil.Discard, rsp.Body)
+        rsp.Body.Close()
+        if err := runtime.Sleep(ctx, wait); err != nil {
+            return nil, err
+        }
+        waited += wait
+    }
+}
+
+// send sends the request built by build to the servers, in the order of the
+// ServerPolicy, until one of them doesn't fail over.
+func (c *Client) send(ctx context.Context, build func(server string) (*http.Request, error), replayable func() bool, reqEditors []RequestEditorFn) (*http.Response, error) {
+    servers := []string{c.Server}
+    if len(c.Servers) > 0 {
+        servers = runtime.ServerOrder(c.ServerPolicy, c.Servers, atomic.AddUint32(&c.requests, 1)-1)
+    }
+    for i, server := range servers {
+        req, err := build(server)
+        if err != nil {
+            return nil, err
+        }
+        if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+            return nil, err
+        }
+        rsp, err := c.Client.Do(req)
+        if i == len(servers)-1 || !replayable() || !runtime.ShouldFailOver(ctx, c.ServerPolicy, req, rsp, err) {
+            return runtime.LimitResponseBody(rsp, c.maxResponseSize(runtime.OperationIDFromContext(ctx))), err
+        }
+        if rsp != nil {
+            _, _ = io.Copy(ioutil.Discard, rsp.Body)
+            rsp.Body.Close()
+        }
+    }
+    return nil, fmt.Errorf("no servers")
+}
+
+// maxResponseSize returns the maximum size of the response bodies of the
+// operation with the given ID, or zero when they aren't limited.
+func (c *Client) maxResponseSize(operationID string) int64 {
+{{- range .}}{{if .MaxResponseSize}}
+    if operationID == "{{.OperationId}}" {
+        return {{.MaxResponseSize}}
+    }
+{{- end}}{{end}}
+    return c.MaxResponseSize
+}
+
+// newRequest builds the request of the operation, like do does, for the
+// first server, with ctx, after editing it, without sending it.
+func (c *Client) newRequest(ctx context.Context, operationID string, newRequest func(server string) (*http.Request, error), reqEditors []RequestEditorFn) (*http.Request, error) {
+    ctx = runtime.ContextWithOperationID(ctx, operationID)
+    req, err := newRequest(c.Server)
+    if err != nil {
+        return nil, err
+    }
+    req = req.WithContext(ctx)
+    if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+        return nil, err
+    }
+    return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
     for _, r := range c.RequestEditors {
         if err := r(ctx, req); err != nil {
@@ -799,24 +1311,227 @@ const (
   {{$index}} {{$Enum.TypeName}} = {{$Enum.ValueWrapper}}{{$value}}{{$Enum.ValueWrapper}}
 {{end}}
 )
+{{if opts.EnumStringer}}
+// String returns the value of the {{$Enum.TypeName}}.
+func (e {{$Enum.TypeName}}) String() string {
+{{- if $Enum.IsString}}
+    return string(e)
+{{- else}}
+    return fmt.Sprint({{$Enum.Schema.GoType}}(e))
+{{- end}}
+}
 {{end}}
+{{- if and (or opts.EnumText opts.EnumSQL) $Enum.SQLNullType}}
+// valid returns whether e is one of the values of {{$Enum.TypeName}}.
+func (e {{$Enum.TypeName}}) valid() bool {
+    switch e {
+{{- range $index, $value := $Enum.Schema.EnumValues}}
+    case {{$index}}:
+        return true
+{{- end}}
+    }
+    return false
+}
 {{end}}
-`,
-	"echo-interface.tmpl": `// ServerInterface represents all server handlers.
-type ServerInterface interface {
-{{range .}}{{.SummaryAsComment }}
-// ({{.Method}} {{.Path}})
-{{.OperationId}}(ctx echo.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}}) error
-{{end}}
+{{- if and opts.EnumText $Enum.SQLNullType}}
+// MarshalText implements encoding.TextMarshaler, it fails for values which
+// aren't a {{$Enum.TypeName}}.
+func (e {{$Enum.TypeName}}) MarshalText() ([]byte, error) {
+    if !e.valid() {
+        return nil, fmt.Errorf("invalid {{$Enum.TypeName}} value %v", {{$Enum.Schema.GoType}}(e))
+    }
+{{- if $Enum.IsString}}
+    return []byte(e), nil
+{{- else}}
+    return json.Marshal({{$Enum.Schema.GoType}}(e))
+{{- end}}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, it fails for values
+// which aren't a {{$Enum.TypeName}}.
+func (e *{{$Enum.TypeName}}) UnmarshalText(text []byte) error {
+{{- if $Enum.IsString}}
+    value := {{$Enum.TypeName}}(text)
+{{- else}}
+    var v {{$Enum.Schema.GoType}}
+    if err := json.Unmarshal(text, &v); err != nil {
+        return fmt.Errorf("invalid {{$Enum.TypeName}} value %q: %w", text, err)
+    }
+    value := {{$Enum.TypeName}}(v)
+{{- end}}
+    if !value.valid() {
+        return fmt.Errorf("invalid {{$Enum.TypeName}} value %q", text)
+    }
+    *e = value
+    return nil
+}
+{{if not (or $Enum.IsString $Enum.IsConst)}}
+// MarshalJSON keeps {{$Enum.TypeName}} encoded as its {{$Enum.Schema.GoType}} value in JSON, rather
+// than as text.
+func (e {{$Enum.TypeName}}) MarshalJSON() ([]byte, error) {
+    return e.MarshalText()
 }
-`,
-	"echo-register.tmpl": `
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+// UnmarshalJSON decodes {{$Enum.TypeName}} from its {{$Enum.Schema.GoType}} value in JSON, rather
+// than from text.
+func (e *{{$Enum.TypeName}}) UnmarshalJSON(data []byte) error {
+    return e.UnmarshalText(data)
+}
+{{end}}
+{{- end}}
+{{- if $Enum.IsConst}}
+// MarshalJSON encodes {{$Enum.TypeName}} as {{$Enum.ConstName}}, its only value, whatever the
+// value of e is.
+func (e {{$Enum.TypeName}}) MarshalJSON() ([]byte, error) {
+    return json.Marshal({{$Enum.Schema.GoType}}({{$Enum.ConstName}}))
+}
+
+// UnmarshalJSON fails for values other than {{$Enum.ConstName}}, the only value of
+// {{$Enum.TypeName}}.
+func (e *{{$Enum.TypeName}}) UnmarshalJSON(data []byte) error {
+    var v {{$Enum.Schema.GoType}}
+    if err := json.Unmarshal(data, &v); err != nil {
+        return fmt.Errorf("invalid {{$Enum.TypeName}} value %s: %w", data, err)
+    }
+    if {{$Enum.TypeName}}(v) != {{$Enum.ConstName}} {
+        return fmt.Errorf("invalid {{$Enum.TypeName}} value %s, expected %v", data, {{$Enum.ConstName}})
+    }
+    *e = {{$Enum.ConstName}}
+    return nil
+}
+{{end}}
+{{- if and opts.EnumSQL $Enum.SQLNullType}}
+// Value implements driver.Valuer, it fails for values which aren't a
+// {{$Enum.TypeName}}.
+func (e {{$Enum.TypeName}}) Value() (driver.Value, error) {
+    if !e.valid() {
+        return nil, fmt.Errorf("invalid {{$Enum.TypeName}} value %v", {{$Enum.Schema.GoType}}(e))
+    }
+    return {{$Enum.SQLValueType}}(e), nil
+}
+
+// Scan implements sql.Scanner, it fails for values which aren't a
+// {{$Enum.TypeName}}, and for NULL, which *{{$Enum.TypeName}} fields take.
+func (e *{{$Enum.TypeName}}) Scan(src interface{}) error {
+    var v sql.Null{{$Enum.SQLNullType}}
+    if err := v.Scan(src); err != nil {
+        return fmt.Errorf("error scanning {{$Enum.TypeName}}: %w", err)
+    }
+    if !v.Valid {
+        return errors.New("can't scan NULL into {{$Enum.TypeName}}")
+    }
+    value := {{$Enum.TypeName}}(v.{{$Enum.SQLNullType}})
+    if !value.valid() {
+        return fmt.Errorf("invalid {{$Enum.TypeName}} value %v", v.{{$Enum.SQLNullType}})
+    }
+    *e = value
+    return nil
+}
+{{end}}
+{{- end}}
+{{end}}
+`,
+	"deepcopy.tmpl": `{{range .}}
+// DeepCopyInto copies the receiver into out, which must be non-nil.
+func (in *{{.TypeName}}) DeepCopyInto(out *{{.TypeName}}) {
+{{.Body}}
+}
+
+// DeepCopy returns a deep copy of the receiver, or nil if it's nil.
+func (in *{{.TypeName}}) DeepCopy() *{{.TypeName}} {
+    if in == nil {
+        return nil
+    }
+    out := new({{.TypeName}})
+    in.DeepCopyInto(out)
+    return out
+}
+{{end}}
+`,
+	"defaults.tmpl": `{{range .}}
+// ApplyDefaults sets the absent fields of the receiver to the defaults of
+// their schemas.
+func (v *{{.TypeName}}) ApplyDefaults() {
+{{.Body}}
+}
+{{end}}
+`,
+	"docs.tmpl": `{{- /* The pages are raw string literals, whose backquotes can't be in templates. */}}
+{{- $bq := "\x60"}}
+{{- if eq .UI "redoc"}}
+// docsPage is the Redoc page of the spec, which loads Redoc from a CDN.
+const docsPage = {{$bq}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<redoc spec-url="openapi.json"></redoc>
+<script src="https://cdn.jsdelivr.net/npm/redoc@2/bundles/redoc.standalone.js"></script>
+</body>
+</html>
+{{$bq}}
+{{- else}}
+// docsPage is the Swagger UI page of the spec, which loads Swagger UI from a
+// CDN.
+const docsPage = {{$bq}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@4/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@4/swagger-ui-bundle.js"></script>
+<script>
+window.onload = function() {
+  window.ui = SwaggerUIBundle({url: "openapi.json", dom_id: "#swagger-ui"});
+};
+</script>
+</body>
+</html>
+{{$bq}}
+{{- end}}
+
+// ServeSpec responds with the embedded spec as JSON. The servers serve it at
+// openapi.json, under their base URL.
+func ServeSpec(w http.ResponseWriter, r *http.Request) {
+    spec, err := rawSpec()
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    _, _ = w.Write(spec)
+}
+
+// ServeDocs responds with the documentation page of the spec, which it loads
+// from openapi.json, next to it. The servers serve it at docs, under their
+// base URL.
+func ServeDocs(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "text/html; charset=utf-8")
+    _, _ = io.WriteString(w, docsPage)
+}
+`,
+	"echo-interface.tmpl": `// ServerInterface represents all server handlers.
+type ServerInterface interface {
+{{range .}}{{.SummaryAsComment }}
+// ({{.Method}} {{.Path}})
+{{with .DeprecationComment}}//
+{{.}}
+{{end}}{{.OperationId}}(ctx {{echoContext}}{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}}) error
+{{end}}
+}
+`,
+	"echo-register.tmpl": `
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
@@ -827,46 +1542,162 @@ type EchoRouter interface {
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
 
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+    BaseURL string
+    // RouteMiddlewares returns the middlewares of the route of an operation.
+    RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+    // RouteName names the route of an operation, which it's registered
+    // under, for echo.Echo.Reverse.
+    RouteName func(route runtime.Route) string
+    // OnRoute is told of the route of each operation once it's registered.
+    OnRoute func(route runtime.Route)
+    // BindErrorTranslator translates the errors binding the parameters of
+    // requests, which are reported instead of the default ones, unless it's
+    // nil.
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    // ParamErrorBody builds the body of the 400 response to a request
+    // missing a required parameter of an operation, or returns nil for the
+    // usual error. It's DefaultParamErrorBody when nil.
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    // ErrorResponder responds to the errors and panics of the handlers,
+    // unless it's nil.
+    ErrorResponder ErrorResponder
+{{- end}}
+}
+
 // RegisterHandlers adds each server route to the EchoRouter.
 func RegisterHandlers(router EchoRouter, si ServerInterface) {
-    RegisterHandlersWithBaseURL(router, si, "")
+    RegisterHandlersWithOptions(router, si, EchoServerOptions{})
 }
 
 // Registers handlers, and prepends BaseURL to the paths, so that the paths
 // can be served under a prefix.
 func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+    RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+{{if opts.ServerRecovery}}
+// RegisterHandlersWithErrorResponder registers handlers like
+// RegisterHandlersWithBaseURL, whose errors and panics are responded to by
+// responder, unless it's nil.
+func RegisterHandlersWithErrorResponder(router EchoRouter, si ServerInterface, baseURL string, responder ErrorResponder) {
+    RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL, ErrorResponder: responder})
+}
+{{end}}
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
 {{if .}}
+{{- if opts.ParamErrorResponses}}
+    if options.ParamErrorBody == nil {
+        options.ParamErrorBody = DefaultParamErrorBody
+    }
+{{- end}}
     wrapper := ServerInterfaceWrapper{
         Handler: si,
+        BindErrorTranslator: options.BindErrorTranslator,
+{{- if opts.ParamErrorResponses}}
+        ParamErrorBody: options.ParamErrorBody,
+{{- end}}
+{{- if opts.ServerRecovery}}
+        ErrorResponder: options.ErrorResponder,
+{{- end}}
+    }
+
+    register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route, handler echo.HandlerFunc) {
+        route.BaseURL = options.BaseURL
+        route.RouterPath = options.BaseURL + route.RouterPath
+        if options.RouteName != nil {
+            route.Name = options.RouteName(route)
+        }
+        var middlewares []echo.MiddlewareFunc
+        if options.RouteMiddlewares != nil {
+            middlewares = options.RouteMiddlewares(route)
+        }
+        if added := add(route.RouterPath, handler, middlewares...); route.Name != "" {
+            added.Name = route.Name
+        }
+        if options.OnRoute != nil {
+            options.OnRoute(route)
+        }
     }
 {{end}}
-{{range .}}router.{{.Method}}(baseURL + "{{.Path | swaggerUriToEchoUri}}", wrapper.{{.OperationId}})
+{{range .}}register(runtime.Route{OperationID: "{{.OperationId}}", Method: "{{.Method}}", Path: "{{.Path}}", RouterPath: "{{.Path | swaggerUriToEchoUri}}"}, router.{{.Method}}, wrapper.{{.OperationId}})
 {{end}}
+{{- if opts.DocsUI}}
+router.GET(options.BaseURL + "/openapi.json", echo.WrapHandler(http.HandlerFunc(ServeSpec)))
+router.GET(options.BaseURL + "/docs", echo.WrapHandler(http.HandlerFunc(ServeDocs)))
+{{- end}}
 }
 `,
 	"echo-wrappers.tmpl": `// ServerInterfaceWrapper converts echo contexts to parameters.
 type ServerInterfaceWrapper struct {
     Handler ServerInterface
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    ErrorResponder ErrorResponder
+{{- end}}
+}
+{{if opts.ServerRecovery}}
+// ErrorResponder responds to the errors returned by the handlers, and to
+// their panics.
+type ErrorResponder func(ctx {{echoContext}}, err *runtime.OperationError) error
+{{end}}
+{{- if opts.ParamErrorResponses}}
+// paramError responds to a request missing a required parameter of
+// operationID with a 400 and the body built by the ParamErrorBody, unless
+// it's nil. Otherwise, it returns err as translated by the
+// BindErrorTranslator, or defaultErr.
+func (w *ServerInterfaceWrapper) paramError(ctx {{echoContext}}, operationID string, err *runtime.BindError, defaultErr error) error {
+    if err.Kind == runtime.BindErrorRequired {
+        if body := w.ParamErrorBody(operationID, err); body != nil {
+            return ctx.JSON(http.StatusBadRequest, body)
+        }
+    }
+    return runtime.TranslateBindError(w.BindErrorTranslator, err, defaultErr)
 }
+{{end}}
 
-{{range .}}{{$opid := .OperationId}}// {{$opid}} converts echo context to params.
-func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
+{{range .}}{{$opid := .OperationId}}{{region "handler" $opid}}// {{$opid}} converts echo context to params.
+func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx {{echoContext}}) {{if opts.ServerRecovery}}(err error){{else}}error{{end}} {
+{{- if opts.ServerRecovery}}
+    defer func() {
+        if p := recover(); p != nil {
+            err = w.respond{{$opid}}Error(ctx, runtime.RecoverOperation("{{$opid}}", p))
+        }
+    }()
+{{- else}}
     var err error
+{{- end}}
 {{range .PathParams}}// ------------- Path parameter "{{.ParamName}}" -------------
     var {{$varName := .GoVariableName}}{{$varName}} {{.TypeDef}}
+{{- if opts.ParamErrorResponses}}
+    if ctx.Param("{{.ParamName}}") == "" {
+        return w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationPath, nil),
+            echo.NewHTTPError(http.StatusBadRequest, "Path parameter {{.ParamName}} is required, but not found"))
+    }
+{{- end}}
 {{if .IsPassThrough}}
     {{$varName}} = ctx.Param("{{.ParamName}}")
 {{end}}
 {{if .IsJson}}
-    err = json.Unmarshal([]byte(ctx.Param("{{.ParamName}}")), &{{$varName}})
+    err = runtime.BindJSONParameter("{{.ParamName}}", runtime.ParamLocationPath, ctx.Param("{{.ParamName}}"), &{{$varName}})
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationPath, err),
+            echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON"))
     }
 {{end}}
 {{if .IsStyled}}
     err = runtime.BindStyledParameterWithLocation("{{.Style}}",{{.Explode}}, "{{.ParamName}}", runtime.ParamLocationPath, ctx.Param("{{.ParamName}}"), &{{$varName}})
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationPath, err),
+            echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err)))
     }
 {{end}}
 {{end}}
@@ -882,7 +1713,13 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
     {{if .IsStyled}}
     err = runtime.BindQueryParameter("{{.Style}}", {{.Explode}}, {{.Required}}, "{{.ParamName}}", ctx.QueryParams(), &params.{{.GoName}})
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+        {{- if opts.ParamErrorResponses}}
+        return w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+            echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err)))
+        {{- else}}
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+            echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err)))
+        {{- end}}
     }
     {{else}}
     if paramValue := ctx.QueryParam("{{.ParamName}}"); paramValue != "" {
@@ -893,12 +1730,19 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
     var value {{.TypeDef}}
     err = json.Unmarshal([]byte(paramValue), &value)
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+            echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON"))
     }
     params.{{.GoName}} = {{if not .Required}}&{{end}}value
     {{end}}
     }{{if .Required}} else {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found"))
+        {{- if opts.ParamErrorResponses}}
+        return w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationQuery, nil),
+            echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found")))
+        {{- else}}
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationQuery, nil),
+            echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found")))
+        {{- end}}
     }{{end}}
     {{end}}
 {{end}}
@@ -908,28 +1752,41 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
 {{range .HeaderParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} header parameter "{{.ParamName}}" -------------
     if valueList, found := headers[http.CanonicalHeaderKey("{{.ParamName}}")]; found {
         var {{.GoName}} {{.TypeDef}}
+{{- if .IsList}}
+        valueList = []string{strings.Join(valueList, ",")}
+{{- else}}
         n := len(valueList)
         if n != 1 {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for {{.ParamName}}, got %d", n))
+            return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+                echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for {{.ParamName}}, got %d", n)))
         }
+{{- end}}
 {{if .IsPassThrough}}
         params.{{.GoName}} = {{if not .Required}}&{{end}}valueList[0]
 {{end}}
 {{if .IsJson}}
         err = json.Unmarshal([]byte(valueList[0]), &{{.GoName}})
         if err != nil {
-            return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+            return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+                echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON"))
         }
 {{end}}
 {{if .IsStyled}}
         err = runtime.BindStyledParameterWithLocation("{{.Style}}",{{.Explode}}, "{{.ParamName}}", runtime.ParamLocationHeader, valueList[0], &{{.GoName}})
         if err != nil {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+            return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+                echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err)))
         }
 {{end}}
         params.{{.GoName}} = {{if not .Required}}&{{end}}{{.GoName}}
         } {{if .Required}}else {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter {{.ParamName}} is required, but not found"))
+            {{- if opts.ParamErrorResponses}}
+            return w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+                echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter {{.ParamName}} is required, but not found")))
+            {{- else}}
+            return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+                echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter {{.ParamName}} is required, but not found")))
+            {{- end}}
         }{{end}}
 {{end}}
 {{end}}
@@ -944,11 +1801,13 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
     var decoded string
     decoded, err := url.QueryUnescape(cookie.Value)
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unescaping cookie parameter '{{.ParamName}}'")
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            echo.NewHTTPError(http.StatusBadRequest, "Error unescaping cookie parameter '{{.ParamName}}'"))
     }
     err = json.Unmarshal([]byte(decoded), &value)
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON"))
     }
     params.{{.GoName}} = {{if not .Required}}&{{end}}value
     {{end}}
@@ -956,28 +1815,193 @@ func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
     var value {{.TypeDef}}
     err = runtime.BindStyledParameterWithLocation("simple",{{.Explode}}, "{{.ParamName}}", runtime.ParamLocationCookie, cookie.Value, &value)
     if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err)))
     }
     params.{{.GoName}} = {{if not .Required}}&{{end}}value
     {{end}}
     }{{if .Required}} else {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found"))
+        {{- if opts.ParamErrorResponses}}
+        return w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationCookie, nil),
+            echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found")))
+        {{- else}}
+        return runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationCookie, nil),
+            echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found")))
+        {{- end}}
     }{{end}}
 
 {{end}}{{/* .CookieParams */}}
 
 {{end}}{{/* .RequiresParamObject */}}
+{{- if and opts.ApplyDefaults .ParamsHaveDefaults}}
+    params.ApplyDefaults()
+{{- end}}
+{{- if and opts.ValidateMethods (hasValidateMethod (printf "%sParams" .OperationId))}}
+    if err := params.Validate(); err != nil {
+        bindErr := runtime.NewParamValidationError(err, {{genParamLocations .}})
+        {{- if opts.ParamErrorResponses}}
+        return w.paramError(ctx, "{{$opid}}", bindErr, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid parameter: %s", err)))
+        {{- else}}
+        return runtime.TranslateBindError(w.BindErrorTranslator, bindErr, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid parameter: %s", err)))
+        {{- end}}
+    }
+{{- end}}
+{{- if and opts.ServerDeadlines .Timeout}}
+    // The deadline of x-timeout, which is only set on the context, the
+    // handler isn't interrupted, and its errors after it are responded to
+    // with a 503
+    timeoutCtx, cancel := context.WithTimeout(ctx.Request().Context(), {{.TimeoutLiteral}})
+    defer cancel()
+    ctx.SetRequest(ctx.Request().WithContext(timeoutCtx))
+{{- end}}
     // Invoke the callback with all the unmarshalled arguments
     err = w.Handler.{{.OperationId}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}})
+{{- if and opts.ServerDeadlines .Timeout}}
+    if err != nil && timeoutCtx.Err() == context.DeadlineExceeded {
+        return echo.NewHTTPError(http.StatusServiceUnavailable, http.StatusText(http.StatusServiceUnavailable))
+    }
+{{- end}}
+{{- if opts.ServerRecovery}}
+    if err != nil {
+        return w.respond{{$opid}}Error(ctx, runtime.NewOperationError("{{$opid}}", err))
+    }
+{{- end}}
     return err
 }
+{{if opts.ServerRecovery}}
+// respond{{$opid}}Error responds to the errors of {{$opid}} with the
+// ErrorResponder, or else with a 500 response, except for echo's HTTP errors.
+func (w *ServerInterfaceWrapper) respond{{$opid}}Error(ctx {{echoContext}}, err *runtime.OperationError) error {
+    if w.ErrorResponder != nil {
+        return w.ErrorResponder(ctx, err)
+    }
+    var httpErr *echo.HTTPError
+    if errors.As(err, &httpErr) {
+        return httpErr
+    }
+{{- with .GetErrorResponse}}
+    {{.Build}}
+    return ctx.JSON(http.StatusInternalServerError, body)
+{{- else}}
+    return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+{{- end}}
+}
+{{end}}
+{{endregion "handler" .OperationId}}{{end}}
+`,
+	"echo5-register.tmpl": `
+
+// This is a simple interface which specifies the route addition functions
+// which are present on both echo.Echo and echo.Group of echo v5, since we
+// want to allow using either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) echo.RouteInfo
+}
+
+// EchoServerOptions provides options for the echo server.
+type EchoServerOptions struct {
+    BaseURL string
+    // RouteMiddlewares returns the middlewares of the route of an operation.
+    RouteMiddlewares func(route runtime.Route) []echo.MiddlewareFunc
+    // RouteName names the route of an operation, for OnRoute, since the
+    // routes which echo v5 registers can't be renamed.
+    RouteName func(route runtime.Route) string
+    // OnRoute is told of the route of each operation once it's registered.
+    OnRoute func(route runtime.Route)
+    // BindErrorTranslator translates the errors binding the parameters of
+    // requests, which are reported instead of the default ones, unless it's
+    // nil.
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    // ParamErrorBody builds the body of the 400 response to a request
+    // missing a required parameter of an operation, or returns nil for the
+    // usual error. It's DefaultParamErrorBody when nil.
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    // ErrorResponder responds to the errors and panics of the handlers,
+    // unless it's nil.
+    ErrorResponder ErrorResponder
+{{- end}}
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+    RegisterHandlersWithOptions(router, si, EchoServerOptions{})
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+    RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL})
+}
+{{if opts.ServerRecovery}}
+// RegisterHandlersWithErrorResponder registers handlers like
+// RegisterHandlersWithBaseURL, whose errors and panics are responded to by
+// responder, unless it's nil.
+func RegisterHandlersWithErrorResponder(router EchoRouter, si ServerInterface, baseURL string, responder ErrorResponder) {
+    RegisterHandlersWithOptions(router, si, EchoServerOptions{BaseURL: baseURL, ErrorResponder: responder})
+}
+{{end}}
+// RegisterHandlersWithOptions adds each server route to the EchoRouter, with
+// additional options.
+func RegisterHandlersWithOptions(router EchoRouter, si ServerInterface, options EchoServerOptions) {
+{{if .}}
+{{- if opts.ParamErrorResponses}}
+    if options.ParamErrorBody == nil {
+        options.ParamErrorBody = DefaultParamErrorBody
+    }
+{{- end}}
+    wrapper := ServerInterfaceWrapper{
+        Handler: si,
+        BindErrorTranslator: options.BindErrorTranslator,
+{{- if opts.ParamErrorResponses}}
+        ParamErrorBody: options.ParamErrorBody,
+{{- end}}
+{{- if opts.ServerRecovery}}
+        ErrorResponder: options.ErrorResponder,
+{{- end}}
+    }
+
+    register := func(route runtime.Route, add func(string, echo.HandlerFunc, ...echo.MiddlewareFunc) echo.RouteInfo, handler echo.HandlerFunc) {
+        route.BaseURL = options.BaseURL
+        route.RouterPath = options.BaseURL + route.RouterPath
+        if options.RouteName != nil {
+            route.Name = options.RouteName(route)
+        }
+        var middlewares []echo.MiddlewareFunc
+        if options.RouteMiddlewares != nil {
+            middlewares = options.RouteMiddlewares(route)
+        }
+        add(route.RouterPath, handler, middlewares...)
+        if options.OnRoute != nil {
+            options.OnRoute(route)
+        }
+    }
+{{end}}
+{{range .}}register(runtime.Route{OperationID: "{{.OperationId}}", Method: "{{.Method}}", Path: "{{.Path}}", RouterPath: "{{.Path | swaggerUriToEchoUri}}"}, router.{{.Method}}, wrapper.{{.OperationId}})
 {{end}}
+{{- if opts.DocsUI}}
+router.GET(options.BaseURL + "/openapi.json", echo.WrapHandler(http.HandlerFunc(ServeSpec)))
+router.GET(options.BaseURL + "/docs", echo.WrapHandler(http.HandlerFunc(ServeDocs)))
+{{- end}}
+}
 `,
 	"gin-interface.tmpl": `// ServerInterface represents all server handlers.
 type ServerInterface interface {
 {{range .}}{{.SummaryAsComment }}
 // ({{.Method}} {{.Path}})
-{{.OperationId}}(c *gin.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}})
+{{with .DeprecationComment}}//
+{{.}}
+{{end}}{{.OperationId}}(c *gin.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}})
 {{end}}
 }
 `,
@@ -985,6 +2009,28 @@ type ServerInterface interface {
 type GinServerOptions struct {
     BaseURL string
     Middlewares []MiddlewareFunc
+    // RouteMiddlewares returns the gin middlewares of the route of an
+    // operation, which run before its parameters are bound, and before the
+    // Middlewares.
+    RouteMiddlewares func(route runtime.Route) []gin.HandlerFunc
+    // RouteName names the route of an operation.
+    RouteName func(route runtime.Route) string
+    // OnRoute is told of the route of each operation once it's registered.
+    OnRoute func(route runtime.Route)
+    ErrorHandler func(*gin.Context, error, int)
+    // BindErrorTranslator translates the errors binding the parameters of
+    // requests, which are reported instead of the default ones, unless it's
+    // nil.
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    // ParamErrorBody builds the body of the 400 response to a request
+    // missing a required parameter of an operation, or returns nil for the
+    // usual error. It's DefaultParamErrorBody when nil.
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    ErrorResponder ErrorResponder
+{{- end}}
 }
 
 // RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
@@ -994,14 +2040,53 @@ func RegisterHandlers(router *gin.Engine, si ServerInterface) *gin.Engine {
 
 // RegisterHandlersWithOptions creates http.Handler with additional options
 func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options GinServerOptions) *gin.Engine {
-{{if .}}wrapper := ServerInterfaceWrapper{
+{{if .}}
+errorHandler := options.ErrorHandler
+if errorHandler == nil {
+    errorHandler = func(c *gin.Context, err error, statusCode int) {
+        c.JSON(statusCode, gin.H{"msg": err.Error()})
+    }
+}
+{{if opts.ParamErrorResponses}}
+if options.ParamErrorBody == nil {
+    options.ParamErrorBody = DefaultParamErrorBody
+}
+{{end}}
+wrapper := ServerInterfaceWrapper{
 Handler: si,
 HandlerMiddlewares: options.Middlewares,
+ErrorHandler: errorHandler,
+BindErrorTranslator: options.BindErrorTranslator,
+{{- if opts.ParamErrorResponses}}
+ParamErrorBody: options.ParamErrorBody,
+{{- end}}
+{{- if opts.ServerRecovery}}
+ErrorResponder: options.ErrorResponder,
+{{- end}}
+}
+
+register := func(route runtime.Route, handler gin.HandlerFunc) {
+    route.BaseURL = options.BaseURL
+    route.RouterPath = options.BaseURL + route.RouterPath
+    if options.RouteName != nil {
+        route.Name = options.RouteName(route)
+    }
+    var handlers []gin.HandlerFunc
+    if options.RouteMiddlewares != nil {
+        handlers = append(handlers, options.RouteMiddlewares(route)...)
+    }
+    router.Handle(route.Method, route.RouterPath, append(handlers, handler)...)
+    if options.OnRoute != nil {
+        options.OnRoute(route)
+    }
 }
 {{end}}
-{{range .}}
-router.{{.Method }}(options.BaseURL+"{{.Path | swaggerUriToGinUri }}", wrapper.{{.OperationId}})
+{{range .}}register(runtime.Route{OperationID: "{{.OperationId}}", Method: "{{.Method}}", Path: "{{.Path}}", RouterPath: "{{.Path | swaggerUriToGinUri}}"}, wrapper.{{.OperationId}})
 {{end}}
+{{- if opts.DocsUI}}
+router.GET(options.BaseURL+"/openapi.json", gin.WrapF(ServeSpec))
+router.GET(options.BaseURL+"/docs", gin.WrapF(ServeDocs))
+{{- end}}
 return router
 }
 `,
@@ -1009,36 +2094,80 @@ return router
 type ServerInterfaceWrapper struct {
     Handler ServerInterface
     HandlerMiddlewares []MiddlewareFunc
-}
+    ErrorHandler func(*gin.Context, error, int)
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    ErrorResponder ErrorResponder
+{{- end}}
+}
+{{if opts.ServerRecovery}}
+// ErrorResponder responds to the panics of the handlers.
+type ErrorResponder func(c *gin.Context, err *runtime.OperationError)
+{{end}}
 
 type MiddlewareFunc func(c *gin.Context)
+{{if opts.ParamErrorResponses}}
+// paramError responds to a request missing a required parameter of
+// operationID with a 400 and the body built by the ParamErrorBody, unless
+// it's nil. Otherwise, it passes err as translated by the
+// BindErrorTranslator, or defaultErr, to the ErrorHandler.
+func (siw *ServerInterfaceWrapper) paramError(c *gin.Context, operationID string, err *runtime.BindError, defaultErr error) {
+    if err.Kind == runtime.BindErrorRequired {
+        if body := siw.ParamErrorBody(operationID, err); body != nil {
+            c.JSON(http.StatusBadRequest, body)
+            return
+        }
+    }
+    siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, err, defaultErr), http.StatusBadRequest)
+}
+{{end}}
 
-{{range .}}{{$opid := .OperationId}}
+{{range .}}{{$opid := .OperationId}}{{region "handler" $opid}}
 
 // {{$opid}} operation middleware
 func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
+{{- if opts.ServerRecovery}}
+  defer func() {
+    if p := recover(); p != nil {
+      siw.respond{{$opid}}Error(c, runtime.RecoverOperation("{{$opid}}", p))
+    }
+  }()
+{{- end}}
 
   {{if or .RequiresParamObject (gt (len .PathParams) 0) }}
   var err error
+  _ = err // not every parameter is bound through err
   {{end}}
 
   {{range .PathParams}}// ------------- Path parameter "{{.ParamName}}" -------------
   var {{$varName := .GoVariableName}}{{$varName}} {{.TypeDef}}
+  {{- if opts.ParamErrorResponses}}
+  if c.Param("{{.ParamName}}") == "" {
+    siw.paramError(c, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationPath, nil),
+      fmt.Errorf("Path parameter {{.ParamName}} is required, but not found"))
+    return
+  }
+  {{- end}}
 
   {{if .IsPassThrough}}
   {{$varName}} = c.Query("{{.ParamName}}")
   {{end}}
   {{if .IsJson}}
-  err = json.Unmarshal([]byte(c.Query("{{.ParamName}}")), &{{$varName}})
+  err = runtime.BindJSONParameter("{{.ParamName}}", runtime.ParamLocationPath, c.Param("{{.ParamName}}"), &{{$varName}})
   if err != nil {
-    c.JSON(http.StatusBadRequest, gin.H{"msg": "Error unmarshaling parameter '{{.ParamName}}' as JSON"})
+    siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationPath, err),
+      fmt.Errorf("Error unmarshaling parameter '{{.ParamName}}' as JSON")), http.StatusBadRequest)
     return
   }
   {{end}}
   {{if .IsStyled}}
   err = runtime.BindStyledParameter("{{.Style}}",{{.Explode}}, "{{.ParamName}}", c.Param("{{.ParamName}}"), &{{$varName}})
   if err != nil {
-    c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err)})
+    siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationPath, err),
+      fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err)), http.StatusBadRequest)
     return
   }
   {{end}}
@@ -1064,36 +2193,54 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
         var value {{.TypeDef}}
         err = json.Unmarshal([]byte(paramValue), &value)
         if err != nil {
-          c.JSON(http.StatusBadRequest, gin.H{"msg": "Error unmarshaling parameter '{{.ParamName}}' as JSON"})
+          siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+            fmt.Errorf("Error unmarshaling parameter '{{.ParamName}}' as JSON")), http.StatusBadRequest)
           return
         }
 
         params.{{.GoName}} = {{if not .Required}}&{{end}}value
       {{end}}
       }{{if .Required}} else {
-          c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument {{.ParamName}} is required, but not found"})
+          {{- if opts.ParamErrorResponses}}
+          siw.paramError(c, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationQuery, nil),
+            fmt.Errorf("Query argument {{.ParamName}} is required, but not found"))
+          {{- else}}
+          siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationQuery, nil),
+            fmt.Errorf("Query argument {{.ParamName}} is required, but not found")), http.StatusBadRequest)
+          {{- end}}
           return
       }{{end}}
       {{if .IsStyled}}
       err = runtime.BindQueryParameter("{{.Style}}", {{.Explode}}, {{.Required}}, "{{.ParamName}}", c.Request.URL.Query(), &params.{{.GoName}})
       if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err)})
+        {{- if opts.ParamErrorResponses}}
+        siw.paramError(c, "{{$opid}}", runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+          fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err))
+        {{- else}}
+        siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+          fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err)), http.StatusBadRequest)
+        {{- end}}
         return
       }
       {{end}}
   {{end}}
 
     {{if .HeaderParams}}
-      headers := r.Header
+      headers := c.Request.Header
 
       {{range .HeaderParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} header parameter "{{.ParamName}}" -------------
         if valueList, found := headers[http.CanonicalHeaderKey("{{.ParamName}}")]; found {
           var {{.GoName}} {{.TypeDef}}
+{{- if .IsList}}
+          valueList = []string{strings.Join(valueList, ",")}
+{{- else}}
           n := len(valueList)
           if n != 1 {
-            c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Expected one value for {{.ParamName}}, got %d", n)})
+            siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+              fmt.Errorf("Expected one value for {{.ParamName}}, got %d", n)), http.StatusBadRequest)
             return
           }
+{{- end}}
 
         {{if .IsPassThrough}}
           params.{{.GoName}} = {{if not .Required}}&{{end}}valueList[0]
@@ -1102,7 +2249,8 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
         {{if .IsJson}}
           err = json.Unmarshal([]byte(valueList[0]), &{{.GoName}})
           if err != nil {
-            c.JSON(http.StatusBadRequest, gin.H{"msg": "Error unmarshaling parameter '{{.ParamName}}' as JSON"})
+            siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+              fmt.Errorf("Error unmarshaling parameter '{{.ParamName}}' as JSON")), http.StatusBadRequest)
             return
           }
         {{end}}
@@ -1110,7 +2258,8 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
         {{if .IsStyled}}
           err = runtime.BindStyledParameterWithLocation("{{.Style}}",{{.Explode}}, "{{.ParamName}}", runtime.ParamLocationHeader, valueList[0], &{{.GoName}})
           if err != nil {
-            c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err)})
+            siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+              fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err)), http.StatusBadRequest)
             return
           }
         {{end}}
@@ -1118,7 +2267,13 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
           params.{{.GoName}} = {{if not .Required}}&{{end}}{{.GoName}}
 
         } {{if .Required}}else {
-            c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Header parameter {{.ParamName}} is required, but not found: %s", err)})
+            {{- if opts.ParamErrorResponses}}
+            siw.paramError(c, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+              fmt.Errorf("Header parameter {{.ParamName}} is required, but not found"))
+            {{- else}}
+            siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+              fmt.Errorf("Header parameter {{.ParamName}} is required, but not found")), http.StatusBadRequest)
+            {{- end}}
             return
         }{{end}}
 
@@ -1126,9 +2281,7 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
     {{end}}
 
     {{range .CookieParams}}
-      var cookie *http.Cookie
-
-      if cookie, err = c.Cookie("{{.ParamName}}"); err == nil {
+      if cookie, err := c.Request.Cookie("{{.ParamName}}"); err == nil {
 
       {{- if .IsPassThrough}}
         params.{{.GoName}} = {{if not .Required}}&{{end}}cookie.Value
@@ -1139,13 +2292,15 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
         var decoded string
         decoded, err := url.QueryUnescape(cookie.Value)
         if err != nil {
-          c.JSON(http.StatusBadRequest, gin.H{"msg": "Error unescaping cookie parameter '{{.ParamName}}'"})
+          siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            fmt.Errorf("Error unescaping cookie parameter '{{.ParamName}}'")), http.StatusBadRequest)
           return
         }
 
         err = json.Unmarshal([]byte(decoded), &value)
         if err != nil {
-          c.JSON(http.StatusBadRequest, gin.H{"msg": "Error unmarshaling parameter '{{.ParamName}}' as JSON"})
+          siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            fmt.Errorf("Error unmarshaling parameter '{{.ParamName}}' as JSON")), http.StatusBadRequest)
           return
         }
 
@@ -1156,7 +2311,8 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
         var value {{.TypeDef}}
         err = runtime.BindStyledParameter("simple",{{.Explode}}, "{{.ParamName}}", cookie.Value, &value)
         if err != nil {
-          c.JSON(http.StatusBadRequest, gin.H{"msg": "Invalid format for parameter {{.ParamName}}: %s"})
+          siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err)), http.StatusBadRequest)
           return
         }
         params.{{.GoName}} = {{if not .Required}}&{{end}}value
@@ -1165,20 +2321,70 @@ func (siw *ServerInterfaceWrapper) {{$opid}}(c *gin.Context) {
       }
 
       {{- if .Required}} else {
-        c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument {{.ParamName}} is required, but not found"})
+        {{- if opts.ParamErrorResponses}}
+        siw.paramError(c, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationCookie, nil),
+          fmt.Errorf("Query argument {{.ParamName}} is required, but not found"))
+        {{- else}}
+        siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationCookie, nil),
+          fmt.Errorf("Query argument {{.ParamName}} is required, but not found")), http.StatusBadRequest)
+        {{- end}}
         return
       }
       {{- end}}
     {{end}}
+{{- if and opts.ValidateMethods (hasValidateMethod (printf "%sParams" .OperationId))}}
+  if err := params.Validate(); err != nil {
+    bindErr := runtime.NewParamValidationError(err, {{genParamLocations .}})
+    {{- if opts.ParamErrorResponses}}
+    siw.paramError(c, "{{$opid}}", bindErr, fmt.Errorf("Invalid parameter: %s", err))
+    {{- else}}
+    siw.ErrorHandler(c, runtime.TranslateBindError(siw.BindErrorTranslator, bindErr, fmt.Errorf("Invalid parameter: %s", err)), http.StatusBadRequest)
+    {{- end}}
+    return
+  }
+{{- end}}
   {{end}}
 
   for _, middleware := range siw.HandlerMiddlewares {
     middleware(c)
   }
+{{- if and opts.ApplyDefaults .ParamsHaveDefaults}}
+  params.ApplyDefaults()
+{{- end}}
+{{- if and opts.ServerDeadlines .Timeout}}
+
+  // The deadline of x-timeout, which is only set on the context, the
+  // handler isn't interrupted, and it's responded for with a 503 after it,
+  // unless it has responded
+  timeoutCtx, cancel := context.WithTimeout(c.Request.Context(), {{.TimeoutLiteral}})
+  defer cancel()
+  c.Request = c.Request.WithContext(timeoutCtx)
+{{- end}}
 
   siw.Handler.{{.OperationId}}(c{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}})
+{{- if and opts.ServerDeadlines .Timeout}}
+  if timeoutCtx.Err() == context.DeadlineExceeded && !c.Writer.Written() {
+    c.AbortWithStatus(http.StatusServiceUnavailable)
+  }
+{{- end}}
+}
+{{if opts.ServerRecovery}}
+// respond{{$opid}}Error responds to the panics of {{$opid}} with the
+// ErrorResponder, or else with a 500 response.
+func (siw *ServerInterfaceWrapper) respond{{$opid}}Error(c *gin.Context, err *runtime.OperationError) {
+    if siw.ErrorResponder != nil {
+        siw.ErrorResponder(c, err)
+        return
+    }
+{{- with .GetErrorResponse}}
+    {{.Build}}
+    c.JSON(http.StatusInternalServerError, body)
+{{- else}}
+    siw.ErrorHandler(c, err, http.StatusInternalServerError)
+{{- end}}
 }
 {{end}}
+{{endregion "handler" .OperationId}}{{end}}
 `,
 	"imports.tmpl": `// Package {{.PackageName}} provides primitives to interact with the openapi HTTP API.
 //
@@ -1187,10 +2393,23 @@ package {{.PackageName}}
 
 import (
 	"bytes"
+	{{- if and opts.EmbedSpec (not opts.EmbedSpecFile)}}
 	"compress/gzip"
+	{{- end}}
 	"context"
+	{{- if opts.EnumSQL}}
+	"database/sql"
+	"database/sql/driver"
+	{{- end}}
+	{{- if and opts.EmbedSpec opts.EmbedSpecFile}}
+	_ "embed"
+	{{- end}}
 	"encoding/base64"
+	{{- if opts.JSONPackage}}
+	json "{{opts.JSONPackage}}"
+	{{- else}}
 	"encoding/json"
+	{{- end}}
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -1201,19 +2420,31 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	{{- if opts.EmbedSpec}}
 	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/go-chi/chi/v5"
-	"github.com/labstack/echo/v4"
+	{{- end}}
 	{{- range .ExternalImports}}
 	{{ . }}
 	{{- end}}
 )
 `,
-	"inline.tmpl": `// Base64 encoded, gzipped, json marshaled Swagger object
+	"inline.tmpl": `{{- if .EmbedFile}}
+// swaggerSpec is the json marshaled Swagger object, embedded from {{.EmbedFile}}
+//go:embed {{.EmbedFile}}
+var swaggerSpec []byte
+
+// decodeSpec returns the content of the embedded swagger specification file
+func decodeSpec() ([]byte, error) {
+    return swaggerSpec, nil
+}
+{{- else}}
+// Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 {{range .SpecParts}}
     "{{.}}",{{end}}
@@ -1238,15 +2469,21 @@ func decodeSpec() ([]byte, error) {
 
     return buf.Bytes(), nil
 }
+{{- end}}
 
-var rawSpec = decodeSpecCached()
+var (
+    rawSpecOnce sync.Once
+    rawSpecData []byte
+    rawSpecErr  error
+)
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
-	}
+// rawSpec returns the decoded swagger specification, which is only decoded
+// the first time it's needed, and cached
+func rawSpec() ([]byte, error) {
+    rawSpecOnce.Do(func() {
+        rawSpecData, rawSpecErr = decodeSpec()
+    })
+    return rawSpecData, rawSpecErr
 }
 
 // Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
@@ -1301,25 +2538,953 @@ func GetSwagger() (swagger *openapi3.T, err error) {
     return
 }
 `,
-	"param-types.tmpl": `{{range .}}{{$opid := .OperationId}}
-{{range .TypeDefinitions}}
-// {{.TypeName}} defines parameters for {{$opid}}.
-type {{.TypeName}} {{if and (opts.AliasTypes) (.CanAlias)}}={{end}} {{.Schema.TypeDecl}}
-{{end}}
+	"iris-interface.tmpl": `// ServerInterface represents all server handlers.
+type ServerInterface interface {
+{{range .}}{{.SummaryAsComment }}
+// ({{.Method}} {{.Path}})
+{{with .DeprecationComment}}//
+{{.}}
+{{end}}{{.OperationId}}(ctx iris.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}})
 {{end}}
+}
 `,
-	"request-bodies.tmpl": `{{range .}}{{$opid := .OperationId}}
-{{range .Bodies}}
-{{with .TypeDef $opid}}
-// {{.TypeName}} defines body for {{$opid}} for application/json ContentType.
-type {{.TypeName}} {{if and (opts.AliasTypes) (.CanAlias)}}={{end}} {{.Schema.TypeDecl}}
+	"iris-register.tmpl": `// IrisServerOptions provides options for the iris server.
+type IrisServerOptions struct {
+    BaseURL string
+    // Middlewares run before the handlers, in order, each calling ctx.Next.
+    Middlewares []iris.Handler
+    // RouteMiddlewares returns the middlewares of the route of an operation,
+    // which run after the Middlewares.
+    RouteMiddlewares func(route runtime.Route) []iris.Handler
+    // RouteName names the route of an operation, which it's registered
+    // under, for iris.Party.GetRoute and the URL helpers of iris.
+    RouteName func(route runtime.Route) string
+    // OnRoute is told of the route of each operation once it's registered.
+    OnRoute func(route runtime.Route)
+    ErrorHandler func(ctx iris.Context, err error, statusCode int)
+    // BindErrorTranslator translates the errors binding the parameters of
+    // requests, which are reported instead of the default ones, unless it's
+    // nil.
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    // ParamErrorBody builds the body of the 400 response to a request
+    // missing a required parameter of an operation, or returns nil for the
+    // usual error. It's DefaultParamErrorBody when nil.
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    ErrorResponder ErrorResponder
+{{- end}}
+}
+
+// RegisterHandlers adds each server route to the iris router, which can be
+// an *iris.Application or any of its parties.
+func RegisterHandlers(router iris.Party, si ServerInterface) {
+    RegisterHandlersWithOptions(router, si, IrisServerOptions{})
+}
+
+// RegisterHandlersWithOptions adds each server route to the iris router, with
+// additional options.
+func RegisterHandlersWithOptions(router iris.Party, si ServerInterface, options IrisServerOptions) {
+{{if .}}
+errorHandler := options.ErrorHandler
+if errorHandler == nil {
+    errorHandler = func(ctx iris.Context, err error, statusCode int) {
+        ctx.StopWithError(statusCode, err)
+    }
+}
+{{if opts.ParamErrorResponses}}
+if options.ParamErrorBody == nil {
+    options.ParamErrorBody = DefaultParamErrorBody
+}
 {{end}}
+wrapper := ServerInterfaceWrapper{
+Handler: si,
+ErrorHandler: errorHandler,
+BindErrorTranslator: options.BindErrorTranslator,
+{{- if opts.ParamErrorResponses}}
+ParamErrorBody: options.ParamErrorBody,
+{{- end}}
+{{- if opts.ServerRecovery}}
+ErrorResponder: options.ErrorResponder,
+{{- end}}
+}
+
+register := func(route runtime.Route, handler iris.Handler) {
+    route.BaseURL = options.BaseURL
+    route.RouterPath = options.BaseURL + route.RouterPath
+    if options.RouteName != nil {
+        route.Name = options.RouteName(route)
+    }
+    handlers := append([]iris.Handler{}, options.Middlewares...)
+    if options.RouteMiddlewares != nil {
+        handlers = append(handlers, options.RouteMiddlewares(route)...)
+    }
+    if added := router.Handle(route.Method, route.RouterPath, append(handlers, handler)...); route.Name != "" {
+        added.Name = route.Name
+    }
+    if options.OnRoute != nil {
+        options.OnRoute(route)
+    }
+}
 {{end}}
+{{range .}}register(runtime.Route{OperationID: "{{.OperationId}}", Method: "{{.Method}}", Path: "{{.Path}}", RouterPath: "{{.Path | swaggerUriToIrisUri}}"}, wrapper.{{.OperationId}})
 {{end}}
+{{- if opts.DocsUI}}
+router.Handle("GET", options.BaseURL+"/openapi.json", iris.FromStd(ServeSpec))
+router.Handle("GET", options.BaseURL+"/docs", iris.FromStd(ServeDocs))
+{{- end}}
+}
 `,
-	"typedef.tmpl": `{{range .Types}}
-{{ with .Schema.Description }}{{ . }}{{ else }}// {{.TypeName}} defines model for {{.JsonName}}.{{ end }}
-type {{.TypeName}} {{if and (opts.AliasTypes) (.CanAlias)}}={{end}} {{.Schema.TypeDecl}}
+	"iris-wrappers.tmpl": `// ServerInterfaceWrapper converts iris contexts to parameters.
+type ServerInterfaceWrapper struct {
+    Handler ServerInterface
+    ErrorHandler func(ctx iris.Context, err error, statusCode int)
+    BindErrorTranslator runtime.BindErrorTranslator
+{{- if opts.ParamErrorResponses}}
+    ParamErrorBody func(operationID string, err *runtime.BindError) interface{}
+{{- end}}
+{{- if opts.ServerRecovery}}
+    ErrorResponder ErrorResponder
+{{- end}}
+}
+{{if opts.ServerRecovery}}
+// ErrorResponder responds to the panics of the handlers.
+type ErrorResponder func(ctx iris.Context, err *runtime.OperationError)
+{{end}}
+{{- if opts.ParamErrorResponses}}
+// paramError responds to a request missing a required parameter of
+// operationID with a 400 and the body built by the ParamErrorBody, unless
+// it's nil. Otherwise, it passes err as translated by the
+// BindErrorTranslator, or defaultErr, to the ErrorHandler.
+func (w *ServerInterfaceWrapper) paramError(ctx iris.Context, operationID string, err *runtime.BindError, defaultErr error) {
+    if err.Kind == runtime.BindErrorRequired {
+        if body := w.ParamErrorBody(operationID, err); body != nil {
+            ctx.StopWithJSON(http.StatusBadRequest, body)
+            return
+        }
+    }
+    w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, err, defaultErr), http.StatusBadRequest)
+}
+{{end}}
+
+{{range .}}{{$opid := .OperationId}}{{region "handler" $opid}}// {{$opid}} converts iris context to params.
+func (w *ServerInterfaceWrapper) {{$opid}}(ctx iris.Context) {
+{{- if opts.ServerRecovery}}
+    defer func() {
+        if p := recover(); p != nil {
+            w.respond{{$opid}}Error(ctx, runtime.RecoverOperation("{{$opid}}", p))
+        }
+    }()
+{{- end}}
+{{if or .RequiresParamObject (gt (len .PathParams) 0) }}
+    var err error
+    _ = err // not every parameter is bound through err
+{{end}}
+{{range .PathParams}}// ------------- Path parameter "{{.ParamName}}" -------------
+    var {{$varName := .GoVariableName}}{{$varName}} {{.TypeDef}}
+{{- if opts.ParamErrorResponses}}
+    if ctx.Params().Get("{{.ParamName}}") == "" {
+        w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationPath, nil),
+            fmt.Errorf("Path parameter {{.ParamName}} is required, but not found"))
+        return
+    }
+{{- end}}
+{{if .IsPassThrough}}
+    {{$varName}} = ctx.Params().Get("{{.ParamName}}")
+{{end}}
+{{if .IsJson}}
+    err = runtime.BindJSONParameter("{{.ParamName}}", runtime.ParamLocationPath, ctx.Params().Get("{{.ParamName}}"), &{{$varName}})
+    if err != nil {
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationPath, err),
+            fmt.Errorf("Error unmarshaling parameter '{{.ParamName}}' as JSON")), http.StatusBadRequest)
+        return
+    }
+{{end}}
+{{if .IsStyled}}
+    err = runtime.BindStyledParameterWithLocation("{{.Style}}",{{.Explode}}, "{{.ParamName}}", runtime.ParamLocationPath, ctx.Params().Get("{{.ParamName}}"), &{{$varName}})
+    if err != nil {
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationPath, err),
+            fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err)), http.StatusBadRequest)
+        return
+    }
+{{end}}
+{{end}}
+
+{{range .SecurityDefinitions}}
+    ctx.Values().Set({{.ProviderName | sanitizeGoIdentity | ucFirst}}Scopes, {{toStringArray .Scopes}})
+{{end}}
+
+{{if .RequiresParamObject}}
+    // Parameter object where we will unmarshal all parameters from the context
+    var params {{.OperationId}}Params
+{{range $paramIdx, $param := .QueryParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} query parameter "{{.ParamName}}" -------------
+    {{if .IsStyled}}
+    err = runtime.BindQueryParameter("{{.Style}}", {{.Explode}}, {{.Required}}, "{{.ParamName}}", ctx.Request().URL.Query(), &params.{{.GoName}})
+    if err != nil {
+        {{- if opts.ParamErrorResponses}}
+        w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+            fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err))
+        {{- else}}
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+            fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err)), http.StatusBadRequest)
+        {{- end}}
+        return
+    }
+    {{else}}
+    if paramValue := ctx.Request().URL.Query().Get("{{.ParamName}}"); paramValue != "" {
+    {{if .IsPassThrough}}
+    params.{{.GoName}} = {{if not .Required}}&{{end}}paramValue
+    {{end}}
+    {{if .IsJson}}
+    var value {{.TypeDef}}
+    err = json.Unmarshal([]byte(paramValue), &value)
+    if err != nil {
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationQuery, err),
+            fmt.Errorf("Error unmarshaling parameter '{{.ParamName}}' as JSON")), http.StatusBadRequest)
+        return
+    }
+    params.{{.GoName}} = {{if not .Required}}&{{end}}value
+    {{end}}
+    }{{if .Required}} else {
+        {{- if opts.ParamErrorResponses}}
+        w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationQuery, nil),
+            fmt.Errorf("Query argument {{.ParamName}} is required, but not found"))
+        {{- else}}
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationQuery, nil),
+            fmt.Errorf("Query argument {{.ParamName}} is required, but not found")), http.StatusBadRequest)
+        {{- end}}
+        return
+    }{{end}}
+    {{end}}
+{{end}}
+
+{{if .HeaderParams}}
+    headers := ctx.Request().Header
+{{range .HeaderParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} header parameter "{{.ParamName}}" -------------
+    if valueList, found := headers[http.CanonicalHeaderKey("{{.ParamName}}")]; found {
+        var {{.GoName}} {{.TypeDef}}
+{{- if .IsList}}
+        valueList = []string{strings.Join(valueList, ",")}
+{{- else}}
+        n := len(valueList)
+        if n != 1 {
+            w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorTooManyValues, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+                fmt.Errorf("Expected one value for {{.ParamName}}, got %d", n)), http.StatusBadRequest)
+            return
+        }
+{{- end}}
+{{if .IsPassThrough}}
+        params.{{.GoName}} = {{if not .Required}}&{{end}}valueList[0]
+{{end}}
+{{if .IsJson}}
+        err = json.Unmarshal([]byte(valueList[0]), &{{.GoName}})
+        if err != nil {
+            w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+                fmt.Errorf("Error unmarshaling parameter '{{.ParamName}}' as JSON")), http.StatusBadRequest)
+            return
+        }
+{{end}}
+{{if .IsStyled}}
+        err = runtime.BindStyledParameterWithLocation("{{.Style}}",{{.Explode}}, "{{.ParamName}}", runtime.ParamLocationHeader, valueList[0], &{{.GoName}})
+        if err != nil {
+            w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationHeader, err),
+                fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err)), http.StatusBadRequest)
+            return
+        }
+{{end}}
+        params.{{.GoName}} = {{if not .Required}}&{{end}}{{.GoName}}
+        } {{if .Required}}else {
+            {{- if opts.ParamErrorResponses}}
+            w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+                fmt.Errorf("Header parameter {{.ParamName}} is required, but not found"))
+            {{- else}}
+            w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationHeader, nil),
+                fmt.Errorf("Header parameter {{.ParamName}} is required, but not found")), http.StatusBadRequest)
+            {{- end}}
+            return
+        }{{end}}
+{{end}}
+{{end}}
+
+{{range .CookieParams}}
+    if cookie, cookieErr := ctx.Request().Cookie("{{.ParamName}}"); cookieErr == nil {
+    {{if .IsPassThrough}}
+    params.{{.GoName}} = {{if not .Required}}&{{end}}cookie.Value
+    {{end}}
+    {{if .IsJson}}
+    var value {{.TypeDef}}
+    var decoded string
+    decoded, err = url.QueryUnescape(cookie.Value)
+    if err != nil {
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            fmt.Errorf("Error unescaping cookie parameter '{{.ParamName}}'")), http.StatusBadRequest)
+        return
+    }
+    err = json.Unmarshal([]byte(decoded), &value)
+    if err != nil {
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorType, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            fmt.Errorf("Error unmarshaling parameter '{{.ParamName}}' as JSON")), http.StatusBadRequest)
+        return
+    }
+    params.{{.GoName}} = {{if not .Required}}&{{end}}value
+    {{end}}
+    {{if .IsStyled}}
+    var value {{.TypeDef}}
+    err = runtime.BindStyledParameterWithLocation("simple",{{.Explode}}, "{{.ParamName}}", runtime.ParamLocationCookie, cookie.Value, &value)
+    if err != nil {
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorFormat, "{{.ParamName}}", runtime.ParamLocationCookie, err),
+            fmt.Errorf("Invalid format for parameter {{.ParamName}}: %s", err)), http.StatusBadRequest)
+        return
+    }
+    params.{{.GoName}} = {{if not .Required}}&{{end}}value
+    {{end}}
+    }{{if .Required}} else {
+        {{- if opts.ParamErrorResponses}}
+        w.paramError(ctx, "{{$opid}}", runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationCookie, nil),
+            fmt.Errorf("Cookie parameter {{.ParamName}} is required, but not found"))
+        {{- else}}
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, runtime.NewBindError(runtime.BindErrorRequired, "{{.ParamName}}", runtime.ParamLocationCookie, nil),
+            fmt.Errorf("Cookie parameter {{.ParamName}} is required, but not found")), http.StatusBadRequest)
+        {{- end}}
+        return
+    }{{end}}
+
+{{end}}{{/* .CookieParams */}}
+
+{{end}}{{/* .RequiresParamObject */}}
+{{- if and opts.ApplyDefaults .ParamsHaveDefaults}}
+    params.ApplyDefaults()
+{{- end}}
+{{- if and opts.ValidateMethods (hasValidateMethod (printf "%sParams" .OperationId))}}
+    if err := params.Validate(); err != nil {
+        bindErr := runtime.NewParamValidationError(err, {{genParamLocations .}})
+        {{- if opts.ParamErrorResponses}}
+        w.paramError(ctx, "{{$opid}}", bindErr, fmt.Errorf("Invalid parameter: %s", err))
+        {{- else}}
+        w.ErrorHandler(ctx, runtime.TranslateBindError(w.BindErrorTranslator, bindErr, fmt.Errorf("Invalid parameter: %s", err)), http.StatusBadRequest)
+        {{- end}}
+        return
+    }
+{{- end}}
+{{- if and opts.ServerDeadlines .Timeout}}
+    // The deadline of x-timeout, which is only set on the context, the
+    // handler isn't interrupted, and it's responded for with a 503 after it,
+    // unless it has responded
+    timeoutCtx, cancel := context.WithTimeout(ctx.Request().Context(), {{.TimeoutLiteral}})
+    defer cancel()
+    ctx.ResetRequest(ctx.Request().WithContext(timeoutCtx))
+{{- end}}
+    // Invoke the callback with all the unmarshalled arguments
+    w.Handler.{{.OperationId}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}})
+{{- if and opts.ServerDeadlines .Timeout}}
+    if timeoutCtx.Err() == context.DeadlineExceeded && ctx.ResponseWriter().Written() < 0 {
+        ctx.StopWithStatus(http.StatusServiceUnavailable)
+    }
+{{- end}}
+}
+{{if opts.ServerRecovery}}
+// respond{{$opid}}Error responds to the panics of {{$opid}} with the
+// ErrorResponder, or else with a 500 response.
+func (w *ServerInterfaceWrapper) respond{{$opid}}Error(ctx iris.Context, err *runtime.OperationError) {
+    if w.ErrorResponder != nil {
+        w.ErrorResponder(ctx, err)
+        return
+    }
+{{- with .GetErrorResponse}}
+    {{.Build}}
+    ctx.StopWithJSON(http.StatusInternalServerError, body)
+{{- else}}
+    w.ErrorHandler(ctx, err, http.StatusInternalServerError)
+{{- end}}
+}
+{{end}}
+{{endregion "handler" .OperationId}}{{end}}
+`,
+	"nullable.tmpl": `// Nullable is a runtime.Nullable which is encoded with the JSON package of
+// the rest of the generated code, rather than encoding/json.
+type Nullable[T any] runtime.Nullable[T]
+
+// NewNullable returns a Nullable which is set to value.
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T](runtime.NewNullable(value))
+}
+
+// NewNullNullable returns a Nullable which is explicitly null.
+func NewNullNullable[T any]() Nullable[T] {
+	return Nullable[T](runtime.NewNullNullable[T]())
+}
+
+// IsSpecified tells whether the value is present, either null or set.
+func (n Nullable[T]) IsSpecified() bool {
+	return runtime.Nullable[T](n).IsSpecified()
+}
+
+// IsNull tells whether the value is explicitly null.
+func (n Nullable[T]) IsNull() bool {
+	return runtime.Nullable[T](n).IsNull()
+}
+
+// Get returns the value, and whether it's set.
+func (n Nullable[T]) Get() (T, bool) {
+	return runtime.Nullable[T](n).Get()
+}
+
+// Set sets the value.
+func (n *Nullable[T]) Set(value T) {
+	*n = NewNullable(value)
+}
+
+// SetNull makes the value explicitly null.
+func (n *Nullable[T]) SetNull() {
+	*n = NewNullNullable[T]()
+}
+
+// Unset makes the value absent.
+func (n *Nullable[T]) Unset() {
+	*n = nil
+}
+
+// MarshalJSON encodes the value, or null.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if value, set := n.Get(); set {
+		return json.Marshal(value)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the value, or null.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.SetNull()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Set(value)
+	return nil
+}
+`,
+	"param-errors.tmpl": `// DefaultParamErrorBody builds the body of the 400 response of the server to
+// a request missing a required parameter of an operation, unless the
+// ParamErrorBody of the server options is set. It fills the operation's 400,
+// or else default, JSON response, when it's a named object with a string
+// "message" property, and returns nil otherwise, for the router's usual
+// error.
+func DefaultParamErrorBody(operationID string, err *runtime.BindError) interface{} {
+    switch operationID {
+{{- range .}}{{$opid := .OperationId}}
+{{- with .GetParamErrorResponse}}
+    case "{{$opid}}":
+        {{.Build -}}
+        return body
+{{- end}}
+{{- end}}
+    }
+    return nil
+}
+`,
+	"param-types.tmpl": `{{range .}}{{$opid := .OperationId}}
+{{range .TypeDefinitions}}
+// {{.TypeName}} defines parameters for {{$opid}}.
+type {{.TypeName}} {{if and (opts.AliasTypes) (.CanAlias)}}={{end}} {{.Schema.TypeDecl}}
+{{end}}
+{{end}}
+`,
+	"params-builders.tmpl": `{{if .Patterns}}
+// paramsPatterns are the regular expressions of the patterns of the
+// parameters, which the With methods of the Params types match strings with.
+var paramsPatterns = []*regexp.Regexp{
+{{- range .Patterns}}
+    regexp.MustCompile({{printf "%q" .}}),
+{{- end}}
+}
+{{end}}
+{{range .Types}}
+{{$typeName := .TypeName -}}
+// New{{.TypeName}} returns parameters of {{.OperationId}}, without any set, which
+// its With methods set.
+func New{{.TypeName}}() *{{.TypeName}} {
+    return &{{.TypeName}}{}
+}
+
+// BuildError returns the errors of the invalid values which the With methods
+// set, as runtime.ValidationErrors, or nil when they're all valid. Requests
+// with these parameters fail with it.
+func (p *{{.TypeName}}) BuildError() error {
+    if p == nil || p.errs == nil {
+        return nil
+    }
+    return *p.errs
+}
+{{range .Setters}}
+// With{{.FieldName}} sets the {{.ParamName}} parameter.{{if .Check}} An invalid value is set too,
+// and its error is kept for BuildError.{{end}}
+func (p *{{$typeName}}) With{{.FieldName}}(value {{.GoType}}) *{{$typeName}} {
+{{- if .Check}}
+    if err := func(v {{.GoType}}) error {
+{{.Check}}
+        return nil
+    }(value); err != nil {
+        if p.errs == nil {
+            p.errs = &runtime.ValidationErrors{}
+        }
+        *p.errs = append(*p.errs, err)
+    }
+{{- end}}
+    p.{{.FieldName}} = {{if .Pointer}}&{{end}}value
+    return p
+}
+{{end}}
+{{end}}
+`,
+	"request-bodies.tmpl": `{{range .}}{{$opid := .OperationId}}
+{{range .Bodies}}{{$contentType := .ContentType}}
+{{with .TypeDef $opid}}
+// {{.TypeName}} defines body for {{$opid}} for {{$contentType}} ContentType.
+type {{.TypeName}} {{if and (opts.AliasTypes) (.CanAlias)}}={{end}} {{.Schema.TypeDecl}}
+{{end}}
+{{end}}
+{{end}}
+`,
+	"server-responses.tmpl": `{{range .}}{{$opid := .OperationId}}
+{{with .GetServerResponses}}
+// {{$opid}}ResponseObject is any of the responses of {{$opid}}, which its
+// handler sends with WriteResponse.
+type {{$opid}}ResponseObject interface {
+    WriteResponse(w http.ResponseWriter) error
+    is{{$opid}}Response()
+}
+{{end}}
+{{range .GetServerResponses}}
+// {{.TypeName}} is the {{.ResponseName}} response of {{$opid}}.
+type {{.TypeName}} struct {
+{{- if .HasStatusCode}}
+    // StatusCode is the status of the response, use New{{.TypeName}} to
+    // check that it's a {{.ResponseName}} status.
+    StatusCode int
+{{- end}}
+{{- if .IsJSON}}
+    Body {{.Schema.TypeDecl}}
+{{- end}}
+{{- if .HasRawBody}}
+    ContentType string
+    Body io.Reader
+{{- end}}
+}
+{{if .HasStatusCode}}
+// New{{.TypeName}} returns a {{.ResponseName}} response of {{$opid}}, it fails
+// when the status code isn't one.
+func New{{.TypeName}}(statusCode int{{if .IsJSON}}, body {{.Schema.TypeDecl}}{{end}}{{if .HasRawBody}}, contentType string, body io.Reader{{end}}) ({{.TypeName}}, error) {
+    response := {{.TypeName}}{
+        StatusCode: statusCode,
+{{- if .IsJSON}}
+        Body: body,
+{{- end}}
+{{- if .HasRawBody}}
+        ContentType: contentType,
+        Body: body,
+{{- end}}
+    }
+    if !({{.StatusCondition "statusCode"}}) {
+        return response, fmt.Errorf("status %d is not a {{.ResponseName}} response of {{$opid}}", statusCode)
+    }
+    return response, nil
+}
+{{end}}
+func ({{.TypeName}}) is{{$opid}}Response() {}
+
+// WriteResponse writes the response with w, which can be the response writer
+// of any router.
+func (response {{.TypeName}}) WriteResponse(w http.ResponseWriter) error {
+{{- if .HasStatusCode}}
+    statusCode := response.StatusCode
+    if !({{.StatusCondition "statusCode"}}) {
+        return fmt.Errorf("status %d is not a {{.ResponseName}} response of {{$opid}}", statusCode)
+    }
+{{- else}}
+    statusCode := {{.ResponseName}}
+{{- end}}
+{{- if .IsJSON}}
+    w.Header().Set("Content-Type", "{{.ContentType}}")
+    w.WriteHeader(statusCode)
+    return json.NewEncoder(w).Encode(response.Body)
+{{- else if .HasRawBody}}
+    if response.ContentType != "" {
+        w.Header().Set("Content-Type", response.ContentType)
+    }
+    w.WriteHeader(statusCode)
+    if response.Body == nil {
+        return nil
+    }
+    _, err := io.Copy(w, response.Body)
+    return err
+{{- else}}
+    w.WriteHeader(statusCode)
+    return nil
+{{- end}}
+}
+{{end}}
+{{end}}
+`,
+	"servers.tmpl": `{{if .}}
+// Server URLs declared in the OpenAPI specification. They may contain
+// {variable} placeholders, which are filled in by WithServerVariables.
+const (
+{{range .}}{{with .Comment}}{{.}}
+{{end}}    {{.ConstName}} = {{printf "%q" .URL}}
+{{end}}
+)
+
+// serverVariables holds the variables declared for each server URL.
+var serverVariables = map[string]map[string]runtime.ServerVariable{
+{{range .}}    {{.ConstName}}: {
+{{range .Variables}}        {{printf "%q" .Name}}: {Default: {{printf "%q" .Default}}{{if .Enum}}, Enum: {{toStringArray .Enum}}{{end}}},
+{{end}}    },
+{{end}}
+}
+
+// WithServerVariables substitutes the {variable} placeholders in the server
+// URLs. Variables which aren't given take the default value from the spec, and
+// values are validated against the enums declared in the spec.
+func WithServerVariables(variables map[string]string) ClientOption {
+	return func(c *Client) error {
+		server, err := runtime.SubstituteServerVariables(c.Server, runtime.LookupServerVariables(serverVariables, c.Server), variables)
+		if err != nil {
+			return err
+		}
+		c.Server = server
+		for i, server := range c.Servers {
+			if c.Servers[i], err = runtime.SubstituteServerVariables(server, runtime.LookupServerVariables(serverVariables, server), variables); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+{{end}}
+`,
+	"time-format.tmpl": `{{range .Types}}{{$layout := .Schema.TimeFormat}}
+// TimeLayout returns the layout of the time of {{.TypeName}}.
+func (t {{.TypeName}}) TimeLayout() string {
+	return {{printf "%q" $layout}}
+}
+
+// MarshalJSON encodes the time of {{.TypeName}} in its layout.
+func (t {{.TypeName}}) MarshalJSON() ([]byte, error) {
+	return openapi_types.MarshalTimeJSON(t.Time, t.TimeLayout())
+}
+
+// UnmarshalJSON decodes the time of {{.TypeName}} in its layout.
+func (t *{{.TypeName}}) UnmarshalJSON(data []byte) error {
+	parsed, err := openapi_types.UnmarshalTimeJSON(data, t.TimeLayout())
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// MarshalText encodes the time of {{.TypeName}} in its layout, for XML and
+// parameters.
+func (t {{.TypeName}}) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes the time of {{.TypeName}} in its layout.
+func (t *{{.TypeName}}) UnmarshalText(data []byte) error {
+	parsed, err := openapi_types.ParseTime(string(data), t.TimeLayout())
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// Bind binds parameters of {{.TypeName}}.
+func (t *{{.TypeName}}) Bind(src string) error {
+	return t.UnmarshalText([]byte(src))
+}
+
+func (t {{.TypeName}}) String() string {
+	return openapi_types.FormatTime(t.Time, t.TimeLayout())
+}
+{{end}}
+`,
+	"type-aliases.tmpl": `{{- if .Types}}
+// The types of the spec are declared in the types package.
+type (
+{{range .Types}}    {{.}} = {{$.Package}}.{{.}}
+{{end -}}
+)
+{{end}}
+{{- if .Constants}}
+// The constants of the spec are declared in the types package.
+const (
+{{range .Constants}}    {{.}} = {{$.Package}}.{{.}}
+{{end -}}
+)
+{{end}}
+`,
+	"typedef.tmpl": `{{range .Types}}
+{{ with .Schema.Description }}{{ . }}{{ else }}// {{.TypeName}} defines model for {{.JsonName}}.{{ end }}
+type {{.TypeName}} {{if and (opts.AliasTypes) (.CanAlias)}}={{end}} {{.Schema.TypeDecl}}
+{{end}}
+`,
+	"urls.tmpl": `{{range .}}
+// {{.OperationId}}Route is the route of {{.OperationId}}, as in the spec.
+const {{.OperationId}}Route = "{{.Path}}"
+{{end}}
+
+{{range .}}
+{{$hasParams := .RequiresParamObject -}}
+{{$pathParams := .PathParams -}}
+{{$opid := .OperationId -}}
+
+// Build{{$opid}}URL builds the URL of {{$opid}} on server, serializing its
+// path and query parameters like the client does.
+{{with .DeprecationComment}}//
+{{.}}
+{{end}}func Build{{$opid}}URL(server string{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}) (*url.URL, error) {
+    var err error
+{{range $paramIdx, $param := .PathParams}}
+    var pathParam{{$paramIdx}} string
+    {{if .IsPassThrough}}
+    pathParam{{$paramIdx}} = {{.GoVariableName}}
+    {{end}}
+    {{if .IsJson}}
+    var pathParamBuf{{$paramIdx}} []byte
+    pathParamBuf{{$paramIdx}}, err = json.Marshal({{.GoVariableName}})
+    if err != nil {
+        return nil, err
+    }
+    pathParam{{$paramIdx}} = url.PathEscape(string(pathParamBuf{{$paramIdx}}))
+    {{end}}
+    {{if .IsStyled}}
+    pathParam{{$paramIdx}}, err = runtime.StyleParamWithLocation("{{.Style}}", {{.Explode}}, "{{.ParamName}}", runtime.ParamLocationPath, {{.GoVariableName}})
+    if err != nil {
+        return nil, err
+    }
+    {{end}}
+{{end}}
+    serverURL, err := url.Parse(server)
+    if err != nil {
+        return nil, err
+    }
+
+    operationPath := fmt.Sprintf("{{genParamFmtString .Path}}"{{range $paramIdx, $param := .PathParams}}, pathParam{{$paramIdx}}{{end}})
+    if operationPath[0] == '/' {
+        operationPath = "." + operationPath
+    }
+
+    queryURL, err := serverURL.Parse(operationPath)
+    if err != nil {
+        return nil, err
+    }
+
+{{if .QueryParams}}
+    queryValues := queryURL.Query()
+{{range $paramIdx, $param := .QueryParams}}
+    {{if not .Required}} if params.{{.GoName}} != nil { {{end}}
+    {{if .IsPassThrough}}
+    queryValues.Add("{{.ParamName}}", {{if not .Required}}*{{end}}params.{{.GoName}})
+    {{end}}
+    {{if .IsJson}}
+    if queryParamBuf, err := json.Marshal({{if not .Required}}*{{end}}params.{{.GoName}}); err != nil {
+        return nil, err
+    } else {
+        queryValues.Add("{{.ParamName}}", string(queryParamBuf))
+    }
+
+    {{end}}
+    {{if .IsStyled}}
+    if queryFrag, err := runtime.StyleParamWithLocation("{{.Style}}", {{.Explode}}, "{{.ParamName}}", runtime.ParamLocationQuery, {{if not .Required}}*{{end}}params.{{.GoName}}); err != nil {
+        return nil, err
+    } else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+       return nil, err
+    } else {
+       for k, v := range parsed {
+           for _, v2 := range v {
+               queryValues.Add(k, v2)
+           }
+       }
+    }
+    {{end}}
+    {{if not .Required}}}{{end}}
+{{end}}
+    queryURL.RawQuery = queryValues.Encode()
+{{end}}{{/* if .QueryParams */}}
+    return queryURL, nil
+}
+{{end}}
+`,
+	"validate.tmpl": `{{if .Patterns}}
+// validationPatterns are the regular expressions of the patterns of the
+// schemas, which the Validate methods match strings with.
+var validationPatterns = []*regexp.Regexp{
+{{- range .Patterns}}
+    regexp.MustCompile({{printf "%q" .}}),
+{{- end}}
+}
+{{end}}
+{{range .Types}}
+// Validate checks the receiver against the constraints of its schema.
+func (v *{{.TypeName}}) Validate() error {
+{{.Body}}
+}
+{{end}}
+`,
+	"websocket.tmpl": `{{if or opts.GenerateEchoServer opts.GenerateChiServer opts.GenerateGinServer}}
+{{range .}}{{$opid := .OperationId}}{{with .WebSocket}}
+// {{$opid}}ServerConn is the server side of the {{$opid}} WebSocket.
+type {{$opid}}ServerConn struct {
+    Conn runtime.WebSocketConn
+}
+
+// Receive reads the next message sent by the client.
+func (c *{{$opid}}ServerConn) Receive() ({{.ClientMessage}}, error) {
+    var msg {{.ClientMessage}}
+    err := runtime.ReadJSONMessage(c.Conn, &msg)
+    return msg, err
+}
+
+// Send sends a message to the client.
+func (c *{{$opid}}ServerConn) Send(msg {{.ServerMessage}}) error {
+    return runtime.WriteJSONMessage(c.Conn, msg)
+}
+{{end}}{{end}}
+
+// WebSocketServerInterface represents the handlers of the WebSocket
+// operations, which are given the upgraded connection, closed when they
+// return.
+type WebSocketServerInterface interface {
+{{range .}}{{.SummaryAsComment}}
+// (GET {{.Path}})
+{{.OperationId}}(r *http.Request, conn *{{.OperationId}}ServerConn{{genParamArgs .PathParams}})
+{{end}}
+}
+
+{{range .}}{{$opid := .OperationId}}
+// serve{{$opid}}WebSocket binds the path parameters of {{$opid}}, given by
+// pathParam, upgrades the request, and hands the connection to the handler.
+func serve{{$opid}}WebSocket(si WebSocketServerInterface, upgrader runtime.WebSocketUpgrader, w http.ResponseWriter, r *http.Request, pathParam func(name string) string) {
+{{- range .PathParams}}
+    var {{.GoVariableName}} {{.TypeDef}}
+{{- if .IsPassThrough}}
+    {{.GoVariableName}} = pathParam("{{.ParamName}}")
+{{- end}}
+{{- if .IsJson}}
+    if err := json.Unmarshal([]byte(pathParam("{{.ParamName}}")), &{{.GoVariableName}}); err != nil {
+        http.Error(w, fmt.Sprintf("Error unmarshaling parameter '{{.ParamName}}' as JSON: %s", err), http.StatusBadRequest)
+        return
+    }
+{{- end}}
+{{- if .IsStyled}}
+    if err := runtime.BindStyledParameterWithLocation("{{.Style}}", {{.Explode}}, "{{.ParamName}}", runtime.ParamLocationPath, pathParam("{{.ParamName}}"), &{{.GoVariableName}}); err != nil {
+        http.Error(w, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err), http.StatusBadRequest)
+        return
+    }
+{{- end}}
+{{- end}}
+    conn, err := upgrader(w, r)
+    if err != nil {
+        return
+    }
+    defer conn.Close()
+    si.{{$opid}}(r, &{{$opid}}ServerConn{Conn: conn}{{genParamNames .PathParams}})
+}
+{{end}}
+
+{{if opts.GenerateEchoServer}}
+// RegisterWebSocketHandlers adds the route of each WebSocket operation to the
+// EchoRouter, whose requests are upgraded by upgrader.
+func RegisterWebSocketHandlers(router EchoRouter, si WebSocketServerInterface, upgrader runtime.WebSocketUpgrader) {
+{{range .}}
+    router.GET("{{.Path | swaggerUriToEchoUri}}", func(ctx echo.Context) error {
+        serve{{.OperationId}}WebSocket(si, upgrader, ctx.Response(), ctx.Request(), ctx.Param)
+        return nil
+    })
+{{end}}
+}
+{{end}}
+
+{{if opts.GenerateChiServer}}
+// HandleWebSockets adds the route of each WebSocket operation to the chi
+// router, whose requests are upgraded by upgrader.
+func HandleWebSockets(router chi.Router, si WebSocketServerInterface, upgrader runtime.WebSocketUpgrader) {
+{{range .}}
+    router.Get("{{.Path | swaggerUriToChiUri}}", func(w http.ResponseWriter, r *http.Request) {
+        serve{{.OperationId}}WebSocket(si, upgrader, w, r, func(name string) string {
+            return chi.URLParam(r, name)
+        })
+    })
+{{end}}
+}
+{{end}}
+
+{{if opts.GenerateGinServer}}
+// RegisterWebSocketHandlers adds the route of each WebSocket operation to the
+// gin engine, whose requests are upgraded by upgrader.
+func RegisterWebSocketHandlers(router *gin.Engine, si WebSocketServerInterface, upgrader runtime.WebSocketUpgrader) {
+{{range .}}
+    router.GET("{{.Path | swaggerUriToGinUri}}", func(c *gin.Context) {
+        serve{{.OperationId}}WebSocket(si, upgrader, c.Writer, c.Request, c.Param)
+    })
+{{end}}
+}
+{{end}}
+{{end}}
+
+{{if opts.GenerateClient}}
+{{range .}}{{$opid := .OperationId}}{{$hasParams := .RequiresParamObject}}{{with .WebSocket}}
+// {{$opid}}ClientConn is the client side of the {{$opid}} WebSocket.
+type {{$opid}}ClientConn struct {
+    Conn runtime.WebSocketConn
+}
+
+// Send sends a message to the server.
+func (c *{{$opid}}ClientConn) Send(msg {{.ClientMessage}}) error {
+    return runtime.WriteJSONMessage(c.Conn, msg)
+}
+
+// Receive reads the next message sent by the server.
+func (c *{{$opid}}ClientConn) Receive() ({{.ServerMessage}}, error) {
+    var msg {{.ServerMessage}}
+    err := runtime.ReadJSONMessage(c.Conn, &msg)
+    return msg, err
+}
+
+// Close closes the connection.
+func (c *{{$opid}}ClientConn) Close() error {
+    return c.Conn.Close()
+}
+{{end}}
+
+// Dial{{$opid}} opens the {{$opid}} WebSocket with dial, at its URL on the
+// server, with the ws, or wss, scheme. The headers set by the request editors
+// are sent with the handshake.
+func (c *Client) Dial{{$opid}}(ctx context.Context, dial runtime.WebSocketDialer{{genParamArgs .PathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, reqEditors ...RequestEditorFn) (*{{$opid}}ClientConn, error) {
+    queryURL, err := Build{{$opid}}URL(c.Server{{genParamNames .PathParams}}{{if $hasParams}}, params{{end}})
+    if err != nil {
+        return nil, err
+    }
+    req, err := http.NewRequest("GET", queryURL.String(), nil)
+    if err != nil {
+        return nil, err
+    }
+    req = req.WithContext(ctx)
+    if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+        return nil, err
+    }
+    conn, err := dial(ctx, runtime.WebSocketURL(req.URL).String(), req.Header)
+    if err != nil {
+        return nil, err
+    }
+    return &{{$opid}}ClientConn{Conn: conn}, nil
+}
+{{end}}
 {{end}}
 `,
 }
@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// TypeMapping is the Go type of the schemas of an OpenAPI type and format, in
+// Options.TypeMappings.
+type TypeMapping struct {
+	// GoType is the Go type, eg, "decimal.Decimal" or "int64".
+	GoType string `yaml:"go-type"`
+
+	// Import is the import path of the package of GoType, when it's not
+	// built in, eg, "github.com/shopspring/decimal". It's imported with the
+	// name GoType qualifies the type with.
+	Import string `yaml:"import"`
+}
+
+// defaultTypeMappings are the built in mappings, which Options.TypeMappings
+// override.
+var defaultTypeMappings = map[string]TypeMapping{
+	"string/uuid": {GoType: "uuid.UUID", Import: "github.com/google/uuid"},
+}
+
+// typeQualifier matches the package name which qualifies a Go type.
+var typeQualifier = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\.[A-Za-z_]`)
+
+// schemaTypeMapping returns the Go type which the schema's type and format
+// are mapped to, if any.
+func (g *generator) schemaTypeMapping(schema *openapi3.Schema) (TypeMapping, bool) {
+	if schema.Type == "" || schema.Type == "array" || schema.Type == "object" {
+		return TypeMapping{}, false
+	}
+	key := schema.Type
+	if schema.Format != "" {
+		key += "/" + schema.Format
+	}
+	if mapping, ok := g.opts.TypeMappings[key]; ok {
+		return mapping, true
+	}
+	mapping, ok := defaultTypeMappings[key]
+	return mapping, ok
+}
+
+// checkTypeMappings returns an error when one of the mappings can't be used.
+func checkTypeMappings(mappings map[string]TypeMapping) error {
+	for key, mapping := range mappings {
+		switch strings.SplitN(key, "/", 2)[0] {
+		case "string", "integer", "number", "boolean":
+		default:
+			return fmt.Errorf("type mapping %q: expected a type/format of a string, integer, number or boolean", key)
+		}
+		if mapping.GoType == "" {
+			return fmt.Errorf("type mapping %q: missing go-type", key)
+		}
+		if mapping.Import != "" && !typeQualifier.MatchString(mapping.GoType) {
+			return fmt.Errorf("type mapping %q: go-type %s isn't qualified by the name of package %s", key, mapping.GoType, mapping.Import)
+		}
+	}
+	return nil
+}
+
+// typeMappingImports returns the imports of the packages of the mapped types,
+// the default ones included unless they're overridden, which are only named
+// when their name isn't the last element of their path, so that the packages
+// of the standard library which the generated code imports anyway are
+// repeated as such. The imports of the types which aren't used are removed
+// with the others.
+func typeMappingImports(mappings map[string]TypeMapping) []string {
+	var imports []string
+	seen := map[string]bool{}
+	all := map[string]TypeMapping{}
+	for key, mapping := range defaultTypeMappings {
+		all[key] = mapping
+	}
+	for key, mapping := range mappings {
+		all[key] = mapping
+	}
+	for _, mapping := range all {
+		if mapping.Import == "" {
+			continue
+		}
+		gi := goImport{Path: mapping.Import}
+		if name := typeQualifier.FindStringSubmatch(mapping.GoType)[1]; name != path.Base(mapping.Import) {
+			gi.Name = name
+		}
+		if !seen[gi.String()] {
+			seen[gi.String()] = true
+			imports = append(imports, gi.String())
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
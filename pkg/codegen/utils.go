@@ -213,11 +213,15 @@ func StringInArray(str string, array []string) bool {
 // URL components (http://deepmap.com/schemas/document.json#/Foo) are supported if they present in --import-mapping
 // Remote and URL also support standard local paths even though the spec doesn't mention them.
 func RefPathToGoType(refPath string) (string, error) {
-	return refPathToGoType(refPath, true)
+	return defaultGenerator().RefPathToGoType(refPath)
+}
+
+func (g *generator) RefPathToGoType(refPath string) (string, error) {
+	return g.refPathToGoType(refPath, true)
 }
 
 // refPathToGoType returns the Go typename for refPath given its
-func refPathToGoType(refPath string, local bool) (string, error) {
+func (g *generator) refPathToGoType(refPath string, local bool) (string, error) {
 	if refPath[0] == '#' {
 		pathParts := strings.Split(refPath, "/")
 		depth := len(pathParts)
@@ -235,10 +239,10 @@ func refPathToGoType(refPath string, local bool) (string, error) {
 		return "", fmt.Errorf("unsupported reference: %s", refPath)
 	}
 	remoteComponent, flatComponent := pathParts[0], pathParts[1]
-	if goImport, ok := importMapping[remoteComponent]; !ok {
+	if goImport, ok := g.importMapping[remoteComponent]; !ok {
 		return "", fmt.Errorf("unrecognized external reference '%s'; please provide the known import for this reference using option --import-mapping", remoteComponent)
 	} else {
-		goType, err := refPathToGoType("#"+flatComponent, false)
+		goType, err := g.refPathToGoType("#"+flatComponent, false)
 		if err != nil {
 			return "", err
 		}
@@ -250,7 +254,7 @@ func refPathToGoType(refPath string, local bool) (string, error) {
 // #/components/schemas/Foo                     -> true
 // ./local/file.yml#/components/parameters/Bar  -> true
 // ./local/file.yml                             -> false
-// The function can be used to check whether RefPathToGoType($ref) is possible.
+// The function can be used to check whether g.RefPathToGoType($ref) is possible.
 //
 func IsGoTypeReference(ref string) bool {
 	return ref != "" && !IsWholeDocumentReference(ref)
@@ -312,6 +316,21 @@ func SwaggerUriToGinUri(uri string) string {
 	return pathParamRE.ReplaceAllString(uri, ":$1")
 }
 
+// This function converts a swagger style path URI with parameters to an
+// Iris compatible path URI. We need to replace all of Swagger parameters with
+// "{param}". Valid input parameters are:
+//   {param}
+//   {param*}
+//   {.param}
+//   {.param*}
+//   {;param}
+//   {;param*}
+//   {?param}
+//   {?param*}
+func SwaggerUriToIrisUri(uri string) string {
+	return pathParamRE.ReplaceAllString(uri, "{$1}")
+}
+
 // Returns the argument names, in order, in a given URI string, so for
 // /path/{param1}/{.param2*}/{?param3}, it would return param1, param2, param3
 func OrderedParamsFromUri(uri string) []string {
@@ -599,6 +618,19 @@ func StringToGoComment(in string) string {
 	return in
 }
 
+// DeprecationComment renders a "Deprecated:" Go comment for an element which
+// is marked as deprecated in the spec, so that linters and IDEs warn about its
+// usage. The reason is taken from the x-deprecated-reason extension, if set.
+func DeprecationComment(extensions map[string]interface{}) string {
+	reason := "this has been marked as deprecated in the OpenAPI specification"
+	if extension, ok := extensions[extDeprecationReason]; ok {
+		if extReason, err := extParseDeprecationReason(extension); err == nil && extReason != "" {
+			reason = extReason
+		}
+	}
+	return StringToGoComment("Deprecated: " + reason)
+}
+
 // This function breaks apart a path, and looks at each element. If it's
 // not a path parameter, eg, {param}, it will URL-escape the element.
 func EscapePathElements(path string) string {
@@ -0,0 +1,534 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// validateTag returns the validate tag of the field, which checks the
+// constraints of its schema, or an empty string when it has none.
+func (g *generator) validateTag(p Property) string {
+	if p.spec == nil {
+		return ""
+	}
+	rules := g.schemaValidateRules(p.spec)
+	if p.Required && !p.spec.Nullable && g.isRequirable(p.spec) {
+		rules = append([]string{"required"}, rules...)
+	} else if len(rules) > 0 && !p.Required {
+		// Absent fields are nil, or zero values, which aren't checked.
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	return strings.Join(rules, ",")
+}
+
+// isRequirable tells whether the required rule, which fails for zero values,
+// checks that values of the schema are present. Zero numbers and booleans
+// are valid values, and the rule doesn't apply to structs.
+func (g *generator) isRequirable(schema *openapi3.Schema) bool {
+	if !g.isPlainSchema(schema) {
+		return false
+	}
+	return schema.Type == "string" || schema.Type == "array"
+}
+
+// schemaValidateRules returns the validator rules which check the
+// constraints of the schema, besides the required one. Patterns have no rule,
+// since validator has no regular expressions.
+func (g *generator) schemaValidateRules(schema *openapi3.Schema) []string {
+	if !g.isPlainSchema(schema) {
+		return nil
+	}
+
+	var rules []string
+	switch schema.Type {
+	case "string":
+		if schema.MinLength > 0 {
+			rules = append(rules, fmt.Sprintf("min=%d", schema.MinLength))
+		}
+		if schema.MaxLength != nil {
+			rules = append(rules, fmt.Sprintf("max=%d", *schema.MaxLength))
+		}
+		rules = append(rules, enumRule(schema)...)
+	case "integer", "number":
+		if schema.Min != nil {
+			rule := "gte"
+			if schema.ExclusiveMin {
+				rule = "gt"
+			}
+			rules = append(rules, rule+"="+strconv.FormatFloat(*schema.Min, 'g', -1, 64))
+		}
+		if schema.Max != nil {
+			rule := "lte"
+			if schema.ExclusiveMax {
+				rule = "lt"
+			}
+			rules = append(rules, rule+"="+strconv.FormatFloat(*schema.Max, 'g', -1, 64))
+		}
+		// The oneof rule takes integers, but not other numbers
+		if schema.Type == "integer" {
+			rules = append(rules, enumRule(schema)...)
+		}
+	case "array":
+		if schema.MinItems > 0 {
+			rules = append(rules, fmt.Sprintf("min=%d", schema.MinItems))
+		}
+		if schema.MaxItems != nil {
+			rules = append(rules, fmt.Sprintf("max=%d", *schema.MaxItems))
+		}
+		if schema.UniqueItems {
+			rules = append(rules, "unique")
+		}
+		// The items are checked by diving into them, which structs need
+		// too, to have their fields checked.
+		if schema.Items != nil && schema.Items.Value != nil {
+			items := g.schemaValidateRules(schema.Items.Value)
+			if len(items) > 0 || isStructSchema(schema.Items.Value) {
+				rules = append(rules, "dive")
+				rules = append(rules, items...)
+			}
+		}
+	}
+	return rules
+}
+
+// enumRule returns the oneof rule of the values of the enum, unless it has
+// values which the rule can't tell apart, since it separates them with
+// spaces.
+func enumRule(schema *openapi3.Schema) []string {
+	if len(schema.Enum) == 0 {
+		return nil
+	}
+	values := make([]string, len(schema.Enum))
+	for i, value := range schema.Enum {
+		values[i] = fmt.Sprintf("%v", value)
+		if values[i] == "" || strings.ContainsAny(values[i], " \t\n,|'\"`") {
+			return nil
+		}
+	}
+	return []string{"oneof=" + strings.Join(values, " ")}
+}
+
+// isStructSchema tells whether values of the schema are structs, rather
+// than maps.
+func isStructSchema(schema *openapi3.Schema) bool {
+	if len(schema.AllOf) > 0 {
+		return true
+	}
+	return (schema.Type == "" || schema.Type == "object") && len(schema.Properties) > 0
+}
+
+// ValidateDefinition describes the Validate method of a generated type.
+type ValidateDefinition struct {
+	TypeName string
+	Body     string // The statements of Validate, which check v
+}
+
+// GenerateValidate generates Validate methods for the types of the
+// components and operations whose schemas, or the schemas of their fields,
+// have constraints, along with the regular expressions of their patterns.
+// Aliased types are skipped, like for DeepCopy.
+func GenerateValidate(t *template.Template, swagger *openapi3.T, ops []OperationDefinition, opts Options) (string, error) {
+	return newSpecGenerator(swagger, opts).GenerateValidate(t, swagger, ops)
+}
+
+func (g *generator) GenerateValidate(t *template.Template, swagger *openapi3.T, ops []OperationDefinition) (string, error) {
+	types, err := g.generatedTypeDefinitions(t, swagger, ops, g.opts.ExcludeSchemas)
+	if err != nil {
+		return "", err
+	}
+	v := newValidator(g, types, g.opts.AliasTypes)
+
+	var defs []ValidateDefinition
+	for _, td := range types {
+		if !v.hasMethod(td) {
+			continue
+		}
+		defs = append(defs, ValidateDefinition{
+			TypeName: td.TypeName,
+			Body:     strings.TrimSpace(v.typeBody(td)),
+		})
+	}
+	return GenerateTemplates([]string{"validate.tmpl"}, t, struct {
+		Patterns []string
+		Types    []ValidateDefinition
+	}{*v.patterns, defs})
+}
+
+// validatedTypeNames returns the names of the types which GenerateValidate
+// generates Validate methods for.
+func (g *generator) validatedTypeNames(t *template.Template, swagger *openapi3.T, ops []OperationDefinition) (map[string]bool, error) {
+	types, err := g.generatedTypeDefinitions(t, swagger, ops, g.opts.ExcludeSchemas)
+	if err != nil {
+		return nil, err
+	}
+	v := newValidator(g, types, g.opts.AliasTypes)
+	names := map[string]bool{}
+	for _, td := range types {
+		if v.hasMethod(td) {
+			names[td.TypeName] = true
+		}
+	}
+	return names, nil
+}
+
+// validator generates the code which checks values of generated types
+// against the constraints of their schemas: the lengths and patterns of
+// strings, the bounds of numbers, the values of enums, the numbers of items
+// of arrays, and the presence of the required fields which can tell it.
+type validator struct {
+	g          *generator
+	types      map[string]TypeDefinition
+	aliasTypes bool
+	// patterns are the patterns which the generated code matches, whose
+	// regular expressions are compiled once, by index, into the slice
+	// variable patternsVar.
+	patterns    *[]string
+	patternsVar string
+}
+
+func newValidator(g *generator, types []TypeDefinition, aliasTypes bool) validator {
+	v := validator{
+		g:           g,
+		types:       make(map[string]TypeDefinition),
+		aliasTypes:  aliasTypes,
+		patterns:    new([]string),
+		patternsVar: "validationPatterns",
+	}
+	for _, td := range types {
+		v.types[td.TypeName] = td
+	}
+	return v
+}
+
+// pathElem is an element of the path of a value in the errors of Validate:
+// the JSON name of a field, or the variable of the index of an item.
+type pathElem struct {
+	name  string
+	index bool
+}
+
+// pathExpr returns the Go expression of the path, eg, "tags[" +
+// strconv.Itoa(i) + "]".
+func pathExpr(path []pathElem) string {
+	var parts []string
+	var literal strings.Builder
+	for i, elem := range path {
+		if !elem.index {
+			if i > 0 {
+				literal.WriteString(".")
+			}
+			literal.WriteString(elem.name)
+			continue
+		}
+		literal.WriteString("[")
+		parts = append(parts, strconv.Quote(literal.String()), "strconv.Itoa("+elem.name+")")
+		literal.Reset()
+		literal.WriteString("]")
+	}
+	if literal.Len() > 0 || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(literal.String()))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func appendPath(path []pathElem, elem pathElem) []pathElem {
+	return append(path[:len(path):len(path)], elem)
+}
+
+// definition returns the generated type which the schema refers to by name.
+func (v validator) definition(s Schema) (TypeDefinition, bool) {
+	td, found := v.types[s.TypeDecl()]
+	return td, found
+}
+
+// hasMethod tells whether the generated type has its own Validate.
+func (v validator) hasMethod(td TypeDefinition) bool {
+	if v.aliasTypes && td.CanAlias() {
+		return false
+	}
+	return v.hasConstraints(td.Schema, td.Schema.OAPISchema, map[string]bool{td.TypeName: true})
+}
+
+// hasConstraints tells whether values of the schema's type, whose schema in
+// the spec is spec, when it's known, have constraints at any depth.
+func (v validator) hasConstraints(s Schema, spec *openapi3.Schema, seen map[string]bool) bool {
+	if td, found := v.definition(s); found {
+		if seen[td.TypeName] {
+			return false
+		}
+		seen[td.TypeName] = true
+		return v.hasConstraints(td.Schema, td.Schema.OAPISchema, seen)
+	}
+
+	if spec != nil && v.g.isPlainSchema(spec) {
+		switch spec.Type {
+		case "string":
+			if spec.MinLength > 0 || spec.MaxLength != nil || spec.Pattern != "" && isGoPattern(spec.Pattern) || len(spec.Enum) > 0 {
+				return true
+			}
+		case "integer", "number":
+			if spec.Min != nil || spec.Max != nil || len(spec.Enum) > 0 {
+				return true
+			}
+		case "array":
+			if spec.MinItems > 0 || spec.MaxItems != nil {
+				return true
+			}
+		}
+	}
+
+	switch {
+	case s.ArrayType != nil:
+		return v.hasConstraints(*s.ArrayType, itemsSpec(spec), seen)
+	case strings.HasPrefix(s.TypeDecl(), "struct"):
+		for _, embedded := range v.g.embeddedTypes(s) {
+			if v.hasConstraints(embedded, nil, seen) {
+				return true
+			}
+		}
+		for _, p := range s.Properties {
+			if p.requiredCheck("v") != "" || v.hasConstraints(p.Schema, p.spec, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// itemsSpec returns the schema of the items of the array schema, if it's
+// known.
+func itemsSpec(spec *openapi3.Schema) *openapi3.Schema {
+	if spec == nil || spec.Items == nil {
+		return nil
+	}
+	return spec.Items.Value
+}
+
+// isGoPattern tells whether the pattern is a regular expression of Go, which
+// doesn't have some of the syntax of ECMA 262, such as lookaheads. Other
+// patterns aren't checked.
+func isGoPattern(pattern string) bool {
+	_, err := regexp.Compile(pattern)
+	return err == nil
+}
+
+// typeBody returns the body of Validate for a generated type.
+func (v validator) typeBody(td TypeDefinition) string {
+	s := td.Schema
+	// The type is defined from another generated type, whose method it
+	// calls, unless that's an alias.
+	for {
+		def, found := v.definition(s)
+		if !found || def.TypeName == td.TypeName {
+			break
+		}
+		if v.hasMethod(def) {
+			return fmt.Sprintf("return (*%s)(v).Validate()", def.TypeName)
+		}
+		s = def.Schema
+	}
+
+	var w strings.Builder
+	v.value(&w, "*v", nil, s, s.OAPISchema, 0)
+	w.WriteString("return nil\n")
+	return w.String()
+}
+
+// value writes the code which checks the value of expr, an addressable
+// expression of the schema's type, whose schema in the spec is spec, at the
+// given depth of arrays.
+func (v validator) value(w *strings.Builder, expr string, path []pathElem, s Schema, spec *openapi3.Schema, depth int) {
+	if td, found := v.definition(s); found {
+		if v.hasMethod(td) {
+			fmt.Fprintf(w, "if err := %s.Validate(); err != nil {\n", selector(expr))
+			if len(path) == 0 {
+				w.WriteString("return err\n")
+			} else {
+				fmt.Fprintf(w, "return runtime.PrefixValidationError(err, %s)\n", pathExpr(path))
+			}
+			w.WriteString("}\n")
+			return
+		}
+		s = td.Schema
+		spec = td.Schema.OAPISchema
+	}
+
+	if spec != nil && v.g.isPlainSchema(spec) {
+		v.constraints(w, expr, path, s.TypeDecl(), spec)
+	}
+
+	switch {
+	case s.ArrayType != nil:
+		items := itemsSpec(spec)
+		if !v.hasConstraints(*s.ArrayType, items, map[string]bool{}) {
+			return
+		}
+		index := fmt.Sprintf("i%d", depth)
+		if depth == 0 {
+			index = "i"
+		}
+		fmt.Fprintf(w, "for %s := range %s {\n", index, expr)
+		v.value(w, fmt.Sprintf("%s[%s]", receiver(expr), index), appendPath(path, pathElem{name: index, index: true}),
+			*s.ArrayType, items, depth+1)
+		w.WriteString("}\n")
+	case strings.HasPrefix(s.TypeDecl(), "struct"):
+		v.structFields(w, selector(expr), path, s, depth)
+	}
+}
+
+// constraints writes the code which checks the value of expr, of the Go
+// type goType, against the constraints of the schema, which is plain.
+func (v validator) constraints(w *strings.Builder, expr string, path []pathElem, goType string, spec *openapi3.Schema) {
+	fail := func(cond, message string) {
+		fmt.Fprintf(w, "if %s {\n", cond)
+		fmt.Fprintf(w, "return runtime.NewValidationError(%s, %s)\n", pathExpr(path), strconv.Quote(message))
+		w.WriteString("}\n")
+	}
+
+	switch spec.Type {
+	case "string":
+		str := expr
+		if goType != "string" {
+			str = "string(" + expr + ")"
+		}
+		if spec.MinLength > 0 {
+			fail(fmt.Sprintf("utf8.RuneCountInString(%s) < %d", str, spec.MinLength),
+				fmt.Sprintf("length must be at least %d", spec.MinLength))
+		}
+		if spec.MaxLength != nil {
+			fail(fmt.Sprintf("utf8.RuneCountInString(%s) > %d", str, *spec.MaxLength),
+				fmt.Sprintf("length must be at most %d", *spec.MaxLength))
+		}
+		if spec.Pattern != "" && isGoPattern(spec.Pattern) {
+			fail(fmt.Sprintf("!%s[%d].MatchString(%s)", v.patternsVar, v.patternIndex(spec.Pattern), str),
+				"must match "+spec.Pattern)
+		}
+	case "integer", "number":
+		// Numbers are compared as float64, so that bounds which aren't
+		// values of the type, such as fractions, compare as they should.
+		if spec.Min != nil {
+			min := strconv.FormatFloat(*spec.Min, 'g', -1, 64)
+			if spec.ExclusiveMin {
+				fail(fmt.Sprintf("float64(%s) <= %s", expr, min), "must be greater than "+min)
+			} else {
+				fail(fmt.Sprintf("float64(%s) < %s", expr, min), "must be at least "+min)
+			}
+		}
+		if spec.Max != nil {
+			max := strconv.FormatFloat(*spec.Max, 'g', -1, 64)
+			if spec.ExclusiveMax {
+				fail(fmt.Sprintf("float64(%s) >= %s", expr, max), "must be less than "+max)
+			} else {
+				fail(fmt.Sprintf("float64(%s) > %s", expr, max), "must be at most "+max)
+			}
+		}
+	case "array":
+		if spec.MinItems > 0 {
+			fail(fmt.Sprintf("len(%s) < %d", expr, spec.MinItems), "must have at least "+countItems(spec.MinItems))
+		}
+		if spec.MaxItems != nil {
+			fail(fmt.Sprintf("len(%s) > %d", expr, *spec.MaxItems), "must have at most "+countItems(*spec.MaxItems))
+		}
+	}
+
+	if len(spec.Enum) > 0 && spec.Type != "array" {
+		literals := make([]string, 0, len(spec.Enum))
+		values := make([]string, 0, len(spec.Enum))
+		for _, value := range spec.Enum {
+			literal, _, ok := v.g.primitiveLiteral(spec, value)
+			if !ok {
+				return
+			}
+			literals = append(literals, literal)
+			values = append(values, fmt.Sprintf("%v", value))
+		}
+		fmt.Fprintf(w, "switch %s {\n", expr)
+		fmt.Fprintf(w, "case %s:\n", strings.Join(literals, ", "))
+		w.WriteString("default:\n")
+		fmt.Fprintf(w, "return runtime.NewValidationError(%s, %s)\n", pathExpr(path),
+			strconv.Quote("must be one of "+strings.Join(values, ", ")))
+		w.WriteString("}\n")
+	}
+}
+
+// countItems returns the number of items in words, eg, "2 items".
+func countItems(n uint64) string {
+	if n == 1 {
+		return "1 item"
+	}
+	return fmt.Sprintf("%d items", n)
+}
+
+// patternIndex returns the index of the pattern in the patterns variable.
+func (v validator) patternIndex(pattern string) int {
+	for i, p := range *v.patterns {
+		if p == pattern {
+			return i
+		}
+	}
+	*v.patterns = append(*v.patterns, pattern)
+	return len(*v.patterns) - 1
+}
+
+// structFields writes the code which checks the fields of the struct v,
+// which is a struct, or a pointer to one.
+func (v validator) structFields(w *strings.Builder, expr string, path []pathElem, s Schema, depth int) {
+	for _, embedded := range v.g.embeddedTypes(s) {
+		if v.hasConstraints(embedded, nil, map[string]bool{}) {
+			goType := embedded.GoType
+			v.value(w, expr+"."+goType[strings.LastIndex(goType, ".")+1:], path, embedded, nil, depth)
+		}
+	}
+
+	for _, p := range s.Properties {
+		field := expr + "." + p.GoFieldName()
+		fieldPath := appendPath(path, pathElem{name: p.JsonFieldName})
+		if check := p.requiredCheck(field); check != "" {
+			fmt.Fprintf(w, "if %s {\n", check)
+			fmt.Fprintf(w, "return runtime.NewValidationError(%s, \"is required\")\n", pathExpr(fieldPath))
+			w.WriteString("}\n")
+		}
+		if strings.HasPrefix(p.Schema.TypeDecl(), "runtime.") ||
+			!v.hasConstraints(p.Schema, p.spec, map[string]bool{}) {
+			continue
+		}
+		if strings.HasPrefix(p.GoTypeDef(), "*") {
+			fmt.Fprintf(w, "if %s != nil {\n", field)
+			v.value(w, "*"+field, fieldPath, p.Schema, p.spec, depth)
+			w.WriteString("}\n")
+		} else if present := p.PresenceCheck(expr); present != "" && !p.Required {
+			// Absent optional fields which aren't pointers are zero
+			fmt.Fprintf(w, "if %s {\n", present)
+			v.value(w, field, fieldPath, p.Schema, p.spec, depth)
+			w.WriteString("}\n")
+		} else {
+			v.value(w, field, fieldPath, p.Schema, p.spec, depth)
+		}
+	}
+}
+
+// requiredCheck returns the condition under which the required field is
+// absent, for the fields which can tell, or an empty string: nullable ones,
+// and slices, maps and interfaces, which are nil. The other fields are zero
+// values, which may be present.
+func (p Property) requiredCheck(field string) string {
+	if !p.Required || p.spec == nil {
+		return ""
+	}
+	goType := p.Schema.TypeDecl()
+	switch {
+	case isNullableType(goType):
+		return "!" + field + ".IsSpecified()"
+	case p.spec.Nullable, strings.HasPrefix(p.GoTypeDef(), "*"):
+		return ""
+	case strings.HasPrefix(goType, "[]"), strings.HasPrefix(goType, "map["),
+		goType == "interface{}", goType == "json.RawMessage":
+		return field + " == nil"
+	}
+	return ""
+}
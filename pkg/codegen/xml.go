@@ -0,0 +1,81 @@
+package codegen
+
+import (
+	"encoding/json"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// XMLObject is the OpenAPI xml object of a schema, which describes how it is
+// represented in XML.
+type XMLObject struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Prefix    string `json:"prefix"`
+	Attribute bool   `json:"attribute"`
+	Wrapped   bool   `json:"wrapped"`
+}
+
+// schemaXMLObject returns the xml object declared by a schema, or nil when it
+// doesn't declare one, or it's malformed.
+func schemaXMLObject(schema *openapi3.Schema) *XMLObject {
+	if schema == nil || schema.XML == nil {
+		return nil
+	}
+	raw, err := json.Marshal(schema.XML)
+	if err != nil {
+		return nil
+	}
+	var obj XMLObject
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil
+	}
+	return &obj
+}
+
+// propertiesDeclareXML tells whether any of the properties, or their array
+// items, declare an xml object, in which case their struct gets xml tags.
+func propertiesDeclareXML(props []Property) bool {
+	for _, p := range props {
+		if p.XML != nil || p.XMLItems != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// XMLTag returns the value of the xml struct tag of the property. Prefixes
+// aren't supported by encoding/xml, so only namespaces are kept.
+func (p Property) XMLTag(omitEmpty bool) string {
+	name := p.JsonFieldName
+	var namespace string
+	if p.XML != nil {
+		if p.XML.Name != "" {
+			name = p.XML.Name
+		}
+		namespace = p.XML.Namespace
+	}
+
+	tag := name
+	if p.Schema.ArrayType != nil {
+		// Array items are repeated elements named after the items, or the
+		// property, which are nested in an element when wrapped.
+		item := name
+		if p.XMLItems != nil && p.XMLItems.Name != "" {
+			item = p.XMLItems.Name
+		}
+		tag = item
+		if p.XML != nil && p.XML.Wrapped {
+			tag = name + ">" + item
+		}
+	} else if p.XML != nil && p.XML.Attribute {
+		tag += ",attr"
+	}
+	if namespace != "" {
+		tag = namespace + " " + tag
+	}
+	if omitEmpty {
+		tag += ",omitempty"
+	}
+	return tag
+}
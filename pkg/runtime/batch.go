@@ -0,0 +1,56 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"sync"
+)
+
+// RunBatch makes the n calls of a batch, call(ctx, i) for each i in [0, n),
+// with at most concurrency of them at a time, or all at once when
+// concurrency isn't positive, and returns their errors in order. An error
+// doesn't stop the other calls, but those which haven't started once ctx is
+// done aren't made, and fail with its error.
+func RunBatch(ctx context.Context, concurrency, n int, call func(ctx context.Context, i int) error) []error {
+	if concurrency <= 0 || concurrency > n {
+		concurrency = n
+	}
+	errs := make([]error, n)
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			errs[i] = call(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
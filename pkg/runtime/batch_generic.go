@@ -0,0 +1,31 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import "context"
+
+// Batch makes the calls of a batch, such as calls of the same operation of a
+// generated client with different parameters, with at most concurrency of
+// them at a time, like RunBatch does, and returns their results and errors in
+// the order of the calls.
+func Batch[T any](ctx context.Context, concurrency int, calls ...func(ctx context.Context) (T, error)) ([]T, []error) {
+	results := make([]T, len(calls))
+	errs := RunBatch(ctx, concurrency, len(calls), func(ctx context.Context, i int) error {
+		var err error
+		results[i], err = calls[i](ctx)
+		return err
+	})
+	return results, errs
+}
@@ -0,0 +1,27 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	var calls []func(ctx context.Context) (string, error)
+	for _, name := range []string{"a", "", "c"} {
+		name := name
+		calls = append(calls, func(ctx context.Context) (string, error) {
+			if name == "" {
+				return "", errors.New("no name")
+			}
+			return name + "!", nil
+		})
+	}
+	results, errs := Batch(context.Background(), 2, calls...)
+	assert.Equal(t, []string{"a!", "", "c!"}, results)
+	assert.NoError(t, errs[0])
+	assert.EqualError(t, errs[1], "no name")
+	assert.NoError(t, errs[2])
+}
@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBatch(t *testing.T) {
+	var running, maxRunning int32
+	results := make([]int, 10)
+	errs := RunBatch(context.Background(), 3, len(results), func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		if i == 4 {
+			return errors.New("failed")
+		}
+		results[i] = i * i
+		return nil
+	})
+	assert.LessOrEqual(t, maxRunning, int32(3))
+	assert.Equal(t, []int{0, 1, 4, 9, 0, 25, 36, 49, 64, 81}, results)
+	for i, err := range errs {
+		if i == 4 {
+			assert.EqualError(t, err, "failed")
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	// The calls aren't made once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls int32
+	errs = RunBatch(ctx, 0, 5, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	assert.Equal(t, int32(0), calls)
+	assert.Equal(t, []error{context.Canceled, context.Canceled, context.Canceled, context.Canceled, context.Canceled}, errs)
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BindErrorKind is the reason a parameter couldn't be bound.
+type BindErrorKind string
+
+const (
+	// BindErrorRequired is a required parameter which is missing.
+	BindErrorRequired BindErrorKind = "required"
+	// BindErrorType is a value which can't be converted to the type of the
+	// parameter, or of one of its properties.
+	BindErrorType BindErrorKind = "type"
+	// BindErrorFormat is a value which isn't formatted as the style of the
+	// parameter requires.
+	BindErrorFormat BindErrorKind = "format"
+	// BindErrorTooManyValues is a parameter, or property, which is given
+	// more values than it takes.
+	BindErrorTooManyValues BindErrorKind = "too-many-values"
+	// BindErrorConstraint is a value which violates a constraint of the
+	// schema of the parameter, as its Validate method tells.
+	BindErrorConstraint BindErrorKind = "constraint"
+)
+
+// String returns the name of the location as in OpenAPI specs, eg, "query".
+func (l ParamLocation) String() string {
+	switch l {
+	case ParamLocationQuery:
+		return "query"
+	case ParamLocationPath:
+		return "path"
+	case ParamLocationHeader:
+		return "header"
+	case ParamLocationCookie:
+		return "cookie"
+	default:
+		return ""
+	}
+}
+
+// BindError is returned when a request parameter can't be bound. It tells
+// which parameter failed, and why, so that applications can report it in
+// their own error payloads.
+type BindError struct {
+	ParamName string
+	Location  ParamLocation
+	// Property is the property of an object parameter which failed, when it
+	// is known.
+	Property string
+	Kind     BindErrorKind
+	Err      error
+}
+
+// NewBindError returns a BindError for the given parameter. When err is a
+// BindError itself, its kind and property are kept, since they are more
+// precise.
+func NewBindError(kind BindErrorKind, paramName string, location ParamLocation, err error) *BindError {
+	bindErr := &BindError{
+		ParamName: paramName,
+		Location:  location,
+		Kind:      kind,
+		Err:       err,
+	}
+	var inner *BindError
+	if errors.As(err, &inner) {
+		if inner.Kind != "" {
+			bindErr.Kind = inner.Kind
+		}
+		bindErr.Property = inner.Property
+	}
+	return bindErr
+}
+
+func (e *BindError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	if e.Kind == BindErrorRequired {
+		return fmt.Sprintf("parameter '%s' is required", e.ParamName)
+	}
+	return fmt.Sprintf("invalid parameter '%s'", e.ParamName)
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// bindErrorKind wraps an error with the reason for it, the binding functions
+// fill in the rest.
+func bindErrorKind(kind BindErrorKind, err error) error {
+	return &BindError{Kind: kind, Err: err}
+}
+
+// BindErrorTranslator maps a parameter binding error to the error which
+// generated servers report for it. It's set in the options of the servers.
+type BindErrorTranslator func(err *BindError) error
+
+// TranslateBindError is used by generated servers to report a parameter
+// binding error. It returns the error translated by t, or defaultErr when t
+// is nil.
+func TranslateBindError(t BindErrorTranslator, err *BindError, defaultErr error) error {
+	if t == nil {
+		return defaultErr
+	}
+	return t(err)
+}
@@ -0,0 +1,133 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindErrors(t *testing.T) {
+	type Object struct {
+		Count int `json:"count"`
+	}
+
+	tests := []struct {
+		name     string
+		bind     func() error
+		location ParamLocation
+		kind     BindErrorKind
+		property string
+	}{
+		{
+			name: "wrong type",
+			bind: func() error {
+				var dest int
+				return BindStyledParameterWithLocation("simple", false, "id", ParamLocationPath, "five", &dest)
+			},
+			location: ParamLocationPath,
+			kind:     BindErrorType,
+		},
+		{
+			name: "bad format",
+			bind: func() error {
+				var dest []int
+				return BindStyledParameterWithLocation("label", false, "id", ParamLocationPath, "1,2", &dest)
+			},
+			location: ParamLocationPath,
+			kind:     BindErrorFormat,
+		},
+		{
+			name: "missing required",
+			bind: func() error {
+				var dest int
+				return BindQueryParameter("form", true, true, "id", url.Values{}, &dest)
+			},
+			location: ParamLocationQuery,
+			kind:     BindErrorRequired,
+		},
+		{
+			name: "too many values",
+			bind: func() error {
+				var dest int
+				return BindQueryParameter("form", true, true, "id", url.Values{"id": {"1", "2"}}, &dest)
+			},
+			location: ParamLocationQuery,
+			kind:     BindErrorTooManyValues,
+		},
+		{
+			name: "property of wrong type",
+			bind: func() error {
+				var dest Object
+				return BindQueryParameter("form", true, true, "id", url.Values{"count": {"many"}}, &dest)
+			},
+			location: ParamLocationQuery,
+			kind:     BindErrorType,
+			property: "count",
+		},
+		{
+			name: "invalid JSON",
+			bind: func() error {
+				var dest Object
+				return BindJSONParameter("id", ParamLocationQuery, "{count:", &dest)
+			},
+			location: ParamLocationQuery,
+			kind:     BindErrorType,
+		},
+		{
+			name: "badly escaped JSON",
+			bind: func() error {
+				var dest Object
+				return BindJSONParameter("id", ParamLocationPath, "%7B%zz", &dest)
+			},
+			location: ParamLocationPath,
+			kind:     BindErrorFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bind()
+			var bindErr *BindError
+			require.True(t, errors.As(err, &bindErr))
+			assert.Equal(t, "id", bindErr.ParamName)
+			assert.Equal(t, tt.location, bindErr.Location)
+			assert.Equal(t, tt.kind, bindErr.Kind)
+			assert.Equal(t, tt.property, bindErr.Property)
+		})
+	}
+
+	// Wrapping an error keeps what's known about it
+	var dest int
+	err := BindStyledParameter("simple", false, "id", "five", &dest)
+	bindErr := NewBindError(BindErrorFormat, "id", ParamLocationCookie, err)
+	assert.Equal(t, BindErrorType, bindErr.Kind)
+	assert.Equal(t, ParamLocationCookie, bindErr.Location)
+	assert.Equal(t, err.Error(), bindErr.Error())
+}
+
+func TestTranslateBindError(t *testing.T) {
+	bindErr := NewBindError(BindErrorRequired, "id", ParamLocationHeader, nil)
+	defaultErr := errors.New("default")
+	assert.Equal(t, defaultErr, TranslateBindError(nil, bindErr, defaultErr))
+
+	translator := func(err *BindError) error {
+		return fmt.Errorf("%s parameter %s: %s", err.Location, err.ParamName, err.Kind)
+	}
+	assert.EqualError(t, TranslateBindError(translator, bindErr, defaultErr), "header parameter id: required")
+}
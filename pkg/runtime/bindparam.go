@@ -39,11 +39,41 @@ func BindStyledParameter(style string, explode bool, paramName string,
 // This function binds a parameter as described in the Path Parameters
 // section here to a Go object:
 // https://swagger.io/docs/specification/serialization/
+// Errors are returned as *BindError.
 func BindStyledParameterWithLocation(style string, explode bool, paramName string,
 	paramLocation ParamLocation, value string, dest interface{}) error {
+	if err := bindStyledParameter(style, explode, paramName, paramLocation, value, dest); err != nil {
+		return NewBindError(BindErrorType, paramName, paramLocation, err)
+	}
+	return nil
+}
+
+// BindJSONParameter binds a parameter with JSON content, rather than a
+// schema, to a Go object, by decoding its value, which is unescaped when it's
+// in the path, as JSON. Errors are returned as *BindError.
+func BindJSONParameter(paramName string, paramLocation ParamLocation, value string, dest interface{}) error {
+	if err := bindJSONParameter(paramName, paramLocation, value, dest); err != nil {
+		return NewBindError(BindErrorType, paramName, paramLocation, err)
+	}
+	return nil
+}
+
+func bindJSONParameter(paramName string, paramLocation ParamLocation, value string, dest interface{}) error {
+	if paramLocation == ParamLocationPath {
+		var err error
+		value, err = url.PathUnescape(value)
+		if err != nil {
+			return bindErrorKind(BindErrorFormat, fmt.Errorf("error unescaping path parameter '%s': %v", paramName, err))
+		}
+	}
+	return json.Unmarshal([]byte(value), dest)
+}
+
+func bindStyledParameter(style string, explode bool, paramName string,
+	paramLocation ParamLocation, value string, dest interface{}) error {
 
 	if value == "" {
-		return fmt.Errorf("parameter '%s' is empty, can't bind its value", paramName)
+		return bindErrorKind(BindErrorRequired, fmt.Errorf("parameter '%s' is empty, can't bind its value", paramName))
 	}
 
 	// Based on the location of the parameter, we need to unescape it properly.
@@ -54,12 +84,12 @@ func BindStyledParameterWithLocation(style string, explode bool, paramName strin
 		// since prior to this refactoring, they always query unescaped.
 		value, err = url.QueryUnescape(value)
 		if err != nil {
-			return fmt.Errorf("error unescaping query parameter '%s': %v", paramName, err)
+			return bindErrorKind(BindErrorFormat, fmt.Errorf("error unescaping query parameter '%s': %v", paramName, err))
 		}
 	case ParamLocationPath:
 		value, err = url.PathUnescape(value)
 		if err != nil {
-			return fmt.Errorf("error unescaping path parameter '%s': %v", paramName, err)
+			return bindErrorKind(BindErrorFormat, fmt.Errorf("error unescaping path parameter '%s': %v", paramName, err))
 		}
 	default:
 		// Headers and cookies aren't escaped.
@@ -85,8 +115,9 @@ func BindStyledParameterWithLocation(style string, explode bool, paramName strin
 		// of the input value, and let the json library deal with the unmarshaling
 		parts, err := splitStyledParameter(style, explode, true, paramName, value)
 		if err != nil {
-			return err
+			return bindErrorKind(BindErrorFormat, err)
 		}
+		trimHeaderListParts(paramLocation, parts)
 
 		return bindSplitPartsToDestinationStruct(paramName, parts, explode, dest)
 	}
@@ -95,8 +126,9 @@ func BindStyledParameterWithLocation(style string, explode bool, paramName strin
 		// Chop up the parameter into parts based on its style
 		parts, err := splitStyledParameter(style, explode, false, paramName, value)
 		if err != nil {
-			return fmt.Errorf("error splitting input '%s' into parts: %s", value, err)
+			return bindErrorKind(BindErrorFormat, fmt.Errorf("error splitting input '%s' into parts: %s", value, err))
 		}
+		trimHeaderListParts(paramLocation, parts)
 
 		return bindSplitPartsToDestinationArray(parts, dest)
 	}
@@ -105,6 +137,18 @@ func BindStyledParameterWithLocation(style string, explode bool, paramName strin
 	return BindStringToObject(value, dest)
 }
 
+// trimHeaderListParts trims the whitespace around the elements of header
+// lists, which HTTP allows after the commas, eg, "X-Ids: 1, 2", and which
+// proxies add when they join several header lines.
+func trimHeaderListParts(paramLocation ParamLocation, parts []string) {
+	if paramLocation != ParamLocationHeader {
+		return
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+}
+
 // This is a complex set of operations, but each given parameter style can be
 // packed together in multiple ways, using different styles of separators, and
 // different packing strategies based on the explode flag. This function takes
@@ -228,37 +272,44 @@ func bindSplitPartsToDestinationArray(parts []string, dest interface{}) error {
 // ["firstName=Alex", "role=admin"], where in the non-exploded case, we would
 // pass "firstName", "Alex", "role", "admin"]
 //
-// We punt the hard work of binding these values to the object to the json
-// library. We'll turn those arrays into JSON strings, and unmarshal
-// into the struct.
+// The values are bound to the fields like the ones of exploded form query
+// objects are, so fields of any primitive type can be set. Types which
+// unmarshal themselves from JSON, such as the ones with additional
+// properties, are unmarshaled from a JSON object of the string values.
 func bindSplitPartsToDestinationStruct(paramName string, parts []string, explode bool, dest interface{}) error {
-	// We've got a destination object, we'll create a JSON representation
-	// of the input value, and let the json library deal with the unmarshaling
-	var fields []string
+	values := url.Values{}
 	if explode {
-		fields = make([]string, len(parts))
-		for i, property := range parts {
+		for _, property := range parts {
 			propertyParts := strings.Split(property, "=")
 			if len(propertyParts) != 2 {
-				return fmt.Errorf("parameter '%s' has invalid exploded format", paramName)
+				return bindErrorKind(BindErrorFormat, fmt.Errorf("parameter '%s' has invalid exploded format", paramName))
 			}
-			fields[i] = "\"" + propertyParts[0] + "\":\"" + propertyParts[1] + "\""
+			values.Add(propertyParts[0], propertyParts[1])
 		}
 	} else {
 		if len(parts)%2 != 0 {
-			return fmt.Errorf("parameter '%s' has invalid format, property/values need to be pairs", paramName)
+			return bindErrorKind(BindErrorFormat, fmt.Errorf("parameter '%s' has invalid format, property/values need to be pairs", paramName))
 		}
-		fields = make([]string, len(parts)/2)
 		for i := 0; i < len(parts); i += 2 {
-			key := parts[i]
-			value := parts[i+1]
-			fields[i/2] = "\"" + key + "\":\"" + value + "\""
+			values.Add(parts[i], parts[i+1])
+		}
+	}
+	if _, ok := dest.(json.Unmarshaler); ok {
+		fields := map[string]string{}
+		for key := range values {
+			fields[key] = values.Get(key)
+		}
+		jsonParam, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("error binding parameter %s fields: %w", paramName, err)
+		}
+		if err := json.Unmarshal(jsonParam, dest); err != nil {
+			return fmt.Errorf("error binding parameter %s fields: %w", paramName, err)
 		}
+		return nil
 	}
-	jsonParam := "{" + strings.Join(fields, ",") + "}"
-	err := json.Unmarshal([]byte(jsonParam), dest)
-	if err != nil {
-		return fmt.Errorf("error binding parameter %s fields: %s", paramName, err)
+	if err := bindParamsToExplodedObject(paramName, values, dest); err != nil {
+		return fmt.Errorf("error binding parameter %s fields: %w", paramName, err)
 	}
 	return nil
 }
@@ -277,8 +328,18 @@ func bindSplitPartsToDestinationStruct(paramName string, parts []string, explode
 // tell them apart. This code tries to fail, but the moral of the story is that
 // you shouldn't pass objects via form styled query arguments, just use
 // the Content parameter form.
+//
+// Errors are returned as *BindError.
 func BindQueryParameter(style string, explode bool, required bool, paramName string,
 	queryParams url.Values, dest interface{}) error {
+	if err := bindQueryParameter(style, explode, required, paramName, queryParams, dest); err != nil {
+		return NewBindError(BindErrorType, paramName, ParamLocationQuery, err)
+	}
+	return nil
+}
+
+func bindQueryParameter(style string, explode bool, required bool, paramName string,
+	queryParams url.Values, dest interface{}) error {
 
 	// dv = destination value.
 	dv := reflect.Indirect(reflect.ValueOf(dest))
@@ -320,6 +381,11 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 	// This is the basic type of the destination object.
 	t := v.Type()
 	k := t.Kind()
+	// Slices which decode themselves from text, such as base64 bytes, are
+	// bound like primitives.
+	if k == reflect.Slice && reflect.PtrTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
+		k = reflect.String
+	}
 
 	switch style {
 	case "form":
@@ -338,7 +404,7 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 				// http library.
 				if !found {
 					if required {
-						return fmt.Errorf("query parameter '%s' is required", paramName)
+						return bindErrorKind(BindErrorRequired, fmt.Errorf("query parameter '%s' is required", paramName))
 					} else {
 						return nil
 					}
@@ -355,13 +421,13 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 				// unmarshal.
 				if len(values) == 0 {
 					if required {
-						return fmt.Errorf("query parameter '%s' is required", paramName)
+						return bindErrorKind(BindErrorRequired, fmt.Errorf("query parameter '%s' is required", paramName))
 					} else {
 						return nil
 					}
 				}
 				if len(values) != 1 {
-					return fmt.Errorf("multiple values for single value parameter '%s'", paramName)
+					return bindErrorKind(BindErrorTooManyValues, fmt.Errorf("multiple values for single value parameter '%s'", paramName))
 				}
 				err = BindStringToObject(values[0], output)
 			}
@@ -378,13 +444,13 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 			values, found := queryParams[paramName]
 			if !found {
 				if required {
-					return fmt.Errorf("query parameter '%s' is required", paramName)
+					return bindErrorKind(BindErrorRequired, fmt.Errorf("query parameter '%s' is required", paramName))
 				} else {
 					return nil
 				}
 			}
 			if len(values) != 1 {
-				return fmt.Errorf("parameter '%s' is not exploded, but is specified multiple times", paramName)
+				return bindErrorKind(BindErrorTooManyValues, fmt.Errorf("parameter '%s' is not exploded, but is specified multiple times", paramName))
 			}
 			parts = strings.Split(values[0], ",")
 		}
@@ -397,13 +463,13 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 		default:
 			if len(parts) == 0 {
 				if required {
-					return fmt.Errorf("query parameter '%s' is required", paramName)
+					return bindErrorKind(BindErrorRequired, fmt.Errorf("query parameter '%s' is required", paramName))
 				} else {
 					return nil
 				}
 			}
 			if len(parts) != 1 {
-				return fmt.Errorf("multiple values for single value parameter '%s'", paramName)
+				return bindErrorKind(BindErrorTooManyValues, fmt.Errorf("multiple values for single value parameter '%s'", paramName))
 			}
 			err = BindStringToObject(parts[0], output)
 		}
@@ -416,13 +482,13 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 		return nil
 	case "deepObject":
 		if !explode {
-			return errors.New("deepObjects must be exploded")
+			return bindErrorKind(BindErrorFormat, errors.New("deepObjects must be exploded"))
 		}
 		return UnmarshalDeepObject(dest, paramName, queryParams)
 	case "spaceDelimited", "pipeDelimited":
-		return fmt.Errorf("query arguments of style '%s' aren't yet supported", style)
+		return bindErrorKind(BindErrorFormat, fmt.Errorf("query arguments of style '%s' aren't yet supported", style))
 	default:
-		return fmt.Errorf("style '%s' on parameter '%s' is invalid", style, paramName)
+		return bindErrorKind(BindErrorFormat, fmt.Errorf("style '%s' on parameter '%s' is invalid", style, paramName))
 
 	}
 }
@@ -441,7 +507,14 @@ func bindParamsToExplodedObject(paramName string, values url.Values, dest interf
 	if t.Kind() != reflect.Struct {
 		return fmt.Errorf("unmarshaling query arg '%s' into wrong type", paramName)
 	}
+	_, err := bindParamsToExplodedFields(paramName, values, v, t)
+	return err
+}
 
+// bindParamsToExplodedFields binds the values to the fields of the struct v,
+// and to the ones of the structs it embeds, and returns whether any was set.
+func bindParamsToExplodedFields(paramName string, values url.Values, v reflect.Value, t reflect.Type) (bool, error) {
+	bound := false
 	for i := 0; i < t.NumField(); i++ {
 		fieldT := t.Field(i)
 
@@ -462,19 +535,66 @@ func bindParamsToExplodedObject(paramName string, values url.Values, dest interf
 			}
 		}
 
+		// The fields of embedded structs are bound like the ones of v,
+		// as they are by encoding/json, unless they're named by a tag.
+		if embedded, ok := embeddedStruct(fieldT, tag); ok {
+			field := v.Field(i)
+			if fieldT.Type.Kind() == reflect.Ptr {
+				field = reflect.New(embedded).Elem()
+			}
+			ok, err := bindParamsToExplodedFields(paramName, values, field, embedded)
+			if err != nil {
+				return bound, err
+			}
+			if ok && fieldT.Type.Kind() == reflect.Ptr {
+				if v.Field(i).IsNil() {
+					v.Field(i).Set(field.Addr())
+				} else {
+					v.Field(i).Elem().Set(field)
+				}
+			}
+			bound = bound || ok
+			continue
+		}
+
 		// At this point, we look up field name in the parameter list.
 		fieldVal, found := values[fieldName]
 		if found {
 			if len(fieldVal) != 1 {
-				return fmt.Errorf("field '%s' specified multiple times for param '%s'", fieldName, paramName)
+				return bound, &BindError{
+					Kind:     BindErrorTooManyValues,
+					Property: fieldName,
+					Err:      fmt.Errorf("field '%s' specified multiple times for param '%s'", fieldName, paramName),
+				}
 			}
 			err := BindStringToObject(fieldVal[0], v.Field(i).Addr().Interface())
 			if err != nil {
-				return fmt.Errorf("could not bind query arg '%s' to request object: %s'", paramName, err)
+				return bound, &BindError{
+					Kind:     BindErrorType,
+					Property: fieldName,
+					Err:      fmt.Errorf("could not bind query arg '%s' to request object: %s'", paramName, err),
+				}
 			}
+			bound = true
 		}
 	}
-	return nil
+	return bound, nil
+}
+
+// embeddedStruct returns the struct type of an embedded field without a json
+// name, whose fields are then promoted.
+func embeddedStruct(field reflect.StructField, tag string) (reflect.Type, bool) {
+	if !field.Anonymous || strings.Split(tag, ",")[0] != "" {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || reflect.PtrTo(t).Implements(reflect.TypeOf((*Binder)(nil)).Elem()) {
+		return nil, false
+	}
+	return t, true
 }
 
 // indirect
@@ -15,6 +15,7 @@ package runtime
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/url"
@@ -335,6 +336,24 @@ func TestBindQueryParameter(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, expected, birthday)
 	})
+
+	t.Run("base64", func(t *testing.T) {
+		var key types.Base64
+		queryParams := url.Values{
+			"key": {"+/8="},
+		}
+		err := BindQueryParameter("form", true, true, "key", queryParams, &key)
+		assert.NoError(t, err)
+		assert.Equal(t, types.Base64{0xfb, 0xff}, key)
+
+		var keys *[]types.Base64
+		queryParams = url.Values{
+			"keys": {"+/8=,AQ=="},
+		}
+		err = BindQueryParameter("form", false, false, "keys", queryParams, &keys)
+		assert.NoError(t, err)
+		assert.Equal(t, &[]types.Base64{{0xfb, 0xff}, {0x01}}, keys)
+	})
 }
 
 func TestBindParameterViaAlias(t *testing.T) {
@@ -466,3 +485,97 @@ func TestBindStyledParameterWithLocation(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, *expectedBig, dstBigNumber)
 }
+
+func TestBindJSONParameter(t *testing.T) {
+	type Object struct {
+		Name string `json:"name"`
+	}
+
+	var dest Object
+	err := BindJSONParameter("obj", ParamLocationPath, "%7B%22name%22%3A%22a%2Fb%20c%22%7D", &dest)
+	assert.NoError(t, err)
+	assert.Equal(t, Object{Name: "a/b c"}, dest)
+
+	// Only path parameters are unescaped, the routers unescape the others.
+	dest = Object{}
+	err = BindJSONParameter("obj", ParamLocationQuery, `{"name":"100%25"}`, &dest)
+	assert.NoError(t, err)
+	assert.Equal(t, Object{Name: "100%25"}, dest)
+}
+
+func TestBindStyledHeaderObject(t *testing.T) {
+	type filter struct {
+		Name  *string `json:"name,omitempty"`
+		Limit *int    `json:"limit,omitempty"`
+	}
+	name, limit := "fido", 3
+
+	for _, explode := range []bool{false, true} {
+		styled, err := StyleParamWithLocation("simple", explode, "X-Filter", ParamLocationHeader, filter{Name: &name, Limit: &limit})
+		require.NoError(t, err)
+
+		var dest filter
+		require.NoError(t, BindStyledParameterWithLocation("simple", explode, "X-Filter", ParamLocationHeader, styled, &dest))
+		assert.Equal(t, filter{Name: &name, Limit: &limit}, dest)
+	}
+
+	// Whitespace after the commas of header lists is ignored
+	var dest filter
+	require.NoError(t, BindStyledParameterWithLocation("simple", true, "X-Filter", ParamLocationHeader, "limit=3, name=fido", &dest))
+	assert.Equal(t, filter{Name: &name, Limit: &limit}, dest)
+
+	var ids []int
+	require.NoError(t, BindStyledParameterWithLocation("simple", false, "X-Ids", ParamLocationHeader, "1, 2", &ids))
+	assert.Equal(t, []int{1, 2}, ids)
+
+	err := BindStyledParameterWithLocation("simple", true, "X-Filter", ParamLocationHeader, "limit=many", &dest)
+	var bindErr *BindError
+	require.True(t, errors.As(err, &bindErr))
+	assert.Equal(t, BindErrorType, bindErr.Kind)
+	assert.Equal(t, "limit", bindErr.Property)
+}
+
+// labeledObject unmarshals its own JSON, like the types with additional
+// properties do.
+type labeledObject struct {
+	Labels map[string]string
+}
+
+func (o *labeledObject) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &o.Labels)
+}
+
+func TestBindStyledObjectFields(t *testing.T) {
+	type Base struct {
+		Name string `json:"name"`
+	}
+	type Audit struct {
+		By string `json:"by"`
+	}
+	type derived struct {
+		Base
+		*Audit
+		Role string `json:"role"`
+	}
+
+	// The fields of embedded structs are bound too
+	for _, explode := range []bool{false, true} {
+		value := "name,x,role,y,by,z"
+		if explode {
+			value = "name=x,role=y,by=z"
+		}
+		var dest derived
+		require.NoError(t, BindStyledParameterWithLocation("simple", explode, "X-User", ParamLocationHeader, value, &dest))
+		assert.Equal(t, derived{Base: Base{Name: "x"}, Audit: &Audit{By: "z"}, Role: "y"}, dest)
+	}
+
+	// Embedded pointers are left nil when none of their fields are given
+	var dest derived
+	require.NoError(t, BindStyledParameter("simple", true, "user", "name=x", &dest))
+	assert.Equal(t, derived{Base: Base{Name: "x"}}, dest)
+
+	// Types unmarshaling their own JSON get all the values
+	var labeled labeledObject
+	require.NoError(t, BindStyledParameter("simple", true, "labels", "env=prod,team=pets", &labeled))
+	assert.Equal(t, map[string]string{"env": "prod", "team": "pets"}, labeled.Labels)
+}
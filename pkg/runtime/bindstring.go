@@ -14,6 +14,7 @@
 package runtime
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"reflect"
@@ -85,12 +86,27 @@ func BindStringToObject(src string, dst interface{}) error {
 		if err == nil {
 			v.SetBool(val)
 		}
+	case reflect.Array, reflect.Slice:
+		// Arrays, such as UUIDs, and slices, such as base64 bytes, which
+		// decode themselves from text
+		if tu, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			err = tu.UnmarshalText([]byte(src))
+		} else {
+			err = fmt.Errorf("can not bind to destination of type: %s", t.Kind())
+		}
 	case reflect.Struct:
 		// if this is not of type Time or of type Date look to see if this is of type Binder.
 		if dstType, ok := dst.(Binder); ok {
 			return dstType.Bind(src)
 		}
 
+		// Other structs which decode themselves from text, such as decimals
+		if tu, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok &&
+			!t.ConvertibleTo(reflect.TypeOf(time.Time{})) && !t.ConvertibleTo(reflect.TypeOf(types.Date{})) {
+			err = tu.UnmarshalText([]byte(src))
+			break
+		}
+
 		if t.ConvertibleTo(reflect.TypeOf(time.Time{})) {
 			// Don't fail on empty string.
 			if src == "" {
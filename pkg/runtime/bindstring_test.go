@@ -14,6 +14,8 @@
 package runtime
 
 import (
+	"encoding/hex"
+	"errors"
 	"testing"
 	"time"
 
@@ -169,4 +171,49 @@ func TestBindStringToObject(t *testing.T) {
 	var dstEmbeddedMockBinder EmbeddedMockBinder
 	assert.NoError(t, BindStringToObject(dateString, &dstEmbeddedMockBinder))
 	assert.EqualValues(t, dateString, dstEmbeddedMockBinder.Time.Format("2006-01-02"))
+
+	// Checks whether arrays which decode themselves from text, like UUIDs, work
+	var id testUUID
+	assert.NoError(t, BindStringToObject("000102030405060708090a0b0c0d0e0f", &id))
+	assert.Equal(t, testUUID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, id)
+	var optionalID *testUUID
+	assert.NoError(t, BindStringToObject("000102030405060708090a0b0c0d0e0f", &optionalID))
+	assert.Equal(t, &id, optionalID)
+	assert.Error(t, BindStringToObject("nope", &id))
+	var array [2]int
+	assert.Error(t, BindStringToObject("1", &array))
+
+	// And structs, like decimals
+	var price testDecimal
+	assert.NoError(t, BindStringToObject("12.50", &price))
+	assert.Equal(t, testDecimal{"12.50"}, price)
+}
+
+// testDecimal is a struct which is encoded as text, like the decimal types.
+type testDecimal struct {
+	value string
+}
+
+func (d testDecimal) MarshalText() ([]byte, error) {
+	return []byte(d.value), nil
+}
+
+func (d *testDecimal) UnmarshalText(text []byte) error {
+	d.value = string(text)
+	return nil
+}
+
+// testUUID is an array which is encoded as text, like the UUID types.
+type testUUID [16]byte
+
+func (u testUUID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(u[:])), nil
+}
+
+func (u *testUUID) UnmarshalText(text []byte) error {
+	if hex.DecodedLen(len(text)) != len(u) {
+		return errors.New("invalid UUID")
+	}
+	_, err := hex.Decode(u[:], text)
+	return err
 }
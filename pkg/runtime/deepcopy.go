@@ -0,0 +1,49 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import "encoding/json"
+
+// DeepCopyJSONValue deep copies an untyped value, as decoded by encoding/json,
+// for the DeepCopy methods of generated types. Maps, slices and raw messages
+// are copied, anything else is returned as is.
+func DeepCopyJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[key] = DeepCopyJSONValue(val)
+		}
+		return out
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = DeepCopyJSONValue(val)
+		}
+		return out
+	case json.RawMessage:
+		if v == nil {
+			return v
+		}
+		return append(json.RawMessage{}, v...)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeepCopyJSONValue(t *testing.T) {
+	var in interface{}
+	err := json.Unmarshal([]byte(`{"a": [1, {"b": "c"}], "d": true}`), &in)
+	assert.NoError(t, err)
+
+	out := DeepCopyJSONValue(in)
+	assert.Equal(t, in, out)
+
+	out.(map[string]interface{})["a"].([]interface{})[1].(map[string]interface{})["b"] = "changed"
+	out.(map[string]interface{})["d"] = false
+	assert.Equal(t, "c", in.(map[string]interface{})["a"].([]interface{})[1].(map[string]interface{})["b"])
+	assert.Equal(t, true, in.(map[string]interface{})["d"])
+
+	assert.Nil(t, DeepCopyJSONValue(nil))
+	assert.Equal(t, "s", DeepCopyJSONValue("s"))
+}
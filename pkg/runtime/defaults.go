@@ -0,0 +1,62 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"reflect"
+)
+
+// Defaulter is implemented by the generated types whose schemas declare
+// defaults, when they're generated with the apply-defaults option.
+type Defaulter interface {
+	// ApplyDefaults sets the unset fields to their defaults.
+	ApplyDefaults()
+}
+
+// ApplyDefaults sets the unset fields of the value which v points to to their
+// defaults, when it's a Defaulter, or else of the elements of the slice, array
+// or map which it points to. It's meant to be called on values decoded from
+// requests and responses, whose absent fields are unset.
+func ApplyDefaults(v interface{}) {
+	if d, ok := v.(Defaulter); ok {
+		d.ApplyDefaults()
+		return
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return
+	}
+	rv = rv.Elem()
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if !rv.IsNil() {
+			ApplyDefaults(rv.Interface())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			ApplyDefaults(rv.Index(i).Addr().Interface())
+		}
+	case reflect.Map:
+		if rv.Type().Elem().Kind() == reflect.Interface {
+			return
+		}
+		// Map elements aren't addressable, so they're replaced by copies.
+		for _, key := range rv.MapKeys() {
+			elem := reflect.New(rv.Type().Elem())
+			elem.Elem().Set(rv.MapIndex(key))
+			ApplyDefaults(elem.Interface())
+			rv.SetMapIndex(key, elem.Elem())
+		}
+	}
+}
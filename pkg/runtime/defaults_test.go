@@ -0,0 +1,62 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type defaultedPet struct {
+	Name string
+	Tag  *string
+}
+
+func (p *defaultedPet) ApplyDefaults() {
+	if p.Tag == nil {
+		v := "dog"
+		p.Tag = &v
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	cat := "cat"
+
+	pet := defaultedPet{Name: "Fido"}
+	ApplyDefaults(&pet)
+	assert.Equal(t, "dog", *pet.Tag)
+
+	pets := []defaultedPet{{Name: "Fido"}, {Name: "Tom", Tag: &cat}}
+	ApplyDefaults(&pets)
+	assert.Equal(t, "dog", *pets[0].Tag)
+	assert.Equal(t, "cat", *pets[1].Tag)
+
+	petPtr := &defaultedPet{Name: "Fido"}
+	ApplyDefaults(&petPtr)
+	assert.Equal(t, "dog", *petPtr.Tag)
+
+	petMap := map[string]defaultedPet{"fido": {Name: "Fido"}}
+	ApplyDefaults(&petMap)
+	assert.Equal(t, "dog", *petMap["fido"].Tag)
+
+	// Values without defaults are left alone
+	var nilPet *defaultedPet
+	ApplyDefaults(&nilPet)
+	assert.Nil(t, nilPet)
+	values := map[string]interface{}{"name": "Fido"}
+	ApplyDefaults(&values)
+	assert.Equal(t, map[string]interface{}{"name": "Fido"}, values)
+	ApplyDefaults(pet)
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ResponseTooLargeError is the error of reading a response body which is
+// larger than the limit of its client.
+type ResponseTooLargeError struct {
+	Limit int64 // The maximum size of the body, in bytes
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body is larger than the limit of %d bytes", e.Limit)
+}
+
+// LimitResponseBody makes reading the body of rsp fail with a
+// *ResponseTooLargeError once it's larger than limit bytes, or right away when
+// its Content-Length says so, rather than reading it all. The body isn't
+// limited when limit is zero or less.
+func LimitResponseBody(rsp *http.Response, limit int64) *http.Response {
+	if rsp == nil || rsp.Body == nil || limit <= 0 {
+		return rsp
+	}
+	rsp.Body = &limitedBody{
+		ReadCloser: rsp.Body,
+		limit:      limit,
+		remaining:  limit,
+		exceeded:   rsp.ContentLength > limit,
+	}
+	return rsp
+}
+
+// limitedBody is a response body which fails to be read beyond its limit.
+type limitedBody struct {
+	io.ReadCloser
+	limit     int64
+	remaining int64
+	exceeded  bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, &ResponseTooLargeError{Limit: b.limit}
+	}
+	// One more byte than remains tells a body which exceeds the limit from
+	// one which ends at it.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		b.exceeded = true
+		return n, &ResponseTooLargeError{Limit: b.limit}
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
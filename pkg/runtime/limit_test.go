@@ -0,0 +1,58 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitResponseBody(t *testing.T) {
+	newResponse := func(body string, contentLength int64) *http.Response {
+		return &http.Response{Body: ioutil.NopCloser(strings.NewReader(body)), ContentLength: contentLength}
+	}
+
+	// A body which ends at the limit is read whole
+	rsp := LimitResponseBody(newResponse("body", -1), 4)
+	body, err := ioutil.ReadAll(rsp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "body", string(body))
+
+	// A longer one fails once the limit is exceeded
+	rsp = LimitResponseBody(newResponse("larger body", -1), 4)
+	body, err = ioutil.ReadAll(rsp.Body)
+	var tooLarge *ResponseTooLargeError
+	assert.True(t, errors.As(err, &tooLarge))
+	assert.Equal(t, int64(4), tooLarge.Limit)
+	assert.Equal(t, "larg", string(body))
+
+	// Or right away, when its Content-Length is larger
+	rsp = LimitResponseBody(newResponse("larger body", 11), 4)
+	body, err = ioutil.ReadAll(rsp.Body)
+	assert.True(t, errors.As(err, &tooLarge))
+	assert.Empty(t, body)
+
+	// Bodies aren't limited without a limit
+	rsp = LimitResponseBody(newResponse("larger body", 11), 0)
+	body, err = ioutil.ReadAll(rsp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "larger body", string(body))
+
+	assert.Nil(t, LimitResponseBody(nil, 4))
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import "encoding/json"
+
+// Nullable is the value of a nullable field, which is either absent,
+// explicitly null, or set to a value, unlike a pointer, which can't tell
+// absent and null values apart.
+//
+// Like Opt, it's a map, so that encoding/json omits absent values from fields
+// tagged omitempty: an empty map is absent, a false key is null, and a true key
+// holds the value. Absent values of fields which aren't tagged omitempty are
+// encoded as null.
+type Nullable[T any] map[bool]T
+
+// NewNullable returns a Nullable which is set to value.
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T]{true: value}
+}
+
+// NewNullNullable returns a Nullable which is explicitly null.
+func NewNullNullable[T any]() Nullable[T] {
+	var zero T
+	return Nullable[T]{false: zero}
+}
+
+// IsSpecified tells whether the value is present, either null or set.
+func (n Nullable[T]) IsSpecified() bool {
+	return len(n) != 0
+}
+
+// IsNull tells whether the value is explicitly null.
+func (n Nullable[T]) IsNull() bool {
+	_, null := n[false]
+	return null
+}
+
+// Get returns the value, and whether it's set. It's false for absent and
+// null values.
+func (n Nullable[T]) Get() (T, bool) {
+	value, set := n[true]
+	return value, set
+}
+
+// Set sets the value.
+func (n *Nullable[T]) Set(value T) {
+	*n = NewNullable(value)
+}
+
+// SetNull makes the value explicitly null.
+func (n *Nullable[T]) SetNull() {
+	*n = NewNullNullable[T]()
+}
+
+// Unset makes the value absent.
+func (n *Nullable[T]) Unset() {
+	*n = nil
+}
+
+// MarshalJSON encodes the value, or null.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if value, set := n.Get(); set {
+		return json.Marshal(value)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the value, or null. It's not called for absent
+// values, which are left empty.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.SetNull()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Set(value)
+	return nil
+}
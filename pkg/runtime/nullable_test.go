@@ -0,0 +1,69 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullable(t *testing.T) {
+	type pet struct {
+		Name   Nullable[string] `json:"name"`
+		Tag    Nullable[string] `json:"tag,omitempty"`
+		Owners Nullable[[]int]  `json:"owners,omitempty"`
+	}
+
+	// Required fields are null when absent, optional ones are omitted
+	buf, err := json.Marshal(pet{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": null}`, string(buf))
+
+	buf, err = json.Marshal(pet{
+		Name:   NewNullable("rex"),
+		Tag:    NewNullNullable[string](),
+		Owners: NewNullable([]int{1, 2}),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "rex", "tag": null, "owners": [1, 2]}`, string(buf))
+
+	var decoded pet
+	err = json.Unmarshal([]byte(`{"name": "rex", "tag": null}`), &decoded)
+	assert.NoError(t, err)
+
+	name, set := decoded.Name.Get()
+	assert.True(t, set)
+	assert.Equal(t, "rex", name)
+
+	assert.True(t, decoded.Tag.IsSpecified())
+	assert.True(t, decoded.Tag.IsNull())
+	_, set = decoded.Tag.Get()
+	assert.False(t, set)
+
+	assert.False(t, decoded.Owners.IsSpecified())
+	assert.False(t, decoded.Owners.IsNull())
+
+	decoded.Owners.Set([]int{3})
+	decoded.Name.SetNull()
+	decoded.Tag.Unset()
+	buf, err = json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": null, "owners": [3]}`, string(buf))
+
+	err = json.Unmarshal([]byte(`{"owners": "none"}`), &decoded)
+	assert.Error(t, err)
+}
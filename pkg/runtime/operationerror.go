@@ -0,0 +1,65 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// OperationError is an error raised by the handler of a server operation,
+// which the generated wrappers pass to the error responder: either an error
+// the handler returned, or a panic it recovered from.
+type OperationError struct {
+	OperationID string
+	Err         error
+
+	// Panic is the value the handler panicked with, and Stack the stack
+	// it panicked from. They're nil for returned errors.
+	Panic interface{}
+	Stack []byte
+}
+
+// NewOperationError wraps the error returned by the handler of an operation.
+func NewOperationError(operationID string, err error) *OperationError {
+	return &OperationError{OperationID: operationID, Err: err}
+}
+
+// RecoverOperation turns the value recovered from a panic of the handler of
+// an operation into an error, with the stack it panicked from. It panics
+// again with http.ErrAbortHandler, which is meant to abort the response.
+func RecoverOperation(operationID string, p interface{}) *OperationError {
+	if p == http.ErrAbortHandler {
+		panic(p)
+	}
+	err, ok := p.(error)
+	if !ok {
+		err = fmt.Errorf("%v", p)
+	}
+	return &OperationError{
+		OperationID: operationID,
+		Err:         fmt.Errorf("panic: %w", err),
+		Panic:       p,
+		Stack:       debug.Stack(),
+	}
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation %s: %s", e.OperationID, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationError(t *testing.T) {
+	err := NewOperationError("getPet", io.EOF)
+	assert.Equal(t, "operation getPet: EOF", err.Error())
+	assert.True(t, errors.Is(err, io.EOF))
+	assert.Nil(t, err.Panic)
+
+	recovered := func(f func()) (err *OperationError) {
+		defer func() {
+			err = RecoverOperation("getPet", recover())
+		}()
+		f()
+		return nil
+	}
+
+	err = recovered(func() { panic("boom") })
+	assert.Equal(t, "operation getPet: panic: boom", err.Error())
+	assert.Equal(t, "boom", err.Panic)
+	assert.NotEmpty(t, err.Stack)
+
+	err = recovered(func() { panic(io.ErrUnexpectedEOF) })
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		recovered(func() { panic(http.ErrAbortHandler) })
+	})
+}
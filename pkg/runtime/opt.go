@@ -0,0 +1,93 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import "encoding/json"
+
+// Opt is a tri-state optional value, for the fields of JSON merge patches,
+// which are either absent, explicitly null, or set to a value.
+//
+// It's a map, so that encoding/json omits absent values from fields tagged
+// omitempty: an empty map is absent, a false key is null, and a true key
+// holds the value.
+type Opt[T any] map[bool]T
+
+// NewOpt returns an Opt which is set to value.
+func NewOpt[T any](value T) Opt[T] {
+	return Opt[T]{true: value}
+}
+
+// NewNullOpt returns an Opt which is explicitly null.
+func NewNullOpt[T any]() Opt[T] {
+	var zero T
+	return Opt[T]{false: zero}
+}
+
+// IsSpecified tells whether the value is present, either null or set.
+func (o Opt[T]) IsSpecified() bool {
+	return len(o) != 0
+}
+
+// IsNull tells whether the value is explicitly null.
+func (o Opt[T]) IsNull() bool {
+	_, null := o[false]
+	return null
+}
+
+// Get returns the value, and whether it's set. It's false for absent and
+// null values.
+func (o Opt[T]) Get() (T, bool) {
+	value, set := o[true]
+	return value, set
+}
+
+// Set sets the value.
+func (o *Opt[T]) Set(value T) {
+	*o = NewOpt(value)
+}
+
+// SetNull makes the value explicitly null.
+func (o *Opt[T]) SetNull() {
+	*o = NewNullOpt[T]()
+}
+
+// Unset makes the value absent.
+func (o *Opt[T]) Unset() {
+	*o = nil
+}
+
+// MarshalJSON encodes the value, or null. Absent values are omitted from
+// fields tagged omitempty, before it's called.
+func (o Opt[T]) MarshalJSON() ([]byte, error) {
+	if value, set := o.Get(); set {
+		return json.Marshal(value)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the value, or null. It's not called for absent
+// values, which are left empty.
+func (o *Opt[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		o.SetNull()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Set(value)
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpt(t *testing.T) {
+	type patch struct {
+		Name Opt[string] `json:"name,omitempty"`
+		Tag  Opt[string] `json:"tag,omitempty"`
+		Age  Opt[int]    `json:"age,omitempty"`
+	}
+
+	p := patch{
+		Name: NewOpt("rex"),
+		Tag:  NewNullOpt[string](),
+	}
+	buf, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "rex", "tag": null}`, string(buf))
+
+	var decoded patch
+	err = json.Unmarshal([]byte(`{"name": "rex", "tag": null}`), &decoded)
+	assert.NoError(t, err)
+
+	name, set := decoded.Name.Get()
+	assert.True(t, set)
+	assert.Equal(t, "rex", name)
+
+	assert.True(t, decoded.Tag.IsSpecified())
+	assert.True(t, decoded.Tag.IsNull())
+	_, set = decoded.Tag.Get()
+	assert.False(t, set)
+
+	assert.False(t, decoded.Age.IsSpecified())
+	assert.False(t, decoded.Age.IsNull())
+
+	decoded.Age.Set(3)
+	decoded.Name.Unset()
+	decoded.Tag.SetNull()
+	buf, err = json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"tag": null, "age": 3}`, string(buf))
+
+	err = json.Unmarshal([]byte(`{"age": "old"}`), &decoded)
+	assert.Error(t, err)
+}
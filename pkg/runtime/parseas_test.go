@@ -0,0 +1,32 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAs(t *testing.T) {
+	rsp := NewLazyResponse(newTestResponse("application/json", `{"name": "Rex"}`))
+	pet, err := ParseAs[map[string]string](rsp)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "Rex"}, pet)
+
+	_, err = ParseAs[int](NewLazyResponse(newTestResponse("application/json", `"Rex"`)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimiter limits the rate of the requests of a generated client, eg, with
+// a token bucket per operation.
+type RateLimiter interface {
+	// Wait blocks until a request of the operation with the given ID can be
+	// sent, or returns an error, such as the one of ctx when it's done
+	// first, when it can't be.
+	Wait(ctx context.Context, operationID string) error
+}
+
+// RateLimiterFunc is a function which is a RateLimiter, eg, one waiting on
+// the golang.org/x/time/rate limiter of the operation.
+type RateLimiterFunc func(ctx context.Context, operationID string) error
+
+// Wait calls f.
+func (f RateLimiterFunc) Wait(ctx context.Context, operationID string) error {
+	return f(ctx, operationID)
+}
+
+// MinRetryAfter is the shortest wait before retrying a request which was
+// rate limited, so that a server asking to retry right away, with a zero
+// delay or a date in the past, isn't sent requests without pause.
+const MinRetryAfter = time.Second
+
+// RetryAfter returns how long to wait before retrying a request which got
+// rsp, when it was rate limited, with a 429 status, and a Retry-After header
+// giving a delay in seconds, or a date, after now. The wait is at least
+// MinRetryAfter.
+func RetryAfter(rsp *http.Response, now time.Time) (time.Duration, bool) {
+	if rsp == nil || rsp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	value := strings.TrimSpace(rsp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = date.Sub(now)
+	} else {
+		return 0, false
+	}
+	if wait < MinRetryAfter {
+		wait = MinRetryAfter
+	}
+	return wait, true
+}
+
+// Sleep waits for d, or returns the error of ctx when it's done first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	response := func(status int, retryAfter string) *http.Response {
+		rsp := &http.Response{StatusCode: status, Header: http.Header{}}
+		if retryAfter != "" {
+			rsp.Header.Set("Retry-After", retryAfter)
+		}
+		return rsp
+	}
+
+	wait, ok := RetryAfter(response(http.StatusTooManyRequests, "3"), now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = RetryAfter(response(http.StatusTooManyRequests, "Tue, 01 Jun 2021 12:00:05 GMT"), now)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+
+	// Retrying right away, or at a date in the past, waits the minimum
+	wait, ok = RetryAfter(response(http.StatusTooManyRequests, "0"), now)
+	assert.True(t, ok)
+	assert.Equal(t, MinRetryAfter, wait)
+	wait, ok = RetryAfter(response(http.StatusTooManyRequests, "Tue, 01 Jun 2021 11:00:00 GMT"), now)
+	assert.True(t, ok)
+	assert.Equal(t, MinRetryAfter, wait)
+
+	_, ok = RetryAfter(response(http.StatusTooManyRequests, ""), now)
+	assert.False(t, ok)
+	_, ok = RetryAfter(response(http.StatusTooManyRequests, "soon"), now)
+	assert.False(t, ok)
+	_, ok = RetryAfter(response(http.StatusServiceUnavailable, "3"), now)
+	assert.False(t, ok)
+	_, ok = RetryAfter(nil, now)
+	assert.False(t, ok)
+}
+
+func TestSleep(t *testing.T) {
+	assert.NoError(t, Sleep(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, Sleep(ctx, time.Hour))
+}
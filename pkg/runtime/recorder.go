@@ -0,0 +1,339 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+type operationIDKey struct{}
+
+// ContextWithOperationID returns a copy of ctx which carries the ID of the
+// operation of a request, which the generated clients send their requests
+// with.
+func ContextWithOperationID(ctx context.Context, operationID string) context.Context {
+	return context.WithValue(ctx, operationIDKey{}, operationID)
+}
+
+// OperationIDFromContext returns the ID of the operation of the request with
+// ctx, or an empty string when it isn't sent by a generated client.
+func OperationIDFromContext(ctx context.Context) string {
+	operationID, _ := ctx.Value(operationIDKey{}).(string)
+	return operationID
+}
+
+// RecorderMode is whether a Recorder records responses, or replays them.
+type RecorderMode int
+
+const (
+	// RecorderReplay replays the recorded responses, and fails the requests
+	// which haven't been recorded.
+	RecorderReplay RecorderMode = iota
+	// RecorderRecord sends the requests, and records their responses, over
+	// the ones which were recorded before.
+	RecorderRecord
+	// RecorderReplayOrRecord replays the recorded responses, and sends, and
+	// records, the requests which haven't been recorded.
+	RecorderReplayOrRecord
+)
+
+// RedactedValue replaces the values of the headers which are redacted from
+// the recorded interactions.
+const RedactedValue = "REDACTED"
+
+// redactedHeaders are the headers which always are redacted from recorded
+// interactions, since they hold credentials.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Recorder is an http.RoundTripper for tests, which records the responses
+// to the requests of clients to golden files, and replays them. Each request
+// is recorded to its own file in Dir, named after its operation, as told by
+// OperationIDFromContext, and a hash of its method, path, query and body, so
+// the requests are replayed whatever the server, and their headers are. A
+// request which is sent again replays the last response to it.
+type Recorder struct {
+	// Dir is the directory of the golden files.
+	Dir string
+
+	// Mode is whether the responses are recorded, or replayed.
+	Mode RecorderMode
+
+	// Transport sends the requests which are recorded, http.DefaultTransport
+	// when it's nil.
+	Transport http.RoundTripper
+
+	// Redact, when set, removes the secrets from the interactions which are
+	// recorded, besides the values of the Authorization, Cookie and
+	// Set-Cookie headers, which always are.
+	Redact func(interaction *RecordedInteraction)
+}
+
+// Doer sends requests, like *http.Client does.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// doerTransport is an http.RoundTripper which sends the requests with a Doer.
+type doerTransport struct {
+	doer Doer
+}
+
+func (t doerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.doer.Do(req)
+}
+
+// WithDoer returns a Recorder like r, which sends the requests it records
+// with doer, such as the *http.Client of a client, unless r has a Transport
+// of its own, or doer is nil. r isn't changed.
+func (r *Recorder) WithDoer(doer Doer) *Recorder {
+	with := *r
+	if with.Transport == nil && doer != nil {
+		with.Transport = doerTransport{doer}
+	}
+	return &with
+}
+
+// RecordedInteraction is a request and its response, as they're recorded in
+// golden files.
+type RecordedInteraction struct {
+	OperationID string           `json:"operationId,omitempty"`
+	Request     RecordedRequest  `json:"request"`
+	Response    RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	RecordedBody
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	RecordedBody
+}
+
+// RecordedBody is the body of a recorded request or response, which is
+// recorded as a string, or base64 encoded when it isn't UTF-8.
+type RecordedBody struct {
+	Body       string `json:"body,omitempty"`
+	BodyBase64 string `json:"bodyBase64,omitempty"`
+}
+
+func newRecordedBody(body []byte) RecordedBody {
+	if utf8.Valid(body) {
+		return RecordedBody{Body: string(body)}
+	}
+	return RecordedBody{BodyBase64: base64.StdEncoding.EncodeToString(body)}
+}
+
+// Bytes returns the recorded body.
+func (b RecordedBody) Bytes() ([]byte, error) {
+	if b.BodyBase64 != "" {
+		return base64.StdEncoding.DecodeString(b.BodyBase64)
+	}
+	return []byte(b.Body), nil
+}
+
+// RoundTrip replays the response to req, or sends it and records its
+// response, as per the Mode.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	operationID := OperationIDFromContext(req.Context())
+	file := filepath.Join(r.Dir, recordingName(operationID, req, body))
+
+	if r.Mode != RecorderRecord {
+		data, err := ioutil.ReadFile(file)
+		if err == nil {
+			return replay(req, file, data)
+		}
+		if !errors.Is(err, os.ErrNotExist) || r.Mode == RecorderReplay {
+			return nil, fmt.Errorf("no recorded response to %s %s: %w", req.Method, req.URL, err)
+		}
+	}
+	return r.record(req, body, operationID, file)
+}
+
+// recordingName returns the name of the golden file of the request.
+func recordingName(operationID string, req *http.Request, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s?%s\n", req.Method, req.URL.EscapedPath(), req.URL.RawQuery)
+	hash.Write(body)
+	if operationID == "" {
+		operationID = "request"
+	}
+	return fmt.Sprintf("%s-%s.json", operationID, hex.EncodeToString(hash.Sum(nil))[:16])
+}
+
+// replay returns the response recorded in the golden file, with the content
+// data.
+func replay(req *http.Request, file string, data []byte) (*http.Response, error) {
+	var interaction RecordedInteraction
+	if err := json.Unmarshal(data, &interaction); err != nil {
+		return nil, fmt.Errorf("error decoding recorded response %s: %w", file, err)
+	}
+	body, err := interaction.Response.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("error decoding recorded response %s: %w", file, err)
+	}
+	status := interaction.Response.StatusCode
+	header := interaction.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record sends the request, whose body was read already, and records its
+// response in the golden file.
+func (r *Recorder) record(req *http.Request, body []byte, operationID string, file string) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	sent := req.Clone(req.Context())
+	if req.Body != nil {
+		sent.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	rsp, err := transport.RoundTrip(sent)
+	if err != nil {
+		return nil, err
+	}
+	rspBody, err := ioutil.ReadAll(rsp.Body)
+	rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	rsp.Body = ioutil.NopCloser(bytes.NewReader(rspBody))
+
+	interaction := RecordedInteraction{
+		OperationID: operationID,
+		Request: RecordedRequest{
+			Method:       req.Method,
+			URL:          req.URL.String(),
+			Header:       redactHeader(req.Header),
+			RecordedBody: newRecordedBody(body),
+		},
+		Response: RecordedResponse{
+			StatusCode:   rsp.StatusCode,
+			Header:       redactHeader(rsp.Header),
+			RecordedBody: newRecordedBody(rspBody),
+		},
+	}
+	if r.Redact != nil {
+		r.Redact(&interaction)
+	}
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(file, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("error recording response to %s %s: %w", req.Method, req.URL, err)
+	}
+	return rsp, nil
+}
+
+// redactHeader returns a copy of header, without the values of the headers
+// which hold credentials.
+func redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	header = header.Clone()
+	for _, name := range redactedHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			header[http.CanonicalHeaderKey(name)] = []string{RedactedValue}
+		}
+	}
+	return header
+}
+
+// RedactJSONFields returns a Redact hook for a Recorder, which replaces the
+// values of the fields with the given names, at any depth, of the JSON bodies
+// of the requests and responses with RedactedValue, eg, "password" or
+// "access_token".
+func RedactJSONFields(names ...string) func(interaction *RecordedInteraction) {
+	redacted := make(map[string]bool, len(names))
+	for _, name := range names {
+		redacted[name] = true
+	}
+	redactBody := func(body *RecordedBody) {
+		var value interface{}
+		if body.Body == "" || json.Unmarshal([]byte(body.Body), &value) != nil {
+			return
+		}
+		data, err := json.Marshal(redactJSON(value, redacted))
+		if err == nil {
+			body.Body = string(data)
+		}
+	}
+	return func(interaction *RecordedInteraction) {
+		redactBody(&interaction.Request.RecordedBody)
+		redactBody(&interaction.Response.RecordedBody)
+	}
+}
+
+func redactJSON(value interface{}, redacted map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if redacted[name] {
+				v[name] = RedactedValue
+			} else {
+				v[name] = redactJSON(field, redacted)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item, redacted)
+		}
+	}
+	return value
+}
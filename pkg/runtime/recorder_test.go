@@ -0,0 +1,100 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = w.Write([]byte(`{"echo":` + string(body) + `,"token":"secret"}`))
+	}))
+	defer server.Close()
+
+	send := func(recorder *Recorder, body string) (string, error) {
+		ctx := ContextWithOperationID(context.Background(), "AddPet")
+		req, err := http.NewRequestWithContext(ctx, "POST", server.URL+"/pets?tag=dog", strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer secret")
+		rsp, err := (&http.Client{Transport: recorder}).Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer rsp.Body.Close()
+		data, err := ioutil.ReadAll(rsp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", rsp.Header.Get("Content-Type"))
+		return string(data), nil
+	}
+
+	recorder := &Recorder{Dir: dir, Mode: RecorderRecord, Redact: RedactJSONFields("token")}
+	body, err := send(recorder, `{"name":"Fido"}`)
+	assert.NoError(t, err)
+	// The response isn't redacted, only its recording
+	assert.Equal(t, `{"echo":{"name":"Fido"},"token":"secret"}`, body)
+	assert.Equal(t, 1, requests)
+
+	files, err := filepath.Glob(filepath.Join(dir, "AddPet-*.json"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	recording, err := ioutil.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.NotContains(t, string(recording), "secret")
+	assert.Contains(t, string(recording), `"Authorization": [`)
+
+	recorder.Mode = RecorderReplay
+	body, err = send(recorder, `{"name":"Fido"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"echo":{"name":"Fido"},"token":"REDACTED"}`, body)
+	assert.Equal(t, 1, requests)
+
+	// Other bodies aren't recorded
+	_, err = send(recorder, `{"name":"Rex"}`)
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+
+	recorder.Mode = RecorderReplayOrRecord
+	body, err = send(recorder, `{"name":"Rex"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"echo":{"name":"Rex"},"token":"secret"}`, body)
+	_, err = send(recorder, `{"name":"Rex"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestRecordedBody(t *testing.T) {
+	body := newRecordedBody([]byte{0xff, 0x00})
+	assert.Empty(t, body.Body)
+	data, err := body.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0x00}, data)
+
+	body = newRecordedBody([]byte("text"))
+	assert.Equal(t, "text", body.Body)
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LazyResponse is an HTTP response whose body is only read when it's needed,
+// and only decoded into the type which the caller asks for. This is what the
+// lazy clients return, so that callers who only care about some statuses
+// don't pay for decoding the others.
+type LazyResponse struct {
+	HTTPResponse *http.Response
+
+	body    []byte
+	bodyErr error
+	read    bool
+}
+
+// NewLazyResponse wraps rsp, without reading its body.
+func NewLazyResponse(rsp *http.Response) *LazyResponse {
+	return &LazyResponse{HTTPResponse: rsp}
+}
+
+// Status returns HTTPResponse.Status
+func (r *LazyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r *LazyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// Header returns the headers of the response.
+func (r *LazyResponse) Header() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// Body returns a reader of the body. Until the body has been read, it
+// streams it from the connection, and it's up to the caller to close it.
+func (r *LazyResponse) Body() io.ReadCloser {
+	if !r.read && r.HTTPResponse != nil && r.HTTPResponse.Body != nil {
+		r.read = true
+		return r.HTTPResponse.Body
+	}
+	return ioutil.NopCloser(bytes.NewReader(r.body))
+}
+
+// Bytes reads the whole body, and closes it. It's only read once, the
+// following calls return the same bytes.
+func (r *LazyResponse) Bytes() ([]byte, error) {
+	if !r.read {
+		r.read = true
+		if r.HTTPResponse != nil && r.HTTPResponse.Body != nil {
+			r.body, r.bodyErr = ioutil.ReadAll(r.HTTPResponse.Body)
+			_ = r.HTTPResponse.Body.Close()
+		}
+	}
+	return r.body, r.bodyErr
+}
+
+// Close discards the body, when it hasn't been read.
+func (r *LazyResponse) Close() error {
+	if r.read || r.HTTPResponse == nil || r.HTTPResponse.Body == nil {
+		return nil
+	}
+	r.read = true
+	return r.HTTPResponse.Body.Close()
+}
+
+// Decode unmarshals the body into dest, as XML or YAML when the content type
+// of the response says so, and as JSON otherwise.
+func (r *LazyResponse) Decode(dest interface{}) error {
+	body, err := r.Bytes()
+	if err != nil {
+		return err
+	}
+	contentType := r.Header().Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "xml"):
+		return xml.Unmarshal(body, dest)
+	case strings.Contains(contentType, "yaml"):
+		return yaml.Unmarshal(body, dest)
+	default:
+		return json.Unmarshal(body, dest)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResponse(contentType string, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {contentType}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestLazyResponse(t *testing.T) {
+	type Pet struct {
+		Name string `json:"name" xml:"name" yaml:"name"`
+	}
+
+	tests := []struct {
+		contentType string
+		body        string
+	}{
+		{"application/json", `{"name": "Rex"}`},
+		{"application/xml", `<Pet><name>Rex</name></Pet>`},
+		{"application/yaml", `name: Rex`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			rsp := NewLazyResponse(newTestResponse(tt.contentType, tt.body))
+			assert.Equal(t, http.StatusOK, rsp.StatusCode())
+
+			var pet Pet
+			require.NoError(t, rsp.Decode(&pet))
+			assert.Equal(t, "Rex", pet.Name)
+
+			// The body is kept once it's been read
+			body, err := rsp.Bytes()
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+
+	// The body can be streamed instead
+	rsp := NewLazyResponse(newTestResponse("text/plain", "raw"))
+	body, err := ioutil.ReadAll(rsp.Body())
+	require.NoError(t, err)
+	assert.Equal(t, "raw", string(body))
+}
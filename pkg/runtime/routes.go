@@ -0,0 +1,68 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Route is the route of an operation which a generated server registers.
+// The server options name the routes with their RouteName, and report them
+// to OnRoute.
+type Route struct {
+	OperationID string
+	// Name is the name which RouteName gives the route, if any. Echo v4 and
+	// iris routes are registered under it.
+	Name    string
+	Method  string
+	BaseURL string
+	// Path is the path of the operation in the spec, eg, "/pets/{id}".
+	Path string
+	// RouterPath is the path which the route is registered with, in the
+	// syntax of the router, under BaseURL, eg, "/api/pets/:id".
+	RouterPath string
+}
+
+// Reverse returns the path of the route under BaseURL, with the path
+// parameters of Path replaced by params, in order, which are styled like the
+// clients style them.
+func (r Route) Reverse(params ...interface{}) (string, error) {
+	var path strings.Builder
+	path.WriteString(r.BaseURL)
+	rest := r.Path
+	for i := 0; ; i++ {
+		start := strings.Index(rest, "{")
+		end := strings.Index(rest, "}")
+		if start < 0 || end < start {
+			if i != len(params) {
+				return "", fmt.Errorf("route %s has %d path parameters, got %d", r.Path, i, len(params))
+			}
+			path.WriteString(rest)
+			return path.String(), nil
+		}
+		if i >= len(params) {
+			return "", fmt.Errorf("route %s has more path parameters than the %d given", r.Path, len(params))
+		}
+		name := rest[start+1 : end]
+		value, err := StyleParamWithLocation("simple", false, name, ParamLocationPath, params[i])
+		if err != nil {
+			return "", fmt.Errorf("error styling path parameter %s of route %s: %w", name, r.Path, err)
+		}
+		path.WriteString(rest[:start])
+		path.WriteString(value)
+		rest = rest[end+1:]
+	}
+}
@@ -0,0 +1,29 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteReverse(t *testing.T) {
+	route := Route{
+		OperationID: "GetPetPhoto",
+		Method:      "GET",
+		BaseURL:     "/api",
+		Path:        "/pets/{id}/photos/{name}",
+		RouterPath:  "/api/pets/:id/photos/:name",
+	}
+	path, err := route.Reverse(42, "a b.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/pets/42/photos/a%20b.png", path)
+
+	_, err = route.Reverse(42)
+	assert.EqualError(t, err, "route /pets/{id}/photos/{name} has more path parameters than the 1 given")
+	_, err = route.Reverse(42, "a", "b")
+	assert.EqualError(t, err, "route /pets/{id}/photos/{name} has 2 path parameters, got 3")
+
+	path, err = Route{Path: "/pets"}.Reverse()
+	assert.NoError(t, err)
+	assert.Equal(t, "/pets", path)
+}
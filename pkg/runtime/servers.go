@@ -0,0 +1,162 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var serverVariableRE = regexp.MustCompile(`{([^{}]+)}`)
+
+// ServerVariable describes a variable in a server URL template, as declared
+// in the servers section of an OpenAPI spec.
+type ServerVariable struct {
+	Default string
+	Enum    []string
+}
+
+// LookupServerVariables returns the variables which declared holds for server,
+// by the URL of the servers of the spec, or nil when the server isn't one of
+// them. Trailing slashes are ignored, since clients add one to their server.
+func LookupServerVariables(declared map[string]map[string]ServerVariable, server string) map[string]ServerVariable {
+	if variables, found := declared[server]; found {
+		return variables
+	}
+	trimmed := strings.TrimSuffix(server, "/")
+	for url, variables := range declared {
+		if strings.TrimSuffix(url, "/") == trimmed {
+			return variables
+		}
+	}
+	return nil
+}
+
+// SubstituteServerVariables replaces the {variable} placeholders in a server
+// URL template with the given values. Variables without a value fall back to
+// the default declared in the spec, and values are checked against the enum
+// of their variable. When declared is nil, the server isn't known from the
+// spec, so values are substituted without any validation.
+func SubstituteServerVariables(server string, declared map[string]ServerVariable, values map[string]string) (string, error) {
+	if declared != nil {
+		var names []string
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if _, found := declared[name]; !found {
+				return "", fmt.Errorf("server '%s' has no variable '%s'", server, name)
+			}
+		}
+	}
+
+	var err error
+	result := serverVariableRE.ReplaceAllStringFunc(server, func(placeholder string) string {
+		name := strings.Trim(placeholder, "{}")
+		variable := declared[name]
+		value, found := values[name]
+		if !found {
+			value = variable.Default
+		}
+		if value == "" {
+			if err == nil {
+				err = fmt.Errorf("no value for variable '%s' of server '%s'", name, server)
+			}
+			return placeholder
+		}
+		if len(variable.Enum) > 0 && !stringInSlice(value, variable.Enum) {
+			if err == nil {
+				err = fmt.Errorf("value '%s' of server variable '%s' isn't one of %s", value, name, strings.Join(variable.Enum, ", "))
+			}
+			return placeholder
+		}
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// ServerPolicy is how a generated client with several servers picks the
+// servers of its requests.
+type ServerPolicy int
+
+const (
+	// ServerFailover sends every request to the first server, and fails over
+	// to the next ones, in order, when it can't be reached, or, for
+	// idempotent requests, responds with a 5xx status.
+	ServerFailover ServerPolicy = iota
+	// ServerRoundRobin sends each request to the next server in turn,
+	// without failing over.
+	ServerRoundRobin
+	// ServerFailoverAnyMethod is like ServerFailover, except that requests
+	// of any method fail over on 5xx statuses, for servers which don't
+	// process the requests they fail, or operations which can be repeated.
+	ServerFailoverAnyMethod
+)
+
+// ServerOrder returns the servers which the n-th request of a client is sent
+// to, in order, as per policy.
+func ServerOrder(policy ServerPolicy, servers []string, n uint32) []string {
+	if policy == ServerRoundRobin && len(servers) > 0 {
+		i := int(n % uint32(len(servers)))
+		return servers[i : i+1]
+	}
+	return servers
+}
+
+// ShouldFailOver tells whether req, which got rsp, or err, is sent to the
+// next server as per policy: when it failed, except for ctx being done, or
+// the server responded with a 5xx status to an idempotent request. Requests
+// of any method fail over on 5xx statuses with ServerFailoverAnyMethod.
+func ShouldFailOver(ctx context.Context, policy ServerPolicy, req *http.Request, rsp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	if rsp.StatusCode < 500 {
+		return false
+	}
+	return policy == ServerFailoverAnyMethod || isIdempotent(req)
+}
+
+// isIdempotent tells whether req can be sent again with the same effect, as
+// its method is idempotent, or it has an idempotency key, like
+// net/http.Transport does.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	for _, key := range []string{"Idempotency-Key", "X-Idempotency-Key"} {
+		if _, ok := req.Header[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func stringInSlice(a string, list []string) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
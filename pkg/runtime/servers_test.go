@@ -0,0 +1,99 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubstituteServerVariables(t *testing.T) {
+	server := "https://{region}.example.com:{port}/v1"
+	declared := map[string]ServerVariable{
+		"region": {Default: "us", Enum: []string{"us", "eu"}},
+		"port":   {Default: "443"},
+	}
+
+	url, err := SubstituteServerVariables(server, declared, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://us.example.com:443/v1", url)
+
+	url, err = SubstituteServerVariables(server, declared, map[string]string{"region": "eu", "port": "8443"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://eu.example.com:8443/v1", url)
+
+	// Values must be one of the enum values
+	_, err = SubstituteServerVariables(server, declared, map[string]string{"region": "ap"})
+	assert.Error(t, err)
+
+	// Variables which aren't declared are rejected
+	_, err = SubstituteServerVariables(server, declared, map[string]string{"zone": "a"})
+	assert.Error(t, err)
+
+	// Variables without a default need a value
+	_, err = SubstituteServerVariables("https://{tenant}.example.com", map[string]ServerVariable{"tenant": {}}, nil)
+	assert.Error(t, err)
+
+	// Servers which weren't declared are substituted without validation
+	url, err = SubstituteServerVariables("https://{tenant}.example.com", nil, map[string]string{"tenant": "acme"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://acme.example.com", url)
+}
+
+func TestLookupServerVariables(t *testing.T) {
+	declared := map[string]map[string]ServerVariable{
+		"https://{region}.example.com":  {"region": {Default: "us"}},
+		"https://api.example.com/v1/":   {},
+		"https://{tenant}.example.org/": {"tenant": {}},
+	}
+	assert.Contains(t, LookupServerVariables(declared, "https://{region}.example.com/"), "region")
+	assert.Contains(t, LookupServerVariables(declared, "https://{tenant}.example.org"), "tenant")
+	assert.NotNil(t, LookupServerVariables(declared, "https://api.example.com/v1"))
+	assert.Nil(t, LookupServerVariables(declared, "https://other.example.com/"))
+}
+
+func TestServerOrder(t *testing.T) {
+	servers := []string{"a", "b", "c"}
+
+	assert.Equal(t, servers, ServerOrder(ServerFailover, servers, 0))
+	assert.Equal(t, servers, ServerOrder(ServerFailover, servers, 4))
+	assert.Equal(t, []string{"a"}, ServerOrder(ServerRoundRobin, servers, 0))
+	assert.Equal(t, []string{"b"}, ServerOrder(ServerRoundRobin, servers, 4))
+	assert.Empty(t, ServerOrder(ServerRoundRobin, nil, 1))
+}
+
+func TestShouldFailOver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	get := &http.Request{Method: http.MethodGet}
+	post := &http.Request{Method: http.MethodPost, Header: http.Header{}}
+
+	assert.True(t, ShouldFailOver(ctx, ServerFailover, get, nil, errors.New("connection refused")))
+	assert.True(t, ShouldFailOver(ctx, ServerFailover, post, nil, errors.New("connection refused")))
+	assert.True(t, ShouldFailOver(ctx, ServerFailover, get, &http.Response{StatusCode: http.StatusBadGateway}, nil))
+	assert.False(t, ShouldFailOver(ctx, ServerFailover, get, &http.Response{StatusCode: http.StatusNotFound}, nil))
+
+	// Requests which aren't idempotent are only failed over on 5xx statuses
+	// when the policy allows it, or they have an idempotency key
+	assert.False(t, ShouldFailOver(ctx, ServerFailover, post, &http.Response{StatusCode: http.StatusBadGateway}, nil))
+	assert.True(t, ShouldFailOver(ctx, ServerFailoverAnyMethod, post, &http.Response{StatusCode: http.StatusBadGateway}, nil))
+	post.Header.Set("Idempotency-Key", "8e03978e")
+	assert.True(t, ShouldFailOver(ctx, ServerFailover, post, &http.Response{StatusCode: http.StatusBadGateway}, nil))
+
+	cancel()
+	assert.False(t, ShouldFailOver(ctx, ServerFailover, get, nil, ctx.Err()))
+}
@@ -14,6 +14,7 @@
 package runtime
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"net/url"
@@ -64,6 +65,14 @@ func StyleParamWithLocation(style string, explode bool, paramName string, paramL
 
 	switch t.Kind() {
 	case reflect.Slice:
+		// Slices which encode themselves as text, such as base64 bytes, are
+		// styled as primitives.
+		if text, ok, err := marshalTextValue(value); ok {
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal '%s' as text: %w", paramName, err)
+			}
+			return stylePrimitive(style, explode, paramName, paramLocation, text)
+		}
 		n := v.Len()
 		sliceVal := make([]interface{}, n)
 		for i := 0; i < n; i++ {
@@ -173,12 +182,27 @@ func marshalDateTimeValue(value interface{}) (string, bool) {
 	if t.ConvertibleTo(reflect.TypeOf(types.Date{})) {
 		d := v.Convert(reflect.TypeOf(types.Date{}))
 		dateVal := d.Interface().(types.Date)
+		if lt, ok := v.Interface().(types.LayoutTime); ok {
+			return types.FormatTime(dateVal.Time, lt.TimeLayout()), true
+		}
 		return dateVal.Format(types.DateFormat), true
 	}
 
 	return "", false
 }
 
+// marshalTextValue returns the text of a value which implements
+// encoding.TextMarshaler, and whether it does.
+func marshalTextValue(value interface{}) (string, bool, error) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	m, ok := v.Interface().(encoding.TextMarshaler)
+	if !ok {
+		return "", false, nil
+	}
+	text, err := m.MarshalText()
+	return string(text), true, err
+}
+
 func styleStruct(style string, explode bool, paramName string, paramLocation ParamLocation, value interface{}) (string, error) {
 
 	if timeVal, ok := marshalDateTimeValue(value); ok {
@@ -189,6 +213,15 @@ func styleStruct(style string, explode bool, paramName string, paramLocation Par
 		return styledVal, nil
 	}
 
+	// Structs which encode themselves as text, such as decimals, are styled
+	// as primitives too.
+	if text, ok, err := marshalTextValue(value); ok {
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal '%s' as text: %w", paramName, err)
+		}
+		return stylePrimitive(style, explode, paramName, paramLocation, text)
+	}
+
 	if style == "deepObject" {
 		if !explode {
 			return "", errors.New("deepObjects must be exploded")
@@ -368,6 +401,22 @@ func primitiveToString(value interface{}) (string, error) {
 		}
 	case reflect.String:
 		output = v.String()
+	case reflect.Array, reflect.Slice, reflect.Struct:
+		// Times and dates, such as the items of arrays of them, and the
+		// values which encode themselves as text, such as UUIDs and base64
+		// bytes.
+		if timeVal, ok := marshalDateTimeValue(value); ok {
+			output = timeVal
+			break
+		}
+		text, ok, err := marshalTextValue(value)
+		if !ok {
+			return "", fmt.Errorf("unsupported type %s", reflect.TypeOf(value).String())
+		}
+		if err != nil {
+			return "", err
+		}
+		output = text
 	default:
 		return "", fmt.Errorf("unsupported type %s", reflect.TypeOf(value).String())
 	}
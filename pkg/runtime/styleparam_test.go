@@ -505,6 +505,39 @@ func TestStyleParam(t *testing.T) {
 		FirstName *string `json:"firstName"`
 		Role      *string `json:"role"`
 	}
+	result, err = StyleParamWithLocation("simple", false, "born", ParamLocationQuery, compactDate{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "20200101", result)
+
+	result, err = StyleParamWithLocation("form", true, "born", ParamLocationQuery, []compactDate{{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, {time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "born=20200101&born=20210203", result)
+
+	id := testUUID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	result, err = StyleParamWithLocation("simple", false, "id", ParamLocationPath, id)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "000102030405060708090a0b0c0d0e0f", result)
+
+	result, err = StyleParamWithLocation("form", true, "id", ParamLocationQuery, []testUUID{id, {}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=000102030405060708090a0b0c0d0e0f&id=00000000000000000000000000000000", result)
+
+	result, err = StyleParamWithLocation("form", true, "key", ParamLocationQuery, types.Base64{0xfb, 0xff})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "key=%2B%2F8%3D", result)
+
+	result, err = StyleParamWithLocation("simple", false, "keys", ParamLocationPath, []types.Base64URL{{0xfb, 0xff}, {0x01}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "-_8=,AQ==", result)
+
+	result, err = StyleParamWithLocation("form", true, "price", ParamLocationQuery, testDecimal{"12.50"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "price=12.50", result)
+
+	result, err = StyleParamWithLocation("simple", false, "price", ParamLocationPath, []testDecimal{{"1.5"}, {"2"}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "1.5,2", result)
+
 	name := "Alex"
 	role := "admin"
 	object2 := TestObject2{
@@ -521,3 +554,12 @@ func TestStyleParam(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, "firstName,Alex", result)
 }
+
+// compactDate is a date in another layout, as generated for x-go-time-format.
+type compactDate struct {
+	time.Time
+}
+
+func (d compactDate) TimeLayout() string {
+	return "20060102"
+}
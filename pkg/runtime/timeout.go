@@ -0,0 +1,45 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// CancelOnClose cancels the context of a request, with cancel, once the body
+// of its response, rsp, is closed, since reading the body needs the context.
+// It's canceled right away when there's no response.
+func CancelOnClose(rsp *http.Response, err error, cancel context.CancelFunc) (*http.Response, error) {
+	if err != nil || rsp == nil || rsp.Body == nil {
+		cancel()
+		return rsp, err
+	}
+	rsp.Body = &cancelOnClose{ReadCloser: rsp.Body, cancel: cancel}
+	return rsp, nil
+}
+
+// cancelOnClose is a response body which cancels the context of its request
+// when it's closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
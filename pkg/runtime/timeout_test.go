@@ -0,0 +1,47 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelOnClose(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rsp := &http.Response{Body: ioutil.NopCloser(strings.NewReader("body"))}
+	rsp, err := CancelOnClose(rsp, nil, cancel)
+	assert.NoError(t, err)
+
+	// The context lives until the body is closed
+	body, err := ioutil.ReadAll(rsp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "body", string(body))
+	assert.NoError(t, ctx.Err())
+	assert.NoError(t, rsp.Body.Close())
+	assert.Equal(t, context.Canceled, ctx.Err())
+
+	// Without a response, it's canceled right away
+	ctx, cancel = context.WithCancel(context.Background())
+	rsp, err = CancelOnClose(nil, errors.New("failed"), cancel)
+	assert.EqualError(t, err, "failed")
+	assert.Nil(t, rsp)
+	assert.Equal(t, context.Canceled, ctx.Err())
+}
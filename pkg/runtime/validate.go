@@ -0,0 +1,143 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Validator is implemented by the generated types whose schemas have
+// constraints, when they're generated with the validate-methods option.
+type Validator interface {
+	// Validate returns a *ValidationError when the value violates a
+	// constraint of its schema.
+	Validate() error
+}
+
+// ValidationError is returned by the Validate methods of generated types when
+// a value violates a constraint of its schema.
+type ValidationError struct {
+	// Path is the path of the value which violates the constraint, as the
+	// JSON names of fields and the indexes of items, eg, "tags[1]" or
+	// "owner.city". It's empty for the validated value itself.
+	Path    string
+	Message string
+}
+
+// NewValidationError returns a ValidationError of the value at path.
+func NewValidationError(path, message string) *ValidationError {
+	return &ValidationError{Path: path, Message: message}
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors are the errors of several invalid values, such as the
+// parameters which the With methods of generated Params types set.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// PrefixValidationError returns the error of a value nested in another one,
+// at prefix, whose path is the one of err under prefix.
+func PrefixValidationError(err error, prefix string) error {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	path := prefix
+	switch {
+	case validationErr.Path == "":
+	case strings.HasPrefix(validationErr.Path, "["):
+		path += validationErr.Path
+	default:
+		path += "." + validationErr.Path
+	}
+	return &ValidationError{Path: path, Message: validationErr.Message}
+}
+
+// Validate validates the value which v points to, when it's a Validator, or
+// else the elements of the slice, array or map which it points to. It's meant
+// to be called on values decoded from requests and responses.
+func Validate(v interface{}) error {
+	if validator, ok := v.(Validator); ok {
+		return validator.Validate()
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil
+	}
+	rv = rv.Elem()
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if !rv.IsNil() {
+			return Validate(rv.Interface())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := Validate(rv.Index(i).Addr().Interface()); err != nil {
+				return PrefixValidationError(err, fmt.Sprintf("[%d]", i))
+			}
+		}
+	case reflect.Map:
+		if rv.Type().Elem().Kind() == reflect.Interface {
+			return nil
+		}
+		for _, key := range rv.MapKeys() {
+			elem := reflect.New(rv.Type().Elem())
+			elem.Elem().Set(rv.MapIndex(key))
+			if err := Validate(elem.Interface()); err != nil {
+				return PrefixValidationError(err, fmt.Sprint(key.Interface()))
+			}
+		}
+	}
+	return nil
+}
+
+// NewParamValidationError returns the BindError of the error of the Validate
+// method of the parameters of an operation, whose locations are given by
+// name. The parameter is the first element of the path of err.
+func NewParamValidationError(err error, locations map[string]ParamLocation) *BindError {
+	bindErr := &BindError{Kind: BindErrorConstraint, Err: err}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return bindErr
+	}
+	// Parameter names may contain dots and brackets, so the longest name
+	// which the path starts with is the one.
+	for name, location := range locations {
+		rest := strings.TrimPrefix(validationErr.Path, name)
+		if rest == validationErr.Path || len(name) <= len(bindErr.ParamName) ||
+			rest != "" && rest[0] != '.' && rest[0] != '[' {
+			continue
+		}
+		bindErr.ParamName = name
+		bindErr.Location = location
+		bindErr.Property = strings.TrimPrefix(rest, ".")
+	}
+	return bindErr
+}
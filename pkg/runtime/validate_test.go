@@ -0,0 +1,96 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validatedPet struct {
+	Name string
+}
+
+func (p *validatedPet) Validate() error {
+	if p.Name == "" {
+		return NewValidationError("name", "length must be at least 1")
+	}
+	return nil
+}
+
+func TestValidationError(t *testing.T) {
+	err := NewValidationError("name", "length must be at least 1")
+	assert.EqualError(t, err, "name: length must be at least 1")
+	assert.EqualError(t, NewValidationError("", "must be one of cat, dog"), "must be one of cat, dog")
+
+	assert.EqualError(t, PrefixValidationError(err, "owner"), "owner.name: length must be at least 1")
+	assert.EqualError(t, PrefixValidationError(err, "[2]"), "[2].name: length must be at least 1")
+	assert.EqualError(t, PrefixValidationError(NewValidationError("[1]", "must be at most 3"), "tags"), "tags[1]: must be at most 3")
+	assert.EqualError(t, PrefixValidationError(NewValidationError("", "must be one of cat, dog"), "kind"), "kind: must be one of cat, dog")
+	other := errors.New("other")
+	assert.Equal(t, other, PrefixValidationError(other, "owner"))
+}
+
+func TestValidationErrors(t *testing.T) {
+	err := ValidationErrors{
+		NewValidationError("limit", "must be at most 100"),
+		NewValidationError("tags[1]", "length must be at most 3"),
+	}
+	assert.EqualError(t, err, "limit: must be at most 100; tags[1]: length must be at most 3")
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err[1], &validationErr))
+	assert.Equal(t, "tags[1]", validationErr.Path)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(&validatedPet{Name: "Fido"}))
+	assert.EqualError(t, Validate(&validatedPet{}), "name: length must be at least 1")
+
+	pets := []validatedPet{{Name: "Fido"}, {}}
+	assert.EqualError(t, Validate(&pets), "[1].name: length must be at least 1")
+	petMap := map[string]validatedPet{"fido": {}}
+	assert.EqualError(t, Validate(&petMap), "fido.name: length must be at least 1")
+	petPtr := &validatedPet{}
+	assert.Error(t, Validate(&petPtr))
+
+	// Values without Validate methods are valid
+	values := map[string]interface{}{"name": ""}
+	assert.NoError(t, Validate(&values))
+	assert.NoError(t, Validate(validatedPet{}))
+}
+
+func TestNewParamValidationError(t *testing.T) {
+	locations := map[string]ParamLocation{
+		"limit":     ParamLocationQuery,
+		"filter":    ParamLocationQuery,
+		"filter.by": ParamLocationHeader,
+	}
+	err := NewParamValidationError(NewValidationError("limit", "must be at most 100"), locations)
+	assert.Equal(t, "limit", err.ParamName)
+	assert.Equal(t, ParamLocationQuery, err.Location)
+	assert.Equal(t, BindErrorConstraint, err.Kind)
+	assert.Equal(t, "", err.Property)
+	assert.EqualError(t, err, "limit: must be at most 100")
+
+	err = NewParamValidationError(NewValidationError("filter.name", "length must be at least 1"), locations)
+	assert.Equal(t, "filter", err.ParamName)
+	assert.Equal(t, "name", err.Property)
+
+	err = NewParamValidationError(NewValidationError("filter.by[0]", "must be one of a, b"), locations)
+	assert.Equal(t, "filter.by", err.ParamName)
+	assert.Equal(t, ParamLocationHeader, err.Location)
+	assert.Equal(t, "[0]", err.Property)
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// WebSocketTextMessage is the type of the text messages of WebSocket
+// connections, as numbered by RFC 6455, and by the WebSocket libraries.
+const WebSocketTextMessage = 1
+
+// WebSocketConn is a WebSocket connection, on which the generated code
+// exchanges the messages of the operations marked with x-websocket. The
+// *Conn of github.com/gorilla/websocket implements it, and the connections
+// of the other libraries are easily adapted to it.
+type WebSocketConn interface {
+	ReadMessage() (messageType int, data []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// WebSocketUpgrader upgrades the request of a generated WebSocket server
+// handler. When it fails, it responds to the request itself, like
+// gorilla's Upgrader.Upgrade does.
+type WebSocketUpgrader func(w http.ResponseWriter, r *http.Request) (WebSocketConn, error)
+
+// WebSocketDialer opens the WebSocket connections of generated clients, with
+// the given request headers.
+type WebSocketDialer func(ctx context.Context, url string, header http.Header) (WebSocketConn, error)
+
+// ReadJSONMessage reads the next message from conn, and decodes it as JSON
+// into v.
+func ReadJSONMessage(conn WebSocketConn, v interface{}) error {
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error decoding WebSocket message: %w", err)
+	}
+	return nil
+}
+
+// WriteJSONMessage writes v, encoded as JSON, as a text message to conn.
+func WriteJSONMessage(conn WebSocketConn, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("error encoding WebSocket message: %w", err)
+	}
+	return conn.WriteMessage(WebSocketTextMessage, data)
+}
+
+// WebSocketURL returns u with the ws, or wss, scheme which matches its http,
+// or https, one.
+func WebSocketURL(u *url.URL) *url.URL {
+	wsURL := *u
+	switch u.Scheme {
+	case "http":
+		wsURL.Scheme = "ws"
+	case "https":
+		wsURL.Scheme = "wss"
+	}
+	return &wsURL
+}
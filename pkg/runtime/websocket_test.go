@@ -0,0 +1,79 @@
+// Copyright 2021 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// messageConn is a WebSocketConn which queues the messages written to it.
+type messageConn struct {
+	types    []int
+	messages [][]byte
+}
+
+func (c *messageConn) ReadMessage() (int, []byte, error) {
+	if len(c.messages) == 0 {
+		return 0, nil, io.EOF
+	}
+	messageType, data := c.types[0], c.messages[0]
+	c.types, c.messages = c.types[1:], c.messages[1:]
+	return messageType, data, nil
+}
+
+func (c *messageConn) WriteMessage(messageType int, data []byte) error {
+	c.types = append(c.types, messageType)
+	c.messages = append(c.messages, data)
+	return nil
+}
+
+func (c *messageConn) Close() error {
+	return nil
+}
+
+func TestJSONMessages(t *testing.T) {
+	type message struct {
+		Text string `json:"text"`
+	}
+	conn := &messageConn{}
+
+	assert.NoError(t, WriteJSONMessage(conn, message{Text: "hello"}))
+	assert.Equal(t, []int{WebSocketTextMessage}, conn.types)
+	assert.Equal(t, `{"text":"hello"}`, string(conn.messages[0]))
+
+	var msg message
+	assert.NoError(t, ReadJSONMessage(conn, &msg))
+	assert.Equal(t, "hello", msg.Text)
+
+	assert.Equal(t, io.EOF, ReadJSONMessage(conn, &msg))
+
+	assert.NoError(t, conn.WriteMessage(WebSocketTextMessage, []byte("{")))
+	assert.Error(t, ReadJSONMessage(conn, &msg))
+}
+
+func TestWebSocketURL(t *testing.T) {
+	for in, out := range map[string]string{
+		"http://example.com/chat?room=1": "ws://example.com/chat?room=1",
+		"https://example.com/chat":       "wss://example.com/chat",
+		"ws://example.com/chat":          "ws://example.com/chat",
+	} {
+		u, err := url.Parse(in)
+		assert.NoError(t, err)
+		assert.Equal(t, out, WebSocketURL(u).String())
+	}
+}
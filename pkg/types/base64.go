@@ -0,0 +1,61 @@
+package types
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// Base64 is the bytes of a string with the byte format, which it encodes in
+// standard base64, like encoding/json does []byte, as JSON, parameters and
+// form fields. It decodes both standard and URL-safe base64, with or without
+// padding.
+type Base64 []byte
+
+func (b Base64) MarshalText() ([]byte, error) {
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+func (b *Base64) UnmarshalText(data []byte) error {
+	decoded, err := decodeBase64(string(data))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+func (b Base64) String() string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// Base64URL is the bytes of a string with the byte format, which it encodes
+// in URL-safe base64, with padding, as JSON, parameters and form fields. It
+// decodes both standard and URL-safe base64, with or without padding.
+type Base64URL []byte
+
+func (b Base64URL) MarshalText() ([]byte, error) {
+	return []byte(base64.URLEncoding.EncodeToString(b)), nil
+}
+
+func (b *Base64URL) UnmarshalText(data []byte) error {
+	decoded, err := decodeBase64(string(data))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+func (b Base64URL) String() string {
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+// decodeBase64 decodes standard or URL-safe base64, whose padding is
+// optional.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "-_") {
+		return base64.RawURLEncoding.DecodeString(s)
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
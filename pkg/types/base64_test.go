@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase64_JSON(t *testing.T) {
+	b := struct {
+		Std Base64    `json:"std"`
+		URL Base64URL `json:"url"`
+	}{
+		Std: Base64{0xfb, 0xff, 0x01},
+		URL: Base64URL{0xfb, 0xff, 0x01},
+	}
+	jsonBytes, err := json.Marshal(b)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"std":"+/8B","url":"-_8B"}`, string(jsonBytes))
+
+	// Either alphabet decodes, with or without padding
+	err = json.Unmarshal([]byte(`{"std":"-_8B","url":"+/8B"}`), &b)
+	assert.NoError(t, err)
+	assert.Equal(t, Base64{0xfb, 0xff, 0x01}, b.Std)
+	assert.Equal(t, Base64URL{0xfb, 0xff, 0x01}, b.URL)
+	err = json.Unmarshal([]byte(`{"std":"aGk=","url":"aGk"}`), &b)
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", string(b.Std))
+	assert.Equal(t, "hi", string(b.URL))
+
+	err = json.Unmarshal([]byte(`{"std":"not base64!"}`), &b)
+	assert.Error(t, err)
+}
+
+func TestBase64_Text(t *testing.T) {
+	text, err := Base64("hi?").MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "aGk/", string(text))
+	assert.Equal(t, "aGk_", Base64URL("hi?").String())
+
+	var b Base64
+	assert.NoError(t, b.UnmarshalText([]byte("aGk_")))
+	assert.Equal(t, Base64("hi?"), b)
+}
@@ -29,6 +29,21 @@ func (d *Date) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalText encodes the date without its time, rather than as the time
+// it embeds does, for XML, parameters and map keys.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.Time.Format(DateFormat)), nil
+}
+
+func (d *Date) UnmarshalText(data []byte) error {
+	parsed, err := time.Parse(DateFormat, string(data))
+	if err != nil {
+		return err
+	}
+	d.Time = parsed
+	return nil
+}
+
 func (d Date) String() string {
 	return d.Time.Format(DateFormat)
 }
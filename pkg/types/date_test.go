@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"testing"
 	"time"
@@ -52,3 +53,22 @@ func TestDate_Stringer(t *testing.T) {
 		assert.Equal(t, "2019-04-01", fmt.Sprintf("%v", d))
 	})
 }
+
+func TestDate_Text(t *testing.T) {
+	d := Date{time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)}
+	text, err := d.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-04-01", string(text))
+
+	var parsed Date
+	assert.NoError(t, parsed.UnmarshalText([]byte("2019-04-01")))
+	assert.Equal(t, d, parsed)
+	assert.Error(t, parsed.UnmarshalText([]byte("2019-04-01T00:00:00Z")))
+
+	b, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"pet"`
+		Born    Date     `xml:"born"`
+	}{Born: d})
+	assert.NoError(t, err)
+	assert.Equal(t, "<pet><born>2019-04-01</born></pet>", string(b))
+}
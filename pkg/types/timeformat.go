@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// These layouts, which time.Format doesn't know, encode times as the number
+// of seconds, or milliseconds, since the Unix epoch. They're JSON numbers.
+const (
+	TimeFormatUnix       = "unix"
+	TimeFormatUnixMillis = "unix-millis"
+)
+
+// LayoutTime is implemented by the types of times in other layouts, which
+// embed a time.Time like Date does. TimeLayout returns their layout.
+type LayoutTime interface {
+	TimeLayout() string
+}
+
+// FormatTime returns the text of t in the given layout, which is either one
+// of time.Format, or TimeFormatUnix or TimeFormatUnixMillis.
+func FormatTime(t time.Time, layout string) string {
+	switch layout {
+	case TimeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case TimeFormatUnixMillis:
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	}
+	return t.Format(layout)
+}
+
+// ParseTime parses the text of a time in the given layout, as FormatTime
+// writes it.
+func ParseTime(value string, layout string) (time.Time, error) {
+	switch layout {
+	case TimeFormatUnix, TimeFormatUnixMillis:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing time %q as %s: %w", value, layout, err)
+		}
+		if layout == TimeFormatUnixMillis {
+			return time.Unix(0, n*int64(time.Millisecond)), nil
+		}
+		return time.Unix(n, 0), nil
+	}
+	return time.Parse(layout, value)
+}
+
+// MarshalTimeJSON encodes t in the given layout, as a number for the Unix
+// layouts, and as a string for the others.
+func MarshalTimeJSON(t time.Time, layout string) ([]byte, error) {
+	value := FormatTime(t, layout)
+	if layout == TimeFormatUnix || layout == TimeFormatUnixMillis {
+		return []byte(value), nil
+	}
+	return json.Marshal(value)
+}
+
+// UnmarshalTimeJSON decodes a time encoded by MarshalTimeJSON. Numbers are
+// accepted as strings too, and null is the zero time.
+func UnmarshalTimeJSON(data []byte, layout string) (time.Time, error) {
+	var value string
+	if string(data) == "null" {
+		return time.Time{}, nil
+	} else if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return time.Time{}, err
+		}
+	} else {
+		value = string(data)
+	}
+	return ParseTime(value, layout)
+}
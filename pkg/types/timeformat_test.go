@@ -0,0 +1,47 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeFormat(t *testing.T) {
+	testTime := time.Date(2019, 4, 1, 12, 30, 15, 250000000, time.UTC)
+
+	tests := []struct {
+		layout string
+		json   string
+		parsed time.Time
+	}{
+		{"20060102", `"20190401"`, time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{time.RFC1123, `"Mon, 01 Apr 2019 12:30:15 UTC"`, testTime.Truncate(time.Second)},
+		{TimeFormatUnix, `1554121815`, testTime.Truncate(time.Second)},
+		{TimeFormatUnixMillis, `1554121815250`, testTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			data, err := MarshalTimeJSON(testTime, tt.layout)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.json, string(data))
+
+			parsed, err := UnmarshalTimeJSON(data, tt.layout)
+			assert.NoError(t, err)
+			assert.True(t, tt.parsed.Equal(parsed), "got %s", parsed)
+		})
+	}
+
+	parsed, err := UnmarshalTimeJSON([]byte(`"1554121815"`), TimeFormatUnix)
+	assert.NoError(t, err)
+	assert.True(t, testTime.Truncate(time.Second).Equal(parsed))
+
+	parsed, err = UnmarshalTimeJSON([]byte(`null`), TimeFormatUnix)
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsZero())
+
+	_, err = UnmarshalTimeJSON([]byte(`"2019-04-01"`), "20060102")
+	assert.Error(t, err)
+	_, err = ParseTime("soon", TimeFormatUnixMillis)
+	assert.Error(t, err)
+}
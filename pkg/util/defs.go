@@ -0,0 +1,232 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// These are the keywords which change the shape of a schema. When they appear
+// next to a $ref, they can't be dropped without changing the meaning of the
+// schema.
+var structuralSchemaKeywords = []string{
+	"type", "properties", "additionalProperties", "items", "enum", "format",
+	"allOf", "anyOf", "oneOf", "not", "minimum", "maximum", "exclusiveMinimum",
+	"exclusiveMaximum", "multipleOf", "minLength", "maxLength", "pattern",
+	"minItems", "maxItems", "uniqueItems", "minProperties", "maxProperties",
+}
+
+// schemaDef is a schema found under $defs, which will become a component
+// schema.
+type schemaDef struct {
+	pointer string
+	parent  string
+	name    string
+	holder  map[string]interface{}
+	schema  interface{}
+}
+
+// ResolveDefs rewrites constructs which bundlers produce for JSON Schema, but
+// which OpenAPI 3.0 loaders don't understand:
+//
+//   - Schemas under $defs are moved into components/schemas, so that they become
+//     named types, and the references to them are updated.
+//   - Schema keywords next to a $ref are kept by turning the schema into an allOf
+//     of the reference and the sibling keywords, as in OpenAPI 3.1.
+//
+// The document may be YAML or JSON. When there is nothing to rewrite, it is
+// returned as is, otherwise it is returned as JSON.
+func ResolveDefs(data []byte) ([]byte, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing spec: %w", err)
+	}
+	doc, ok := normalizeYAML(raw).(map[string]interface{})
+	if !ok {
+		return data, nil
+	}
+
+	var defs []schemaDef
+	collectDefs(doc, "#", "", &defs)
+	changed := len(defs) > 0
+
+	if changed {
+		components, _ := doc["components"].(map[string]interface{})
+		if components == nil {
+			components = map[string]interface{}{}
+			doc["components"] = components
+		}
+		schemas, _ := components["schemas"].(map[string]interface{})
+		if schemas == nil {
+			schemas = map[string]interface{}{}
+			components["schemas"] = schemas
+		}
+
+		taken := func(name string) bool {
+			_, found := schemas[name]
+			return found
+		}
+		refs := map[string]string{}
+		for _, def := range defs {
+			name := def.name
+			if taken(name) && def.parent != "" {
+				name = def.parent + "_" + def.name
+			}
+			base := name
+			for i := 1; taken(name); i++ {
+				name = fmt.Sprintf("%s%d", base, i)
+			}
+			schemas[name] = def.schema
+			refs[def.pointer] = "#/components/schemas/" + escapePointer(name)
+		}
+		for _, def := range defs {
+			delete(def.holder, "$defs")
+		}
+		rewriteRefs(doc, refs)
+	}
+
+	if wrapRefSiblings(doc) {
+		changed = true
+	}
+
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(doc)
+}
+
+// normalizeYAML turns the maps produced by the YAML parser into maps with
+// string keys, which can be marshaled as JSON.
+func normalizeYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprintf("%v", key)] = normalizeYAML(value)
+		}
+		return m
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeYAML(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// collectDefs walks the document looking for $defs, and records every schema
+// found in them, along with its JSON pointer. parent is the name of the
+// closest named schema, which is used to disambiguate definitions.
+func collectDefs(v interface{}, pointer string, parent string, defs *[]schemaDef) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			childPointer := pointer + "/" + escapePointer(key)
+			if key == "$defs" {
+				defsMap, ok := v[key].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				for _, name := range sortedKeys(defsMap) {
+					*defs = append(*defs, schemaDef{
+						pointer: childPointer + "/" + escapePointer(name),
+						parent:  parent,
+						name:    name,
+						holder:  v,
+						schema:  defsMap[name],
+					})
+					collectDefs(defsMap[name], childPointer+"/"+escapePointer(name), name, defs)
+				}
+				continue
+			}
+			childParent := parent
+			if strings.HasSuffix(pointer, "/components/schemas") {
+				childParent = key
+			}
+			collectDefs(v[key], childPointer, childParent, defs)
+		}
+	case []interface{}:
+		for i, value := range v {
+			collectDefs(value, fmt.Sprintf("%s/%d", pointer, i), parent, defs)
+		}
+	}
+}
+
+// rewriteRefs updates all the local references to the given pointers.
+func rewriteRefs(v interface{}, refs map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if ref, ok := value.(string); ok && key == "$ref" {
+				if newRef, found := refs[ref]; found {
+					v[key] = newRef
+				}
+				continue
+			}
+			rewriteRefs(value, refs)
+		}
+	case []interface{}:
+		for _, value := range v {
+			rewriteRefs(value, refs)
+		}
+	}
+}
+
+// wrapRefSiblings turns schemas which have structural keywords next to a $ref
+// into an allOf of the reference and the keywords, since a $ref replaces the
+// whole object in OpenAPI 3.0, and those keywords would be lost. Annotations,
+// such as descriptions, are left as they are.
+func wrapRefSiblings(v interface{}) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, value := range v {
+			if wrapRefSiblings(value) {
+				changed = true
+			}
+		}
+		ref, ok := v["$ref"].(string)
+		if !ok || !hasStructuralKeywords(v) {
+			break
+		}
+		delete(v, "$ref")
+		allOf, _ := v["allOf"].([]interface{})
+		v["allOf"] = append([]interface{}{map[string]interface{}{"$ref": ref}}, allOf...)
+		changed = true
+	case []interface{}:
+		for _, value := range v {
+			if wrapRefSiblings(value) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func hasStructuralKeywords(m map[string]interface{}) bool {
+	for _, keyword := range structuralSchemaKeywords {
+		if _, found := m[keyword]; found {
+			return true
+		}
+	}
+	// required is a list of properties in schemas, but a flag elsewhere
+	_, isList := m["required"].([]interface{})
+	return isList
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
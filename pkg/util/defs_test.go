@@ -0,0 +1,83 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDefs(t *testing.T) {
+	data, err := ResolveDefs([]byte(defsTestSpec))
+	require.NoError(t, err)
+
+	swagger, err := openapi3.NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+
+	schemas := swagger.Components.Schemas
+	require.Contains(t, schemas, "Tag")
+	require.Contains(t, schemas, "Pet_Owner")
+	assert.Equal(t, "#/components/schemas/Tag", schemas["Pet"].Value.Properties["tag"].Ref)
+	assert.Equal(t, "#/components/schemas/Pet_Owner", schemas["Pet"].Value.Properties["owner"].Ref)
+
+	// Schema keywords next to a $ref are kept in an allOf
+	status := schemas["Pet"].Value.Properties["status"]
+	assert.Empty(t, status.Ref)
+	require.Len(t, status.Value.AllOf, 1)
+	assert.Equal(t, "#/components/schemas/Tag", status.Value.AllOf[0].Ref)
+	assert.Contains(t, status.Value.Properties, "since")
+
+	// Annotations next to a $ref are left alone
+	assert.Equal(t, "#/components/schemas/Owner", schemas["Pet"].Value.Properties["previousOwner"].Ref)
+}
+
+func TestResolveDefsUnchanged(t *testing.T) {
+	spec := []byte(`
+openapi: 3.0.1
+info:
+  title: Unchanged
+  version: 1.0.0
+paths: {}
+`)
+	data, err := ResolveDefs(spec)
+	require.NoError(t, err)
+	assert.Equal(t, spec, data)
+}
+
+const defsTestSpec = `
+openapi: 3.0.1
+info:
+  title: Defs test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Owner:
+      properties:
+        name:
+          type: string
+    Pet:
+      properties:
+        tag:
+          $ref: '#/components/schemas/Pet/$defs/Tag'
+        owner:
+          $ref: '#/components/schemas/Pet/$defs/Owner'
+        previousOwner:
+          $ref: '#/components/schemas/Owner'
+          description: The owner before this one
+        status:
+          $ref: '#/components/schemas/Pet/$defs/Tag'
+          properties:
+            since:
+              type: string
+      $defs:
+        Tag:
+          properties:
+            name:
+              type: string
+        Owner:
+          properties:
+            id:
+              type: string
+`
@@ -1,20 +1,63 @@
 package util
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
 	"net/url"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
 func LoadSwagger(filePath string) (swagger *openapi3.T, err error) {
+	return LoadSwaggerWithOptions(context.Background(), filePath, LoadOptions{})
+}
+
+// LoadSwaggerWithOptions loads a spec like LoadSwagger, fetching the remote
+// documents it refers to through a cache, as configured by opts. Fetching
+// them stops when ctx is done.
+func LoadSwaggerWithOptions(ctx context.Context, filePath string, opts LoadOptions) (swagger *openapi3.T, err error) {
+	cache, err := newRefCache(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := readURL(ctx, cache, location)
+		if err != nil {
+			return nil, err
+		}
+		return ResolveDefs(data)
+	}
 
 	u, err := url.Parse(filePath)
 	if err == nil && u.Scheme != "" && u.Host != "" {
-		return loader.LoadFromURI(u)
+		swagger, err = loader.LoadFromURI(u)
 	} else {
-		return loader.LoadFromFile(filePath)
+		swagger, err = loader.LoadFromFile(filePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.saveLock(); err != nil {
+		return nil, err
+	}
+	return swagger, nil
+}
+
+// readURL reads a spec, or a document it refers to, the same way the loader
+// does by default, except that remote documents go through the cache.
+func readURL(ctx context.Context, cache *refCache, location *url.URL) ([]byte, error) {
+	if location.Scheme != "" && location.Host != "" {
+		return cache.fetch(ctx, location)
+	}
+	if location.Scheme != "" || location.Host != "" || location.RawQuery != "" {
+		return nil, fmt.Errorf("unsupported URI: %q", location.String())
+	}
+	if cache.opts.OnLocalFile != nil {
+		cache.opts.OnLocalFile(location.Path)
 	}
+	return ioutil.ReadFile(location.Path)
 }
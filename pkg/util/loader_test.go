@@ -0,0 +1,136 @@
+package util
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSwaggerWithRemoteRefs(t *testing.T) {
+	pet := `
+components:
+  schemas:
+    Pet:
+      properties:
+        name:
+          type: string
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(pet))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "oapi-codegen-loader")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	specFile := filepath.Join(dir, "spec.yaml")
+	spec := `
+openapi: 3.0.1
+info:
+  title: Remote refs
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Dog:
+      $ref: '` + server.URL + `/pet.yaml#/components/schemas/Pet'
+`
+	require.NoError(t, ioutil.WriteFile(specFile, []byte(spec), 0644))
+
+	opts := LoadOptions{
+		CacheDir: filepath.Join(dir, "cache"),
+		LockFile: filepath.Join(dir, "refs.lock"),
+	}
+
+	swagger, err := LoadSwaggerWithOptions(context.Background(), specFile, opts)
+	require.NoError(t, err)
+	assert.Contains(t, swagger.Components.Schemas["Dog"].Value.Properties, "name")
+
+	lock, err := ioutil.ReadFile(opts.LockFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(lock), server.URL+"/pet.yaml")
+	assert.Contains(t, string(lock), contentDigest([]byte(pet)))
+
+	// Locked documents can't change behind our back
+	pet = pet + "        age:\n          type: integer\n"
+	_, err = LoadSwaggerWithOptions(context.Background(), specFile, LoadOptions{LockFile: opts.LockFile})
+	assert.Error(t, err)
+
+	// But the cached version is used, without fetching anything
+	swagger, err = LoadSwaggerWithOptions(context.Background(), specFile, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, swagger.Components.Schemas["Dog"].Value.Properties, "age")
+
+	// Offline, everything has to come from the cache
+	server.Close()
+	opts.Offline = true
+	_, err = LoadSwaggerWithOptions(context.Background(), specFile, opts)
+	assert.NoError(t, err)
+
+	_, err = LoadSwaggerWithOptions(context.Background(), specFile, LoadOptions{CacheDir: filepath.Join(dir, "empty"), Offline: true})
+	assert.Error(t, err)
+}
+
+func TestLoadSwaggerFetches(t *testing.T) {
+	var slow int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&slow) != 0 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte("components:\n  schemas:\n    Pet:\n      type: string\n"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "oapi-codegen-loader")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	specFile := filepath.Join(dir, "spec.yaml")
+	spec := `
+openapi: 3.0.1
+info:
+  title: Remote refs
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Dog:
+      $ref: '` + server.URL + `/pet.yaml#/components/schemas/Pet'
+`
+	require.NoError(t, ioutil.WriteFile(specFile, []byte(spec), 0644))
+
+	// Without a lock file, the fetched documents are reported as unpinned
+	var unpinned []string
+	opts := LoadOptions{OnUnpinnedFetch: func(url string) { unpinned = append(unpinned, url) }}
+	_, err = LoadSwaggerWithOptions(context.Background(), specFile, opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{server.URL + "/pet.yaml"}, unpinned)
+
+	unpinned = nil
+	opts.LockFile = filepath.Join(dir, "refs.lock")
+	_, err = LoadSwaggerWithOptions(context.Background(), specFile, opts)
+	require.NoError(t, err)
+	assert.Empty(t, unpinned)
+
+	// Servers which don't respond are given up on
+	atomic.StoreInt32(&slow, 1)
+	opts = LoadOptions{HTTPClient: &http.Client{Timeout: 50 * time.Millisecond}}
+	_, err = LoadSwaggerWithOptions(context.Background(), specFile, opts)
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = LoadSwaggerWithOptions(ctx, specFile, LoadOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,258 @@
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultFetchTimeout is how long fetching a remote document may take, unless
+// LoadOptions.HTTPClient says otherwise.
+const DefaultFetchTimeout = 30 * time.Second
+
+// LoadOptions controls how specs, and the documents they refer to, are loaded.
+type LoadOptions struct {
+	// CacheDir is where documents fetched over http(s) are cached, by the
+	// SHA-256 digest of their content. Remote documents aren't cached when
+	// it's empty.
+	CacheDir string
+	// LockFile records the digest of every remote document which was used.
+	// Documents in the lock file are always loaded with that exact content,
+	// and loading fails if the remote content has changed. New documents are
+	// added to it.
+	LockFile string
+	// Offline forbids fetching anything over the network, remote documents
+	// must be in the cache.
+	Offline bool
+	// OnLocalFile, when set, is called with the path of every local file
+	// which is read, that is the spec, unless it's remote, and the local
+	// documents it refers to.
+	OnLocalFile func(path string)
+	// OnUnpinnedFetch, when set, is called with the URL of every remote
+	// document which is fetched without a lock file to pin its content, which
+	// may then change from one generation to the next.
+	OnUnpinnedFetch func(url string)
+	// HTTPClient fetches the remote documents. It defaults to a client which
+	// gives up after DefaultFetchTimeout.
+	HTTPClient *http.Client
+}
+
+// DefaultCacheDir returns the directory in which remote documents are cached
+// by default.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "oapi-codegen")
+}
+
+// refCache fetches remote documents through a content addressed cache. The
+// loader may read documents concurrently, so it's safe for concurrent use.
+type refCache struct {
+	opts LoadOptions
+
+	mu          sync.Mutex
+	lock        map[string]string
+	lockChanged bool
+}
+
+func newRefCache(opts LoadOptions) (*refCache, error) {
+	c := &refCache{
+		opts: opts,
+		lock: map[string]string{},
+	}
+	if c.opts.HTTPClient == nil {
+		c.opts.HTTPClient = &http.Client{Timeout: DefaultFetchTimeout}
+	}
+	if opts.LockFile != "" {
+		data, err := ioutil.ReadFile(opts.LockFile)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error reading lock file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &c.lock); err != nil {
+			return nil, fmt.Errorf("error parsing lock file %s: %w", opts.LockFile, err)
+		}
+		if c.lock == nil {
+			c.lock = map[string]string{}
+		}
+	}
+	return c, nil
+}
+
+// fetch returns the content of a remote document.
+func (c *refCache) fetch(ctx context.Context, location *url.URL) ([]byte, error) {
+	u := location.String()
+
+	c.mu.Lock()
+	digest := c.lock[u]
+	c.mu.Unlock()
+
+	// A locked document which is in the cache is never fetched again, this is
+	// what makes builds reproducible.
+	if digest == "" && c.opts.Offline {
+		digest = c.lastDigest(u)
+	}
+	if digest != "" {
+		data, err := c.readBlob(digest)
+		if err == nil {
+			return data, nil
+		}
+		if c.opts.Offline {
+			return nil, fmt.Errorf("%s is not cached, and fetching it isn't allowed offline: %w", u, err)
+		}
+	} else if c.opts.Offline {
+		return nil, fmt.Errorf("%s is not cached, and fetching it isn't allowed offline", u)
+	}
+
+	data, err := httpGet(ctx, c.opts.HTTPClient, location)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.LockFile == "" && c.opts.OnUnpinnedFetch != nil {
+		c.opts.OnUnpinnedFetch(u)
+	}
+	fetched := contentDigest(data)
+	if digest != "" && fetched != digest {
+		return nil, fmt.Errorf("content of %s has changed: locked to %s, but got %s; remove it from %s to accept the change",
+			u, digest, fetched, c.opts.LockFile)
+	}
+
+	if err := c.writeBlob(u, fetched, data); err != nil {
+		return nil, err
+	}
+	if c.opts.LockFile != "" && digest == "" {
+		c.mu.Lock()
+		c.lock[u] = fetched
+		c.lockChanged = true
+		c.mu.Unlock()
+	}
+	return data, nil
+}
+
+// saveLock writes the lock file when new documents were added to it.
+func (c *refCache) saveLock() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.opts.LockFile == "" || !c.lockChanged {
+		return nil
+	}
+
+	urls := make([]string, 0, len(c.lock))
+	for u := range c.lock {
+		urls = append(urls, u)
+	}
+	sort.Strings(urls)
+
+	var b strings.Builder
+	b.WriteString("# Generated by oapi-codegen, records the content of the remote documents\n")
+	b.WriteString("# referenced by the spec. Remove an entry to accept a change upstream.\n")
+	for _, u := range urls {
+		fmt.Fprintf(&b, "%q: %s\n", u, c.lock[u])
+	}
+	if err := ioutil.WriteFile(c.opts.LockFile, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("error writing lock file: %w", err)
+	}
+	c.lockChanged = false
+	return nil
+}
+
+func (c *refCache) blobPath(digest string) (string, error) {
+	hexDigest := strings.TrimPrefix(digest, "sha256:")
+	if hexDigest == digest || len(hexDigest) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid digest %q", digest)
+	}
+	return filepath.Join(c.opts.CacheDir, "blobs", "sha256", hexDigest), nil
+}
+
+func (c *refCache) urlPath(u string) string {
+	sum := sha256.Sum256([]byte(u))
+	return filepath.Join(c.opts.CacheDir, "urls", hex.EncodeToString(sum[:]))
+}
+
+// lastDigest returns the digest of the last version of the document which was
+// fetched, if any.
+func (c *refCache) lastDigest(u string) string {
+	if c.opts.CacheDir == "" {
+		return ""
+	}
+	data, err := ioutil.ReadFile(c.urlPath(u))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func (c *refCache) readBlob(digest string) ([]byte, error) {
+	if c.opts.CacheDir == "" {
+		return nil, fmt.Errorf("no cache directory")
+	}
+	path, err := c.blobPath(digest)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// Don't trust the cache blindly, it may have been tampered with
+	if contentDigest(data) != digest {
+		return nil, fmt.Errorf("cached content of %s doesn't match its digest", path)
+	}
+	return data, nil
+}
+
+func (c *refCache) writeBlob(u string, digest string, data []byte) error {
+	if c.opts.CacheDir == "" {
+		return nil
+	}
+	path, err := c.blobPath(digest)
+	if err != nil {
+		return err
+	}
+	for _, dir := range []string{filepath.Dir(path), filepath.Dir(c.urlPath(u))} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("error creating cache directory: %w", err)
+		}
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing to cache: %w", err)
+	}
+	if err := ioutil.WriteFile(c.urlPath(u), []byte(digest+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing to cache: %w", err)
+	}
+	return nil
+}
+
+func contentDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func httpGet(ctx context.Context, client *http.Client, location *url.URL) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 399 {
+		return nil, fmt.Errorf("error loading %q: request returned status code %d", location.String(), resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}